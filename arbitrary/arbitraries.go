@@ -1,6 +1,8 @@
 package arbitrary
 
 import (
+	"fmt"
+	"math/big"
 	"reflect"
 	"time"
 
@@ -12,26 +14,46 @@ import (
 // Values are generated by either providing a generator for a specific type
 // or by creating a generator on the fly using golang reflection.
 type Arbitraries struct {
-	generators map[reflect.Type]gopter.Gen
+	generators      map[reflect.Type]gopter.Gen
+	genericGens     map[string]func(reflect.Type) gopter.Gen
+	implementations map[reflect.Type][]reflect.Type
 }
 
 // DefaultArbitraries creates a default arbitrary context with the widest
 // possible ranges for all types.
+// Numbers of math/big have up to 256 bits.
 func DefaultArbitraries() *Arbitraries {
 	return &Arbitraries{
 		generators: map[reflect.Type]gopter.Gen{
 			reflect.TypeOf(time.Time{}):  gen.Time(),
 			reflect.TypeOf(&time.Time{}): gen.PtrOf(gen.Time()),
+			reflect.TypeOf(&big.Int{}):   gen.BigInt(0, 256),
+			reflect.TypeOf(&big.Rat{}):   gen.BigRat(256),
+			reflect.TypeOf(&big.Float{}): gen.BigFloat(1, 256),
 		},
+		genericGens:     map[string]func(reflect.Type) gopter.Gen{},
+		implementations: map[reflect.Type][]reflect.Type{},
 	}
 }
 
 // GenForType gets a generator for a generator for a type
 func (a *Arbitraries) GenForType(rt reflect.Type) gopter.Gen {
+	return a.genForType(rt, map[reflect.Type]bool{})
+}
+
+// genForType gets a generator for a type, inProgress contains the struct
+// types that are currently derived (to detect recursive types)
+func (a *Arbitraries) genForType(rt reflect.Type, inProgress map[reflect.Type]bool) gopter.Gen {
 	if gen, ok := a.generators[rt]; ok {
 		return gen
 	}
-	return a.genForKind(rt)
+	if factory, ok := a.genericGens[genericOrigin(rt)]; ok {
+		return factory(rt)
+	}
+	if implementations, ok := a.implementations[rt]; ok {
+		return a.genForImplementations(rt, implementations, inProgress)
+	}
+	return a.genForKind(rt, inProgress)
 }
 
 // RegisterGen registers a generator
@@ -40,3 +62,31 @@ func (a *Arbitraries) RegisterGen(gen gopter.Gen) {
 	rt := result.ResultType
 	a.generators[rt] = gen
 }
+
+// RegisterGenericGen registers a factory of generators for all instantiations
+// of a generic type. "instance" may be any instantiation of the generic type
+// (e.g. Option[int]{}), "factory" is called with the actual instantiation
+// (e.g. the reflect.Type of Option[string]) whenever a generator is required.
+// Use TypeArgs to get the type arguments of the instantiation.
+func (a *Arbitraries) RegisterGenericGen(instance interface{}, factory func(rt reflect.Type) gopter.Gen) {
+	a.genericGens[genericOrigin(reflect.TypeOf(instance))] = factory
+}
+
+// RegisterImpl registers concrete implementations of an interface type,
+// "iface" is a nil pointer to the interface (e.g. (*Shape)(nil)) and "impls"
+// are instances of the implementations (e.g. Circle{}, &Square{}).
+// Values of the interface type are generated by picking one of the
+// implementations (which are derived like any other type).
+func (a *Arbitraries) RegisterImpl(iface interface{}, impls ...interface{}) {
+	rt := reflect.TypeOf(iface)
+	if rt == nil || rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("RegisterImpl requires a pointer to an interface, but got %v", rt))
+	}
+	for _, impl := range impls {
+		implType := reflect.TypeOf(impl)
+		if implType == nil || !implType.Implements(rt.Elem()) {
+			panic(fmt.Sprintf("%v does not implement %v", implType, rt.Elem()))
+		}
+		a.implementations[rt.Elem()] = append(a.implementations[rt.Elem()], implType)
+	}
+}
@@ -28,5 +28,21 @@ arbitraries context. I.e. by adding
       arbitraries.RegisterGen(gen.Int64Range(-1000, 1000))
 
 any generated int64 number will be between -1000 and 1000.
+
+The generators of struct fields can be constrained by tags as well:
+
+    type User struct {
+      Age  int      `gopter:"range=18:99"`
+      Name string   `gopter:"regex=^[a-z]+$"`
+      Tags []string `gopter:"size=1:3;regex=^#[a-z]+$"`
+    }
+
+"range" and "regex" apply to the elements of slices, maps and pointers, "size"
+to the length of strings, slices and maps. Several options are separated by
+";" (with the regex as last option).
+
+Instantiations of generic types (e.g. Option[int] or "type Set[T comparable]
+map[T]struct{}") are derived from their underlying struct, slice, array or map
+type like any other named type.
 */
 package arbitrary
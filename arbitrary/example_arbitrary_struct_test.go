@@ -64,13 +64,16 @@ func Example_arbitrary_structs() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! MyInt64: Falsified after 6 passed tests.
+	// > Seed: 1234
 	// ARG_0: -1000
 	// ARG_0_ORIGINAL (54 shrinks): -1601066829744837253
 	// ! MyUInt32Type: Falsified after 0 passed tests.
+	// > Seed: 1234
 	// ARG_0: 2000
 	// ARG_0_ORIGINAL (23 shrinks): 2161922319
 	// + Foo: OK, passed 100 tests.
 	// ! Foo2: Falsified after 1 passed tests.
+	// > Seed: 1234
 	// ARG_0: {Name: Id1:0 Id2:0 Id3:0 Id4:0 Id5:0 Id6:0 Id7:0 Id8:0
 	//    ATime:1970-01-01 00:00:00 +0000 UTC ATimePtr:1970-01-01 05:33:20 +0000
 	//    UTC}
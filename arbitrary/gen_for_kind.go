@@ -2,6 +2,7 @@ package arbitrary
 
 import (
 	"reflect"
+	"sync"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -49,7 +50,7 @@ func mapStringish(to reflect.Type, v interface{}) interface{} {
 	return result.Interface()
 }
 
-func (a *Arbitraries) genForKind(rt reflect.Type) gopter.Gen {
+func (a *Arbitraries) genForKind(rt reflect.Type, inProgress map[reflect.Type]bool) gopter.Gen {
 	switch rt.Kind() {
 	case reflect.Bool:
 		return gen.Bool().MapResult(func(result *gopter.GenResult) *gopter.GenResult {
@@ -304,34 +305,120 @@ func (a *Arbitraries) genForKind(rt reflect.Type) gopter.Gen {
 			}
 		})
 	case reflect.Slice:
-		if elementGen := a.GenForType(rt.Elem()); elementGen != nil {
-			return gen.SliceOf(elementGen)
+		if inProgress[rt.Elem()] {
+			return a.genRecursive(rt, gen.Const(reflect.MakeSlice(rt, 0, 0).Interface()))
+		}
+		if elementGen := a.genForType(rt.Elem(), inProgress); elementGen != nil {
+			// named slices like "type List[T any] []T" are converted
+			return convertGen(gen.SliceOf(elementGen), rt)
+		}
+	case reflect.Array:
+		if elementGen := a.genForType(rt.Elem(), inProgress); elementGen != nil {
+			return arrayGen(gen.SliceOfN(rt.Len(), elementGen), rt)
 		}
 	case reflect.Ptr:
+		if inProgress[rt.Elem()] {
+			return a.genRecursive(rt, gen.Const(reflect.Zero(rt).Interface()))
+		}
 		if rt.Elem().Kind() == reflect.Struct {
-			gens := make(map[string]gopter.Gen)
-			for i := 0; i < rt.Elem().NumField(); i++ {
-				field := rt.Elem().Field(i)
-				if gen := a.GenForType(field.Type); gen != nil {
-					gens[field.Name] = gen
-				}
-			}
-			return gen.StructPtr(rt, gens)
+			return gen.StructPtr(rt, a.genForFields(rt.Elem(), inProgress))
 		}
-		return gen.PtrOf(a.GenForType(rt.Elem()))
+		return gen.PtrOf(a.genForType(rt.Elem(), inProgress))
 	case reflect.Struct:
-		gens := make(map[string]gopter.Gen)
-		for i := 0; i < rt.NumField(); i++ {
-			field := rt.Field(i)
-			if gen := a.GenForType(field.Type); gen != nil {
-				gens[field.Name] = gen
-			}
-		}
-		return gen.Struct(rt, gens)
+		return gen.Struct(rt, a.genForFields(rt, inProgress))
 	case reflect.Map:
-		keyGen := a.GenForType(rt.Key())
-		valueGen := a.GenForType(rt.Elem())
-		return gen.MapOf(keyGen, valueGen)
+		keyGen := a.genForType(rt.Key(), inProgress)
+		valueGen := a.genForType(rt.Elem(), inProgress)
+		if keyGen == nil || valueGen == nil {
+			return nil
+		}
+		// named maps like "type Set[T comparable] map[T]struct{}" are converted
+		return convertGen(gen.MapOf(keyGen, valueGen), rt)
 	}
 	return nil
 }
+
+// genForFields gets the generators for all exported fields of a struct
+// (honoring their `gopter:"..."` tags, see parseTag)
+func (a *Arbitraries) genForFields(rt reflect.Type, inProgress map[reflect.Type]bool) map[string]gopter.Gen {
+	inProgress[rt] = true
+	defer delete(inProgress, rt)
+
+	gens := make(map[string]gopter.Gen)
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			// unexported fields can not be set
+			continue
+		}
+		if tag, ok := field.Tag.Lookup("gopter"); ok {
+			gens[field.Name] = a.genForTag(rt, field, tag, inProgress)
+		} else if gen := a.genForType(field.Type, inProgress); gen != nil {
+			gens[field.Name] = gen
+		}
+	}
+	return gens
+}
+
+// genRecursive creates a generator for a recursive reference (e.g. the next
+// pointer of a linked list) that ends the recursion with the "end" value in two
+// out of three cases and derives the generator only if required.
+func (a *Arbitraries) genRecursive(rt reflect.Type, end gopter.Gen) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		if genParams.Rng.Intn(3) != 0 {
+			return end(genParams)
+		}
+		return a.GenForType(rt)(genParams)
+	}
+}
+
+// genForImplementations creates a generator for an interface type that picks
+// one of its implementations. Implementations that are currently derived
+// (i.e. recursive references like the operands of a binary expression) are
+// only picked in one out of three cases and derived only if required.
+// If all implementations are recursive, no generator is derived.
+func (a *Arbitraries) genForImplementations(rt reflect.Type, implementations []reflect.Type, inProgress map[reflect.Type]bool) gopter.Gen {
+	var gens, recursiveGens []gopter.Gen
+	for _, implementation := range implementations {
+		implementation := implementation
+		if inProgress[implementation] || implementation.Kind() == reflect.Ptr && inProgress[implementation.Elem()] {
+			recursiveGens = append(recursiveGens, func(genParams *gopter.GenParameters) *gopter.GenResult {
+				return a.GenForType(implementation)(genParams)
+			})
+		} else if implementationGen := a.genForType(implementation, inProgress); implementationGen != nil {
+			gens = append(gens, implementationGen)
+		}
+	}
+	if len(gens) == 0 {
+		return nil
+	}
+	// the sieves and shrinkers of the implementations by type (the results of
+	// slices or structs might use the sieve and shrinker of any element)
+	var results sync.Map
+	sieve := func(v interface{}) bool {
+		result, ok := results.Load(reflect.TypeOf(v))
+		return ok && (result.(*gopter.GenResult).Sieve == nil || result.(*gopter.GenResult).Sieve(v))
+	}
+	shrinker := func(v interface{}) gopter.Shrink {
+		if result, ok := results.Load(reflect.TypeOf(v)); ok {
+			return result.(*gopter.GenResult).Shrinker(v)
+		}
+		return gopter.NoShrink
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var result *gopter.GenResult
+		if len(recursiveGens) > 0 && genParams.Rng.Intn(3) == 0 {
+			result = recursiveGens[genParams.Rng.Intn(len(recursiveGens))](genParams)
+		} else {
+			result = gens[genParams.Rng.Intn(len(gens))](genParams)
+		}
+		if value, ok := result.Retrieve(); ok {
+			results.Store(reflect.TypeOf(value), result)
+		}
+		interfaceResult := *result
+		interfaceResult.ResultType = rt
+		interfaceResult.Sieve = sieve
+		interfaceResult.Shrinker = shrinker
+		return &interfaceResult
+	}
+}
@@ -1,6 +1,7 @@
 package arbitrary_test
 
 import (
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -132,4 +133,22 @@ func TestArbitrariesSimple(t *testing.T) {
 		_, ok := value.(string)
 		return ok
 	})
+
+	gen = arbitraries.GenForType(reflect.TypeOf(&big.Int{}))
+	commonGeneratorTest(t, "big int", gen, func(value interface{}) bool {
+		v, ok := value.(*big.Int)
+		return ok && v.BitLen() <= 256
+	})
+
+	gen = arbitraries.GenForType(reflect.TypeOf(&big.Rat{}))
+	commonGeneratorTest(t, "big rat", gen, func(value interface{}) bool {
+		_, ok := value.(*big.Rat)
+		return ok
+	})
+
+	gen = arbitraries.GenForType(reflect.TypeOf(&big.Float{}))
+	commonGeneratorTest(t, "big float", gen, func(value interface{}) bool {
+		_, ok := value.(*big.Float)
+		return ok
+	})
 }
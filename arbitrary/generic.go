@@ -0,0 +1,123 @@
+package arbitrary
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var predeclaredTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, v := range []interface{}{
+		false, 0, int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0), uintptr(0),
+		float32(0), float64(0), complex64(0), complex128(0), "",
+	} {
+		rt := reflect.TypeOf(v)
+		predeclaredTypes[rt.Name()] = rt
+	}
+}
+
+// genericOrigin gets the fully qualified name of the generic type rt is an
+// instantiation of (e.g. "example.com/pkg.Option" for pkg.Option[int]), or
+// an empty string if rt is not an instantiated generic type
+func genericOrigin(rt reflect.Type) string {
+	name := rt.Name()
+	idx := strings.IndexByte(name, '[')
+	if idx < 0 {
+		return ""
+	}
+	return rt.PkgPath() + "." + name[:idx]
+}
+
+// TypeArgs gets the type arguments of an instantiated generic type (e.g.
+// [int, string] for Pair[int, string]).
+// Since reflection does not provide the type arguments directly, they are
+// resolved by name from the predeclared types and the types used by the fields
+// of rt. Type arguments that can not be resolved this way are nil.
+// Returns nil if rt is not an instantiated generic type.
+func TypeArgs(rt reflect.Type) []reflect.Type {
+	name := rt.Name()
+	idx := strings.IndexByte(name, '[')
+	if idx < 0 || !strings.HasSuffix(name, "]") {
+		return nil
+	}
+	known := map[string]reflect.Type{}
+	for typeName, t := range predeclaredTypes {
+		known[typeName] = t
+	}
+	collectTypeNames(rt, known, map[reflect.Type]bool{})
+
+	argNames := splitTypeArgs(name[idx+1 : len(name)-1])
+	args := make([]reflect.Type, len(argNames))
+	for i, argName := range argNames {
+		args[i] = known[argName]
+	}
+	return args
+}
+
+// splitTypeArgs splits a comma separated list of type arguments ignoring the
+// commas of nested type argument lists
+func splitTypeArgs(list string) []string {
+	var result []string
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(result, strings.TrimSpace(list[start:]))
+}
+
+// collectTypeNames collects all types reachable via the fields, elements and
+// keys of rt by the name they have in a type argument list
+func collectTypeNames(rt reflect.Type, known map[string]reflect.Type, visited map[reflect.Type]bool) {
+	if visited[rt] {
+		return
+	}
+	visited[rt] = true
+	known[typeArgName(rt)] = rt
+
+	switch rt.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+		collectTypeNames(rt.Elem(), known, visited)
+	case reflect.Map:
+		collectTypeNames(rt.Key(), known, visited)
+		collectTypeNames(rt.Elem(), known, visited)
+	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			collectTypeNames(rt.Field(i).Type, known, visited)
+		}
+	}
+}
+
+// typeArgName formats a type the way it appears in the type argument list of
+// an instantiated generic type, i.e. with the full package path
+func typeArgName(rt reflect.Type) string {
+	if rt.Name() != "" {
+		if rt.PkgPath() == "" {
+			return rt.Name()
+		}
+		return rt.PkgPath() + "." + rt.Name()
+	}
+	switch rt.Kind() {
+	case reflect.Ptr:
+		return "*" + typeArgName(rt.Elem())
+	case reflect.Slice:
+		return "[]" + typeArgName(rt.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", rt.Len(), typeArgName(rt.Elem()))
+	case reflect.Map:
+		return "map[" + typeArgName(rt.Key()) + "]" + typeArgName(rt.Elem())
+	}
+	return rt.String()
+}
@@ -0,0 +1,142 @@
+//go:build go1.18
+// +build go1.18
+
+package arbitrary_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/arbitrary"
+	"github.com/leanovate/gopter/gen"
+)
+
+type Option[T any] struct {
+	Value T
+	Valid bool
+}
+
+type Pair[K comparable, V any] struct {
+	Key    K
+	Values []V
+}
+
+type Node[T any] struct {
+	Value T
+	Next  *Node[T]
+}
+
+type List[T any] []T
+
+type Set[T comparable] map[T]struct{}
+
+type Result[T any] struct {
+	Value  T
+	Errors List[string]
+	Window [2]T
+}
+
+type Stack[T any] struct {
+	Name  string
+	items []T
+}
+
+func TestArbitrariesGenericTypes(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	arbitraries.RegisterGen(gen.IntRange(1, 10))
+
+	for _, value := range []interface{}{Option[int]{}, Pair[string, *int]{}, Stack[int]{}} {
+		rt := reflect.TypeOf(value)
+		result, ok := arbitraries.GenForType(rt).Sample()
+		if !ok || reflect.TypeOf(result) != rt {
+			t.Errorf("Invalid value for %v: %#v", rt, result)
+		}
+	}
+
+	lengths := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		result, ok := arbitraries.GenForType(reflect.TypeOf(&Node[int]{})).Sample()
+		node, isNode := result.(*Node[int])
+		if !ok || !isNode {
+			t.Fatalf("Invalid list: %#v", result)
+		}
+		length := 0
+		for ; node != nil; node = node.Next {
+			if node.Value < 1 || node.Value > 10 {
+				t.Errorf("Invalid list value: %d", node.Value)
+			}
+			length++
+		}
+		lengths[length] = true
+	}
+	if len(lengths) < 2 {
+		t.Errorf("Lists should have various lengths: %v", lengths)
+	}
+}
+
+func TestArbitrariesGenericContainers(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	arbitraries.RegisterGen(gen.IntRange(1, 10))
+
+	for _, value := range []interface{}{List[int]{}, Set[string]{}, Result[int]{}, Option[List[Set[int]]]{}, [3]int{}} {
+		rt := reflect.TypeOf(value)
+		for i := 0; i < 20; i++ {
+			result, ok := arbitraries.GenForType(rt).Sample()
+			if !ok || reflect.TypeOf(result) != rt {
+				t.Fatalf("Invalid value for %v: %#v", rt, result)
+			}
+		}
+	}
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := arbitraries.ForAll(func(list List[int]) bool {
+		return len(list) < 3
+	}).Check(parameters)
+	if list, ok := result.Args[0].Arg.(List[int]); result.Status != gopter.TestFailed || !ok || len(list) != 3 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	result = arbitraries.ForAll(func(set Set[int]) bool {
+		return len(set) < 2
+	}).Check(parameters)
+	if set, ok := result.Args[0].Arg.(Set[int]); result.Status != gopter.TestFailed || !ok || len(set) != 2 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	result = arbitraries.ForAll(func(result Result[int]) bool {
+		return result.Window[1] < 5
+	}).Check(parameters)
+	if value, ok := result.Args[0].Arg.(Result[int]); result.Status != gopter.TestFailed || !ok || value.Window != [2]int{1, 5} {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
+
+func TestArbitrariesRegisterGenericGen(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	arbitraries.RegisterGenericGen(Option[int]{}, func(rt reflect.Type) gopter.Gen {
+		return gen.Struct(rt, map[string]gopter.Gen{
+			"Value": arbitraries.GenForType(arbitrary.TypeArgs(rt)[0]),
+			"Valid": gen.Const(true),
+		})
+	})
+
+	for i := 0; i < 100; i++ {
+		result, ok := arbitraries.GenForType(reflect.TypeOf(Option[string]{})).Sample()
+		if option, isOption := result.(Option[string]); !ok || !isOption || !option.Valid {
+			t.Fatalf("Invalid option: %#v", result)
+		}
+	}
+}
+
+func TestTypeArgs(t *testing.T) {
+	args := arbitrary.TypeArgs(reflect.TypeOf(Pair[Option[int], map[string][]*Node[int]]{}))
+	expected := []reflect.Type{reflect.TypeOf(Option[int]{}), reflect.TypeOf(map[string][]*Node[int]{})}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Invalid type args: %v", args)
+	}
+	if args := arbitrary.TypeArgs(reflect.TypeOf(Option[struct{ A int }]{})); len(args) != 1 || args[0] == nil {
+		t.Errorf("Invalid type args: %v", args)
+	}
+	if args := arbitrary.TypeArgs(reflect.TypeOf(DemoStruct{})); args != nil {
+		t.Errorf("Invalid type args: %v", args)
+	}
+}
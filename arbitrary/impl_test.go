@@ -0,0 +1,140 @@
+package arbitrary_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/arbitrary"
+	"github.com/leanovate/gopter/prop"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Radius float64 `gopter:"range=0:10"`
+}
+
+func (c Circle) Area() float64 {
+	return 3.14159 * c.Radius * c.Radius
+}
+
+type Square struct {
+	Side int `gopter:"range=0:10"`
+}
+
+func (s *Square) Area() float64 {
+	return float64(s.Side * s.Side)
+}
+
+type Drawing struct {
+	Main   Shape
+	Shapes []Shape
+}
+
+type Expr interface {
+	Eval() int
+}
+
+type Num struct {
+	Value int `gopter:"range=-10:10"`
+}
+
+func (n Num) Eval() int {
+	return n.Value
+}
+
+type Add struct {
+	Left, Right Expr
+}
+
+func (a *Add) Eval() int {
+	return a.Left.Eval() + a.Right.Eval()
+}
+
+func exprDepth(expr Expr) int {
+	if add, ok := expr.(*Add); ok {
+		left, right := exprDepth(add.Left), exprDepth(add.Right)
+		if left > right {
+			return left + 1
+		}
+		return right + 1
+	}
+	return 1
+}
+
+func TestArbitrariesImplementations(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	arbitraries.RegisterImpl((*Shape)(nil), Circle{}, &Square{})
+	arbitraries.RegisterImpl((*Expr)(nil), Num{}, &Add{})
+
+	types := map[reflect.Type]bool{}
+	drawingGen := arbitraries.GenForType(reflect.TypeOf(Drawing{}))
+	for i := 0; i < 100; i++ {
+		raw, ok := drawingGen.Sample()
+		if !ok {
+			t.Fatalf("Invalid value: %#v", raw)
+		}
+		drawing := raw.(Drawing)
+		for _, shape := range append(drawing.Shapes, drawing.Main) {
+			if shape == nil || shape.Area() < 0 || shape.Area() > 315 {
+				t.Errorf("Invalid shape: %#v", shape)
+			}
+			types[reflect.TypeOf(shape)] = true
+		}
+	}
+	if !types[reflect.TypeOf(Circle{})] || !types[reflect.TypeOf(&Square{})] {
+		t.Errorf("Implementations not generated: %v", types)
+	}
+
+	exprGen := arbitraries.GenForType(reflect.TypeOf((*Expr)(nil)).Elem())
+	maxDepth := 0
+	for i := 0; i < 100; i++ {
+		raw, ok := exprGen.Sample()
+		if !ok {
+			t.Fatalf("Invalid value: %#v", raw)
+		}
+		if depth := exprDepth(raw.(Expr)); depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+	if maxDepth < 2 {
+		t.Errorf("Recursive implementation never generated")
+	}
+}
+
+func TestArbitrariesImplementationsShrink(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	arbitraries.RegisterImpl((*Expr)(nil), Num{}, &Add{})
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(expr Expr) bool {
+		return expr.Eval() < 5
+	}, arbitraries.GenForType(reflect.TypeOf((*Expr)(nil)).Elem())).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if expr := result.Args[0].Arg.(Expr); expr.Eval() != 5 {
+		t.Errorf("Invalid shrunk value: %#v", expr)
+	}
+}
+
+func TestRegisterImplInvalid(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	for _, register := range []func(){
+		func() { arbitraries.RegisterImpl(Circle{}, Circle{}) },
+		func() { arbitraries.RegisterImpl((*Shape)(nil), Num{}) },
+		func() { arbitraries.RegisterImpl((*Shape)(nil), Square{}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic")
+				}
+			}()
+			register()
+		}()
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Package openapi compiles OpenAPI 3 documents into generators of valid
+requests (path, query, header and cookie parameters and JSON bodies) and
+responses of their operations, so contract properties of HTTP handlers can be
+generated directly from the specification.
+
+A simple example might look like this:
+
+	func TestPetsHandler(t *testing.T) {
+	  spec, err := openapi.Parse(petstoreJSON)
+	  if err != nil {
+	    t.Fatal(err)
+	  }
+	  properties := gopter.NewProperties(nil)
+
+	  properties.Property("handler never fails", prop.ForAll(
+	    func(request openapi.Request) bool {
+	      httpRequest, _ := request.HTTPRequest("http://localhost")
+	      recorder := httptest.NewRecorder()
+	      handler.ServeHTTP(recorder, httpRequest)
+	      return recorder.Code < 500
+	    },
+	    spec.AnyRequestGen()))
+
+	  properties.TestingRun(t)
+	}
+
+The supported schemas are the types of JSON Schema with their common
+constraints (lengths, ranges, multipleOf, patterns, formats, enum, uniqueItems),
+nullable, allOf, oneOf, anyOf and local (possibly recursive) references.
+YAML documents have to be converted to JSON first.
+*/
+package openapi
@@ -0,0 +1,553 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+// methods are the HTTP methods of the operations of a path item
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// bodyKey is the key of the request body in the values of a request
+const bodyKey = "body"
+
+// Spec is a compiled OpenAPI 3 document
+type Spec struct {
+	operationIDs []string
+	operations   map[string]*operation
+	schemas      map[string]*schema
+}
+
+type operation struct {
+	id          string
+	method      string
+	path        string
+	parameters  map[string]*parameter
+	contentType string
+	values      *schema
+	responses   []*response
+}
+
+// parameter is a parameter of an operation (the key of its value is
+// "<in>:<name>")
+type parameter struct {
+	name    string
+	in      string
+	explode bool
+}
+
+type response struct {
+	statusCode  int
+	contentType string
+	body        *schema
+}
+
+// Request is a generated request of an operation
+type Request struct {
+	OperationID string
+	Method      string
+	// Path is the path of the operation with the (escaped) path parameters
+	Path    string
+	Query   url.Values
+	Header  http.Header
+	Cookies []*http.Cookie
+	// ContentType is the content type of the body (if HasBody)
+	ContentType string
+	// Body is the request body as decoded by encoding/json (but integers as
+	// int64)
+	Body    interface{}
+	HasBody bool
+	// values are the generated parameters and body
+	values map[string]interface{}
+}
+
+// Response is a generated response of an operation
+type Response struct {
+	StatusCode  int
+	ContentType string
+	// Body is the response body as decoded by encoding/json (but integers as
+	// int64)
+	Body    interface{}
+	HasBody bool
+}
+
+// Parse compiles an OpenAPI 3 document in JSON format.
+// Only local references ("#/...") and JSON request and response bodies are
+// supported.
+func Parse(data []byte) (*Spec, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("Invalid OpenAPI document: %v", err)
+	}
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("Unsupported OpenAPI version: %v", doc["openapi"])
+	}
+	spec := &Spec{
+		operations: map[string]*operation{},
+		schemas:    map[string]*schema{},
+	}
+	requests, responses := newCompiler(doc, false), newCompiler(doc, true)
+
+	components, _ := doc["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	for name := range schemas {
+		compiled, err := requests.compile(map[string]interface{}{"$ref": "#/components/schemas/" + name})
+		if err != nil {
+			return nil, fmt.Errorf("Schema %s: %v", name, err)
+		}
+		spec.schemas[name] = compiled
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for path, item := range paths {
+		itemNode, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid path item: %s", path)
+		}
+		for _, method := range methods {
+			operationNode, ok := itemNode[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			compiled, err := compileOperation(requests, responses, path, method, itemNode, operationNode)
+			if err != nil {
+				return nil, fmt.Errorf("Operation %s %s: %v", strings.ToUpper(method), path, err)
+			}
+			if spec.operations[compiled.id] != nil {
+				return nil, fmt.Errorf("Duplicate operation: %s", compiled.id)
+			}
+			spec.operations[compiled.id] = compiled
+			spec.operationIDs = append(spec.operationIDs, compiled.id)
+		}
+	}
+	sort.Strings(spec.operationIDs)
+	return spec, nil
+}
+
+func compileOperation(requests, responses *compiler, path, method string, itemNode, operationNode map[string]interface{}) (*operation, error) {
+	compiled := &operation{
+		method:     strings.ToUpper(method),
+		path:       path,
+		parameters: map[string]*parameter{},
+	}
+	compiled.id, _ = operationNode["operationId"].(string)
+	if compiled.id == "" {
+		compiled.id = compiled.method + " " + path
+	}
+
+	var names []string
+	properties := map[string]*schema{}
+	required := map[string]bool{}
+	// the parameters of the operation override those of the path item
+	itemParameters, _ := itemNode["parameters"].([]interface{})
+	operationParameters, _ := operationNode["parameters"].([]interface{})
+	for _, parameterValue := range append(itemParameters, operationParameters...) {
+		parameterNode, ok := parameterValue.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid parameter: %v", parameterValue)
+		}
+		parameterNode, err := requests.resolveNode(parameterNode)
+		if err != nil {
+			return nil, err
+		}
+		name, _ := parameterNode["name"].(string)
+		in, _ := parameterNode["in"].(string)
+		schemaNode, ok := parameterNode["schema"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Parameter %s without schema", name)
+		}
+		compiledSchema, err := requests.compile(schemaNode)
+		if err != nil {
+			return nil, fmt.Errorf("Parameter %s: %v", name, err)
+		}
+		if in == "path" {
+			compiledSchema = nonEmptySchema(compiledSchema)
+		}
+		key := in + ":" + name
+		if properties[key] == nil {
+			names = append(names, key)
+		}
+		properties[key] = compiledSchema
+		required[key], _ = parameterNode["required"].(bool)
+		required[key] = required[key] || in == "path"
+		explode, ok := parameterNode["explode"].(bool)
+		if !ok {
+			// only the form style (of query and cookie parameters) explodes by default
+			style, _ := parameterNode["style"].(string)
+			explode = style == "form" || style == "" && (in == "query" || in == "cookie")
+		}
+		compiled.parameters[key] = &parameter{name: name, in: in, explode: explode}
+	}
+
+	if bodyValue, ok := operationNode["requestBody"].(map[string]interface{}); ok {
+		bodyNode, err := requests.resolveNode(bodyValue)
+		if err != nil {
+			return nil, err
+		}
+		contentType, schemaNode := jsonContent(bodyNode)
+		bodyRequired, _ := bodyNode["required"].(bool)
+		switch {
+		case schemaNode != nil:
+			body, err := requests.compile(schemaNode)
+			if err != nil {
+				return nil, fmt.Errorf("Request body: %v", err)
+			}
+			compiled.contentType = contentType
+			names = append(names, bodyKey)
+			properties[bodyKey] = body
+			required[bodyKey] = bodyRequired
+		case bodyRequired:
+			return nil, fmt.Errorf("Unsupported request body content")
+		}
+	}
+	sort.Strings(names)
+	compiled.values = objectSchema(names, properties, required)
+
+	responseNodes, _ := operationNode["responses"].(map[string]interface{})
+	statuses := make([]string, 0, len(responseNodes))
+	for status := range responseNodes {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		responseNode, ok := responseNodes[status].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid response: %s", status)
+		}
+		compiledResponse, err := compileResponse(responses, status, responseNode)
+		if err != nil {
+			return nil, fmt.Errorf("Response %s: %v", status, err)
+		}
+		compiled.responses = append(compiled.responses, compiledResponse)
+	}
+	return compiled, nil
+}
+
+func compileResponse(responses *compiler, status string, responseNode map[string]interface{}) (*response, error) {
+	compiled := &response{}
+	switch {
+	case status == "default":
+		compiled.statusCode = http.StatusInternalServerError
+	case len(status) == 3 && strings.HasSuffix(strings.ToUpper(status), "XX"):
+		compiled.statusCode = int(status[0]-'0') * 100
+	default:
+		statusCode, err := strconv.Atoi(status)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid status code: %s", status)
+		}
+		compiled.statusCode = statusCode
+	}
+	responseNode, err := responses.resolveNode(responseNode)
+	if err != nil {
+		return nil, err
+	}
+	if contentType, schemaNode := jsonContent(responseNode); schemaNode != nil {
+		body, err := responses.compile(schemaNode)
+		if err != nil {
+			return nil, err
+		}
+		compiled.contentType = contentType
+		compiled.body = body
+	}
+	return compiled, nil
+}
+
+// jsonContent finds the JSON content ("application/json", "*/*+json" or
+// "*/*") of a request body or response
+func jsonContent(node map[string]interface{}) (string, map[string]interface{}) {
+	content, _ := node["content"].(map[string]interface{})
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	for _, contentType := range contentTypes {
+		mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") && mediaType != "*/*" {
+			continue
+		}
+		mediaTypeNode, _ := content[contentType].(map[string]interface{})
+		if schemaNode, ok := mediaTypeNode["schema"].(map[string]interface{}); ok {
+			if mediaType == "*/*" {
+				contentType = "application/json"
+			}
+			return contentType, schemaNode
+		}
+	}
+	return "", nil
+}
+
+// nonEmptySchema restricts a schema to values that are not serialized as
+// an empty string (which is not a valid path parameter)
+func nonEmptySchema(inner *schema) *schema {
+	valid := func(v interface{}) bool {
+		return inner.valid(v) && serialize(v, false) != ""
+	}
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			for i := 0; i < 100; i++ {
+				if value, ok := inner.generate(genParams, depth); ok && valid(value) {
+					return value, true
+				}
+			}
+			return nil, false
+		},
+		valid: valid,
+		shrink: func(v interface{}) gopter.Shrink {
+			return inner.shrink(v).Filter(valid)
+		},
+	}
+}
+
+// OperationIDs are the IDs of all operations (the "operationId" or
+// "<METHOD> <path>" if there is none)
+func (s *Spec) OperationIDs() []string {
+	return append([]string{}, s.operationIDs...)
+}
+
+// SchemaGen generates the values of a schema of the components of the
+// document
+func (s *Spec) SchemaGen(name string) gopter.Gen {
+	compiled, ok := s.schemas[name]
+	if !ok {
+		return gen.Fail(valueType)
+	}
+	return compiled.Gen()
+}
+
+// RequestGen generates valid requests (Request) of an operation: its path,
+// query, header and cookie parameters and its JSON request body.
+// Optional parameters, optional properties and nullable values are omitted
+// randomly.
+// The operation ID is added as label.
+// The requests shrink by removing optional parameters and properties and
+// shrinking the values (as long as they stay valid).
+func (s *Spec) RequestGen(operationID string) gopter.Gen {
+	compiled, ok := s.operations[operationID]
+	if !ok {
+		return gen.Fail(reflect.TypeOf(Request{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		values, ok := compiled.values.generate(genParams, MaxDepth+1)
+		if !ok {
+			return gopter.NewEmptyResult(reflect.TypeOf(Request{}))
+		}
+		genResult := gopter.NewGenResult(compiled.request(values.(map[string]interface{})), func(v interface{}) gopter.Shrink {
+			return compiled.values.shrink(v.(Request).values).Map(compiled.request)
+		})
+		genResult.Labels = []string{operationID}
+		return genResult
+	}
+}
+
+// AnyRequestGen generates the requests of any of the operations (see
+// RequestGen)
+func (s *Spec) AnyRequestGen() gopter.Gen {
+	gens := make([]gopter.Gen, len(s.operationIDs))
+	for i, operationID := range s.operationIDs {
+		gens[i] = s.RequestGen(operationID)
+	}
+	if len(gens) == 0 {
+		return gen.Fail(reflect.TypeOf(Request{}))
+	}
+	return gen.OneGenOf(gens...)
+}
+
+// ResponseGen generates valid responses (Response) of an operation with any
+// of its documented status codes ("2XX" becomes 200 and "default" 500).
+// The status code is added as label.
+// The responses shrink by shrinking their bodies.
+func (s *Spec) ResponseGen(operationID string) gopter.Gen {
+	compiled, ok := s.operations[operationID]
+	if !ok || len(compiled.responses) == 0 {
+		return gen.Fail(reflect.TypeOf(Response{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		compiledResponse := compiled.responses[genParams.Rng.Intn(len(compiled.responses))]
+		result := Response{StatusCode: compiledResponse.statusCode}
+		shrinker := gopter.NoShrinker
+		if compiledResponse.body != nil {
+			body, ok := compiledResponse.body.generate(genParams, MaxDepth)
+			if !ok {
+				return gopter.NewEmptyResult(reflect.TypeOf(Response{}))
+			}
+			result.ContentType, result.Body, result.HasBody = compiledResponse.contentType, body, true
+			shrinker = func(v interface{}) gopter.Shrink {
+				response := v.(Response)
+				bodyShrink := compiledResponse.body.shrink(response.Body)
+				// Map does not support shrinking to null
+				return func() (interface{}, bool) {
+					body, ok := bodyShrink()
+					if !ok {
+						return nil, false
+					}
+					response.Body = body
+					return response, true
+				}
+			}
+		}
+		genResult := gopter.NewGenResult(result, shrinker)
+		genResult.Labels = []string{strconv.Itoa(result.StatusCode)}
+		return genResult
+	}
+}
+
+// request serializes the generated values of the parameters and the body
+func (o *operation) request(values map[string]interface{}) Request {
+	request := Request{
+		OperationID: o.id,
+		Method:      o.method,
+		Path:        o.path,
+		Query:       url.Values{},
+		Header:      http.Header{},
+		values:      values,
+	}
+	if body, ok := values[bodyKey]; ok {
+		request.ContentType, request.Body, request.HasBody = o.contentType, body, true
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		p, value := o.parameters[key], values[key]
+		if p == nil {
+			continue
+		}
+		switch p.in {
+		case "path":
+			request.Path = strings.Replace(request.Path, "{"+p.name+"}", url.PathEscape(serialize(value, p.explode)), -1)
+		case "header":
+			request.Header.Set(p.name, serialize(value, p.explode))
+		case "cookie":
+			request.Cookies = append(request.Cookies, &http.Cookie{Name: p.name, Value: serialize(value, false)})
+		case "query":
+			switch value := value.(type) {
+			case []interface{}:
+				if !p.explode {
+					request.Query.Add(p.name, serialize(value, false))
+					continue
+				}
+				for _, element := range value {
+					request.Query.Add(p.name, serialize(element, false))
+				}
+			case map[string]interface{}:
+				if !p.explode {
+					request.Query.Add(p.name, serialize(value, false))
+					continue
+				}
+				for _, name := range sortedKeys(value) {
+					request.Query.Add(name, serialize(value[name], false))
+				}
+			default:
+				request.Query.Add(p.name, serialize(value, false))
+			}
+		}
+	}
+	return request
+}
+
+// serialize serializes a parameter value in the simple style: arrays as
+// comma separated values, objects as "key,value" pairs (or "key=value" if
+// exploded)
+func serialize(value interface{}, explode bool) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case []interface{}:
+		elements := make([]string, len(value))
+		for i, element := range value {
+			elements[i] = serialize(element, false)
+		}
+		return strings.Join(elements, ",")
+	case map[string]interface{}:
+		elements := make([]string, 0, 2*len(value))
+		for _, name := range sortedKeys(value) {
+			if explode {
+				elements = append(elements, name+"="+serialize(value[name], false))
+			} else {
+				elements = append(elements, name, serialize(value[name], false))
+			}
+		}
+		return strings.Join(elements, ",")
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HTTPRequest creates an http.Request of the request against a server at
+// baseURL (e.g. "http://localhost:8080/api")
+func (r Request) HTTPRequest(baseURL string) (*http.Request, error) {
+	target := strings.TrimSuffix(baseURL, "/") + r.Path
+	if len(r.Query) > 0 {
+		target += "?" + r.Query.Encode()
+	}
+	var body *bytes.Reader
+	if r.HasBody {
+		data, err := json.Marshal(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	var httpRequest *http.Request
+	var err error
+	if body != nil {
+		httpRequest, err = http.NewRequest(r.Method, target, body)
+	} else {
+		httpRequest, err = http.NewRequest(r.Method, target, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.Header {
+		httpRequest.Header[name] = append([]string{}, values...)
+	}
+	for _, cookie := range r.Cookies {
+		httpRequest.AddCookie(cookie)
+	}
+	if r.HasBody {
+		httpRequest.Header.Set("Content-Type", r.ContentType)
+	}
+	return httpRequest, nil
+}
+
+func (r Request) String() string {
+	target := r.Path
+	if len(r.Query) > 0 {
+		target += "?" + r.Query.Encode()
+	}
+	if !r.HasBody {
+		return r.Method + " " + target
+	}
+	data, _ := json.Marshal(r.Body)
+	return r.Method + " " + target + " " + string(data)
+}
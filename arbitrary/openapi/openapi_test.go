@@ -0,0 +1,254 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/arbitrary/openapi"
+	"github.com/leanovate/gopter/prop"
+)
+
+const petstore = `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "integer", "format": "int32", "minimum": 1, "maximum": 100}},
+          {"name": "tags", "in": "query", "schema": {"type": "array", "items": {"type": "string", "enum": ["cat", "dog"]}}},
+          {"$ref": "#/components/parameters/RequestID"}
+        ],
+        "responses": {
+          "200": {"description": "pets", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}},
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "operationId": "createPet",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+        },
+        "responses": {
+          "201": {"description": "created"}
+        }
+      }
+    },
+    "/pets/{petId}": {
+      "parameters": [
+        {"name": "petId", "in": "path", "required": true, "schema": {"type": "integer", "minimum": 1}}
+      ],
+      "get": {
+        "responses": {
+          "200": {"description": "pet", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+          "4XX": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "RequestID": {"name": "X-Request-ID", "in": "header", "required": true, "schema": {"type": "string", "format": "uuid"}}
+    },
+    "responses": {
+      "Error": {"description": "error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}
+    },
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "required": ["name", "age"],
+        "properties": {
+          "id": {"type": "integer", "readOnly": true},
+          "name": {"type": "string", "minLength": 1, "maxLength": 20},
+          "age": {"type": "integer", "minimum": 0, "maximum": 30},
+          "tag": {"type": "string", "nullable": true},
+          "tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]{2,5}$"}, "uniqueItems": true, "maxItems": 3},
+          "owner": {"$ref": "#/components/schemas/Person"}
+        }
+      },
+      "Person": {
+        "type": "object",
+        "required": ["email"],
+        "properties": {
+          "email": {"type": "string", "format": "email"},
+          "friends": {"type": "array", "items": {"$ref": "#/components/schemas/Person"}}
+        }
+      },
+      "Error": {
+        "required": ["code", "message"],
+        "properties": {
+          "code": {"type": "integer", "minimum": 400, "maximum": 599},
+          "message": {"type": "string"}
+        }
+      }
+    }
+  }
+}`
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func parsePetstore(t *testing.T) *openapi.Spec {
+	spec, err := openapi.Parse([]byte(petstore))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return spec
+}
+
+func checkPet(t *testing.T, value interface{}) {
+	pet, ok := value.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Invalid pet: %#v", value)
+	}
+	name, ok := pet["name"].(string)
+	if !ok || len(name) < 1 || len(name) > 20 {
+		t.Errorf("Invalid name: %#v", pet)
+	}
+	if age, ok := pet["age"].(int64); !ok || age < 0 || age > 30 {
+		t.Errorf("Invalid age: %#v", pet)
+	}
+	if tags, ok := pet["tags"]; ok && len(tags.([]interface{})) > 3 {
+		t.Errorf("Invalid tags: %#v", pet)
+	}
+}
+
+func TestParse(t *testing.T) {
+	spec := parsePetstore(t)
+	if ids := spec.OperationIDs(); len(ids) != 3 || ids[0] != "GET /pets/{petId}" || ids[1] != "createPet" || ids[2] != "listPets" {
+		t.Errorf("Invalid operation IDs: %#v", ids)
+	}
+
+	for _, doc := range []string{
+		`not json`,
+		`{"swagger": "2.0"}`,
+		`{"openapi": "3.0.0", "components": {"schemas": {"A": {"$ref": "#/components/schemas/B"}}}}`,
+		`{"openapi": "3.0.0", "components": {"schemas": {"A": {"type": "file"}}}}`,
+		`{"openapi": "3.0.0", "components": {"schemas": {"A": {"type": "string", "minLength": 3, "maxLength": 2}}}}`,
+		`{"openapi": "3.0.0", "components": {"schemas": {"A": {"type": "integer", "minimum": 5, "maximum": 5, "exclusiveMaximum": true}}}}`,
+		`{"openapi": "3.0.0", "paths": {"/": {"post": {"requestBody": {"required": true, "content": {"text/plain": {"schema": {"type": "string"}}}}}}}}`,
+	} {
+		if _, err := openapi.Parse([]byte(doc)); err == nil {
+			t.Errorf("Expected error for %s", doc)
+		}
+	}
+}
+
+func TestRequestGen(t *testing.T) {
+	spec := parsePetstore(t)
+	parameters := gopter.DefaultGenParameters()
+	pathPattern := regexp.MustCompile(`^/pets/[1-9][0-9]*$`)
+
+	for i := 0; i < 100; i++ {
+		value, ok := spec.RequestGen("createPet")(parameters).Retrieve()
+		if !ok {
+			t.Fatal("Request not generated")
+		}
+		request := value.(openapi.Request)
+		if request.Method != "POST" || request.Path != "/pets" || !request.HasBody || request.ContentType != "application/json" {
+			t.Errorf("Invalid request: %v", request)
+		}
+		checkPet(t, request.Body)
+		if _, ok := request.Body.(map[string]interface{})["id"]; ok {
+			t.Errorf("Read-only property in request: %v", request)
+		}
+		httpRequest, err := request.HTTPRequest("http://localhost:8080/api/")
+		if err != nil || httpRequest.URL.String() != "http://localhost:8080/api/pets" || httpRequest.Header.Get("Content-Type") != "application/json" {
+			t.Fatalf("Invalid http request: %#v (%v)", httpRequest, err)
+		}
+		data, _ := ioutil.ReadAll(httpRequest.Body)
+		var body interface{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("Invalid body: %s", data)
+		}
+
+		value, _ = spec.RequestGen("listPets")(parameters).Retrieve()
+		request = value.(openapi.Request)
+		if !uuidPattern.MatchString(request.Header.Get("X-Request-ID")) || request.HasBody {
+			t.Errorf("Invalid request: %v", request)
+		}
+		if limit := request.Query.Get("limit"); limit != "" {
+			if n, err := strconv.Atoi(limit); err != nil || n < 1 || n > 100 {
+				t.Errorf("Invalid limit: %v", request)
+			}
+		}
+		for _, tag := range request.Query["tags"] {
+			if tag != "cat" && tag != "dog" {
+				t.Errorf("Invalid tags: %v", request)
+			}
+		}
+
+		value, _ = spec.RequestGen("GET /pets/{petId}")(parameters).Retrieve()
+		request = value.(openapi.Request)
+		if !pathPattern.MatchString(request.Path) {
+			t.Errorf("Invalid path: %v", request)
+		}
+	}
+
+	if _, ok := spec.RequestGen("unknown")(parameters).Retrieve(); ok {
+		t.Error("Request of unknown operation generated")
+	}
+}
+
+func TestRequestGenShrink(t *testing.T) {
+	spec := parsePetstore(t)
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(request openapi.Request) bool {
+		return request.Body.(map[string]interface{})["age"].(int64) < 10
+	}, spec.RequestGen("createPet")).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	request := result.Args[0].Arg.(openapi.Request)
+	body := request.Body.(map[string]interface{})
+	if len(body) != 2 || body["age"] != int64(10) || len(body["name"].(string)) != 1 {
+		t.Errorf("Invalid shrunk request: %v", request)
+	}
+
+	result = prop.ForAll(func(request openapi.Request) bool {
+		return len(request.Query) < 2
+	}, spec.RequestGen("listPets")).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	request = result.Args[0].Arg.(openapi.Request)
+	if len(request.Query["limit"]) != 1 || len(request.Query["tags"]) != 1 || request.Query.Get("limit") != "1" {
+		t.Errorf("Invalid shrunk request: %v", request)
+	}
+}
+
+func TestResponseGen(t *testing.T) {
+	spec := parsePetstore(t)
+	parameters := gopter.DefaultGenParameters()
+	statusCodes := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		value, ok := spec.ResponseGen("GET /pets/{petId}")(parameters).Retrieve()
+		if !ok {
+			t.Fatal("Response not generated")
+		}
+		response := value.(openapi.Response)
+		statusCodes[response.StatusCode] = true
+		switch response.StatusCode {
+		case 200:
+			checkPet(t, response.Body)
+		case 400:
+			if code := response.Body.(map[string]interface{})["code"].(int64); code < 400 || code > 599 {
+				t.Errorf("Invalid error: %#v", response)
+			}
+		default:
+			t.Errorf("Invalid status code: %#v", response)
+		}
+	}
+	if len(statusCodes) != 2 {
+		t.Errorf("Invalid status codes: %v", statusCodes)
+	}
+
+	value, _ := spec.ResponseGen("createPet")(parameters).Retrieve()
+	if response := value.(openapi.Response); response.StatusCode != 201 || response.HasBody {
+		t.Errorf("Invalid response: %#v", response)
+	}
+}
@@ -0,0 +1,780 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+// MaxDepth is the maximum nesting depth of generated objects and arrays (which
+// limits recursive schemas)
+const MaxDepth = 5
+
+// stringChars are the characters of strings without format or pattern
+const stringChars = "abcdefghijklmnopqrstuvwxyzABCXYZ0123456789 -_."
+
+var valueType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// schema generates and shrinks the values of a schema in the form decoded by
+// encoding/json (but integers as int64)
+type schema struct {
+	// generate creates a value with at most depth levels of nesting, false if
+	// there is none (a recursive schema requiring deeper nesting)
+	generate func(genParams *gopter.GenParameters, depth int) (interface{}, bool)
+	// valid checks the type and the constraints of the value (but not of its
+	// elements)
+	valid func(v interface{}) bool
+	// shrink shrinks a valid value to valid values
+	shrink gopter.Shrinker
+}
+
+// Gen creates a generator of the values of the schema
+func (s *schema) Gen() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		value, ok := s.generate(genParams, MaxDepth)
+		if !ok {
+			return gopter.NewEmptyResult(valueType)
+		}
+		genResult := gopter.NewGenResult(value, s.shrink)
+		// null is a valid value
+		genResult.ResultType = valueType
+		genResult.Sieve = func(interface{}) bool {
+			return true
+		}
+		return genResult
+	}
+}
+
+// compiler compiles the schemas of a document for requests or responses,
+// $refs are compiled only once (which allows recursive schemas)
+type compiler struct {
+	doc      map[string]interface{}
+	refs     map[string]*schema
+	response bool
+}
+
+func newCompiler(doc map[string]interface{}, response bool) *compiler {
+	return &compiler{doc: doc, refs: map[string]*schema{}, response: response}
+}
+
+// skippedKeyword is the keyword of optional properties that are not generated
+func (c *compiler) skippedKeyword() string {
+	if c.response {
+		return "writeOnly"
+	}
+	return "readOnly"
+}
+
+func (c *compiler) compile(node map[string]interface{}) (*schema, error) {
+	if ref, ok := node["$ref"].(string); ok {
+		if compiled, ok := c.refs[ref]; ok {
+			return compiled, nil
+		}
+		target, err := c.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		// a placeholder for recursive references, filled once compiled
+		compiled := &schema{}
+		c.refs[ref] = compiled
+		result, err := c.compile(target)
+		if err != nil {
+			return nil, err
+		}
+		*compiled = *result
+		return compiled, nil
+	}
+
+	var compiled *schema
+	var err error
+	switch {
+	case node["allOf"] != nil:
+		var merged map[string]interface{}
+		if merged, err = c.mergeAllOf(node); err == nil {
+			compiled, err = c.compile(merged)
+		}
+	case node["oneOf"] != nil || node["anyOf"] != nil:
+		compiled, err = c.compileOneOf(node)
+	case node["enum"] != nil:
+		compiled, err = compileEnum(node)
+	default:
+		switch node["type"] {
+		case "string":
+			compiled, err = compileString(node)
+		case "integer":
+			compiled, err = compileInteger(node)
+		case "number":
+			compiled, err = compileNumber(node)
+		case "boolean":
+			compiled = compileBoolean()
+		case "array":
+			compiled, err = c.compileArray(node)
+		case "object", nil:
+			compiled, err = c.compileObject(node)
+		default:
+			err = fmt.Errorf("Unsupported schema type: %v", node["type"])
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if nullable, _ := node["nullable"].(bool); nullable {
+		compiled = nullableSchema(compiled)
+	}
+	return compiled, nil
+}
+
+// resolve resolves a local reference (JSON pointer) like
+// "#/components/schemas/Pet"
+func (c *compiler) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("Unsupported reference: %s", ref)
+	}
+	var node interface{} = c.doc
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unresolvable reference: %s", ref)
+		}
+		node = object[token]
+	}
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Unresolvable reference: %s", ref)
+	}
+	return object, nil
+}
+
+// resolveNode resolves a node that might be a reference
+func (c *compiler) resolveNode(node map[string]interface{}) (map[string]interface{}, error) {
+	for depth := 0; depth < 10; depth++ {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node, nil
+		}
+		resolved, err := c.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		node = resolved
+	}
+	return nil, fmt.Errorf("Too many nested references")
+}
+
+// mergeAllOf merges the (object) schemas of allOf: their properties,
+// required properties and the remaining keywords
+func (c *compiler) mergeAllOf(node map[string]interface{}) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	properties := map[string]interface{}{}
+	var required []interface{}
+	parts, _ := node["allOf"].([]interface{})
+	rest := map[string]interface{}{}
+	for key, value := range node {
+		if key != "allOf" {
+			rest[key] = value
+		}
+	}
+	for _, part := range append(parts, rest) {
+		partNode, ok := part.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid allOf schema: %v", part)
+		}
+		partNode, err := c.resolveNode(partNode)
+		if err != nil {
+			return nil, err
+		}
+		if partNode["allOf"] != nil {
+			if partNode, err = c.mergeAllOf(partNode); err != nil {
+				return nil, err
+			}
+		}
+		for key, value := range partNode {
+			switch key {
+			case "properties":
+				partProperties, _ := value.(map[string]interface{})
+				for name, property := range partProperties {
+					properties[name] = property
+				}
+			case "required":
+				partRequired, _ := value.([]interface{})
+				required = append(required, partRequired...)
+			default:
+				merged[key] = value
+			}
+		}
+	}
+	merged["properties"] = properties
+	merged["required"] = required
+	return merged, nil
+}
+
+func (c *compiler) compileOneOf(node map[string]interface{}) (*schema, error) {
+	alternatives, _ := node["oneOf"].([]interface{})
+	if alternatives == nil {
+		alternatives, _ = node["anyOf"].([]interface{})
+	}
+	compiled := make([]*schema, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		alternativeNode, ok := alternative.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid oneOf/anyOf schema: %v", alternative)
+		}
+		alternativeSchema, err := c.compile(alternativeNode)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, alternativeSchema)
+	}
+	if len(compiled) == 0 {
+		return nil, fmt.Errorf("Empty oneOf/anyOf")
+	}
+	// the first alternative accepting a value is used to shrink it
+	alternativeOf := func(v interface{}) *schema {
+		for _, alternative := range compiled {
+			if alternative.valid(v) {
+				return alternative
+			}
+		}
+		return nil
+	}
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			start := genParams.Rng.Intn(len(compiled))
+			for i := range compiled {
+				if value, ok := compiled[(start+i)%len(compiled)].generate(genParams, depth); ok {
+					return value, true
+				}
+			}
+			return nil, false
+		},
+		valid: func(v interface{}) bool {
+			return alternativeOf(v) != nil
+		},
+		shrink: func(v interface{}) gopter.Shrink {
+			if alternative := alternativeOf(v); alternative != nil {
+				return alternative.shrink(v)
+			}
+			return gopter.NoShrink
+		},
+	}, nil
+}
+
+func compileEnum(node map[string]interface{}) (*schema, error) {
+	values, _ := node["enum"].([]interface{})
+	if len(values) == 0 {
+		return nil, fmt.Errorf("Empty enum")
+	}
+	if node["type"] == "integer" {
+		for i, value := range values {
+			if number, ok := value.(float64); ok {
+				values[i] = int64(number)
+			}
+		}
+	}
+	indexOf := func(v interface{}) int {
+		for i, value := range values {
+			if reflect.DeepEqual(value, v) {
+				return i
+			}
+		}
+		return -1
+	}
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			return values[genParams.Rng.Intn(len(values))], true
+		},
+		valid: func(v interface{}) bool {
+			return indexOf(v) >= 0
+		},
+		// enum values shrink to the earlier values
+		shrink: func(v interface{}) gopter.Shrink {
+			index := indexOf(v)
+			if index <= 0 {
+				return gopter.NoShrink
+			}
+			return valuesShrink(values[:index])
+		},
+	}, nil
+}
+
+// stringFormats generates strings of the supported formats
+var stringFormats = map[string]func(genParams *gopter.GenParameters) (string, bool){
+	"date-time": func(genParams *gopter.GenParameters) (string, bool) {
+		return genTime(genParams).Format(time.RFC3339), true
+	},
+	"date": func(genParams *gopter.GenParameters) (string, bool) {
+		return genTime(genParams).Format("2006-01-02"), true
+	},
+	"byte": func(genParams *gopter.GenParameters) (string, bool) {
+		data := make([]byte, genParams.Rng.Intn(33))
+		genParams.Rng.Read(data)
+		return base64.StdEncoding.EncodeToString(data), true
+	},
+	"uuid":     genStringFormat(gen.UUIDv4()),
+	"email":    genStringFormat(gen.EmailAddress()),
+	"hostname": genStringFormat(gen.Hostname()),
+	"uri":      genStringFormat(gen.URL()),
+	"url":      genStringFormat(gen.URL()),
+	"ipv4":     genStringFormat(gen.IPv4()),
+	"ipv6":     genStringFormat(gen.IPv6()),
+}
+
+// genTime generates a time between 1970 and 2100 (in seconds)
+func genTime(genParams *gopter.GenParameters) time.Time {
+	return time.Unix(genParams.Rng.Int63n(4102444800), 0).UTC()
+}
+
+func genStringFormat(formatGen gopter.Gen) func(genParams *gopter.GenParameters) (string, bool) {
+	return func(genParams *gopter.GenParameters) (string, bool) {
+		value, ok := formatGen(genParams).Retrieve()
+		if !ok {
+			return "", false
+		}
+		if ip, isIP := value.(net.IP); isIP {
+			return ip.String(), true
+		}
+		return value.(string), true
+	}
+}
+
+func compileString(node map[string]interface{}) (*schema, error) {
+	minLength, _ := intKeyword(node, "minLength", 0)
+	maxLength, hasMaxLength := intKeyword(node, "maxLength", math.MaxInt32)
+	if minLength > maxLength {
+		return nil, fmt.Errorf("Invalid string length: %d > %d", minLength, maxLength)
+	}
+	var pattern *regexp.Regexp
+	var patternGen gopter.Gen
+	if patternStr, ok := node["pattern"].(string); ok {
+		var err error
+		if pattern, err = regexp.Compile(patternStr); err != nil {
+			return nil, fmt.Errorf("Invalid pattern: %s", patternStr)
+		}
+		patternGen = gen.RegexMatch(patternStr)
+	}
+	format, _ := node["format"].(string)
+	genFormat := stringFormats[format]
+
+	valid := func(v interface{}) bool {
+		str, ok := v.(string)
+		if !ok {
+			return false
+		}
+		length := utf8.RuneCountInString(str)
+		return length >= minLength && length <= maxLength && (pattern == nil || pattern.MatchString(str))
+	}
+	generate := func(genParams *gopter.GenParameters) (string, bool) {
+		if patternGen != nil {
+			value, ok := patternGen(genParams).Retrieve()
+			if !ok {
+				return "", false
+			}
+			return value.(string), true
+		}
+		if genFormat != nil {
+			return genFormat(genParams)
+		}
+		maxSize := genParams.MaxSize
+		if maxSize > 32 {
+			maxSize = 32
+		}
+		length := minLength
+		if hasMaxLength && maxLength-minLength < maxSize {
+			length += genParams.Rng.Intn(maxLength - minLength + 1)
+		} else {
+			length += genParams.Rng.Intn(maxSize + 1)
+		}
+		str := make([]byte, length)
+		for i := range str {
+			str[i] = stringChars[genParams.Rng.Intn(len(stringChars))]
+		}
+		return string(str), true
+	}
+
+	shrink := gopter.Shrinker(func(v interface{}) gopter.Shrink {
+		return gen.StringShrinker(v).Filter(valid)
+	})
+	if genFormat != nil && patternGen == nil {
+		// formatted strings do not shrink (they might not stay valid)
+		shrink = gopter.NoShrinker
+	}
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			for i := 0; i < 100; i++ {
+				if str, ok := generate(genParams); ok && valid(str) {
+					return str, true
+				}
+			}
+			return nil, false
+		},
+		valid:  valid,
+		shrink: shrink,
+	}, nil
+}
+
+// bounds determines the range [min, max] of a numeric schema, exclusive
+// bounds are reported separately (OpenAPI 3.0 uses booleans for
+// exclusiveMinimum and exclusiveMaximum, OpenAPI 3.1 numbers)
+func bounds(node map[string]interface{}, defaultMin, defaultMax float64) (min, max float64, exclusiveMin, exclusiveMax bool) {
+	min, max = defaultMin, defaultMax
+	if minimum, ok := node["minimum"].(float64); ok {
+		min = minimum
+		exclusiveMin, _ = node["exclusiveMinimum"].(bool)
+	}
+	if exclusiveMinimum, ok := node["exclusiveMinimum"].(float64); ok && exclusiveMinimum >= min {
+		min, exclusiveMin = exclusiveMinimum, true
+	}
+	if maximum, ok := node["maximum"].(float64); ok {
+		max = maximum
+		exclusiveMax, _ = node["exclusiveMaximum"].(bool)
+	}
+	if exclusiveMaximum, ok := node["exclusiveMaximum"].(float64); ok && exclusiveMaximum <= max {
+		max, exclusiveMax = exclusiveMaximum, true
+	}
+	return
+}
+
+func compileInteger(node map[string]interface{}) (*schema, error) {
+	defaultMin, defaultMax := float64(math.MinInt64), float64(math.MaxInt64)
+	if node["format"] == "int32" {
+		defaultMin, defaultMax = math.MinInt32, math.MaxInt32
+	}
+	minFloat, maxFloat, exclusiveMin, exclusiveMax := bounds(node, defaultMin, defaultMax)
+	min, max := int64(math.Max(math.Ceil(minFloat), defaultMin)), int64(math.Min(math.Floor(maxFloat), defaultMax))
+	if maxFloat >= float64(math.MaxInt64) {
+		max = math.MaxInt64
+	}
+	if exclusiveMin && float64(min) == minFloat {
+		min++
+	}
+	if exclusiveMax && float64(max) == maxFloat {
+		max--
+	}
+	multipleOf := int64(1)
+	if multiple, ok := node["multipleOf"].(float64); ok {
+		if multiple < 1 || multiple != math.Trunc(multiple) {
+			return nil, fmt.Errorf("Unsupported multipleOf: %v", multiple)
+		}
+		multipleOf = int64(multiple)
+	}
+	// the values are multiples of multipleOf: multipleOf * [minFactor, maxFactor]
+	minFactor, maxFactor := min/multipleOf, max/multipleOf
+	if minFactor*multipleOf < min {
+		minFactor++
+	}
+	if maxFactor*multipleOf > max {
+		maxFactor--
+	}
+	if minFactor > maxFactor {
+		return nil, fmt.Errorf("Empty integer range: [%d, %d]", min, max)
+	}
+	factorGen := gen.Int64Range(minFactor, maxFactor)
+
+	valid := func(v interface{}) bool {
+		value, ok := v.(int64)
+		return ok && value >= min && value <= max && value%multipleOf == 0
+	}
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			factor, ok := factorGen(genParams).Retrieve()
+			if !ok {
+				return nil, false
+			}
+			return factor.(int64) * multipleOf, true
+		},
+		valid: valid,
+		shrink: func(v interface{}) gopter.Shrink {
+			return gen.Int64Shrinker(v.(int64) / multipleOf).Map(func(factor int64) int64 {
+				return factor * multipleOf
+			}).Filter(valid)
+		},
+	}, nil
+}
+
+func compileNumber(node map[string]interface{}) (*schema, error) {
+	min, max, exclusiveMin, exclusiveMax := bounds(node, -1e9, 1e9)
+	if min > max {
+		return nil, fmt.Errorf("Empty number range: [%v, %v]", min, max)
+	}
+	rangeGen := gen.Float64Range(min, max)
+	valid := func(v interface{}) bool {
+		value, ok := v.(float64)
+		return ok && value >= min && value <= max && (!exclusiveMin || value > min) && (!exclusiveMax || value < max)
+	}
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			for i := 0; i < 100; i++ {
+				if value, ok := rangeGen(genParams).Retrieve(); ok && valid(value) {
+					return value, true
+				}
+			}
+			return nil, false
+		},
+		valid: valid,
+		shrink: func(v interface{}) gopter.Shrink {
+			return gen.Float64Shrinker(v).Filter(valid)
+		},
+	}, nil
+}
+
+func compileBoolean() *schema {
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			return genParams.NextBool(), true
+		},
+		valid: func(v interface{}) bool {
+			_, ok := v.(bool)
+			return ok
+		},
+		shrink: func(v interface{}) gopter.Shrink {
+			if v.(bool) {
+				return valuesShrink([]interface{}{false})
+			}
+			return gopter.NoShrink
+		},
+	}
+}
+
+func (c *compiler) compileArray(node map[string]interface{}) (*schema, error) {
+	itemsNode, ok := node["items"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Array without items")
+	}
+	items, err := c.compile(itemsNode)
+	if err != nil {
+		return nil, err
+	}
+	minItems, _ := intKeyword(node, "minItems", 0)
+	maxItems, _ := intKeyword(node, "maxItems", math.MaxInt32)
+	if minItems > maxItems {
+		return nil, fmt.Errorf("Invalid array length: %d > %d", minItems, maxItems)
+	}
+	uniqueItems, _ := node["uniqueItems"].(bool)
+
+	valid := func(v interface{}) bool {
+		array, ok := v.([]interface{})
+		return ok && len(array) >= minItems && len(array) <= maxItems && (!uniqueItems || unique(array))
+	}
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			if depth <= 0 {
+				if minItems > 0 {
+					return nil, false
+				}
+				return []interface{}{}, true
+			}
+			maxLength := minItems + 5
+			if maxLength > maxItems {
+				maxLength = maxItems
+			}
+			length := minItems + genParams.Rng.Intn(maxLength-minItems+1)
+			array := make([]interface{}, 0, length)
+			for attempts := 0; len(array) < length && attempts < 10*length; attempts++ {
+				item, ok := items.generate(genParams, depth-1)
+				if !ok {
+					break
+				}
+				if !uniqueItems || unique(append(array, item)) {
+					array = append(array, item)
+				}
+			}
+			if len(array) < minItems {
+				return nil, false
+			}
+			return array, true
+		},
+		valid: valid,
+		shrink: func(v interface{}) gopter.Shrink {
+			return gen.SliceShrinker(func(item interface{}) gopter.Shrink {
+				return items.shrink(item)
+			})(v).Filter(valid)
+		},
+	}, nil
+}
+
+func unique(array []interface{}) bool {
+	seen := map[string]bool{}
+	for _, item := range array {
+		data, _ := json.Marshal(item)
+		if seen[string(data)] {
+			return false
+		}
+		seen[string(data)] = true
+	}
+	return true
+}
+
+// compileObject compiles an object schema with its properties (additional
+// properties are never generated)
+func (c *compiler) compileObject(node map[string]interface{}) (*schema, error) {
+	propertyNodes, _ := node["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	requiredNames, _ := node["required"].([]interface{})
+	for _, name := range requiredNames {
+		if nameStr, ok := name.(string); ok {
+			required[nameStr] = true
+		}
+	}
+	names := make([]string, 0, len(propertyNodes))
+	for name := range propertyNodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	properties := make(map[string]*schema, len(names))
+	for _, name := range names {
+		propertyNode, ok := propertyNodes[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid schema of property %s", name)
+		}
+		// read-only properties are not part of requests, write-only
+		// properties not part of responses
+		if skip, _ := propertyNode[c.skippedKeyword()].(bool); skip && !required[name] {
+			continue
+		}
+		property, err := c.compile(propertyNode)
+		if err != nil {
+			return nil, fmt.Errorf("Property %s: %v", name, err)
+		}
+		properties[name] = property
+	}
+	for name := range required {
+		if properties[name] == nil {
+			return nil, fmt.Errorf("Required property %s without schema", name)
+		}
+	}
+	return objectSchema(names, properties, required), nil
+}
+
+// objectSchema creates the schema of objects with properties of the given
+// schemas (in the order of their names)
+func objectSchema(names []string, properties map[string]*schema, required map[string]bool) *schema {
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			object := map[string]interface{}{}
+			for _, name := range names {
+				property := properties[name]
+				if property == nil || !required[name] && (depth <= 0 || genParams.NextBool()) {
+					continue
+				}
+				value, ok := property.generate(genParams, depth-1)
+				if !ok {
+					if required[name] {
+						return nil, false
+					}
+					continue
+				}
+				object[name] = value
+			}
+			return object, true
+		},
+		valid: func(v interface{}) bool {
+			object, ok := v.(map[string]interface{})
+			if !ok {
+				return false
+			}
+			for name := range required {
+				if _, ok := object[name]; !ok {
+					return false
+				}
+			}
+			return true
+		},
+		// objects shrink by removing optional properties and shrinking the
+		// values of the properties
+		shrink: func(v interface{}) gopter.Shrink {
+			object := v.(map[string]interface{})
+			var removed []interface{}
+			shrinks := []gopter.Shrink{}
+			for _, name := range names {
+				value, ok := object[name]
+				if !ok {
+					continue
+				}
+				if !required[name] {
+					removed = append(removed, withProperty(object, name, nil, false))
+				}
+				name := name
+				propertyShrink := properties[name].shrink(value)
+				// Map does not support shrinking to null
+				shrinks = append(shrinks, func() (interface{}, bool) {
+					shrunk, ok := propertyShrink()
+					if !ok {
+						return nil, false
+					}
+					return withProperty(object, name, shrunk, true), true
+				})
+			}
+			return gopter.ConcatShrinks(append([]gopter.Shrink{valuesShrink(removed)}, shrinks...)...)
+		},
+	}
+}
+
+// withProperty copies an object with a changed or removed property
+func withProperty(object map[string]interface{}, name string, value interface{}, present bool) map[string]interface{} {
+	result := make(map[string]interface{}, len(object))
+	for key, element := range object {
+		result[key] = element
+	}
+	if present {
+		result[name] = value
+	} else {
+		delete(result, name)
+	}
+	return result
+}
+
+// nullableSchema extends a schema by null (which is generated in 1 of 5
+// cases), the values shrink to null first
+func nullableSchema(inner *schema) *schema {
+	return &schema{
+		generate: func(genParams *gopter.GenParameters, depth int) (interface{}, bool) {
+			if genParams.Rng.Intn(5) == 0 {
+				return nil, true
+			}
+			if value, ok := inner.generate(genParams, depth); ok {
+				return value, true
+			}
+			return nil, true
+		},
+		valid: func(v interface{}) bool {
+			return v == nil || inner.valid(v)
+		},
+		shrink: func(v interface{}) gopter.Shrink {
+			if v == nil {
+				return gopter.NoShrink
+			}
+			return gopter.ConcatShrinks(valuesShrink([]interface{}{nil}), inner.shrink(v))
+		},
+	}
+}
+
+func intKeyword(node map[string]interface{}, keyword string, defaultValue int) (int, bool) {
+	if value, ok := node[keyword].(float64); ok {
+		return int(value), true
+	}
+	return defaultValue, false
+}
+
+func valuesShrink(values []interface{}) gopter.Shrink {
+	index := 0
+	return func() (interface{}, bool) {
+		if index >= len(values) {
+			return nil, false
+		}
+		value := values[index]
+		index++
+		return value, true
+	}
+}
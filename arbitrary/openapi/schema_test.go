@@ -0,0 +1,126 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/arbitrary/openapi"
+	"github.com/leanovate/gopter/prop"
+)
+
+const schemas = `{
+  "openapi": "3.1.0",
+  "components": {
+    "schemas": {
+      "Even": {"type": "integer", "minimum": -7, "exclusiveMaximum": 10, "multipleOf": 2},
+      "Ratio": {"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+      "Code": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]{2}$"},
+      "Date": {"type": "string", "format": "date"},
+      "Shape": {"oneOf": [
+        {"type": "object", "required": ["radius"], "properties": {"radius": {"type": "number", "minimum": 0}}},
+        {"type": "object", "required": ["width", "height"], "properties": {"width": {"type": "integer"}, "height": {"type": "integer"}}}
+      ]},
+      "Named": {"allOf": [
+        {"$ref": "#/components/schemas/Node"},
+        {"required": ["name"], "properties": {"name": {"type": "string", "maxLength": 3}}}
+      ]},
+      "Node": {
+        "type": "object",
+        "required": ["children"],
+        "properties": {"children": {"type": "array", "items": {"$ref": "#/components/schemas/Node"}}}
+      },
+      "Maybe": {"type": "boolean", "nullable": true}
+    }
+  }
+}`
+
+func parseSchemas(t *testing.T) *openapi.Spec {
+	spec, err := openapi.Parse([]byte(schemas))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return spec
+}
+
+func nodeDepth(value interface{}) int {
+	depth := 0
+	for _, child := range value.(map[string]interface{})["children"].([]interface{}) {
+		if d := nodeDepth(child); d > depth {
+			depth = d
+		}
+	}
+	return depth + 1
+}
+
+func TestSchemaGen(t *testing.T) {
+	spec := parseSchemas(t)
+	parameters := gopter.DefaultGenParameters()
+	nulls := 0
+	for i := 0; i < 200; i++ {
+		value, ok := spec.SchemaGen("Even")(parameters).Retrieve()
+		if even := value.(int64); !ok || even < -7 || even >= 10 || even%2 != 0 {
+			t.Errorf("Invalid even: %#v", value)
+		}
+		value, _ = spec.SchemaGen("Ratio")(parameters).Retrieve()
+		if ratio := value.(float64); ratio <= 0 || ratio > 1 {
+			t.Errorf("Invalid ratio: %#v", value)
+		}
+		value, _ = spec.SchemaGen("Code")(parameters).Retrieve()
+		if code := value.(string); len(code) != 6 || code[3] != '-' {
+			t.Errorf("Invalid code: %#v", value)
+		}
+		value, _ = spec.SchemaGen("Date")(parameters).Retrieve()
+		if date := value.(string); len(date) != 10 {
+			t.Errorf("Invalid date: %#v", value)
+		}
+		value, _ = spec.SchemaGen("Shape")(parameters).Retrieve()
+		shape := value.(map[string]interface{})
+		if _, ok := shape["radius"]; !ok && (shape["width"] == nil || shape["height"] == nil) {
+			t.Errorf("Invalid shape: %#v", value)
+		}
+		value, _ = spec.SchemaGen("Named")(parameters).Retrieve()
+		if name, ok := value.(map[string]interface{})["name"].(string); !ok || len(name) > 3 {
+			t.Errorf("Invalid named: %#v", value)
+		}
+		if depth := nodeDepth(value); depth > openapi.MaxDepth {
+			t.Errorf("Invalid depth %d: %#v", depth, value)
+		}
+		value, ok = spec.SchemaGen("Maybe")(parameters).Retrieve()
+		if !ok {
+			t.Error("Null not retrieved")
+		} else if value == nil {
+			nulls++
+		}
+	}
+	if nulls == 0 {
+		t.Error("Null never generated")
+	}
+
+	if _, ok := spec.SchemaGen("Unknown")(parameters).Retrieve(); ok {
+		t.Error("Unknown schema generated")
+	}
+}
+
+func TestSchemaGenShrink(t *testing.T) {
+	spec := parseSchemas(t)
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(value interface{}) bool {
+		return nodeDepth(value) < 3
+	}, spec.SchemaGen("Node")).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	data, _ := json.Marshal(result.Args[0].Arg)
+	if str := string(data); str != `{"children":[{"children":[{"children":[]}]}]}` {
+		t.Errorf("Invalid shrunk value: %s", str)
+	}
+
+	result = prop.ForAll(func(value interface{}) bool {
+		return value != true
+	}, spec.SchemaGen("Maybe")).Check(parameters)
+	if result.Status != gopter.TestFailed || !reflect.DeepEqual(result.Args[0].Arg, true) {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
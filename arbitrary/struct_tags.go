@@ -0,0 +1,275 @@
+package arbitrary
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+// tagOptions are the options of a `gopter:"..."` struct tag
+type tagOptions struct {
+	// rangeMin and rangeMax are the bounds of numbers (as string to parse them
+	// for the actual kind)
+	rangeMin, rangeMax string
+	hasRange           bool
+	regex              string
+	sizeMin, sizeMax   int
+	hasSize            bool
+}
+
+// parseTag parses the options of a struct tag: "range=<min>:<max>" for
+// numbers, "regex=<regular expression>" for strings and "size=<n>" or
+// "size=<min>:<max>" for the length of strings, slices and maps.
+// Options are separated by ";", a regex has to be the last option.
+func parseTag(tag string) (*tagOptions, error) {
+	options := &tagOptions{}
+	for tag != "" {
+		var option string
+		if strings.HasPrefix(tag, "regex=") {
+			option, tag = tag, ""
+		} else if i := strings.Index(tag, ";"); i >= 0 {
+			option, tag = tag[:i], tag[i+1:]
+		} else {
+			option, tag = tag, ""
+		}
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid option: %s", option)
+		}
+		switch strings.TrimSpace(parts[0]) {
+		case "range":
+			bounds := strings.SplitN(parts[1], ":", 2)
+			if len(bounds) != 2 {
+				return nil, fmt.Errorf("Invalid range: %s", parts[1])
+			}
+			options.rangeMin, options.rangeMax, options.hasRange = bounds[0], bounds[1], true
+		case "regex":
+			options.regex = parts[1]
+		case "size":
+			bounds := strings.SplitN(parts[1], ":", 2)
+			min, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid size: %s", parts[1])
+			}
+			max := min
+			if len(bounds) == 2 {
+				if max, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("Invalid size: %s", parts[1])
+				}
+			}
+			if min < 0 || min > max {
+				return nil, fmt.Errorf("Invalid size: %s", parts[1])
+			}
+			options.sizeMin, options.sizeMax, options.hasSize = min, max, true
+		default:
+			return nil, fmt.Errorf("Unknown option: %s", option)
+		}
+	}
+	return options, nil
+}
+
+// genForTag gets a generator for a struct field with a `gopter:"..."` tag.
+// The range and regex of a slice, map or pointer apply to its elements.
+// Panics if the tag is invalid or does not match the type of the field.
+func (a *Arbitraries) genForTag(structType reflect.Type, field reflect.StructField, tag string, inProgress map[reflect.Type]bool) gopter.Gen {
+	options, err := parseTag(tag)
+	if err == nil {
+		var result gopter.Gen
+		if result, err = a.genForTagOptions(field.Type, options, inProgress); err == nil {
+			return result
+		}
+	}
+	panic(fmt.Sprintf("Invalid gopter tag of field %v.%s: %v", structType, field.Name, err))
+}
+
+func (a *Arbitraries) genForTagOptions(rt reflect.Type, options *tagOptions, inProgress map[reflect.Type]bool) (gopter.Gen, error) {
+	elementOptions := &tagOptions{
+		rangeMin: options.rangeMin,
+		rangeMax: options.rangeMax,
+		hasRange: options.hasRange,
+		regex:    options.regex,
+	}
+	elementGen := func(elementType reflect.Type) (gopter.Gen, error) {
+		if !elementOptions.hasRange && elementOptions.regex == "" {
+			return a.genForType(elementType, inProgress), nil
+		}
+		return a.genForTagOptions(elementType, elementOptions, inProgress)
+	}
+
+	switch rt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if options.regex != "" || options.hasSize || !options.hasRange {
+			return nil, fmt.Errorf("Unsupported options for %v", rt)
+		}
+		min, err1 := strconv.ParseInt(options.rangeMin, 10, 64)
+		max, err2 := strconv.ParseInt(options.rangeMax, 10, 64)
+		if err1 != nil || err2 != nil || min > max || reflect.Zero(rt).OverflowInt(min) || reflect.Zero(rt).OverflowInt(max) {
+			return nil, fmt.Errorf("Invalid range for %v", rt)
+		}
+		return convertGen(gen.Int64Range(min, max), rt), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if options.regex != "" || options.hasSize || !options.hasRange {
+			return nil, fmt.Errorf("Unsupported options for %v", rt)
+		}
+		min, err1 := strconv.ParseUint(options.rangeMin, 10, 64)
+		max, err2 := strconv.ParseUint(options.rangeMax, 10, 64)
+		if err1 != nil || err2 != nil || min > max || reflect.Zero(rt).OverflowUint(max) {
+			return nil, fmt.Errorf("Invalid range for %v", rt)
+		}
+		return convertGen(gen.UInt64Range(min, max), rt), nil
+	case reflect.Float32, reflect.Float64:
+		if options.regex != "" || options.hasSize || !options.hasRange {
+			return nil, fmt.Errorf("Unsupported options for %v", rt)
+		}
+		min, err1 := strconv.ParseFloat(options.rangeMin, 64)
+		max, err2 := strconv.ParseFloat(options.rangeMax, 64)
+		if err1 != nil || err2 != nil || min > max || reflect.Zero(rt).OverflowFloat(min) || reflect.Zero(rt).OverflowFloat(max) {
+			return nil, fmt.Errorf("Invalid range for %v", rt)
+		}
+		return convertGen(gen.Float64Range(min, max), rt), nil
+	case reflect.String:
+		if options.hasRange || options.regex == "" && !options.hasSize {
+			return nil, fmt.Errorf("Unsupported options for %v", rt)
+		}
+		var result gopter.Gen
+		if options.regex != "" {
+			result = gen.RegexMatch(options.regex)
+			if options.hasSize {
+				result = result.SuchThat(func(v string) bool {
+					length := utf8.RuneCountInString(v)
+					return length >= options.sizeMin && length <= options.sizeMax
+				})
+			}
+		} else {
+			result = sizedGen(options, func(v interface{}) int {
+				return utf8.RuneCountInString(v.(string))
+			}, func(restore func(gopter.Gen) gopter.Gen) gopter.Gen {
+				return gen.AnyString()
+			})
+		}
+		return convertGen(result, rt), nil
+	case reflect.Slice:
+		element, err := elementGen(rt.Elem())
+		if err != nil || element == nil {
+			return nil, fmt.Errorf("Unsupported options for %v", rt)
+		}
+		if !options.hasSize {
+			return convertGen(gen.SliceOf(element), rt), nil
+		}
+		return sizedGen(options, func(v interface{}) int {
+			return reflect.ValueOf(v).Len()
+		}, func(restore func(gopter.Gen) gopter.Gen) gopter.Gen {
+			return convertGen(gen.SliceOf(restore(element)), rt)
+		}), nil
+	case reflect.Map:
+		keyGen := a.genForType(rt.Key(), inProgress)
+		element, err := elementGen(rt.Elem())
+		if err != nil || element == nil || keyGen == nil {
+			return nil, fmt.Errorf("Unsupported options for %v", rt)
+		}
+		if !options.hasSize {
+			return convertGen(gen.MapOf(keyGen, element), rt), nil
+		}
+		return sizedGen(options, func(v interface{}) int {
+			return reflect.ValueOf(v).Len()
+		}, func(restore func(gopter.Gen) gopter.Gen) gopter.Gen {
+			return convertGen(gen.MapOf(restore(keyGen), restore(element)), rt)
+		}), nil
+	case reflect.Ptr:
+		element, err := a.genForTagOptions(rt.Elem(), options, inProgress)
+		if err != nil {
+			return nil, err
+		}
+		return gen.PtrOf(element), nil
+	}
+	return nil, fmt.Errorf("Unsupported options for %v", rt)
+}
+
+// sizedGen creates a generator of strings, slices or maps with a length in
+// the size range of the options. The generators of the elements are
+// supposed to be wrapped by "restore" to use the original size parameters.
+func sizedGen(options *tagOptions, length func(interface{}) int, create func(restore func(gopter.Gen) gopter.Gen) gopter.Gen) gopter.Gen {
+	sieve := func(v interface{}) bool {
+		if v == nil {
+			return false
+		}
+		n := length(v)
+		return n >= options.sizeMin && n <= options.sizeMax
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		restore := func(elementGen gopter.Gen) gopter.Gen {
+			return func(elementParams *gopter.GenParameters) *gopter.GenResult {
+				restored := *elementParams
+				restored.MinSize, restored.MaxSize = genParams.MinSize, genParams.MaxSize
+				return elementGen(&restored)
+			}
+		}
+		sizedParams := *genParams
+		// the MaxSize of slices and maps is exclusive
+		sizedParams.MinSize, sizedParams.MaxSize = options.sizeMin, options.sizeMax+1
+		result := create(restore)(&sizedParams)
+		elementSieve := result.Sieve
+		result.Sieve = func(v interface{}) bool {
+			return sieve(v) && (elementSieve == nil || elementSieve(v))
+		}
+		return result
+	}
+}
+
+// convertGen converts the values of a generator to a (named) type of the
+// same kind (e.g. int64 to "type Age int32")
+func convertGen(g gopter.Gen, rt reflect.Type) gopter.Gen {
+	return mapGenType(g, rt, func(v reflect.Value, to reflect.Type) reflect.Value {
+		return v.Convert(to)
+	})
+}
+
+// arrayGen converts the values of a generator of slices with the length of
+// the array type rt to arrays
+func arrayGen(g gopter.Gen, rt reflect.Type) gopter.Gen {
+	return mapGenType(g, rt, func(v reflect.Value, to reflect.Type) reflect.Value {
+		var result reflect.Value
+		if to.Kind() == reflect.Array {
+			result = reflect.New(to).Elem()
+		} else {
+			result = reflect.MakeSlice(to, v.Len(), v.Len())
+		}
+		reflect.Copy(result, v)
+		return result
+	})
+}
+
+// mapGenType maps the values of a generator to the type rt, the sieve and
+// shrinker of the generator are applied to the values converted back.
+func mapGenType(g gopter.Gen, rt reflect.Type, convertValue func(v reflect.Value, to reflect.Type) reflect.Value) gopter.Gen {
+	return g.MapResult(func(result *gopter.GenResult) *gopter.GenResult {
+		if result.ResultType == rt {
+			return result
+		}
+		from := result.ResultType
+		convert := func(v interface{}, to reflect.Type) interface{} {
+			return convertValue(reflect.ValueOf(v), to).Interface()
+		}
+		converted := &gopter.GenResult{
+			Labels:     result.Labels,
+			ResultType: rt,
+			Sieve: func(v interface{}) bool {
+				return v != nil && (result.Sieve == nil || result.Sieve(convert(v, from)))
+			},
+			Shrinker: func(v interface{}) gopter.Shrink {
+				return result.Shrinker(convert(v, from)).Map(func(s interface{}) interface{} {
+					return convert(s, rt)
+				})
+			},
+		}
+		if value, ok := result.Retrieve(); ok {
+			converted.Result = convert(value, rt)
+		}
+		return converted
+	})
+}
@@ -0,0 +1,119 @@
+package arbitrary_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/arbitrary"
+	"github.com/leanovate/gopter/prop"
+)
+
+type Age uint8
+
+type TaggedStruct struct {
+	Count   int            `gopter:"range=1:100"`
+	Age     Age            `gopter:"range=18:65"`
+	Ratio   float32        `gopter:"range=0:1"`
+	Name    string         `gopter:"regex=^[a-z]+$"`
+	Code    string         `gopter:"size=3"`
+	Scores  []int16        `gopter:"size=2:4;range=-5:5"`
+	Tags    []string       `gopter:"size=1:3;regex=^#[a-z]{1,5}$"`
+	Labels  map[string]int `gopter:"size=2"`
+	Limit   *int           `gopter:"range=0:9"`
+	Untyped []string
+}
+
+type TypoTaggedStruct struct {
+	Count int `gopter:"rnage=1:10"`
+}
+
+type OverflowTaggedStruct struct {
+	Count int
+	Small int8 `gopter:"range=1:1000"`
+}
+
+type MismatchTaggedStruct struct {
+	Count int `gopter:"regex=^[a-z]+$"`
+}
+
+type SizeTaggedStruct struct {
+	Name string `gopter:"size=5:1"`
+}
+
+var nameRegex = regexp.MustCompile(`^[a-z]+$`)
+var tagRegex = regexp.MustCompile(`^#[a-z]{1,5}$`)
+
+func TestArbitrariesStructTags(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	gen := arbitraries.GenForType(reflect.TypeOf(TaggedStruct{}))
+	for i := 0; i < 100; i++ {
+		raw, ok := gen.Sample()
+		if !ok {
+			t.Fatalf("Invalid value: %#v", raw)
+		}
+		value := raw.(TaggedStruct)
+		if value.Count < 1 || value.Count > 100 || value.Age < 18 || value.Age > 65 || value.Ratio < 0 || value.Ratio > 1 {
+			t.Errorf("Invalid numbers: %#v", value)
+		}
+		if !nameRegex.MatchString(value.Name) || utf8.RuneCountInString(value.Code) != 3 {
+			t.Errorf("Invalid strings: %#v", value)
+		}
+		if len(value.Scores) < 2 || len(value.Scores) > 4 {
+			t.Errorf("Invalid scores: %#v", value)
+		}
+		for _, score := range value.Scores {
+			if score < -5 || score > 5 {
+				t.Errorf("Invalid scores: %#v", value)
+			}
+		}
+		if len(value.Tags) < 1 || len(value.Tags) > 3 {
+			t.Errorf("Invalid tags: %#v", value)
+		}
+		for _, tag := range value.Tags {
+			if !tagRegex.MatchString(tag) {
+				t.Errorf("Invalid tags: %#v", value)
+			}
+		}
+		if len(value.Labels) != 2 || value.Limit != nil && (*value.Limit < 0 || *value.Limit > 9) {
+			t.Errorf("Invalid value: %#v", value)
+		}
+	}
+}
+
+func TestArbitrariesInvalidStructTags(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	invalids := map[reflect.Type]string{
+		reflect.TypeOf(TypoTaggedStruct{}):     "Invalid gopter tag of field arbitrary_test.TypoTaggedStruct.Count: Unknown option: rnage=1:10",
+		reflect.TypeOf(OverflowTaggedStruct{}): "Invalid gopter tag of field arbitrary_test.OverflowTaggedStruct.Small: Invalid range for int8",
+		reflect.TypeOf(MismatchTaggedStruct{}): "Invalid gopter tag of field arbitrary_test.MismatchTaggedStruct.Count: Unsupported options for int",
+		reflect.TypeOf(SizeTaggedStruct{}):     "Invalid gopter tag of field arbitrary_test.SizeTaggedStruct.Name: Invalid size: 5:1",
+	}
+	for rt, expected := range invalids {
+		func() {
+			defer func() {
+				if r := recover(); r != expected {
+					t.Errorf("Panic does not match: %#v != %#v", r, expected)
+				}
+			}()
+			arbitraries.GenForType(rt)
+		}()
+	}
+}
+
+func TestArbitrariesStructTagsShrink(t *testing.T) {
+	arbitraries := arbitrary.DefaultArbitraries()
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(value TaggedStruct) bool {
+		return value.Count < 50 || len(value.Scores) < 3
+	}, arbitraries.GenForType(reflect.TypeOf(TaggedStruct{}))).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	value := result.Args[0].Arg.(TaggedStruct)
+	if value.Count != 50 || len(value.Scores) != 3 || value.Age != 18 {
+		t.Errorf("Invalid shrunk value: %#v", value)
+	}
+}
@@ -0,0 +1,28 @@
+package gopter
+
+// ArgShrinkStrategy defines how the arguments of a property with multiple
+// generators are shrunk once the property has been falsified.
+type ArgShrinkStrategy int
+
+const (
+	// ShrinkArgsIndividually shrinks one argument after the other, each
+	// to a fixpoint. This is the default.
+	ShrinkArgsIndividually ArgShrinkStrategy = iota
+	// ShrinkArgPairs additionally tries to shrink pairs of arguments
+	// simultaneously once all arguments have been shrunk individually
+	// (e.g. decrementing both endpoints of a range), which might find
+	// smaller counter examples that can not be reached by shrinking a single
+	// argument. Individual and pairwise passes are repeated until no further
+	// progress is made.
+	ShrinkArgPairs
+)
+
+func (s ArgShrinkStrategy) String() string {
+	switch s {
+	case ShrinkArgsIndividually:
+		return "INDIVIDUALLY"
+	case ShrinkArgPairs:
+		return "PAIRS"
+	}
+	return ""
+}
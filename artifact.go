@@ -0,0 +1,200 @@
+package gopter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// DefaultArtifactDir is the default directory of the artifact bundles of
+// failed properties (see TestParameters.ArtifactDir).
+// Like any "testdata" directory it is ignored by the go tool.
+const DefaultArtifactDir = "testdata/gopter_failures"
+
+// ArtifactPath is the path of the artifact bundle of a property in a
+// directory
+func ArtifactPath(dir, propName string) string {
+	return filepath.Join(dir, propFileName(propName))
+}
+
+// ArtifactArg is implemented by arguments that add their own files to the
+// artifact bundle of a failed property (e.g. the commands package adds a
+// replay file of the commands)
+type ArtifactArg interface {
+	WriteArtifact(path string) error
+}
+
+var regressionTemplate = template.Must(template.New("regression").Parse(`package regression
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+// checkRegression{{.Ident}} checks the property {{printf "%q" .Name}} with the
+// seed {{if .Corpus}}and the arguments {{end}}it has been falsified with.
+// Copy it to the package of the property and call it with the property in a
+// test.
+func checkRegression{{.Ident}}(t *testing.T, property gopter.Prop) {
+	parameters := gopter.DefaultTestParametersWithSeed({{.Seed}})
+{{- if .Corpus}}
+	corpus, err := gopter.LoadCorpus({{printf "%q" .Corpus}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parameters.Corpus = corpus
+{{- end}}
+	properties := gopter.NewProperties(parameters)
+	properties.Property({{printf "%q" .Name}}, property)
+	properties.TestingRun(t)
+}
+`))
+
+// WriteArtifact writes the artifact bundle of a failed property to the
+// directory path (replacing any previous bundle), i.e. a single directory
+// that might be attached to a bug report:
+//
+//	result.json        status, seed, labels and error of the check
+//	args.txt           the (shrunk) arguments in go syntax
+//	args.corpus        the arguments as corpus example (if they can be
+//	                   serialized as JSON, see Corpus)
+//	shrink.txt         the original arguments, the number of shrinks and
+//	                   the generation trace of each argument
+//	sut.log            the logs of the system under test (unless logs is nil)
+//	regression_test.go a function to check the property with the seed and
+//	                   arguments of the bundle
+//
+// Arguments implementing ArtifactArg may add further files.
+func WriteArtifact(path string, result *PropertyResult, logs []byte) error {
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+	files := map[string][]byte{}
+
+	errorMessage := ""
+	if result.Error != nil {
+		errorMessage = result.Error.Error()
+	}
+	summary, err := json.MarshalIndent(map[string]interface{}{
+		"property":   result.Name,
+		"status":     result.Status.String(),
+		"seed":       result.Seed,
+		"succeeded":  result.Succeeded,
+		"discarded":  result.Discarded,
+		"labels":     result.Labels,
+		"error":      errorMessage,
+		"errorStack": string(result.ErrorStack),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	files["result.json"] = append(summary, '\n')
+
+	var args, shrinks bytes.Buffer
+	values := make([]interface{}, len(result.Args))
+	for i, arg := range result.Args {
+		values[i] = arg.Arg
+		fmt.Fprintf(&args, "ARG_%d: %#v\n", i, arg.Arg)
+		fmt.Fprintf(&shrinks, "ARG_%d", i)
+		if arg.Label != "" {
+			fmt.Fprintf(&shrinks, " (%s)", arg.Label)
+		}
+		fmt.Fprintf(&shrinks, ": %d shrinks\n  original: %#v\n  shrunk:   %#v\n", arg.Shrinks, arg.OrigArg, arg.Arg)
+		if len(arg.Trace) > 0 {
+			fmt.Fprintln(&shrinks, "  trace:")
+			for _, entry := range arg.Trace {
+				fmt.Fprintf(&shrinks, "    %s\n", entry)
+			}
+		}
+	}
+	files["args.txt"] = args.Bytes()
+	files["shrink.txt"] = shrinks.Bytes()
+
+	corpus := ""
+	if len(values) > 0 {
+		if example, err := json.Marshal(values); err == nil {
+			corpus = filepath.Join(path, "args.corpus")
+			files["args.corpus"] = []byte(fmt.Sprintf("# %s (seed %d)\n%s\n", result.Name, result.Seed, example))
+		}
+	}
+	if logs != nil {
+		files["sut.log"] = logs
+	}
+
+	var regression bytes.Buffer
+	if err := regressionTemplate.Execute(&regression, map[string]interface{}{
+		"Name":   result.Name,
+		"Ident":  identifier(result.Name),
+		"Seed":   result.Seed,
+		"Corpus": filepath.ToSlash(corpus),
+	}); err != nil {
+		return err
+	}
+	if source, err := format.Source(regression.Bytes()); err == nil {
+		files["regression_test.go"] = source
+	} else {
+		files["regression_test.go"] = regression.Bytes()
+	}
+
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(path, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	for _, arg := range result.Args {
+		if artifactArg, ok := arg.Arg.(ArtifactArg); ok {
+			if err := artifactArg.WriteArtifact(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeArtifact writes the artifact bundle of a property to the
+// TestParameters.ArtifactDir if it has not passed and labels the result with
+// the path of the bundle
+func (r *PropertyResult) writeArtifact(parameters *TestParameters) {
+	if parameters.ArtifactDir == "" || r.Status != TestFailed && r.Status != TestError {
+		return
+	}
+	var logs []byte
+	if parameters.ArtifactLogs != nil {
+		logs = parameters.ArtifactLogs(r.Name)
+	}
+	path := ArtifactPath(parameters.ArtifactDir, r.Name)
+	if err := WriteArtifact(path, r, logs); err != nil {
+		r.Labels = append(r.Labels, fmt.Sprintf("artifact failed: %v", err))
+		return
+	}
+	r.Labels = append(r.Labels, fmt.Sprintf("artifact: %s", path))
+}
+
+// identifier converts a property name to a (camel case) go identifier
+func identifier(name string) string {
+	var result strings.Builder
+	upper := true
+	for _, ch := range name {
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) {
+			upper = true
+			continue
+		}
+		if upper {
+			ch = unicode.ToUpper(ch)
+			upper = false
+		}
+		result.WriteRune(ch)
+	}
+	return result.String()
+}
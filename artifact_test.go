@@ -0,0 +1,83 @@
+package gopter_test
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestArtifact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter_artifact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	parameters.ArtifactDir = dir
+	parameters.ArtifactLogs = func(propName string) []byte {
+		return []byte("log of " + propName)
+	}
+	properties := gopter.NewProperties(parameters)
+	properties.Property("small numbers", prop.ForAll(func(v int) bool {
+		return v < 100
+	}, gen.IntRange(0, 1000).WithLabel("number")))
+	properties.Property("no functions", prop.ForAll(func(f func() int) bool {
+		return false
+	}, gen.Const(func() int { return 0 })))
+	properties.Property("passes", prop.ForAll(func(v int) bool {
+		return true
+	}, gen.Int()))
+	results := properties.RunResults(nil)
+
+	path := gopter.ArtifactPath(dir, "small numbers")
+	if labels := results[0].Labels; len(labels) == 0 || labels[len(labels)-1] != "artifact: "+path {
+		t.Errorf("Invalid labels: %#v", labels)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(path, "result.json"))
+	var summary map[string]interface{}
+	if err != nil || json.Unmarshal(data, &summary) != nil || summary["status"] != "FAILED" || summary["seed"] != float64(results[0].Seed) {
+		t.Errorf("Invalid result.json: %s (%v)", data, err)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(path, "args.txt")); err != nil || string(data) != "ARG_0: 100\n" {
+		t.Errorf("Invalid args.txt: %s (%v)", data, err)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(path, "shrink.txt")); err != nil || !strings.Contains(string(data), "ARG_0 (number): ") || !strings.Contains(string(data), "shrunk:   100\n") {
+		t.Errorf("Invalid shrink.txt: %s (%v)", data, err)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(path, "sut.log")); err != nil || string(data) != "log of small numbers" {
+		t.Errorf("Invalid sut.log: %s (%v)", data, err)
+	}
+	corpus, err := gopter.LoadCorpus(filepath.Join(path, "args.corpus"))
+	if err != nil || len(corpus.Examples()) != 1 || corpus.Examples()[0] != "[100]" {
+		t.Errorf("Invalid args.corpus: %v (%v)", corpus, err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(path, "regression_test.go"), nil, 0)
+	if err != nil || len(file.Decls) != 2 {
+		t.Errorf("Invalid regression test: %v", err)
+	}
+	if data, _ := ioutil.ReadFile(filepath.Join(path, "regression_test.go")); !strings.Contains(string(data), "func checkRegressionSmallNumbers(") || !strings.Contains(string(data), "LoadCorpus") {
+		t.Errorf("Invalid regression test: %s", data)
+	}
+
+	path = gopter.ArtifactPath(dir, "no functions")
+	if _, err := os.Stat(filepath.Join(path, "args.corpus")); !os.IsNotExist(err) {
+		t.Errorf("Corpus of unserializable arguments: %v", err)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(path, "regression_test.go")); err != nil || strings.Contains(string(data), "LoadCorpus") {
+		t.Errorf("Invalid regression test: %s (%v)", data, err)
+	}
+
+	if _, err := os.Stat(gopter.ArtifactPath(dir, "passes")); !os.IsNotExist(err) {
+		t.Errorf("Artifact of passed property: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package gopter
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"sync"
+)
+
+// bytesSource is a rand.Source taking all its "random" numbers from a byte
+// buffer. Once the buffer is exhausted only zeros are produced, which usually
+// makes generators choose minimal values.
+type bytesSource struct {
+	lk     sync.Mutex
+	data   []byte
+	offset int
+}
+
+// NewBytesSource creates a rand.Source that derives all numbers from data
+// (8 bytes in big endian order per number).
+func NewBytesSource(data []byte) rand.Source64 {
+	return &bytesSource{data: data}
+}
+
+func (s *bytesSource) Uint64() uint64 {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	var buf [8]byte
+	if s.offset < len(s.data) {
+		s.offset += copy(buf[:], s.data[s.offset:])
+	}
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+func (s *bytesSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *bytesSource) Seed(seed int64) {
+	s.lk.Lock()
+	s.offset = 0
+	s.lk.Unlock()
+}
+
+// FromBytes generates a value with all decisions of the generator derived from
+// data instead of a random generator.
+// The same data always leads to the same value, which enables the use of
+// generators in external fuzzers (e.g. go test -fuzz) and the replay of a
+// corpus. Once the data is exhausted the generator only gets zeros.
+func FromBytes(gen Gen, data []byte) (interface{}, bool) {
+	genParams := DefaultGenParameters()
+	genParams.Rng = rand.New(NewBytesSource(data))
+	return gen(genParams).Retrieve()
+}
@@ -0,0 +1,36 @@
+package gopter_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestFromBytes(t *testing.T) {
+	intGen := gopter.Gen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+		return gopter.NewGenResult(genParams.Rng.Intn(1000), gopter.NoShrinker)
+	})
+
+	first, ok := gopter.FromBytes(intGen, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	if !ok {
+		t.FailNow()
+	}
+	second, _ := gopter.FromBytes(intGen, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	if first != second {
+		t.Errorf("Same data should generate same value: %#v != %#v", first, second)
+	}
+	if value, _ := gopter.FromBytes(intGen, nil); value != 0 {
+		t.Errorf("Exhausted data should generate minimal value: %#v", value)
+	}
+
+	source := gopter.NewBytesSource([]byte{0, 0, 0, 0, 0, 0, 0, 42, 1})
+	if v := source.Uint64(); v != 42 {
+		t.Errorf("Invalid number: %d", v)
+	}
+	if v := source.Uint64(); v != 1<<56 {
+		t.Errorf("Invalid padded number: %d", v)
+	}
+	if v := source.Int63(); v != 0 {
+		t.Errorf("Invalid exhausted number: %d", v)
+	}
+}
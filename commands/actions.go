@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -11,13 +12,24 @@ import (
 type shrinkableCommand struct {
 	command  Command
 	shrinker gopter.Shrinker
+	// dependent is true if the command depended on an earlier command when it
+	// was generated (see DependentCommand)
+	dependent bool
+	// observation is true if the command is an observation (see
+	// ObservationCommand)
+	observation bool
 }
 
 func (s shrinkableCommand) shrink() gopter.Shrink {
+	if s.observation {
+		return gopter.NoShrink
+	}
 	return s.shrinker(s.command).Map(func(command Command) shrinkableCommand {
 		return shrinkableCommand{
-			command:  command,
-			shrinker: s.shrinker,
+			command:     command,
+			shrinker:    s.shrinker,
+			dependent:   s.dependent,
+			observation: s.observation,
 		}
 	})
 }
@@ -31,10 +43,15 @@ type actions struct {
 	// same every time.
 	initialStateProvider func() State
 	sequentialCommands   []shrinkableCommand
-	// parallel commands will come later
+	// parallelCommands are the branches of commands that are run concurrently
+	// after the sequential commands (see ParallelProp)
+	parallelCommands [][]shrinkableCommand
 }
 
 func (a *actions) String() string {
+	if len(a.parallelCommands) > 0 {
+		return fmt.Sprintf("initialState=%v sequential=%s parallel=%s", a.initialStateProvider(), a.sequentialCommands, a.parallelCommands)
+	}
 	return fmt.Sprintf("initialState=%v sequential=%s", a.initialStateProvider(), a.sequentialCommands)
 }
 
@@ -45,13 +62,58 @@ func (a *actions) run(systemUnderTest SystemUnderTest) (*gopter.PropResult, erro
 		if !shrinkableCommand.command.PreCondition(state) {
 			return &gopter.PropResult{Status: gopter.PropFalse}, nil
 		}
-		result := shrinkableCommand.command.Run(systemUnderTest)
-		state = shrinkableCommand.command.NextState(state)
-		propResult = propResult.And(shrinkableCommand.command.PostCondition(state, result))
+		var commandResult *gopter.PropResult
+		state, commandResult = runCommand(shrinkableCommand.command, systemUnderTest, state)
+		propResult = propResult.And(commandResult)
 	}
 	return propResult, nil
 }
 
+// runCommand runs a single command against the system under test and checks
+// its post condition against the next state
+func runCommand(command Command, systemUnderTest SystemUnderTest, state State) (State, *gopter.PropResult) {
+	result, execution := execute(command, systemUnderTest)
+	nextState := command.NextState(state)
+	return nextState, postCondition(command, nextState, result, execution)
+}
+
+// execute runs a single command against the system under test, the
+// execution is only recorded for an ExecutionCommand (nil otherwise)
+func execute(command Command, systemUnderTest SystemUnderTest) (Result, *Execution) {
+	executionCommand, ok := command.(ExecutionCommand)
+	if !ok {
+		return command.Run(systemUnderTest), nil
+	}
+	start := time.Now()
+	result := executionCommand.Run(systemUnderTest)
+	execution := &Execution{
+		Duration: time.Since(start),
+	}
+	execution.Observation = executionCommand.Observe(systemUnderTest)
+	return result, execution
+}
+
+// postCondition checks the post condition of an executed command
+func postCondition(command Command, nextState State, result Result, execution *Execution) *gopter.PropResult {
+	if executionCommand, ok := command.(ExecutionCommand); ok {
+		return executionCommand.PostConditionWithExecution(nextState, result, execution)
+	}
+	return command.PostCondition(nextState, result)
+}
+
+// preConditionsHold checks if the pre conditions of the sequential commands
+// hold in order and the ones of the parallel commands for any interleaving
+func (a *actions) preConditionsHold() bool {
+	state := a.initialStateProvider()
+	for _, shrinkableCommand := range a.sequentialCommands {
+		if !shrinkableCommand.command.PreCondition(state) {
+			return false
+		}
+		state = shrinkableCommand.command.NextState(state)
+	}
+	return len(a.parallelCommands) == 0 || a.parallelPreConditionsHold(nil, make([]int, len(a.parallelCommands)))
+}
+
 type sizedCommands struct {
 	state    State
 	commands []shrinkableCommand
@@ -62,17 +124,93 @@ func actionsShrinker(v interface{}) gopter.Shrink {
 	elementShrinker := gopter.Shrinker(func(v interface{}) gopter.Shrink {
 		return v.(shrinkableCommand).shrink()
 	})
-	return gen.SliceShrinker(elementShrinker)(a.sequentialCommands).Map(func(v []shrinkableCommand) *actions {
-		return &actions{
-			initialStateProvider: a.initialStateProvider,
-			sequentialCommands:   v,
+	return gopter.ConcatShrinks(
+		gopter.ConcatShrinks(
+			observationsShrink(a.sequentialCommands),
+			gen.SliceShrinker(elementShrinker)(a.sequentialCommands),
+		).Map(func(v []shrinkableCommand) *actions {
+			return &actions{
+				initialStateProvider: a.initialStateProvider,
+				sequentialCommands:   repairCommands(a.initialStateProvider, v),
+				parallelCommands:     a.parallelCommands,
+			}
+		}),
+		parallelShrink(a, elementShrinker),
+	)
+}
+
+// observationsShrink drops all observations at once and then one by one
+func observationsShrink(commands []shrinkableCommand) gopter.Shrink {
+	var withoutObservations []shrinkableCommand
+	var candidates []interface{}
+	for i, command := range commands {
+		if !command.observation {
+			withoutObservations = append(withoutObservations, command)
+			continue
 		}
-	})
+		candidate := make([]shrinkableCommand, 0, len(commands)-1)
+		candidate = append(candidate, commands[:i]...)
+		candidates = append(candidates, append(candidate, commands[i+1:]...))
+	}
+	if len(candidates) > 1 {
+		candidates = append([]interface{}{withoutObservations}, candidates...)
+	}
+	return func() (interface{}, bool) {
+		if len(candidates) == 0 {
+			return nil, false
+		}
+		next := candidates[0]
+		candidates = candidates[1:]
+		return next, true
+	}
 }
 
-func genActions(commands Commands) gopter.Gen {
+// isObservation checks if a command is an observation
+func isObservation(command Command) bool {
+	observationCommand, ok := command.(ObservationCommand)
+	return ok && observationCommand.IsObservation()
+}
+
+// dependsOnAny checks if a command depends on any of the earlier commands
+func dependsOnAny(command Command, earlier []shrinkableCommand) bool {
+	dependentCommand, ok := command.(DependentCommand)
+	if !ok {
+		return false
+	}
+	for _, shrinkableCommand := range earlier {
+		if dependentCommand.DependsOn(shrinkableCommand.command) {
+			return true
+		}
+	}
+	return false
+}
+
+// repairCommands re-simulates the state of a shrunk sequence of commands and
+// drops the commands that are no longer valid, i.e. whose pre condition does
+// not hold anymore (e.g. a "Close" after its "Open" has been removed) or whose
+// dependencies have been removed (see DependentCommand). Otherwise most
+// candidates of the shrinker would be discarded.
+func repairCommands(initialStateProvider func() State, commands []shrinkableCommand) []shrinkableCommand {
+	state := initialStateProvider()
+	result := make([]shrinkableCommand, 0, len(commands))
+	for _, shrinkableCommand := range commands {
+		if shrinkableCommand.dependent && !dependsOnAny(shrinkableCommand.command, result) {
+			continue
+		}
+		if !shrinkableCommand.command.PreCondition(state) {
+			continue
+		}
+		state = shrinkableCommand.command.NextState(state)
+		result = append(result, shrinkableCommand)
+	}
+	return result
+}
+
+// genInitialStateProvider generates functions that recreate the same initial
+// state every time
+func genInitialStateProvider(commands Commands) gopter.Gen {
 	genInitialState := commands.GenInitialState()
-	genInitialStateProvider := gopter.Gen(func(params *gopter.GenParameters) *gopter.GenResult {
+	return gopter.Gen(func(params *gopter.GenParameters) *gopter.GenResult {
 		seed := params.NextInt64()
 		return gopter.NewGenResult(func() State {
 			paramsWithSeed := params.CloneWithSeed(seed)
@@ -85,7 +223,10 @@ func genActions(commands Commands) gopter.Gen {
 		state := initialStateProvoder()
 		return state != nil && commands.InitialPreCondition(state)
 	})
-	return genInitialStateProvider.FlatMap(func(v interface{}) gopter.Gen {
+}
+
+func genActions(commands Commands) gopter.Gen {
+	return genInitialStateProvider(commands).FlatMap(func(v interface{}) gopter.Gen {
 		initialStateProvider := v.(func() State)
 		return genSizedCommands(commands, initialStateProvider).Map(func(v sizedCommands) *actions {
 			return &actions{
@@ -93,48 +234,47 @@ func genActions(commands Commands) gopter.Gen {
 				sequentialCommands:   v.commands,
 			}
 		}).SuchThat(func(actions *actions) bool {
-			state := actions.initialStateProvider()
-			for _, shrinkableCommand := range actions.sequentialCommands {
-				if !shrinkableCommand.command.PreCondition(state) {
-					return false
-				}
-				state = shrinkableCommand.command.NextState(state)
-			}
-			return true
+			return actions.preConditionsHold()
 		}).WithShrinker(actionsShrinker)
 	}, reflect.TypeOf((*actions)(nil)))
 }
 
 func genSizedCommands(commands Commands, initialStateProvider func() State) gopter.Gen {
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
-		sizedCommandsGen := gen.Const(sizedCommands{
+		sized := sizedCommands{
 			state:    initialStateProvider(),
 			commands: make([]shrinkableCommand, 0, genParams.MaxSize),
-		})
-		for i := 0; i < genParams.MaxSize; i++ {
-			sizedCommandsGen = sizedCommandsGen.FlatMap(func(v interface{}) gopter.Gen {
-				prev := v.(sizedCommands)
-				return gen.RetryUntil(commands.GenCommand(prev.state), func(command Command) bool {
-					return command.PreCondition(prev.state)
-				}, 100).MapResult(func(result *gopter.GenResult) *gopter.GenResult {
-					value, ok := result.Retrieve()
-					if !ok {
-						return gopter.NewEmptyResult(reflect.TypeOf(sizedCommands{}))
-					}
-					command := value.(Command)
-					return gopter.NewGenResult(
-						sizedCommands{
-							state: command.NextState(prev.state),
-							commands: append(prev.commands, shrinkableCommand{
-								command:  command,
-								shrinker: result.Shrinker,
-							}),
-						},
-						gopter.NoShrinker,
-					)
-				})
-			}, reflect.TypeOf(sizedCommands{}))
 		}
-		return sizedCommandsGen(genParams)
+		// observations do not count towards the size, but there are at most
+		// MaxSize of them (further observations are dropped)
+		size, observations := 0, 0
+		for attempts := 0; size < genParams.MaxSize && attempts < 10*genParams.MaxSize; attempts++ {
+			prev := sized
+			result := gen.RetryUntil(commands.GenCommand(prev.state), func(command Command) bool {
+				return command.PreCondition(prev.state)
+			}, 100)(genParams)
+			value, ok := result.Retrieve()
+			if !ok {
+				return gopter.NewEmptyResult(reflect.TypeOf(sized))
+			}
+			command := value.(Command)
+			observation := isObservation(command)
+			if observation {
+				if observations >= genParams.MaxSize {
+					continue
+				}
+				observations++
+			} else {
+				size++
+			}
+			sized.state = command.NextState(prev.state)
+			sized.commands = append(prev.commands, shrinkableCommand{
+				command:     command,
+				shrinker:    result.Shrinker,
+				dependent:   dependsOnAny(command, prev.commands),
+				observation: observation,
+			})
+		}
+		return gopter.NewGenResult(sized, gopter.NoShrinker)
 	}
 }
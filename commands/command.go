@@ -1,6 +1,10 @@
 package commands
 
-import "github.com/leanovate/gopter"
+import (
+	"time"
+
+	"github.com/leanovate/gopter"
+)
 
 // SystemUnderTest resembles the system under test, which may be any kind
 // of stateful unit of code
@@ -26,13 +30,66 @@ type Command interface {
 	String() string
 }
 
+// Execution contains details about the execution of a command
+type Execution struct {
+	// Duration is the time it took to run the command
+	Duration time.Duration
+	// Observation is an arbitrary observation of the system under test captured
+	// right after the command has been run (see ExecutionCommand.Observe)
+	Observation interface{}
+}
+
+// ExecutionCommand is an optional extension of the Command interface for
+// post conditions that depend on how the command was executed (e.g. SLO-like
+// checks on the duration of a command)
+type ExecutionCommand interface {
+	Command
+	// Observe may capture an observation of the system under test right after
+	// the command has been run
+	Observe(systemUnderTest SystemUnderTest) interface{}
+	// PostConditionWithExecution checks if the state is valid after the command
+	// is applied. It is used instead of PostCondition.
+	PostConditionWithExecution(state State, result Result, execution *Execution) *gopter.PropResult
+}
+
+// DependentCommand is an optional extension of the Command interface for
+// commands that depend on earlier commands (e.g. a "Withdraw" depending on an
+// earlier "Deposit").
+// If a command depended on an earlier command when the sequence of commands
+// was generated, the shrinker will only keep it as long as at least one of the
+// commands it depends on is kept as well. This prevents the shrinker from
+// wasting its budget on orphaned commands that fail their pre condition.
+type DependentCommand interface {
+	Command
+	// DependsOn checks if the command depends on an earlier command
+	DependsOn(earlier Command) bool
+}
+
+// ObservationCommand is an optional extension of the Command interface for
+// read-only commands that do not change the state and are cheap to run (e.g.
+// a "Get" that asserts on the current value).
+// Observations do not count towards the size of a generated sequence of
+// commands (which still contains at most MaxSize observations) and the
+// shrinker drops them first without shrinking them any further. This allows
+// rich assertions without bloating the sequences.
+type ObservationCommand interface {
+	Command
+	// IsObservation checks if the command is an observation
+	IsObservation() bool
+}
+
 // ProtoCommand is a prototype implementation of the Command interface
 type ProtoCommand struct {
-	Name              string
-	RunFunc           func(systemUnderTest SystemUnderTest) Result
-	NextStateFunc     func(state State) State
-	PreConditionFunc  func(state State) bool
-	PostConditionFunc func(state State, result Result) *gopter.PropResult
+	Name                           string
+	RunFunc                        func(systemUnderTest SystemUnderTest) Result
+	NextStateFunc                  func(state State) State
+	PreConditionFunc               func(state State) bool
+	PostConditionFunc              func(state State, result Result) *gopter.PropResult
+	ObserveFunc                    func(systemUnderTest SystemUnderTest) interface{}
+	PostConditionWithExecutionFunc func(state State, result Result, execution *Execution) *gopter.PropResult
+	DependsOnFunc                  func(earlier Command) bool
+	// Observation marks the command as read-only (see ObservationCommand)
+	Observation bool
 }
 
 // Run applies the command to the system under test
@@ -67,6 +124,38 @@ func (p *ProtoCommand) PostCondition(state State, result Result) *gopter.PropRes
 	return &gopter.PropResult{Status: gopter.PropTrue}
 }
 
+// Observe may capture an observation of the system under test right after
+// the command has been run
+func (p *ProtoCommand) Observe(systemUnderTest SystemUnderTest) interface{} {
+	if p.ObserveFunc != nil {
+		return p.ObserveFunc(systemUnderTest)
+	}
+	return nil
+}
+
+// PostConditionWithExecution checks if the state is valid after the command
+// is applied having access to the details of the execution.
+// Falls back to PostCondition if there is no PostConditionWithExecutionFunc
+func (p *ProtoCommand) PostConditionWithExecution(state State, result Result, execution *Execution) *gopter.PropResult {
+	if p.PostConditionWithExecutionFunc != nil {
+		return p.PostConditionWithExecutionFunc(state, result, execution)
+	}
+	return p.PostCondition(state, result)
+}
+
+// DependsOn checks if the command depends on an earlier command
+func (p *ProtoCommand) DependsOn(earlier Command) bool {
+	if p.DependsOnFunc != nil {
+		return p.DependsOnFunc(earlier)
+	}
+	return false
+}
+
+// IsObservation checks if the command is an observation
+func (p *ProtoCommand) IsObservation() bool {
+	return p.Observation
+}
+
 func (p *ProtoCommand) String() string {
 	return p.Name
 }
@@ -26,6 +26,21 @@ type Commands interface {
 	InitialPreCondition(state State) bool
 }
 
+// SetUpCommands is an optional extension of the Commands interface for
+// systems under test that hold external resources (e.g. temporary
+// directories, database connections or servers), which have to be released
+// reliably after every run of the commands (including the runs of the
+// shrinker).
+type SetUpCommands interface {
+	Commands
+	// SetUp is called before the first command is run, an error aborts the
+	// run (the property check ends with an error)
+	SetUp(systemUnderTest SystemUnderTest) error
+	// TearDown is called after the last command has been run, even if a
+	// command has failed or panicked (or SetUp has failed)
+	TearDown(systemUnderTest SystemUnderTest)
+}
+
 // ProtoCommands is a prototype implementation of the Commands interface
 type ProtoCommands struct {
 	NewSystemUnderTestFunc     func(initialState State) SystemUnderTest
@@ -33,6 +48,8 @@ type ProtoCommands struct {
 	InitialStateGen            gopter.Gen
 	GenCommandFunc             func(State) gopter.Gen
 	InitialPreConditionFunc    func(State) bool
+	SetUpFunc                  func(SystemUnderTest) error
+	TearDownFunc               func(SystemUnderTest)
 }
 
 // NewSystemUnderTest should create a new/isolated system under test
@@ -50,6 +67,21 @@ func (p *ProtoCommands) DestroySystemUnderTest(systemUnderTest SystemUnderTest)
 	}
 }
 
+// SetUp is called before the first command is run
+func (p *ProtoCommands) SetUp(systemUnderTest SystemUnderTest) error {
+	if p.SetUpFunc != nil {
+		return p.SetUpFunc(systemUnderTest)
+	}
+	return nil
+}
+
+// TearDown is called after the last command has been run
+func (p *ProtoCommands) TearDown(systemUnderTest SystemUnderTest) {
+	if p.TearDownFunc != nil {
+		p.TearDownFunc(systemUnderTest)
+	}
+}
+
 // GenCommand provides a generator for applicable commands to for a state
 func (p *ProtoCommands) GenCommand(state State) gopter.Gen {
 	if p.GenCommandFunc != nil {
@@ -75,10 +107,28 @@ func (p *ProtoCommands) InitialPreCondition(state State) bool {
 
 // Prop creates a gopter.Prop from Commands
 func Prop(commands Commands) gopter.Prop {
-	return prop.ForAll(func(actions *actions) (*gopter.PropResult, error) {
-		systemUnderTest := commands.NewSystemUnderTest(actions.initialStateProvider())
-		defer commands.DestroySystemUnderTest(systemUnderTest)
+	return prop.ForAll(checkSequential(commands), genActions(commands))
+}
 
-		return actions.run(systemUnderTest)
-	}, genActions(commands))
+// checkSequential creates the condition of Prop, i.e. runs the sequential
+// commands
+func checkSequential(commands Commands) func(*actions) (*gopter.PropResult, error) {
+	return func(actions *actions) (*gopter.PropResult, error) {
+		return withSystemUnderTest(commands, actions, actions.run)
+	}
+}
+
+// withSystemUnderTest runs actions against a new system under test, which is
+// set up before and torn down and destroyed afterwards (see SetUpCommands)
+func withSystemUnderTest(commands Commands, actions *actions, run func(SystemUnderTest) (*gopter.PropResult, error)) (*gopter.PropResult, error) {
+	systemUnderTest := commands.NewSystemUnderTest(actions.initialStateProvider())
+	defer commands.DestroySystemUnderTest(systemUnderTest)
+
+	if setUpCommands, ok := commands.(SetUpCommands); ok {
+		defer setUpCommands.TearDown(systemUnderTest)
+		if err := setUpCommands.SetUp(systemUnderTest); err != nil {
+			return nil, err
+		}
+	}
+	return run(systemUnderTest)
 }
@@ -1,7 +1,10 @@
 package commands_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/commands"
@@ -110,3 +113,295 @@ func TestCommands(t *testing.T) {
 		t.Errorf("Invalid result: %v", result)
 	}
 }
+
+func TestCommandsWithExecution(t *testing.T) {
+	var executions int
+	slowIncCommand := &commands.ProtoCommand{
+		Name: "SLOW_INC",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			time.Sleep(time.Millisecond)
+			return systemUnderTest.(*counter).Inc()
+		},
+		NextStateFunc: func(state commands.State) commands.State {
+			return state.(int) + 1
+		},
+		ObserveFunc: func(systemUnderTest commands.SystemUnderTest) interface{} {
+			return systemUnderTest.(*counter).Get()
+		},
+		PostConditionWithExecutionFunc: func(state commands.State, result commands.Result, execution *commands.Execution) *gopter.PropResult {
+			executions++
+			if execution.Duration < time.Millisecond || execution.Observation.(int) != state.(int) {
+				return &gopter.PropResult{Status: gopter.PropFalse}
+			}
+			return &gopter.PropResult{Status: gopter.PropTrue}
+		},
+	}
+	counterCommands := &commands.ProtoCommands{
+		NewSystemUnderTestFunc: func(initialState commands.State) commands.SystemUnderTest {
+			return &counter{value: initialState.(int)}
+		},
+		InitialStateGen: gen.IntRange(0, 100),
+		GenCommandFunc: func(state commands.State) gopter.Gen {
+			return gen.OneConstOf(GetCommand, slowIncCommand)
+		},
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 5
+	parameters.MaxSize = 10
+	result := commands.Prop(counterCommands).Check(parameters)
+	if !result.Passed() || executions == 0 {
+		t.Errorf("Invalid result: %#v (%d executions)", result, executions)
+	}
+}
+
+func TestCommandsWithDependencies(t *testing.T) {
+	var orphans int
+	depositCommand := &commands.ProtoCommand{
+		Name: "DEPOSIT",
+		NextStateFunc: func(state commands.State) commands.State {
+			return state.(int) + 1
+		},
+	}
+	withdrawCommand := &commands.ProtoCommand{
+		Name: "WITHDRAW",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			return systemUnderTest.(*counter).Inc()
+		},
+		PreConditionFunc: func(state commands.State) bool {
+			if state.(int) == 0 {
+				orphans++
+				return false
+			}
+			return true
+		},
+		PostConditionFunc: func(state commands.State, result commands.Result) *gopter.PropResult {
+			if result.(int) > 1 {
+				return &gopter.PropResult{Status: gopter.PropFalse}
+			}
+			return &gopter.PropResult{Status: gopter.PropTrue}
+		},
+		DependsOnFunc: func(earlier commands.Command) bool {
+			return earlier.String() == "DEPOSIT"
+		},
+	}
+	bankCommands := &commands.ProtoCommands{
+		NewSystemUnderTestFunc: func(initialState commands.State) commands.SystemUnderTest {
+			return &counter{}
+		},
+		InitialStateGen: gen.Const(0),
+		GenCommandFunc: func(state commands.State) gopter.Gen {
+			if state.(int) == 0 {
+				return gen.Const(depositCommand)
+			}
+			return gen.OneConstOf(depositCommand, withdrawCommand)
+		},
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MaxSize = 30
+	result := commands.Prop(bankCommands).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if orphans != 0 {
+		t.Errorf("Shrinker produced %d orphaned commands", orphans)
+	}
+	if shrunk := fmt.Sprintf("%v", result.Args[0].Arg); !strings.HasSuffix(shrunk, "sequential=[DEPOSIT WITHDRAW WITHDRAW]") {
+		t.Errorf("Invalid shrunk commands: %s", shrunk)
+	}
+}
+
+type stuckCounter struct {
+	counter
+}
+
+// Inc gets stuck at 3
+func (c *stuckCounter) Inc() int {
+	if c.value < 3 {
+		c.value++
+	}
+	return c.value
+}
+
+func TestCommandsWithObservations(t *testing.T) {
+	var incs, gets, maxIncs, maxGets int
+	getObservation := &commands.ProtoCommand{
+		Name: "GET",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			gets++
+			return systemUnderTest.(*stuckCounter).Get()
+		},
+		PostConditionFunc: GetCommand.PostConditionFunc,
+		Observation:       true,
+	}
+	incCommand := &commands.ProtoCommand{
+		Name: "INC",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			incs++
+			return systemUnderTest.(*stuckCounter).Inc()
+		},
+		NextStateFunc: IncCommand.NextStateFunc,
+	}
+	stuckCommands := &commands.ProtoCommands{
+		NewSystemUnderTestFunc: func(initialState commands.State) commands.SystemUnderTest {
+			incs, gets = 0, 0
+			return &stuckCounter{}
+		},
+		DestroySystemUnderTestFunc: func(commands.SystemUnderTest) {
+			if incs > maxIncs {
+				maxIncs = incs
+			}
+			if gets > maxGets {
+				maxGets = gets
+			}
+		},
+		InitialStateGen: gen.Const(0),
+		GenCommandFunc: func(state commands.State) gopter.Gen {
+			return gen.Weighted([]gen.WeightedGen{
+				{Weight: 1, Gen: gen.Const(incCommand)},
+				{Weight: 3, Gen: gen.Const(getObservation)},
+			})
+		},
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MaxSize = 3
+	result := commands.Prop(stuckCommands).Check(parameters)
+	if !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	if maxIncs > 3 || maxGets == 0 || maxGets > 3 {
+		t.Errorf("Invalid sizes: %d commands, %d observations", maxIncs, maxGets)
+	}
+
+	parameters.MaxSize = 20
+	result = commands.Prop(stuckCommands).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if shrunk := fmt.Sprintf("%v", result.Args[0].Arg); !strings.HasSuffix(shrunk, "sequential=[INC INC INC INC GET]") {
+		t.Errorf("Invalid shrunk commands: %s", shrunk)
+	}
+}
+
+func TestCommandsSetUpTearDown(t *testing.T) {
+	var setUps, tearDowns int
+	var open bool
+	panicCommand := &commands.ProtoCommand{
+		Name: "PANIC",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			panic("broken")
+		},
+	}
+	resourceCommands := &commands.ProtoCommands{
+		NewSystemUnderTestFunc: func(initialState commands.State) commands.SystemUnderTest {
+			return &counter{value: initialState.(int)}
+		},
+		InitialStateGen: gen.Const(0),
+		GenCommandFunc: func(state commands.State) gopter.Gen {
+			return gen.OneConstOf(IncCommand, GetCommand)
+		},
+		SetUpFunc: func(systemUnderTest commands.SystemUnderTest) error {
+			if open {
+				return fmt.Errorf("Resource has not been released")
+			}
+			setUps++
+			open = true
+			return nil
+		},
+		TearDownFunc: func(systemUnderTest commands.SystemUnderTest) {
+			tearDowns++
+			open = false
+		},
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	result := commands.Prop(resourceCommands).Check(parameters)
+	if !result.Passed() || setUps == 0 || setUps != tearDowns || open {
+		t.Errorf("Invalid result: %#v (%d set ups, %d tear downs)", result, setUps, tearDowns)
+	}
+
+	setUps, tearDowns = 0, 0
+	resourceCommands.GenCommandFunc = func(state commands.State) gopter.Gen {
+		return gen.OneConstOf(IncCommand, panicCommand)
+	}
+	result = commands.Prop(resourceCommands).Check(parameters)
+	if result.Status != gopter.TestError || setUps < 2 || setUps != tearDowns || open {
+		t.Errorf("Invalid result: %#v (%d set ups, %d tear downs)", result, setUps, tearDowns)
+	}
+
+	tearDowns = 0
+	resourceCommands.SetUpFunc = func(systemUnderTest commands.SystemUnderTest) error {
+		return fmt.Errorf("No connection")
+	}
+	result = commands.Prop(resourceCommands).Check(parameters)
+	if result.Status != gopter.TestError || result.Error.Error() != "No connection" || tearDowns != 1 {
+		t.Errorf("Invalid result: %#v (%d tear downs)", result, tearDowns)
+	}
+}
+
+type fileState struct {
+	open   bool
+	writes int
+}
+
+func TestCommandsShrinkRepairsPreConditions(t *testing.T) {
+	openCommand := &commands.ProtoCommand{
+		Name: "OPEN",
+		PreConditionFunc: func(state commands.State) bool {
+			return !state.(fileState).open
+		},
+		NextStateFunc: func(state commands.State) commands.State {
+			return fileState{open: true, writes: state.(fileState).writes}
+		},
+	}
+	closeCommand := &commands.ProtoCommand{
+		Name: "CLOSE",
+		PreConditionFunc: func(state commands.State) bool {
+			return state.(fileState).open
+		},
+		NextStateFunc: func(state commands.State) commands.State {
+			return fileState{open: false, writes: state.(fileState).writes}
+		},
+	}
+	// the third write fails
+	writeCommand := &commands.ProtoCommand{
+		Name: "WRITE",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			return systemUnderTest.(*counter).Inc()
+		},
+		PreConditionFunc: func(state commands.State) bool {
+			return state.(fileState).open
+		},
+		NextStateFunc: func(state commands.State) commands.State {
+			return fileState{open: true, writes: state.(fileState).writes + 1}
+		},
+		PostConditionFunc: func(state commands.State, result commands.Result) *gopter.PropResult {
+			return gopter.NewPropResult(result.(int) < 3, "")
+		},
+	}
+	fileCommands := &commands.ProtoCommands{
+		NewSystemUnderTestFunc: func(initialState commands.State) commands.SystemUnderTest {
+			return &counter{}
+		},
+		InitialStateGen: gen.Const(fileState{}),
+		GenCommandFunc: func(state commands.State) gopter.Gen {
+			return gen.OneConstOf(openCommand, closeCommand, writeCommand)
+		},
+	}
+
+	// removing a single CLOSE (or OPEN) breaks the pre condition of the next
+	// OPEN (or WRITE), which is dropped by the shrinker
+	for seed := int64(0); seed < 10; seed++ {
+		parameters := gopter.DefaultTestParametersWithSeed(seed)
+		parameters.MaxSize = 60
+		result := commands.Prop(fileCommands).Check(parameters)
+		if result.Status != gopter.TestFailed {
+			t.Fatalf("Invalid result: %#v", result)
+		}
+		if shrunk := fmt.Sprintf("%v", result.Args[0].Arg); !strings.HasSuffix(shrunk, "sequential=[OPEN WRITE WRITE WRITE]") {
+			t.Errorf("Invalid shrunk commands (seed %d): %s", seed, shrunk)
+		}
+	}
+}
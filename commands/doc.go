@@ -7,5 +7,14 @@ ProtoCommands as prototype.
 
 The commands themselves have to implement the Command interface, whereas
 testers might choose to use ProtoCommand as prototype.
+
+Prop checks sequences of commands, whereas ParallelProp checks concurrent
+systems for linearizability: A sequential prefix of commands is followed by
+branches of commands that are run concurrently, which passes if some
+interleaving of the branches explains the observed results.
+
+The commands of a failed property can be written to a replay file (see
+WriteReplay, it is also part of the artifact bundle of the property), which is
+checked by Replay to reproduce the failure without generating commands.
 */
 package commands
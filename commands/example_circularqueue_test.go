@@ -236,6 +236,7 @@ func Example_circularqueue() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! circular buffer: Falsified after 96 passed tests.
+	// > Seed: 1234
 	// ARG_0: initialState=State(size=7, elements=[]) sequential=[Put(0) Put(0)
 	//    Get Put(0) Get Put(0) Put(0) Get Put(0) Get Put(0) Get Put(-1) Put(0)
 	//    Put(0) Put(0) Put(0) Get Get Put(2) Get]
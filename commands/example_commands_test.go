@@ -117,6 +117,7 @@ func Example_buggyCounter() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! buggy counter: Falsified after 43 passed tests.
+	// > Seed: 1234
 	// ARG_0: initialState=0 sequential=[INC INC INC INC DEC GET]
 	// ARG_0_ORIGINAL (8 shrinks): initialState=0 sequential=[RESET GET GET GET
 	//    RESET DEC DEC INC INC RESET RESET DEC INC RESET INC INC GET INC INC DEC
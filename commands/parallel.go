@@ -0,0 +1,289 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// MaxParallelCommands is the maximum number of parallel commands of all
+// branches (the number of interleavings that have to be checked grows
+// exponentially with the number of parallel commands)
+const MaxParallelCommands = 8
+
+// parallelRuns is the number of times parallel commands are run (with a
+// new system under test each time), since race conditions do not show up in
+// every run
+const parallelRuns = 3
+
+// ParallelProp creates a gopter.Prop from Commands that checks the system
+// under test for linearizability: The generated commands consist of a
+// sequential prefix followed by "branches" sequences of commands (at most
+// MaxParallelCommands in total) that are run concurrently in separate
+// goroutines.
+// The property holds if there is an interleaving of the parallel commands
+// that explains the results, i.e. the post conditions of all commands hold if
+// the commands are applied to the state in the order of the interleaving.
+// The pre conditions of the parallel commands have to hold for every possible
+// interleaving, commands that violate this are not generated (or shrunk to).
+// Since race conditions do not show up in every run, the commands are run
+// three times (with a new system under test each time).
+func ParallelProp(commands Commands, branches int) gopter.Prop {
+	return prop.ForAll(checkParallel(commands), genParallelActions(commands, branches))
+}
+
+// checkParallel creates the condition of ParallelProp, i.e. runs the
+// sequential and parallel commands a few times
+func checkParallel(commands Commands) func(*actions) (*gopter.PropResult, error) {
+	return func(actions *actions) (*gopter.PropResult, error) {
+		var propResult *gopter.PropResult
+		for run := 0; run < parallelRuns; run++ {
+			var err error
+			propResult, err = withSystemUnderTest(commands, actions, func(systemUnderTest SystemUnderTest) (*gopter.PropResult, error) {
+				sequentialResult, err := actions.run(systemUnderTest)
+				if err != nil || !sequentialResult.Success() {
+					return sequentialResult, err
+				}
+				return sequentialResult.And(actions.runParallel(systemUnderTest)), nil
+			})
+			if err != nil || !propResult.Success() {
+				return propResult, err
+			}
+		}
+		return propResult, nil
+	}
+}
+
+// parallelResult is the result of a command run in a branch
+type parallelResult struct {
+	result    Result
+	execution *Execution
+}
+
+// interleavingStep is a step of an interleaving of the parallel commands,
+// i.e. the index of a command in its branch
+type interleavingStep struct {
+	branch, index int
+}
+
+// runParallel runs the branches of the parallel commands concurrently and
+// checks if their results are linearizable
+func (a *actions) runParallel(systemUnderTest SystemUnderTest) *gopter.PropResult {
+	results := make([][]parallelResult, len(a.parallelCommands))
+	panics := make([]interface{}, len(a.parallelCommands))
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for b, branch := range a.parallelCommands {
+		results[b] = make([]parallelResult, len(branch))
+		wg.Add(1)
+		go func(b int, branch []shrinkableCommand) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panics[b] = r
+				}
+			}()
+			<-start
+			for i, shrinkableCommand := range branch {
+				results[b][i].result, results[b][i].execution = execute(shrinkableCommand.command, systemUnderTest)
+			}
+		}(b, branch)
+	}
+	close(start)
+	wg.Wait()
+
+	for b, r := range panics {
+		if r != nil {
+			return &gopter.PropResult{
+				Status: gopter.PropError,
+				Error:  fmt.Errorf("Command of branch %d panicked: %v", b, r),
+			}
+		}
+	}
+	if a.linearizable(results, nil, make([]int, len(a.parallelCommands))) {
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	}
+	propResult := gopter.NewPropResult(false, "no interleaving of the parallel commands explains their results")
+	for b, branch := range a.parallelCommands {
+		observed := make([]string, len(branch))
+		for i, shrinkableCommand := range branch {
+			observed[i] = fmt.Sprintf("%v -> %v", shrinkableCommand.command, results[b][i].result)
+		}
+		propResult.Labels = append(propResult.Labels, fmt.Sprintf("branch %d: %s", b, strings.Join(observed, ", ")))
+	}
+	return propResult
+}
+
+// stateAfter recreates the state after the sequential commands and the
+// parallel commands of an interleaving (states might be mutable, so they
+// can not be shared between interleavings)
+func (a *actions) stateAfter(interleaving []interleavingStep) State {
+	state := a.initialStateProvider()
+	for _, shrinkableCommand := range a.sequentialCommands {
+		state = shrinkableCommand.command.NextState(state)
+	}
+	for _, step := range interleaving {
+		state = a.parallelCommands[step.branch][step.index].command.NextState(state)
+	}
+	return state
+}
+
+// linearizable searches for an interleaving of the remaining parallel
+// commands (positions are the indices of the next command of each branch)
+// that explains their results
+func (a *actions) linearizable(results [][]parallelResult, interleaving []interleavingStep, positions []int) bool {
+	complete := true
+	for b, branch := range a.parallelCommands {
+		i := positions[b]
+		if i >= len(branch) {
+			continue
+		}
+		complete = false
+		command := branch[i].command
+		state := a.stateAfter(interleaving)
+		if !command.PreCondition(state) {
+			continue
+		}
+		nextState := command.NextState(state)
+		if !postCondition(command, nextState, results[b][i].result, results[b][i].execution).Success() {
+			continue
+		}
+		positions[b]++
+		found := a.linearizable(results, append(interleaving, interleavingStep{branch: b, index: i}), positions)
+		positions[b]--
+		if found {
+			return true
+		}
+	}
+	return complete
+}
+
+// parallelPreConditionsHold checks if the pre conditions of the remaining
+// parallel commands hold for every interleaving
+func (a *actions) parallelPreConditionsHold(interleaving []interleavingStep, positions []int) bool {
+	for b, branch := range a.parallelCommands {
+		i := positions[b]
+		if i >= len(branch) {
+			continue
+		}
+		if !branch[i].command.PreCondition(a.stateAfter(interleaving)) {
+			return false
+		}
+		positions[b]++
+		hold := a.parallelPreConditionsHold(append(interleaving, interleavingStep{branch: b, index: i}), positions)
+		positions[b]--
+		if !hold {
+			return false
+		}
+	}
+	return true
+}
+
+// parallelShrink removes commands from each branch and moves the first
+// command of each branch to the sequential commands
+func parallelShrink(a *actions, elementShrinker gopter.Shrinker) gopter.Shrink {
+	var shrinks []gopter.Shrink
+	for b, branch := range a.parallelCommands {
+		b := b
+		shrinks = append(shrinks, gen.SliceShrinker(elementShrinker)(branch).Map(func(v []shrinkableCommand) *actions {
+			parallelCommands := append([][]shrinkableCommand{}, a.parallelCommands...)
+			parallelCommands[b] = v
+			return &actions{
+				initialStateProvider: a.initialStateProvider,
+				sequentialCommands:   a.sequentialCommands,
+				parallelCommands:     parallelCommands,
+			}
+		}))
+	}
+	var moved []interface{}
+	for b, branch := range a.parallelCommands {
+		if len(branch) == 0 {
+			continue
+		}
+		parallelCommands := append([][]shrinkableCommand{}, a.parallelCommands...)
+		parallelCommands[b] = branch[1:]
+		moved = append(moved, &actions{
+			initialStateProvider: a.initialStateProvider,
+			sequentialCommands:   append(append([]shrinkableCommand{}, a.sequentialCommands...), branch[0]),
+			parallelCommands:     parallelCommands,
+		})
+	}
+	shrinks = append(shrinks, func() (interface{}, bool) {
+		if len(moved) == 0 {
+			return nil, false
+		}
+		next := moved[0]
+		moved = moved[1:]
+		return next, true
+	})
+	return gopter.ConcatShrinks(shrinks...)
+}
+
+func genParallelActions(commands Commands, branches int) gopter.Gen {
+	if branches <= 0 {
+		return gen.Fail(reflect.TypeOf((*actions)(nil)))
+	}
+	return genInitialStateProvider(commands).FlatMap(func(v interface{}) gopter.Gen {
+		initialStateProvider := v.(func() State)
+		return genParallelCommands(commands, initialStateProvider, branches).SuchThat(func(actions *actions) bool {
+			return actions.preConditionsHold()
+		}).WithShrinker(actionsShrinker)
+	}, reflect.TypeOf((*actions)(nil)))
+}
+
+// genParallelCommands generates a sequential prefix of half the size and
+// branches of parallel commands, whose pre conditions hold for every
+// interleaving
+func genParallelCommands(commands Commands, initialStateProvider func() State, branches int) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		prefixParams := *genParams
+		prefixParams.MaxSize = genParams.MaxSize / 2
+		prefix, ok := genSizedCommands(commands, initialStateProvider)(&prefixParams).Retrieve()
+		if !ok {
+			return gopter.NewEmptyResult(reflect.TypeOf((*actions)(nil)))
+		}
+		result := &actions{
+			initialStateProvider: initialStateProvider,
+			sequentialCommands:   prefix.(sizedCommands).commands,
+			parallelCommands:     make([][]shrinkableCommand, branches),
+		}
+
+		maxSize := MaxParallelCommands
+		if genParams.MaxSize < maxSize {
+			maxSize = genParams.MaxSize
+		}
+		// the state in the order the commands are generated
+		state := prefix.(sizedCommands).state
+		size := 0
+		for attempts := 0; size < maxSize && attempts < 10*maxSize; attempts++ {
+			b := genParams.Rng.Intn(branches)
+			prev := state
+			commandResult := gen.RetryUntil(commands.GenCommand(prev), func(command Command) bool {
+				return command.PreCondition(prev)
+			}, 100)(genParams)
+			value, ok := commandResult.Retrieve()
+			if !ok {
+				break
+			}
+			command := value.(Command)
+			branch := result.parallelCommands[b]
+			result.parallelCommands[b] = append(branch, shrinkableCommand{
+				command:     command,
+				shrinker:    commandResult.Shrinker,
+				observation: isObservation(command),
+			})
+			if !result.parallelPreConditionsHold(nil, make([]int, branches)) {
+				result.parallelCommands[b] = branch
+				continue
+			}
+			state = command.NextState(prev)
+			size++
+		}
+		return gopter.NewGenResult(result, gopter.NoShrinker)
+	}
+}
@@ -0,0 +1,99 @@
+package commands_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/commands"
+	"github.com/leanovate/gopter/gen"
+)
+
+type sharedCounter struct {
+	value int64
+	// racy counters might lose updates
+	racy bool
+}
+
+func (c *sharedCounter) Get() int {
+	return int(atomic.LoadInt64(&c.value))
+}
+
+func (c *sharedCounter) Inc() int {
+	if !c.racy {
+		return int(atomic.AddInt64(&c.value, 1))
+	}
+	value := atomic.LoadInt64(&c.value) + 1
+	runtime.Gosched()
+	atomic.StoreInt64(&c.value, value)
+	return int(value)
+}
+
+func (c *sharedCounter) Dec() int {
+	return int(atomic.AddInt64(&c.value, -1))
+}
+
+func sharedCounterCommands(racy bool) commands.Commands {
+	return &commands.ProtoCommands{
+		NewSystemUnderTestFunc: func(initialState commands.State) commands.SystemUnderTest {
+			return &sharedCounter{value: int64(initialState.(int)), racy: racy}
+		},
+		InitialStateGen: gen.IntRange(0, 2),
+		GenCommandFunc: func(state commands.State) gopter.Gen {
+			return gen.OneConstOf(
+				&commands.ProtoCommand{
+					Name: "GET",
+					RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+						return systemUnderTest.(*sharedCounter).Get()
+					},
+					PostConditionFunc: GetCommand.PostConditionFunc,
+				},
+				&commands.ProtoCommand{
+					Name: "INC",
+					RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+						return systemUnderTest.(*sharedCounter).Inc()
+					},
+					NextStateFunc:     IncCommand.NextStateFunc,
+					PostConditionFunc: IncCommand.PostConditionFunc,
+				},
+				&commands.ProtoCommand{
+					Name: "DEC",
+					RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+						return systemUnderTest.(*sharedCounter).Dec()
+					},
+					PreConditionFunc:  DecCommand.PreConditionFunc,
+					NextStateFunc:     DecCommand.NextStateFunc,
+					PostConditionFunc: DecCommand.PostConditionFunc,
+				},
+			)
+		},
+	}
+}
+
+func TestParallelCommands(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 50
+	parameters.MaxSize = 10
+	result := commands.ParallelProp(sharedCounterCommands(false), 3).Check(parameters)
+	if !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	result = commands.ParallelProp(sharedCounterCommands(true), 3).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	// a lost update requires two parallel commands, the shrinker might keep a
+	// few more if the race did not show up while shrinking
+	shrunk := fmt.Sprintf("%v", result.Args[0].Arg)
+	commandCount := strings.Count(shrunk, "INC") + strings.Count(shrunk, "DEC") + strings.Count(shrunk, "GET")
+	if !strings.Contains(shrunk, "parallel=") || commandCount < 2 || commandCount > 4 {
+		t.Errorf("Invalid shrunk commands: %s", shrunk)
+	}
+	if len(result.Labels) == 0 || !strings.HasPrefix(result.Labels[0], "no interleaving") {
+		t.Errorf("Invalid labels: %#v", result.Labels)
+	}
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+// ReplayFile is the name of the replay file of the commands in the artifact
+// bundle of a failed property (see gopter.TestParameters.ArtifactDir)
+const ReplayFile = "commands.replay"
+
+// replayBranch separates the branches of parallel commands in a replay file
+const replayBranch = "--- parallel"
+
+// WriteReplay writes the (shrunk) commands of a failed property created by
+// Prop or ParallelProp to a replay file that can be checked by Replay.
+// The replay file is a text file with one command per line (as formatted by
+// fmt), each branch of parallel commands starts with a "--- parallel" line.
+// Lines starting with "#" are comments, e.g. the initial state.
+func WriteReplay(path string, result *gopter.PropertyResult) error {
+	for _, arg := range result.Args {
+		if actions, ok := arg.Arg.(*actions); ok {
+			return actions.writeReplay(path, fmt.Sprintf("# %s (seed %d)", result.Name, result.Seed))
+		}
+	}
+	return fmt.Errorf("No commands in the arguments of %s", result.Name)
+}
+
+// WriteArtifact adds the replay file of the commands to the artifact bundle of
+// a failed property (see gopter.ArtifactArg)
+func (a *actions) WriteArtifact(path string) error {
+	return a.writeReplay(filepath.Join(path, ReplayFile), "")
+}
+
+func (a *actions) writeReplay(path, header string) error {
+	var replay bytes.Buffer
+	if header != "" {
+		fmt.Fprintln(&replay, header)
+	}
+	fmt.Fprintf(&replay, "# initialState=%s\n", strings.Replace(fmt.Sprintf("%v", a.initialStateProvider()), "\n", " ", -1))
+	writeCommands := func(commands []shrinkableCommand) error {
+		for _, shrinkableCommand := range commands {
+			line := fmt.Sprintf("%v", shrinkableCommand.command)
+			if strings.ContainsAny(line, "\r\n") || line == replayBranch || strings.HasPrefix(line, "#") {
+				return fmt.Errorf("Command can not be replayed: %q", line)
+			}
+			fmt.Fprintln(&replay, line)
+		}
+		return nil
+	}
+	if err := writeCommands(a.sequentialCommands); err != nil {
+		return err
+	}
+	for _, branch := range a.parallelCommands {
+		fmt.Fprintln(&replay, replayBranch)
+		if err := writeCommands(branch); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, replay.Bytes(), 0644)
+}
+
+// Replay creates a gopter.Prop that checks the commands of a replay file (see
+// WriteReplay) like Prop (or ParallelProp if there are parallel commands).
+// "parse" converts a line of the replay file to the command it has been
+// formatted from.
+// The initial state is generated as usual (the initial state of the replay file
+// is a comment), the commands are only checked for initial states their pre
+// conditions hold for. Failing commands are shrunk like generated ones.
+func Replay(commands Commands, path string, parse func(command string) (Command, error)) gopter.Prop {
+	sequential, parallel, err := readReplay(path, parse)
+	if err != nil {
+		return prop.ErrorProp(err)
+	}
+	genReplay := genInitialStateProvider(commands).Map(func(initialStateProvider func() State) *actions {
+		return &actions{
+			initialStateProvider: initialStateProvider,
+			sequentialCommands:   sequential,
+			parallelCommands:     parallel,
+		}
+	}).SuchThat(func(actions *actions) bool {
+		return actions.preConditionsHold()
+	}).WithShrinker(actionsShrinker)
+	if len(parallel) > 0 {
+		return prop.ForAll(checkParallel(commands), genReplay)
+	}
+	return prop.ForAll(checkSequential(commands), genReplay)
+}
+
+// readReplay reads the sequential and parallel commands of a replay file
+func readReplay(path string, parse func(command string) (Command, error)) ([]shrinkableCommand, [][]shrinkableCommand, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sequential []shrinkableCommand
+	var parallel [][]shrinkableCommand
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case line == replayBranch:
+			parallel = append(parallel, []shrinkableCommand{})
+			continue
+		}
+		command, err := parse(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid command in %s:%d: %v", path, lineNumber, err)
+		}
+		if command == nil {
+			return nil, nil, fmt.Errorf("Invalid command in %s:%d: %q", path, lineNumber, line)
+		}
+		shrinkableCommand := shrinkableCommand{
+			command:     command,
+			shrinker:    gopter.NoShrinker,
+			observation: isObservation(command),
+		}
+		if len(parallel) > 0 {
+			parallel[len(parallel)-1] = append(parallel[len(parallel)-1], shrinkableCommand)
+		} else {
+			sequential = append(sequential, shrinkableCommand)
+		}
+	}
+	return sequential, parallel, scanner.Err()
+}
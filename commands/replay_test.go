@@ -0,0 +1,160 @@
+package commands_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/commands"
+	"github.com/leanovate/gopter/gen"
+)
+
+func stuckCounterCommands() (commands.Commands, map[string]commands.Command) {
+	getCommand := &commands.ProtoCommand{
+		Name: "GET",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			return systemUnderTest.(*stuckCounter).Get()
+		},
+		PostConditionFunc: GetCommand.PostConditionFunc,
+	}
+	incCommand := &commands.ProtoCommand{
+		Name: "INC",
+		RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+			return systemUnderTest.(*stuckCounter).Inc()
+		},
+		NextStateFunc: IncCommand.NextStateFunc,
+	}
+	return &commands.ProtoCommands{
+		NewSystemUnderTestFunc: func(initialState commands.State) commands.SystemUnderTest {
+			return &stuckCounter{}
+		},
+		InitialStateGen: gen.Const(0),
+		GenCommandFunc: func(state commands.State) gopter.Gen {
+			return gen.OneConstOf(incCommand, getCommand)
+		},
+	}, map[string]commands.Command{"GET": getCommand, "INC": incCommand}
+}
+
+func TestReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter_replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	stuckCommands, byName := stuckCounterCommands()
+	parse := func(command string) (commands.Command, error) {
+		if command, ok := byName[command]; ok {
+			return command, nil
+		}
+		return nil, fmt.Errorf("Unknown command: %s", command)
+	}
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	parameters.ArtifactDir = dir
+	properties := gopter.NewProperties(parameters)
+	properties.Property("stuck counter", commands.Prop(stuckCommands))
+	results := properties.RunResults(nil)
+	if len(results) != 1 || results[0].Status != gopter.TestFailed {
+		t.Fatalf("Invalid results: %#v", results)
+	}
+
+	path := filepath.Join(dir, "replay.txt")
+	if err := commands.WriteReplay(path, results[0]); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil || !strings.HasSuffix(string(data), "# initialState=0\nINC\nINC\nINC\nINC\nGET\n") {
+		t.Errorf("Invalid replay: %q %v", data, err)
+	}
+	artifact, err := ioutil.ReadFile(filepath.Join(gopter.ArtifactPath(dir, "stuck counter"), commands.ReplayFile))
+	if err != nil || !strings.HasSuffix(string(data), string(artifact)) {
+		t.Errorf("Invalid replay in artifact bundle: %q %v", artifact, err)
+	}
+
+	result := commands.Replay(stuckCommands, path, parse).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if shrunk := fmt.Sprintf("%v", result.Args[0].Arg); !strings.HasSuffix(shrunk, "sequential=[INC INC INC INC GET]") {
+		t.Errorf("Invalid replayed commands: %s", shrunk)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("# comment\nINC\nGET\n\nINC\nGET\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if result := commands.Replay(stuckCommands, path, parse).Check(gopter.DefaultTestParameters()); !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("INC\nRESET\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result = commands.Replay(stuckCommands, path, parse).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestError || !strings.Contains(result.Error.Error(), "replay.txt:2: Unknown command: RESET") {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	result = commands.Replay(stuckCommands, filepath.Join(dir, "missing.txt"), parse).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestError {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
+
+func TestReplayParallel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter_replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 50
+	parameters.MaxSize = 10
+	result := commands.ParallelProp(sharedCounterCommands(true), 3).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	path := filepath.Join(dir, "parallel.txt")
+	if err := commands.WriteReplay(path, &gopter.PropertyResult{Name: "parallel", TestResult: result}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil || strings.Count(string(data), "--- parallel\n") != 3 {
+		t.Errorf("Invalid replay: %q %v", data, err)
+	}
+
+	// without the race the replayed commands pass
+	parse := func(command string) (commands.Command, error) {
+		switch command {
+		case "GET":
+			return &commands.ProtoCommand{
+				Name: "GET",
+				RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+					return systemUnderTest.(*sharedCounter).Get()
+				},
+				PostConditionFunc: GetCommand.PostConditionFunc,
+			}, nil
+		case "INC":
+			return &commands.ProtoCommand{
+				Name: "INC",
+				RunFunc: func(systemUnderTest commands.SystemUnderTest) commands.Result {
+					return systemUnderTest.(*sharedCounter).Inc()
+				},
+				NextStateFunc:     IncCommand.NextStateFunc,
+				PostConditionFunc: IncCommand.PostConditionFunc,
+			}, nil
+		}
+		return nil, fmt.Errorf("Unknown command: %s", command)
+	}
+	if err := ioutil.WriteFile(path, []byte("INC\n--- parallel\nINC\nGET\n--- parallel\nINC\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if result := commands.Replay(sharedCounterCommands(false), path, parse).Check(gopter.DefaultTestParameters()); !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
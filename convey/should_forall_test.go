@@ -74,7 +74,7 @@ func TestShouldSucceedForAll(t *testing.T) {
 				return i > 500
 			}, gen.Int(), parameters)
 
-			So(result, ShouldStartWith, "! : Falsified after 1 passed tests.\nARG_0: 0\nARG_0_ORIGINAL (1 shrinks): -642623569")
+			So(result, ShouldStartWith, "! : Falsified after 1 passed tests.\n> Seed: 1234\nARG_0: 0\nARG_0_ORIGINAL (1 shrinks): -642623569")
 		})
 	})
 }
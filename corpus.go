@@ -0,0 +1,241 @@
+package gopter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DefaultCorpusDir is the default directory of the corpus files of
+// properties (see TestParameters.CorpusDir)
+const DefaultCorpusDir = "testdata/gopter_corpus"
+
+// DefaultFailureDir is the default directory of the failure corpus files of
+// properties (see TestParameters.FailureDir)
+const DefaultFailureDir = "testdata/gopter_regressions"
+
+// Corpus is a file of "frozen" examples of a property, that are checked
+// before any generated values (see TestParameters.Corpus).
+// The file is meant to be committed and edited by hand: Each line contains
+// the arguments of an example as JSON array (e.g. `[42, "abc"]`), empty lines
+// and lines starting with "#" are ignored.
+// Examples are only replayed by properties created with prop.ForAll (and its
+// variants), the arguments are decoded into the parameter types of the
+// condition.
+type Corpus struct {
+	lk       sync.Mutex
+	path     string
+	examples []corpusExample
+}
+
+type corpusExample struct {
+	// path of the file of the example (examples of combined corpora might
+	// be from different files)
+	path string
+	line int
+	data string
+}
+
+// CorpusPath is the path of the corpus file of a property in a directory
+func CorpusPath(dir, propName string) string {
+	return filepath.Join(dir, propFileName(propName)+".corpus")
+}
+
+// propFileName is the name of a property with all characters that might not
+// be used in file names replaced
+func propFileName(propName string) string {
+	return strings.Map(func(ch rune) rune {
+		if ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '.' {
+			return ch
+		}
+		return '_'
+	}, propName)
+}
+
+// LoadCorpus loads the corpus file at path, a missing file is an empty
+// corpus
+func LoadCorpus(path string) (*Corpus, error) {
+	corpus := &Corpus{path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return corpus, nil
+	} else if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var args []json.RawMessage
+		if err := json.Unmarshal([]byte(text), &args); err != nil {
+			return nil, fmt.Errorf("%s:%d: Invalid example: %v", path, line, err)
+		}
+		corpus.examples = append(corpus.examples, corpusExample{path: path, line: line, data: text})
+	}
+	return corpus, scanner.Err()
+}
+
+// Path is the path of the corpus file
+func (c *Corpus) Path() string {
+	return c.path
+}
+
+// Examples gets the serialized examples in the order of the file
+func (c *Corpus) Examples() []string {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	examples := make([]string, len(c.examples))
+	for i, example := range c.examples {
+		examples[i] = example.data
+	}
+	return examples
+}
+
+// Append adds an example with the given arguments to the corpus and its
+// file (unless the corpus already contains it), e.g. to keep an interesting
+// generated case.
+func (c *Corpus) Append(args ...interface{}) error {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	for _, example := range c.examples {
+		if example.data == string(data) {
+			return nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	content, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+	line := bytes.Count(content, []byte("\n")) + 1
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		data = append([]byte("\n"), data...)
+		line++
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	c.examples = append(c.examples, corpusExample{path: c.path, line: line, data: strings.TrimSpace(string(data))})
+	return nil
+}
+
+// AppendArgs adds the arguments of a property check (e.g. the shrunk
+// arguments of a falsified property) as example
+func (c *Corpus) AppendArgs(args PropArgs) error {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Arg
+	}
+	return c.Append(values...)
+}
+
+// combine creates a corpus with the examples of both corpora (new examples
+// are appended to the file of the first one)
+func (c *Corpus) combine(other *Corpus) *Corpus {
+	c.lk.Lock()
+	examples := append([]corpusExample{}, c.examples...)
+	c.lk.Unlock()
+	other.lk.Lock()
+	examples = append(examples, other.examples...)
+	other.lk.Unlock()
+	return &Corpus{path: c.path, examples: examples}
+}
+
+// recordFailure appends the (shrunk) arguments of a failed property to its
+// failure corpus (see TestParameters.FailureDir) and labels the result
+// accordingly. Arguments that can not be restored from JSON are not recorded.
+func (r *PropertyResult) recordFailure(failures *Corpus) {
+	if failures == nil || r.Status != TestFailed && r.Status != TestError || len(r.Args) == 0 {
+		return
+	}
+	for i, arg := range r.Args {
+		if !jsonRoundTrips(arg.Arg) {
+			r.Labels = append(r.Labels, fmt.Sprintf("failure not recorded: ARG_%d can not be restored from JSON", i))
+			return
+		}
+	}
+	if err := failures.AppendArgs(r.Args); err != nil {
+		r.Labels = append(r.Labels, fmt.Sprintf("failure not recorded: %v", err))
+		return
+	}
+	r.Labels = append(r.Labels, fmt.Sprintf("failure recorded: %s", failures.path))
+}
+
+// jsonRoundTrips checks if a value is restored from its JSON serialization
+func jsonRoundTrips(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	restored := reflect.New(reflect.TypeOf(value))
+	if err := json.Unmarshal(data, restored.Interface()); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(restored.Elem().Interface(), value)
+}
+
+// replayCorpus checks the examples of a corpus, the result of the first
+// example that falsifies the property is returned (nil if all examples
+// passed)
+func (prop Prop) replayCorpus(corpus *Corpus, genParams GenParameters) *TestResult {
+	corpus.lk.Lock()
+	examples := append([]corpusExample{}, corpus.examples...)
+	corpus.lk.Unlock()
+	for i, example := range examples {
+		if !genParams.InShard(i) {
+			continue
+		}
+		replayParams := genParams
+		replayParams.CorpusExample = example.data
+		propResult := prop(&replayParams)
+		if propResult.Options != nil && !genParams.OptionsApplied {
+			return &TestResult{options: propResult.Options}
+		}
+		if propResult.Checked != nil {
+			return propResult.Checked
+		}
+		label := fmt.Sprintf("corpus %s:%d", example.path, example.line)
+		switch propResult.Status {
+		case PropFalse:
+			return &TestResult{
+				Status: TestFailed,
+				Labels: append(propResult.Labels, label),
+				Error:  propResult.Error,
+				Args:   propResult.Args,
+			}
+		case PropError:
+			return &TestResult{
+				Status:     TestError,
+				Labels:     append(propResult.Labels, label),
+				Error:      propResult.Error,
+				ErrorStack: propResult.ErrorStack,
+				Args:       propResult.Args,
+			}
+		}
+	}
+	return nil
+}
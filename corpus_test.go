@@ -0,0 +1,193 @@
+package gopter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestCorpus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := gopter.CorpusPath(dir, "small/ints")
+	if path != filepath.Join(dir, "small_ints.corpus") {
+		t.Errorf("Invalid path: %s", path)
+	}
+	if err := ioutil.WriteFile(path, []byte("# regressions\n[3, \"abc\"]\n\n  [100, \"x\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	corpus, err := gopter.LoadCorpus(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if examples := corpus.Examples(); len(examples) != 2 || examples[1] != `[100, "x"]` {
+		t.Errorf("Invalid examples: %#v", examples)
+	}
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	parameters.Corpus = corpus
+	result := prop.ForAll(func(n int, s string) bool {
+		return n < 50
+	}, gen.IntRange(0, 10), gen.AlphaString()).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != 100 || result.Args[1].Arg != "x" {
+		t.Errorf("Corpus example not checked: %#v", result)
+	}
+	if len(result.Labels) != 1 || result.Labels[0] != "corpus "+path+":4" {
+		t.Errorf("Invalid labels: %#v", result.Labels)
+	}
+
+	calls := 0
+	result = prop.ForAllNoShrink(func(n int, s string) bool {
+		calls++
+		return n < 500
+	}, gen.IntRange(0, 10), gen.AlphaString()).Check(parameters)
+	if result.Status != gopter.TestPassed || result.Succeeded != 100 || result.CorpusExamples != 2 || calls != 102 {
+		t.Errorf("Invalid result: %#v (calls: %d)", result, calls)
+	}
+
+	result = prop.ForAll(func(n int) bool {
+		return true
+	}, gen.Int()).Check(parameters)
+	if result.Status != gopter.TestError || !strings.Contains(result.Error.Error(), "has 2 arguments, expected 1") {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
+
+func TestCorpusAppend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	parameters.CorpusDir = filepath.Join(dir, "corpus")
+	properties := gopter.NewProperties(parameters)
+	properties.Property("small ints", prop.ForAll(func(n int) bool {
+		return n < 1000
+	}, gen.IntRange(0, 2000)))
+
+	results := properties.RunResults(nil)
+	if results[0].Status != gopter.TestFailed || results[0].Args[0].Arg != 1000 {
+		t.Fatalf("Invalid result: %#v", results[0].TestResult)
+	}
+	corpus, err := gopter.LoadCorpus(gopter.CorpusPath(parameters.CorpusDir, "small ints"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := corpus.AppendArgs(results[0].Args); err != nil {
+		t.Fatal(err)
+	}
+	if err := corpus.Append(1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := corpus.Append(1500); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(corpus.Path())
+	if err != nil || string(data) != "[1000]\n[1500]\n" {
+		t.Errorf("Invalid corpus file: %q (%v)", string(data), err)
+	}
+
+	// the corpus is replayed first even if the generators would not find the
+	// failure
+	properties = gopter.NewProperties(parameters)
+	properties.Property("small ints", prop.ForAll(func(n int) bool {
+		return n < 1000
+	}, gen.IntRange(0, 10)))
+	results = properties.RunResults(nil)
+	if results[0].Status != gopter.TestFailed || results[0].Args[0].Arg != 1000 || results[0].Succeeded != 0 {
+		t.Errorf("Invalid result: %#v", results[0].TestResult)
+	}
+
+	if err := ioutil.WriteFile(corpus.Path(), []byte("[1000]\n1500\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gopter.LoadCorpus(corpus.Path()); err == nil || !strings.Contains(err.Error(), "small_ints.corpus:2: Invalid example") {
+		t.Errorf("Invalid error: %v", err)
+	}
+	results = properties.RunResults(nil)
+	if results[0].Status != gopter.TestError {
+		t.Errorf("Invalid result: %#v", results[0].TestResult)
+	}
+}
+
+func TestFailureDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.FailureDir = dir
+	properties := gopter.NewProperties(parameters)
+	properties.Property("small numbers", prop.ForAll(func(v int) bool {
+		return v < 100
+	}, gen.IntRange(0, 1000)))
+	properties.Property("no functions", prop.ForAll(func(f func() int) bool {
+		return false
+	}, gen.Const(func() int { return 0 })))
+	results := properties.RunResults(nil)
+
+	path := gopter.CorpusPath(dir, "small numbers")
+	if labels := results[0].Labels; len(labels) == 0 || labels[len(labels)-1] != "failure recorded: "+path {
+		t.Errorf("Invalid labels: %#v", labels)
+	}
+	if data, err := ioutil.ReadFile(path); err != nil || string(data) != "[100]\n" {
+		t.Errorf("Invalid failure corpus: %q %v", data, err)
+	}
+	if labels := results[1].Labels; len(labels) == 0 || labels[len(labels)-1] != "failure not recorded: ARG_0 can not be restored from JSON" {
+		t.Errorf("Invalid labels: %#v", labels)
+	}
+
+	// the recorded failure is replayed even if it is not generated anymore
+	properties = gopter.NewProperties(parameters)
+	properties.Property("small numbers", prop.ForAll(func(v int) bool {
+		return v < 100
+	}, gen.IntRange(0, 50)))
+	results = properties.RunResults(nil)
+	if results[0].Status != gopter.TestFailed || results[0].Args[0].Arg != 100 ||
+		!strings.Contains(strings.Join(results[0].Labels, "\n"), "corpus "+path+":1") {
+		t.Errorf("Invalid result: %#v", results[0])
+	}
+
+	// a fixed failure is checked as regression, the failure corpus is kept
+	properties = gopter.NewProperties(parameters)
+	properties.Property("small numbers", prop.ForAll(func(v int) bool {
+		return v <= 100
+	}, gen.IntRange(0, 50)))
+	results = properties.RunResults(nil)
+	if !results[0].Passed() || results[0].CorpusExamples != 1 {
+		t.Errorf("Invalid result: %#v", results[0])
+	}
+	if data, err := ioutil.ReadFile(path); err != nil || string(data) != "[100]\n" {
+		t.Errorf("Invalid failure corpus: %q %v", data, err)
+	}
+
+	// examples of the corpus dir are checked as well
+	corpusDir := filepath.Join(dir, "corpus")
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(gopter.CorpusPath(corpusDir, "small numbers"), []byte("[7]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	parameters.CorpusDir = corpusDir
+	properties = gopter.NewProperties(parameters)
+	properties.Property("small numbers", prop.ForAll(func(v int) bool {
+		return v <= 100
+	}, gen.IntRange(0, 50)))
+	results = properties.RunResults(nil)
+	if !results[0].Passed() || results[0].CorpusExamples != 2 {
+		t.Errorf("Invalid result: %#v", results[0])
+	}
+}
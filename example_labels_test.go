@@ -20,6 +20,7 @@ func spookyCalculation(a, b int) int {
 // conditions.
 // The output will be:
 //  ! Check spooky: Falsified after 0 passed tests.
+//  > Seed: 1234
 //  > Labels of failing property: even result
 //  a: 3
 //  a_ORIGINAL (44 shrinks): 861384713
@@ -51,6 +52,7 @@ func Example_labels() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! Check spooky: Falsified after 0 passed tests.
+	// > Seed: 1234
 	// > Labels of failing property: even result
 	// a: 3
 	// a_ORIGINAL (44 shrinks): 861384713
@@ -95,5 +95,6 @@ func Example_libraries2() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! libraries always empty: Falsified after 2 passed tests.
+	// > Seed: 1234
 	// ARG_0: &{Libraries:map[z:[]]}
 }
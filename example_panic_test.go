@@ -24,6 +24,7 @@ func Example_panic() {
 	// Output:
 	// ! Will panic: Error on property evaluation after 6 passed tests: Check
 	//    paniced: hi
+	// > Seed: 1234
 	// number: 0
 	// number_ORIGINAL (1 shrinks): 2015020988
 }
@@ -0,0 +1,54 @@
+package gopter
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// passSieve is the sieve of the values of FlatMap, which have passed the sieve
+// of the created generator (the shrinks are filtered by the sieves of the
+// created generators, see flatMapTree)
+func passSieve(interface{}) bool {
+	return true
+}
+
+// flatMapTree creates the tree of a value of FlatMap from the tree of the
+// outer (generated) value and the tree of the inner value (created by the
+// generator of the outer value).
+// The children are the inner values re-generated from the shrinks of the
+// outer value, followed by the shrinks of the inner value.
+func flatMapTree(outer, inner *ShrinkTree, regenerate func(interface{}) (*ShrinkTree, bool)) *ShrinkTree {
+	return &ShrinkTree{
+		Value: inner.Value,
+		children: func() TreeShrink {
+			outerChildren := outer.Children()
+			var innerChildren TreeShrink
+			return func() (*ShrinkTree, bool) {
+				for innerChildren == nil {
+					child, ok := outerChildren()
+					if !ok {
+						innerChildren = inner.Children()
+						break
+					}
+					if regenerated, ok := regenerate(child.Value); ok {
+						return flatMapTree(child, regenerated, regenerate), true
+					}
+				}
+				child, ok := innerChildren()
+				if !ok {
+					return nil, false
+				}
+				return flatMapTree(outer, child, regenerate), true
+			}
+		},
+	}
+}
+
+// regenerationSeed is the seed the inner values of FlatMap are re-generated
+// with. It is derived from the generated outer value (so that the generation
+// itself is not affected), i.e. it is the same for all shrinks of a value.
+func regenerationSeed(outer interface{}) int64 {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%#v", outer)
+	return int64(hash.Sum64())
+}
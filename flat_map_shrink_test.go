@@ -0,0 +1,72 @@
+package gopter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestFlatMapShrink(t *testing.T) {
+	// the slice length depends on the generated size, SliceOfN has no
+	// shrinker for the length
+	sliceGen := gen.IntRange(1, 100).FlatMap(func(v interface{}) gopter.Gen {
+		return gen.SliceOfN(v.(int), gen.IntRange(5, 10))
+	}, reflect.TypeOf([]int{}))
+
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(func(values []int) bool {
+		return len(values) < 10
+	}, sliceGen).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	values := result.Args[0].Arg.([]int)
+	if len(values) != 10 {
+		t.Errorf("Slice has not been shrunk: %v", values)
+	}
+	for _, value := range values {
+		if value != 5 {
+			t.Errorf("Inner values have not been shrunk (within their range): %v", values)
+			break
+		}
+	}
+}
+
+func TestFlatMapShrinkRegeneratedSieve(t *testing.T) {
+	// the re-generated values are filtered by the sieves of their generators,
+	// not by the one of the generated value
+	ranges := gen.IntRange(1, 100).FlatMap(func(v interface{}) gopter.Gen {
+		return gen.IntRange(v.(int), 2*v.(int))
+	}, reflect.TypeOf(0))
+
+	for _, seed := range []int64{1, 2, 3} {
+		result := prop.ForAll(func(v int) bool {
+			return v < 10
+		}, ranges).Check(gopter.DefaultTestParametersWithSeed(seed))
+		if result.Status != gopter.TestFailed || result.Args[0].Arg != 10 {
+			t.Errorf("Invalid result: %#v", result.Args[0])
+		}
+	}
+}
+
+func TestFlatMapShrinkerIsStateless(t *testing.T) {
+	sliceGen := gen.IntRange(1, 100).FlatMap(func(v interface{}) gopter.Gen {
+		return gen.SliceOfN(v.(int), gen.IntRange(5, 10))
+	}, reflect.TypeOf([]int{}))
+	result := sliceGen(gopter.DefaultGenParameters())
+	value, _ := result.Retrieve()
+
+	first := result.Shrinker(value).All()
+	if len(first) == 0 {
+		t.Fatal("Generated value has no shrinks")
+	}
+	for _, shrunk := range first {
+		result.Shrinker(shrunk).All()
+	}
+	if second := result.Shrinker(value).All(); !reflect.DeepEqual(first, second) {
+		t.Errorf("Shrinks differ: %v != %v", first, second)
+	}
+}
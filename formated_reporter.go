@@ -1,20 +1,54 @@
 package gopter
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 const newLine = "\n"
 
+const (
+	defaultReporterWidth = 75
+	colorReset           = "\x1b[0m"
+	colorRed             = "\x1b[31m"
+	colorGreen           = "\x1b[32m"
+	colorYellow          = "\x1b[33m"
+)
+
 // FormatedReporter reports test results in a human readable manager.
 type FormatedReporter struct {
-	verbose bool
-	width   int
-	output  io.Writer
+	verbose      bool
+	width        int
+	output       io.Writer
+	colors       bool
+	maxArgLength int
+	argDumpDir   string
+}
+
+// FormatedReporterOptions are the options of a FormatedReporter
+type FormatedReporterOptions struct {
+	// Verbose toggles verbose output of the property results
+	Verbose bool
+	// Width is the maximal width per line, if 0 the width is taken from the
+	// COLUMNS environment variable or the terminal the output is written to
+	// (e.g. os.Stdout), defaults to 75 if the output is not a terminal
+	Width int
+	// Colors enables ANSI colors (green for passed, red for failed, yellow for
+	// exhausted properties)
+	Colors bool
+	// MaxArgLength truncates the reported arguments to the given number of
+	// bytes (0 means no truncation)
+	MaxArgLength int
+	// ArgDumpDir is a directory where the full values of truncated arguments
+	// are written to (if empty truncated arguments are not dumped)
+	ArgDumpDir string
 }
 
 // NewFormatedReporter create a new formated reporter
@@ -29,45 +63,128 @@ func NewFormatedReporter(verbose bool, width int, output io.Writer) Reporter {
 	}
 }
 
+// NewFormatedReporterWithOptions create a new formated reporter with options
+// output is the writer were the report will be written to
+func NewFormatedReporterWithOptions(output io.Writer, options FormatedReporterOptions) Reporter {
+	width := options.Width
+	if width <= 0 {
+		width = detectTerminalWidth(output)
+	}
+	return &FormatedReporter{
+		verbose:      options.Verbose,
+		width:        width,
+		output:       output,
+		colors:       options.Colors,
+		maxArgLength: options.MaxArgLength,
+		argDumpDir:   options.ArgDumpDir,
+	}
+}
+
+// detectTerminalWidth detects the width of the output, COLUMNS overrides the
+// width of a terminal
+func detectTerminalWidth(output io.Writer) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if file, ok := output.(*os.File); ok {
+		if columns, ok := terminalWidth(file); ok {
+			return columns
+		}
+	}
+	return defaultReporterWidth
+}
+
 // ConsoleReporter creates a FormatedReporter writing to the console (i.e. stdout)
 func ConsoleReporter(verbose bool) Reporter {
-	return NewFormatedReporter(verbose, 75, os.Stdout)
+	return NewFormatedReporter(verbose, defaultReporterWidth, os.Stdout)
 }
 
 // ReportTestResult reports a single property result
 func (r *FormatedReporter) ReportTestResult(propName string, result *TestResult) {
 	if result.Passed() {
-		fmt.Fprintln(r.output, r.formatLines(fmt.Sprintf("+ %s: %s", propName, r.reportResult(result)), "", ""))
+		fmt.Fprintln(r.output, r.colorize(result, r.formatLines(fmt.Sprintf("+ %s: %s", propName, r.reportResult(result)), "", "")))
 	} else {
-		fmt.Fprintln(r.output, r.formatLines(fmt.Sprintf("! %s: %s", propName, r.reportResult(result)), "", ""))
+		fmt.Fprintln(r.output, r.colorize(result, r.formatLines(fmt.Sprintf("! %s: %s", propName, r.reportResult(result)), "", "")))
 	}
 }
 
+func (r *FormatedReporter) colorize(result *TestResult, str string) string {
+	if !r.colors {
+		return str
+	}
+	switch result.Status {
+	case TestPassed, TestProved:
+		return colorGreen + str + colorReset
+	case TestExhausted:
+		return colorYellow + str + colorReset
+	}
+	return colorRed + str + colorReset
+}
+
 func (r *FormatedReporter) reportResult(result *TestResult) string {
 	status := ""
 	switch result.Status {
 	case TestProved:
 		status = "OK, proved property.\n" + r.reportPropArgs(result.Args)
 	case TestPassed:
-		status = fmt.Sprintf("OK, passed %d tests.", result.Succeeded)
+		if result.Exhaustive {
+			status = fmt.Sprintf("OK, exhaustively verified %d cases.", result.Succeeded)
+		} else if result.MaxSizeVerified > 0 {
+			status = fmt.Sprintf("OK, passed %d tests (verified up to size %d).", result.Succeeded, result.MaxSizeVerified)
+		} else {
+			status = fmt.Sprintf("OK, passed %d tests.", result.Succeeded)
+		}
+		if result.EarlyStopped {
+			status += " Stopped early."
+		}
+		if result.Duplicates > 0 {
+			status += fmt.Sprintf(" %d duplicate inputs were skipped.", result.Duplicates)
+		}
 	case TestFailed:
-		status = fmt.Sprintf("Falsified after %d passed tests.\n%s%s", result.Succeeded, r.reportLabels(result.Labels), r.reportPropArgs(result.Args))
+		status = fmt.Sprintf("Falsified after %d passed tests.\n%s%s%s%s%s", result.Succeeded, r.reportSeed(result), r.reportEscalation(result), r.reportLabels(result.Labels), r.reportError(result.Error), r.reportPropArgs(result.Args))
 	case TestExhausted:
 		status = fmt.Sprintf("Gave up after only %d passed tests. %d tests were discarded.", result.Succeeded, result.Discarded)
+		if result.Duplicates > 0 {
+			status += fmt.Sprintf(" %d duplicate inputs were skipped.", result.Duplicates)
+		}
+		for _, stat := range result.SieveStats {
+			if stat.Rejected > 0 {
+				status = concatLines(status, stat.String()+".")
+			}
+		}
 	case TestError:
 		if r.verbose {
-			status = fmt.Sprintf("Error on property evaluation after %d passed tests: %s\n%s\n%s", result.Succeeded, result.Error.Error(), result.ErrorStack, r.reportPropArgs(result.Args))
+			status = fmt.Sprintf("Error on property evaluation after %d passed tests: %s\n%s%s\n%s", result.Succeeded, result.Error.Error(), r.reportSeed(result), result.ErrorStack, r.reportPropArgs(result.Args))
 		} else {
-			status = fmt.Sprintf("Error on property evaluation after %d passed tests: %s\n%s", result.Succeeded, result.Error.Error(), r.reportPropArgs(result.Args))
+			status = fmt.Sprintf("Error on property evaluation after %d passed tests: %s\n%s%s", result.Succeeded, result.Error.Error(), r.reportSeed(result), r.reportPropArgs(result.Args))
 		}
 	}
 
 	if r.verbose {
+		if !result.Timing.IsEmpty() {
+			return concatLines(status, fmt.Sprintf("Elapsed time: %s (%s)", result.Time.String(), result.Timing))
+		}
 		return concatLines(status, fmt.Sprintf("Elapsed time: %s", result.Time.String()))
 	}
 	return status
 }
 
+// reportSeed reports the seed a failure can be reproduced with (see SeedEnv),
+// nothing if the seed is unknown
+func (r *FormatedReporter) reportSeed(result *TestResult) string {
+	if result.Seed == 0 {
+		return ""
+	}
+	return fmt.Sprintf("> Seed: %d\n", result.Seed)
+}
+
+func (r *FormatedReporter) reportEscalation(result *TestResult) string {
+	if result.MaxSizeVerified > 0 {
+		return fmt.Sprintf("> Escalation: verified up to size %d\n", result.MaxSizeVerified)
+	}
+	return ""
+}
+
 func (r *FormatedReporter) reportLabels(labels []string) string {
 	if labels != nil && len(labels) > 0 {
 		return fmt.Sprintf("> Labels of failing property: %s\n", strings.Join(labels, newLine))
@@ -75,6 +192,19 @@ func (r *FormatedReporter) reportLabels(labels []string) string {
 	return ""
 }
 
+// reportError reports the error (and the errors it wraps) of a falsified
+// property
+func (r *FormatedReporter) reportError(err error) string {
+	if err == nil {
+		return ""
+	}
+	result := fmt.Sprintf("> Error: %s\n", err.Error())
+	for cause := errors.Unwrap(err); cause != nil; cause = errors.Unwrap(cause) {
+		result += fmt.Sprintf("> Caused by: %s\n", cause.Error())
+	}
+	return result
+}
+
 func (r *FormatedReporter) reportPropArgs(p PropArgs) string {
 	result := ""
 	for i, arg := range p {
@@ -91,14 +221,58 @@ func (r *FormatedReporter) reportPropArg(idx int, propArg *PropArg) string {
 	if label == "" {
 		label = fmt.Sprintf("ARG_%d", idx)
 	}
-	result := fmt.Sprintf("%s: %+v", label, propArg.Arg)
+	result := fmt.Sprintf("%s: %s", label, r.formatArg(label, propArg.Arg))
 	if propArg.Shrinks > 0 {
-		result += fmt.Sprintf("\n%s_ORIGINAL (%d shrinks): %+v", label, propArg.Shrinks, propArg.OrigArg)
+		result += fmt.Sprintf("\n%s_ORIGINAL (%d shrinks): %s", label, propArg.Shrinks, r.formatArg(label+"_ORIGINAL", propArg.OrigArg))
+	}
+	if len(propArg.Trace) > 0 {
+		result += fmt.Sprintf("\n%s_TRACE:", label)
+		for _, entry := range propArg.Trace {
+			result += "\n  " + entry.String()
+		}
 	}
 
 	return result
 }
 
+// formatArg formats an argument, truncating it if it exceeds the maxArgLength
+func (r *FormatedReporter) formatArg(label string, arg interface{}) string {
+	str := fmt.Sprintf("%+v", arg)
+	if r.maxArgLength <= 0 || len(str) <= r.maxArgLength {
+		return str
+	}
+	truncated := str[:r.maxArgLength]
+	for !utf8.ValidString(truncated) {
+		truncated = truncated[:len(truncated)-1]
+	}
+	if r.argDumpDir != "" {
+		if path, err := r.dumpArg(label, str); err == nil {
+			return fmt.Sprintf("%s... (truncated, %d bytes total, full value in %s)", truncated, len(str), path)
+		}
+	}
+	return fmt.Sprintf("%s... (truncated, %d bytes total)", truncated, len(str))
+}
+
+func (r *FormatedReporter) dumpArg(label, str string) (string, error) {
+	if err := os.MkdirAll(r.argDumpDir, 0755); err != nil {
+		return "", err
+	}
+	file, err := ioutil.TempFile(r.argDumpDir, "gopter-"+strings.Map(func(ch rune) rune {
+		if unicode.IsLetter(ch) || unicode.IsDigit(ch) {
+			return ch
+		}
+		return '_'
+	}, label)+"-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := file.WriteString(str); err != nil {
+		return "", err
+	}
+	return file.Name(), nil
+}
+
 func (r *FormatedReporter) formatLines(str, lead, trail string) string {
 	result := ""
 	for _, line := range strings.Split(str, "\n") {
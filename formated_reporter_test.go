@@ -3,6 +3,11 @@ package gopter
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -27,8 +32,9 @@ func TestConsoleReporter(t *testing.T) {
 		Args: PropArgs([]*PropArg{{
 			Arg: "0",
 		}}),
+		Seed: 1234,
 	})
-	if buffer.String() != "! test property: Falsified after 50 passed tests.\nARG_0: 0\n" {
+	if buffer.String() != "! test property: Falsified after 50 passed tests.\n> Seed: 1234\nARG_0: 0\n" {
 		t.Errorf("Invalid output: %#v", buffer.String())
 	}
 	buffer.Reset()
@@ -58,6 +64,20 @@ func TestConsoleReporter(t *testing.T) {
 	}
 	buffer.Reset()
 
+	reporter.ReportTestResult("test property", &TestResult{
+		Status:    TestExhausted,
+		Succeeded: 50,
+		Discarded: 40,
+		SieveStats: []SieveStat{
+			{Name: "positive", Evaluated: 300, Rejected: 250},
+			{Name: "even", Evaluated: 50, Rejected: 0},
+		},
+	})
+	if buffer.String() != "! test property: Gave up after only 50 passed tests. 40 tests were\n   discarded.\n83% of generated values rejected by sieve 'positive'.\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
 	reporter.ReportTestResult("test property", &TestResult{
 		Status:    TestError,
 		Error:     errors.New("Poop"),
@@ -71,10 +91,133 @@ func TestConsoleReporter(t *testing.T) {
 	}
 	buffer.Reset()
 
+	reporter.ReportTestResult("test property", &TestResult{
+		Status:    TestFailed,
+		Succeeded: 50,
+		Error:     fmt.Errorf("Check failed: %w", errors.New("Poop")),
+		Args: PropArgs([]*PropArg{{
+			Arg: "0",
+		}}),
+	})
+	if buffer.String() != "! test property: Falsified after 50 passed tests.\n> Error: Check failed: Poop\n> Caused by: Poop\nARG_0: 0\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
+	reporter.ReportTestResult("test property", &TestResult{Status: TestPassed, Succeeded: 80, MaxSizeVerified: 800})
+	if buffer.String() != "+ test property: OK, passed 80 tests (verified up to size 800).\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
+	reporter.ReportTestResult("test property", &TestResult{Status: TestPassed, Succeeded: 59, EarlyStopped: true})
+	if buffer.String() != "+ test property: OK, passed 59 tests. Stopped early.\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
+	reporter.ReportTestResult("test property", &TestResult{Status: TestPassed, Succeeded: 2, Duplicates: 500})
+	if buffer.String() != "+ test property: OK, passed 2 tests. 500 duplicate inputs were skipped.\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
+	reporter.ReportTestResult("test property", &TestResult{
+		Status:          TestFailed,
+		Succeeded:       70,
+		MaxSizeVerified: 400,
+		Args: PropArgs([]*PropArg{{
+			Arg: "0",
+		}}),
+	})
+	if buffer.String() != "! test property: Falsified after 70 passed tests.\n> Escalation: verified up to size 400\nARG_0: 0\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
 	reporter.verbose = true
 	reporter.ReportTestResult("test property", &TestResult{Status: TestPassed, Succeeded: 50, Time: time.Minute})
 	if buffer.String() != "+ test property: OK, passed 50 tests.\nElapsed time: 1m0s\n" {
 		t.Errorf("Invalid output: %#v", buffer.String())
 	}
 	buffer.Reset()
+
+	reporter.ReportTestResult("test property", &TestResult{Status: TestPassed, Succeeded: 50, Time: time.Minute,
+		Timing: TimeBreakdown{Generation: 10 * time.Second, Evaluation: 40 * time.Second}})
+	if buffer.String() != "+ test property: OK, passed 50 tests.\nElapsed time: 1m0s (generation: 10s, evaluation: 40s, shrinking: 0s)\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+}
+
+func TestFormatedReporterWithOptions(t *testing.T) {
+	var buffer bytes.Buffer
+	reporter := NewFormatedReporterWithOptions(&buffer, FormatedReporterOptions{
+		Width:        75,
+		Colors:       true,
+		MaxArgLength: 10,
+	})
+
+	reporter.ReportTestResult("test property", &TestResult{Status: TestPassed, Succeeded: 50})
+	if buffer.String() != "\x1b[32m+ test property: OK, passed 50 tests.\x1b[0m\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
+	reporter.ReportTestResult("test property", &TestResult{
+		Status:    TestFailed,
+		Succeeded: 50,
+		Args: PropArgs([]*PropArg{{
+			Arg: strings.Repeat("x", 100),
+		}}),
+	})
+	if buffer.String() != "\x1b[31m! test property: Falsified after 50 passed tests.\nARG_0: xxxxxxxxxx... (truncated, 100 bytes total)\x1b[0m\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+	buffer.Reset()
+
+	dir, err := ioutil.TempDir("", "gopter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	reporter = NewFormatedReporterWithOptions(&buffer, FormatedReporterOptions{
+		Width:        1000,
+		MaxArgLength: 10,
+		ArgDumpDir:   dir,
+	})
+	reporter.ReportTestResult("test property", &TestResult{
+		Status: TestFailed,
+		Args: PropArgs([]*PropArg{{
+			Arg:   strings.Repeat("y", 100),
+			Label: "long arg",
+		}}),
+	})
+	files, err := filepath.Glob(filepath.Join(dir, "gopter-long_arg-*.txt"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Argument not dumped: %#v %v", files, err)
+	}
+	content, err := ioutil.ReadFile(files[0])
+	if err != nil || string(content) != strings.Repeat("y", 100) {
+		t.Errorf("Invalid dump: %#v %v", string(content), err)
+	}
+	if !strings.Contains(buffer.String(), "(truncated, 100 bytes total, full value in "+files[0]+")") {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+
+	os.Unsetenv("COLUMNS")
+	file, err := ioutil.TempFile(dir, "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if reporter := NewFormatedReporterWithOptions(file, FormatedReporterOptions{}).(*FormatedReporter); reporter.width != defaultReporterWidth {
+		t.Errorf("Invalid width of a file: %d", reporter.width)
+	}
+
+	os.Setenv("COLUMNS", "120")
+	defer os.Unsetenv("COLUMNS")
+	if reporter := NewFormatedReporterWithOptions(&buffer, FormatedReporterOptions{}).(*FormatedReporter); reporter.width != 120 {
+		t.Errorf("Invalid detected width: %d", reporter.width)
+	}
 }
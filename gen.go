@@ -3,6 +3,8 @@ package gopter
 import (
 	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
 )
 
 // Gen generator of arbitrary values.
@@ -31,8 +33,9 @@ func (g Gen) Sample() (interface{}, bool) {
 // WithLabel adds a label to a generated value.
 // Labels are usually used for reporting for the arguments of a property check.
 func (g Gen) WithLabel(label string) Gen {
+	traced := g.Traced(label)
 	return func(genParams *GenParameters) *GenResult {
-		result := g(genParams)
+		result := traced(genParams)
 		result.Labels = append(result.Labels, label)
 		return result
 	}
@@ -44,7 +47,20 @@ func (g Gen) WithLabel(label string) Gen {
 //  f(value) == true.
 // Use this care, if the sieve to to fine the generator will have many misses which results
 // in an undecided property.
+// The rejections are recorded with the name of f (see SuchThatNamed).
 func (g Gen) SuchThat(f interface{}) Gen {
+	name := fmt.Sprintf("%v", f)
+	if checkVal := reflect.ValueOf(f); checkVal.Kind() == reflect.Func {
+		name = funcName(checkVal)
+	}
+	return g.SuchThatNamed(name, f)
+}
+
+// SuchThatNamed creates a derived generator by adding a sieve like SuchThat.
+// The ratio of generated values rejected by the sieve is recorded with the
+// given name in the TestResult of a property (see SieveStat), so that the
+// sieves starving a property can be identified.
+func (g Gen) SuchThatNamed(name string, f interface{}) Gen {
 	checkVal := reflect.ValueOf(f)
 	checkType := checkVal.Type()
 
@@ -71,10 +87,18 @@ func (g Gen) SuchThat(f interface{}) Gen {
 		}
 		return checkVal.Call([]reflect.Value{valueOf})[0].Bool()
 	}
+	return g.withSieve(name, sieve)
+}
 
+// withSieve adds a sieve to the generated results and records its
+// evaluation of the generated values if SieveStats are collected
+func (g Gen) withSieve(name string, sieve func(interface{}) bool) Gen {
 	return func(genParams *GenParameters) *GenResult {
 		result := g(genParams)
 		prevSieve := result.Sieve
+		if genParams.SieveStats != nil && result.Result != nil && (prevSieve == nil || prevSieve(result.Result)) {
+			genParams.SieveStats.Record(name, sieve(result.Result))
+		}
 		if prevSieve == nil {
 			result.Sieve = sieve
 		} else {
@@ -86,6 +110,33 @@ func (g Gen) SuchThat(f interface{}) Gen {
 	}
 }
 
+// RetrySieve creates a derived generator that re-draws a value up to
+// "retries" times if it is rejected by the sieve, i.e. the property is only
+// undecided if all draws are rejected.
+// All rejections are still recorded in the sieve statistics (see
+// SuchThatNamed).
+func (g Gen) RetrySieve(retries int) Gen {
+	return func(genParams *GenParameters) *GenResult {
+		result := g(genParams)
+		for i := 0; i < retries; i++ {
+			if _, ok := result.Retrieve(); ok {
+				break
+			}
+			result = g(genParams)
+		}
+		return result
+	}
+}
+
+func funcName(f reflect.Value) string {
+	fn := runtime.FuncForPC(f.Pointer())
+	if fn == nil {
+		return f.Type().String()
+	}
+	name := fn.Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
 // WithShrinker creates a derived generator with a specific shrinker
 func (g Gen) WithShrinker(shrinker Shrinker) Gen {
 	return func(genParams *GenParameters) *GenResult {
@@ -95,13 +146,17 @@ func (g Gen) WithShrinker(shrinker Shrinker) Gen {
 		} else {
 			result.Shrinker = shrinker
 		}
+		result.Tree = nil
 		return result
 	}
 }
 
 // Map creates a derived generator by mapping all generatored values with a given function.
 // f: has to be a function with one parameter (matching the generated value) and a single return.
-// Note: The derived generator will not have a sieve or shrinker unless you are mapping to the same type
+// Note: The derived generator will not have a sieve. Its values are shrunk by the shrinker of the
+// generated value if you are mapping to the same type, otherwise (or if the generated value is
+// derived itself) by mapping the shrinks of the generated value (see GenResult.Tree) unless the
+// mapping function has a "*GenParameters" parameter.
 // Note: The mapping function may have a second parameter "*GenParameters"
 // Note: The first parameter of the mapping function and its return may be a *GenResult (this makes MapResult obsolete)
 func (g Gen) Map(f interface{}) Gen {
@@ -135,6 +190,13 @@ func (g Gen) Map(f interface{}) Gen {
 	} else if reflect.TypeOf(&GenResult{}).AssignableTo(mapperType.Out(0)) {
 		genResultOutput = true
 	}
+	mapValue := func(v interface{}) interface{} {
+		in := reflect.ValueOf(v)
+		if !in.IsValid() {
+			in = reflect.Zero(mapperType.In(0))
+		}
+		return mapperVal.Call([]reflect.Value{in})[0].Interface()
+	}
 
 	return func(genParams *GenParameters) *GenResult {
 		result := g(genParams)
@@ -159,6 +221,7 @@ func (g Gen) Map(f interface{}) Gen {
 		if ok {
 			var mapped reflect.Value
 			shrinker := NoShrinker
+			var tree func() *ShrinkTree
 			if needsGenParameters {
 				mapped = mapperVal.Call([]reflect.Value{value, reflect.ValueOf(genParams)})[0]
 			} else {
@@ -167,14 +230,27 @@ func (g Gen) Map(f interface{}) Gen {
 			if genResultOutput {
 				return mapped.Interface().(*GenResult)
 			}
-			if mapperType.In(0) == mapperType.Out(0) {
+			if mapperType.In(0) == mapperType.Out(0) && result.Tree == nil {
 				shrinker = result.Shrinker
+			} else if (result.Shrinker != nil || result.Tree != nil) && !needsGenParameters {
+				// shrink the generated value and map its shrinks
+				tree = func() *ShrinkTree {
+					source, _ := result.ShrinkTree()
+					return source.Map(mapValue)
+				}
+				shrinker = treeShrinker(tree)
+			}
+			var domain func() []interface{}
+			if result.Domain != nil && !needsGenParameters {
+				domain = mapDomain(result, mapperVal)
 			}
 			return &GenResult{
 				Shrinker:   shrinker,
 				Result:     mapped.Interface(),
 				Labels:     result.Labels,
 				ResultType: mapperType.Out(0),
+				Domain:     domain,
+				Tree:       tree,
 			}
 		}
 		return &GenResult{
@@ -186,14 +262,127 @@ func (g Gen) Map(f interface{}) Gen {
 	}
 }
 
+// MapShrink creates a derived generator like Map, that keeps the shrinker and
+// sieve of the generator by converting values back with the inverse function.
+// f: has to be a function with one parameter (matching the generated value) and a single return.
+// fInv: has to be the inverse of f, i.e. fInv(f(v)) == v for all generated values v.
+// Contrary to Map any value of the derived type can be shrunk (see DeriveGen).
+func (g Gen) MapShrink(f, fInv interface{}) Gen {
+	mapperVal := reflect.ValueOf(f)
+	if mapperVal.Kind() != reflect.Func {
+		panic(fmt.Sprintf("Param of MapShrink has to be a func, but is %v", mapperVal.Kind()))
+	}
+	mapperType := mapperVal.Type()
+	if mapperType.NumIn() != 1 || mapperType.NumOut() != 1 {
+		panic(fmt.Sprintf("Param of MapShrink has to be a func with one param and one return value, but is %v", mapperType))
+	}
+	if genResultType := g(MinGenParams).ResultType; genResultType != nil && !genResultType.AssignableTo(mapperType.In(0)) {
+		panic(fmt.Sprintf("Param of MapShrink has to be a func with one param assignable to %v, but is %v", genResultType, mapperType.In(0)))
+	}
+	return DeriveGen(f, fInv, g)
+}
+
+// FilterMap creates a derived generator that maps and sieves the generated
+// values in one step.
+// f: has to be a function with one parameter (matching the generated value) returning the mapped
+// value and a bool, values with false are discarded.
+// Contrary to Map followed by SuchThat, each rejected value is discarded (and recorded in the
+// sieve statistics with the name of f) only once and the derived values shrink by mapping the
+// shrinks of the generated value that f accepts.
+func (g Gen) FilterMap(f interface{}) Gen {
+	mapperVal := reflect.ValueOf(f)
+	mapperType := mapperVal.Type()
+
+	if mapperVal.Kind() != reflect.Func {
+		panic(fmt.Sprintf("Param of FilterMap has to be a func, but is %v", mapperType.Kind()))
+	}
+	if mapperType.NumIn() != 1 {
+		panic(fmt.Sprintf("Param of FilterMap has to be a func with one param, but is %v", mapperType.NumIn()))
+	} else {
+		genResultType := g(MinGenParams).ResultType
+		if !genResultType.AssignableTo(mapperType.In(0)) {
+			panic(fmt.Sprintf("Param of FilterMap has to be a func with one param assignable to %v, but is %v", genResultType, mapperType.In(0)))
+		}
+	}
+	if mapperType.NumOut() != 2 || mapperType.Out(1).Kind() != reflect.Bool {
+		panic(fmt.Sprintf("Param of FilterMap has to be a func with two return values, the second of bool, but is %v", mapperType))
+	}
+	name := funcName(mapperVal)
+	filterMap := func(v interface{}) (interface{}, bool) {
+		in := reflect.ValueOf(v)
+		if !in.IsValid() {
+			in = reflect.Zero(mapperType.In(0))
+		}
+		out := mapperVal.Call([]reflect.Value{in})
+		return out[0].Interface(), out[1].Bool()
+	}
+
+	return func(genParams *GenParameters) *GenResult {
+		result := g(genParams)
+		value, ok := result.Retrieve()
+		if !ok {
+			return &GenResult{
+				Shrinker:   NoShrinker,
+				Labels:     result.Labels,
+				ResultType: mapperType.Out(0),
+			}
+		}
+		mapped, ok := filterMap(value)
+		if genParams.SieveStats != nil {
+			genParams.SieveStats.Record(name, ok)
+		}
+		if !ok {
+			return &GenResult{
+				Shrinker:   NoShrinker,
+				Labels:     result.Labels,
+				ResultType: mapperType.Out(0),
+			}
+		}
+		tree := func() *ShrinkTree {
+			source, _ := result.ShrinkTree()
+			return source.filterMapped(mapped, filterMap)
+		}
+		return &GenResult{
+			Shrinker:   treeShrinker(tree),
+			Result:     mapped,
+			Labels:     result.Labels,
+			ResultType: mapperType.Out(0),
+			Tree:       tree,
+		}
+	}
+}
+
 // FlatMap creates a derived generator by passing a generated value to a function which itself
 // creates a generator.
+// The derived values shrink by shrinking the generated value and re-running the created generator
+// (with a fixed seed derived from the generated value), followed by the shrinks of the created
+// generator. This gives at least coarse-grained shrinking for generators that depend on a
+// generated value (e.g. slices of a generated length).
 func (g Gen) FlatMap(f func(interface{}) Gen, resultType reflect.Type) Gen {
 	return func(genParams *GenParameters) *GenResult {
 		result := g(genParams)
 		value, ok := result.Retrieve()
 		if ok {
-			return f(value)(genParams)
+			inner := f(value)(genParams)
+			if _, ok := inner.Retrieve(); !ok {
+				return inner
+			}
+			tree := func() *ShrinkTree {
+				seed := regenerationSeed(value)
+				regenerate := func(outer interface{}) (*ShrinkTree, bool) {
+					return f(outer)(genParams.CloneWithSeed(seed)).ShrinkTree()
+				}
+				outerTree, _ := result.ShrinkTree()
+				innerTree, _ := inner.ShrinkTree()
+				return flatMapTree(outerTree, innerTree, regenerate)
+			}
+			derived := *inner
+			derived.Shrinker = treeShrinker(tree)
+			derived.Sieve = passSieve
+			derived.Tree = tree
+			// the domain is the one of the created generator only
+			derived.Domain = nil
+			return &derived
 		}
 		return &GenResult{
 			Shrinker:   NoShrinker,
@@ -207,30 +396,88 @@ func (g Gen) FlatMap(f func(interface{}) Gen, resultType reflect.Type) Gen {
 // MapResult creates a derived generator by mapping the GenResult directly.
 // Contrary to `Map` and `FlatMap` this also allow the conversion of
 // shrinkers and sieves, but implementation is more cumbersome.
+// If f returns a result without Shrinker (and Tree), its value is shrunk by
+// passing the shrinks of the generated value to f.
 // Deprecation note: Map now has the same functionality
 func (g Gen) MapResult(f func(*GenResult) *GenResult) Gen {
 	return func(genParams *GenParameters) *GenResult {
-		return f(g(genParams))
+		result := g(genParams)
+		mapped := f(result)
+		if mapped.Shrinker == nil && mapped.Tree == nil {
+			value, ok := mapped.Retrieve()
+			if !ok {
+				mapped.Shrinker = NoShrinker
+				return mapped
+			}
+			tree := func() *ShrinkTree {
+				source, ok := result.ShrinkTree()
+				if !ok {
+					return NewShrinkTree(value, NoShrinker, nil)
+				}
+				return mapResultTree(value, source, result, f)
+			}
+			mapped.Shrinker = treeShrinker(tree)
+			mapped.Tree = tree
+		}
+		return mapped
+	}
+}
+
+// mapResultTree creates the tree of a value of MapResult from the tree of the
+// generated value (source), whose shrinks are passed to f with the labels,
+// shrinker and sieve of the generated result
+func mapResultTree(value interface{}, source *ShrinkTree, result *GenResult, f func(*GenResult) *GenResult) *ShrinkTree {
+	return &ShrinkTree{
+		Value: value,
+		children: func() TreeShrink {
+			children := source.Children()
+			return func() (*ShrinkTree, bool) {
+				for {
+					child, ok := children()
+					if !ok {
+						return nil, false
+					}
+					mapped := f(&GenResult{
+						Labels:     result.Labels,
+						Shrinker:   result.Shrinker,
+						ResultType: result.ResultType,
+						Result:     child.Value,
+						Sieve:      result.Sieve,
+					})
+					if value, ok := mapped.Retrieve(); ok {
+						return mapResultTree(value, child, result, f), true
+					}
+				}
+			}
+		},
 	}
 }
 
 // CombineGens creates a generators from a list of generators.
 // The result type will be a []interface{} containing the generated values of each generators in
 // the list.
-// Note: The combined generator will not have a sieve or shrinker.
+// The combined values are shrunk one generated value after another (see
+// CombineShrinker), values derived by other combinators (see GenResult.Tree)
+// are shrunk by their trees.
 func CombineGens(gens ...Gen) Gen {
 	return func(genParams *GenParameters) *GenResult {
 		labels := []string{}
+		results := make([]*GenResult, len(gens))
+		derived := false
 		values := make([]interface{}, len(gens))
 		shrinkers := make([]Shrinker, len(gens))
 		sieves := make([]func(v interface{}) bool, len(gens))
+		domains := make([]func() []interface{}, len(gens))
 
 		var ok bool
 		for i, gen := range gens {
 			result := gen(genParams)
+			results[i] = result
+			derived = derived || result.Tree != nil
 			labels = append(labels, result.Labels...)
 			shrinkers[i] = result.Shrinker
 			sieves[i] = result.Sieve
+			domains[i] = result.Domain
 			values[i], ok = result.Retrieve()
 			if !ok {
 				return &GenResult{
@@ -241,11 +488,23 @@ func CombineGens(gens ...Gen) Gen {
 				}
 			}
 		}
+		var tree func() *ShrinkTree
+		if derived {
+			tree = func() *ShrinkTree {
+				trees := make([]*ShrinkTree, len(results))
+				for i, result := range results {
+					trees[i], _ = result.ShrinkTree()
+				}
+				return combineTrees(trees)
+			}
+		}
 		return &GenResult{
 			Shrinker:   CombineShrinker(shrinkers...),
+			Tree:       tree,
 			Result:     values,
 			Labels:     labels,
 			ResultType: reflect.TypeOf(values),
+			Domain:     combineDomains(domains),
 			Sieve: func(v interface{}) bool {
 				values := v.([]interface{})
 				for i, value := range values {
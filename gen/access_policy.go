@@ -0,0 +1,184 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/leanovate/gopter"
+)
+
+// PolicyWildcard matches any subject, action or resource of a PolicyRule
+const PolicyWildcard = "*"
+
+// PolicyRule allows or denies subjects to perform an action on a resource
+type PolicyRule struct {
+	// Subject, Action and Resource of the rule (or PolicyWildcard)
+	Subject  string
+	Action   string
+	Resource string
+	// Allow is true for allow rules, false for deny rules
+	Allow bool
+}
+
+// Matches checks if the rule applies to a query
+func (r PolicyRule) Matches(subject, action, resource string) bool {
+	return (r.Subject == PolicyWildcard || r.Subject == subject) &&
+		(r.Action == PolicyWildcard || r.Action == action) &&
+		(r.Resource == PolicyWildcard || r.Resource == resource)
+}
+
+// AccessQuery is a query of an AccessPolicy with its expected decision
+type AccessQuery struct {
+	Subject  string
+	Action   string
+	Resource string
+	// Allowed is the decision of the reference evaluator (AccessPolicy.Allowed)
+	Allowed bool
+}
+
+// AccessPolicy is a generated access-control matrix, i.e. a set of rules for
+// subjects, actions and resources and queries with their expected decisions
+type AccessPolicy struct {
+	Subjects  []string
+	Actions   []string
+	Resources []string
+	Rules     []PolicyRule
+	// Queries cover all combinations of subjects, actions and resources and a
+	// query of an unknown subject
+	Queries []AccessQuery
+}
+
+// Allowed is the reference evaluator of the policy: Access is denied by
+// default, an allow rule grants access unless a deny rule matches (deny
+// overrides allow).
+func (p *AccessPolicy) Allowed(subject, action, resource string) bool {
+	allowed := false
+	for _, rule := range p.Rules {
+		if rule.Matches(subject, action, resource) {
+			if !rule.Allow {
+				return false
+			}
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+// WithRule creates a copy of the policy with an additional rule (and updated
+// expected decisions). Adding an allow rule never revokes access and adding a
+// deny rule never grants access, i.e. this can be used to check the
+// monotonicity of an authorization engine.
+func (p *AccessPolicy) WithRule(rule PolicyRule) *AccessPolicy {
+	rules := make([]PolicyRule, len(p.Rules), len(p.Rules)+1)
+	copy(rules, p.Rules)
+	return newAccessPolicy(p.Subjects, p.Actions, p.Resources, append(rules, rule))
+}
+
+func newAccessPolicy(subjects, actions, resources []string, rules []PolicyRule) *AccessPolicy {
+	policy := &AccessPolicy{
+		Subjects:  subjects,
+		Actions:   actions,
+		Resources: resources,
+		Rules:     rules,
+	}
+	for _, subject := range append(append([]string{}, subjects...), "unknown") {
+		for _, action := range actions {
+			for _, resource := range resources {
+				policy.Queries = append(policy.Queries, AccessQuery{
+					Subject:  subject,
+					Action:   action,
+					Resource: resource,
+					Allowed:  policy.Allowed(subject, action, resource),
+				})
+			}
+		}
+	}
+	return policy
+}
+
+// AccessPolicies generates access-control matrices (AccessPolicy) of up to 4
+// subjects, 3 actions and 4 resources with random allow and deny rules
+// (including wildcards) and the expected decisions of all queries, so that
+// authorization engines can be checked for equivalence with the reference
+// evaluator.
+// The used features (wildcards, deny rules, conflicts) are added as labels.
+// Policies shrink by removing rules.
+func AccessPolicies() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		subjects := policyNames("user", 1+genParams.Rng.Intn(4))
+		actions := []string{"read", "write", "delete"}[:1+genParams.Rng.Intn(3)]
+		resources := policyNames("doc", 1+genParams.Rng.Intn(4))
+		pick := func(names []string) string {
+			if genParams.Rng.Intn(5) == 0 {
+				return PolicyWildcard
+			}
+			return names[genParams.Rng.Intn(len(names))]
+		}
+
+		count := genParams.Rng.Intn(genParams.MaxSize/10 + 3)
+		rules := make([]PolicyRule, 0, count)
+		for i := 0; i < count; i++ {
+			rules = append(rules, PolicyRule{
+				Subject:  pick(subjects),
+				Action:   pick(actions),
+				Resource: pick(resources),
+				Allow:    genParams.Rng.Intn(4) != 0,
+			})
+		}
+		policy := newAccessPolicy(subjects, actions, resources, rules)
+
+		genResult := gopter.NewGenResult(policy, accessPolicyShrinker)
+		genResult.Labels = accessPolicyFeatures(policy)
+		return genResult
+	}
+}
+
+func policyNames(prefix string, count int) []string {
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return names
+}
+
+func accessPolicyFeatures(policy *AccessPolicy) []string {
+	features := map[string]bool{}
+	if len(policy.Rules) == 0 {
+		features["no rules"] = true
+	}
+	for _, rule := range policy.Rules {
+		if rule.Subject == PolicyWildcard || rule.Action == PolicyWildcard || rule.Resource == PolicyWildcard {
+			features["wildcard"] = true
+		}
+		if !rule.Allow {
+			features["deny rule"] = true
+		}
+	}
+	for _, query := range policy.Queries {
+		allowMatches, denyMatches := false, false
+		for _, rule := range policy.Rules {
+			if rule.Matches(query.Subject, query.Action, query.Resource) {
+				allowMatches = allowMatches || rule.Allow
+				denyMatches = denyMatches || !rule.Allow
+			}
+		}
+		if allowMatches && denyMatches {
+			features["conflicting rules"] = true
+		}
+	}
+	return sortedFeatures(features)
+}
+
+func accessPolicyShrinker(v interface{}) gopter.Shrink {
+	policy := v.(*AccessPolicy)
+	i := 0
+	return func() (interface{}, bool) {
+		if i >= len(policy.Rules) {
+			return nil, false
+		}
+		rules := make([]PolicyRule, 0, len(policy.Rules)-1)
+		rules = append(rules, policy.Rules[:i]...)
+		rules = append(rules, policy.Rules[i+1:]...)
+		i++
+		return newAccessPolicy(policy.Subjects, policy.Actions, policy.Resources, rules), true
+	}
+}
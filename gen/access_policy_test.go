@@ -0,0 +1,95 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+// matrixEvaluator expands the rules of a policy into a decision matrix
+func matrixEvaluator(policy *gen.AccessPolicy) map[gen.AccessQuery]bool {
+	allowed := map[gen.AccessQuery]bool{}
+	denied := map[gen.AccessQuery]bool{}
+	expand := func(value string, all []string) []string {
+		if value == gen.PolicyWildcard {
+			return all
+		}
+		return []string{value}
+	}
+	for _, rule := range policy.Rules {
+		for _, subject := range expand(rule.Subject, append([]string{"unknown"}, policy.Subjects...)) {
+			for _, action := range expand(rule.Action, policy.Actions) {
+				for _, resource := range expand(rule.Resource, policy.Resources) {
+					query := gen.AccessQuery{Subject: subject, Action: action, Resource: resource}
+					if rule.Allow {
+						allowed[query] = true
+					} else {
+						denied[query] = true
+					}
+				}
+			}
+		}
+	}
+	for query := range denied {
+		delete(allowed, query)
+	}
+	return allowed
+}
+
+func TestAccessPolicies(t *testing.T) {
+	features := map[string]bool{}
+	commonGeneratorTest(t, "access policy", gen.AccessPolicies(), func(value interface{}) bool {
+		policy, ok := value.(*gen.AccessPolicy)
+		if !ok || len(policy.Queries) != (len(policy.Subjects)+1)*len(policy.Actions)*len(policy.Resources) {
+			return false
+		}
+		matrix := matrixEvaluator(policy)
+		for _, query := range policy.Queries {
+			allowed := query.Allowed
+			query.Allowed = false
+			if matrix[query] != allowed || (query.Subject == "unknown" && allowed && len(policy.Rules) == 0) {
+				return false
+			}
+		}
+
+		// monotonicity
+		extended := policy.WithRule(gen.PolicyRule{
+			Subject: policy.Subjects[0], Action: gen.PolicyWildcard, Resource: policy.Resources[0], Allow: true,
+		})
+		restricted := policy.WithRule(gen.PolicyRule{
+			Subject: gen.PolicyWildcard, Action: policy.Actions[0], Resource: gen.PolicyWildcard, Allow: false,
+		})
+		if len(extended.Rules) != len(policy.Rules)+1 {
+			return false
+		}
+		for i, query := range policy.Queries {
+			if query.Allowed && !extended.Queries[i].Allowed || !query.Allowed && restricted.Queries[i].Allowed {
+				return false
+			}
+		}
+		return true
+	})
+
+	for i := 0; i < 100; i++ {
+		for _, label := range gen.AccessPolicies()(gopter.DefaultGenParameters()).Labels {
+			features[label] = true
+		}
+	}
+	for _, feature := range []string{"no rules", "wildcard", "deny rule", "conflicting rules"} {
+		if !features[feature] {
+			t.Errorf("Feature %q not generated: %v", feature, features)
+		}
+	}
+
+	policy := &gen.AccessPolicy{Subjects: []string{"user0"}, Actions: []string{"read"}, Resources: []string{"doc0"}}
+	policy = policy.WithRule(gen.PolicyRule{Subject: "user0", Action: "read", Resource: "doc0", Allow: true})
+	policy = policy.WithRule(gen.PolicyRule{Subject: gen.PolicyWildcard, Action: "read", Resource: "doc0"})
+	if policy.Queries[0].Allowed {
+		t.Errorf("Deny does not override allow: %#v", policy.Queries)
+	}
+	shrinks := gen.AccessPolicies()(gopter.DefaultGenParameters()).Shrinker(policy).All()
+	if len(shrinks) != 2 || shrinks[0].(*gen.AccessPolicy).Queries[0].Allowed || !shrinks[1].(*gen.AccessPolicy).Queries[0].Allowed {
+		t.Errorf("Invalid shrinks: %#v", shrinks)
+	}
+}
@@ -0,0 +1,92 @@
+package gen_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestBatchMatchesSingleValues(t *testing.T) {
+	gens := []struct {
+		name string
+		gen  gopter.Gen
+	}{
+		{"Int", gen.Int()},
+		{"IntRange", gen.IntRange(-1000, 1000)},
+		{"Int64", gen.Int64()},
+		{"Int64Full", gen.Int64Range(math.MinInt64, math.MaxInt64)},
+		{"Int16Range", gen.Int16Range(-10, 10)},
+		{"Int8", gen.Int8()},
+		{"UInt64", gen.UInt64()},
+		{"UInt32Range", gen.UInt32Range(5, 500)},
+		{"UInt8", gen.UInt8()},
+		{"UInt", gen.UInt()},
+		{"Bool", gen.Bool()},
+		{"Float64Range", gen.Float64Range(-1, 1)},
+		{"Float32Range", gen.Float32Range(0, 10)},
+		{"Float64", gen.Float64()},
+		{"Labeled", gen.IntRange(0, 10).WithLabel("label")},
+	}
+	for _, g := range gens {
+		single := gopter.DefaultGenParameters().CloneWithSeed(1234)
+		var expected []interface{}
+		for i := 0; i < 100; i++ {
+			value, _ := g.gen(single).Retrieve()
+			expected = append(expected, value)
+		}
+
+		batch := reflect.ValueOf(g.gen.Batch(100, gopter.DefaultGenParameters().CloneWithSeed(1234)))
+		if batch.Len() != len(expected) {
+			t.Fatalf("%s: invalid batch size: %d", g.name, batch.Len())
+		}
+		for i, value := range expected {
+			if batch.Index(i).Interface() != value {
+				t.Errorf("%s: batch value %d is %#v, expected %#v", g.name, i, batch.Index(i).Interface(), value)
+				break
+			}
+		}
+	}
+
+	genParams := gopter.DefaultGenParameters()
+	buf := gen.Int8().BatchInto(make([]int8, 0, 10), 10, genParams).([]int8)
+	if buf = gen.Int8().BatchInto(buf[:0], 10, genParams).([]int8); len(buf) != 10 || cap(buf) != 10 {
+		t.Errorf("Buffer not reused: %#v", buf)
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	gens := []struct {
+		name string
+		gen  gopter.Gen
+	}{
+		{"IntRange", gen.IntRange(-1000, 1000)},
+		{"UInt8", gen.UInt8()},
+		{"Bool", gen.Bool()},
+		{"Float64Range", gen.Float64Range(-1, 1)},
+	}
+	for _, g := range gens {
+		b.Run(g.name+"/Loop", func(b *testing.B) {
+			genParams := gopter.DefaultGenParameters()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 1000; j++ {
+					if _, ok := g.gen(genParams).Retrieve(); !ok {
+						b.Fatal("Invalid gen result")
+					}
+				}
+			}
+		})
+		b.Run(g.name+"/Batch", func(b *testing.B) {
+			genParams := gopter.DefaultGenParameters()
+			buf := g.gen.Batch(1000, genParams)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				buf = g.gen.BatchInto(reflect.ValueOf(buf).Slice(0, 0).Interface(), 1000, genParams)
+			}
+		})
+	}
+}
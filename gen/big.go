@@ -0,0 +1,118 @@
+package gen
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// BigInt generates *big.Int numbers (positive and negative) whose absolute
+// value has a bit length between minBits and maxBits (inclusive), e.g.
+// BigInt(256, 256) generates numbers of exactly 256 bits.
+func BigInt(minBits, maxBits int) gopter.Gen {
+	return bigIntBits(minBits, maxBits, true)
+}
+
+// BigNat generates non-negative *big.Int numbers with a bit length between
+// minBits and maxBits (inclusive)
+func BigNat(minBits, maxBits int) gopter.Gen {
+	return bigIntBits(minBits, maxBits, false)
+}
+
+func bigIntBits(minBits, maxBits int, signed bool) gopter.Gen {
+	if minBits < 0 || maxBits < minBits {
+		return Fail(reflect.TypeOf(&big.Int{}))
+	}
+	sieve := func(v interface{}) bool {
+		value := v.(*big.Int)
+		return value.BitLen() >= minBits && value.BitLen() <= maxBits && (signed || value.Sign() >= 0)
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		value := randomBits(genParams.Rng, minBits+genParams.Rng.Intn(maxBits-minBits+1))
+		if signed && genParams.NextBool() {
+			value.Neg(value)
+		}
+		genResult := gopter.NewGenResult(value, BigIntShrinker)
+		genResult.Sieve = sieve
+		return genResult
+	}
+}
+
+// BigIntRange generates *big.Int numbers within a given range (inclusive)
+func BigIntRange(min, max *big.Int) gopter.Gen {
+	if max.Cmp(min) < 0 {
+		return Fail(reflect.TypeOf(&big.Int{}))
+	}
+	min, max = new(big.Int).Set(min), new(big.Int).Set(max)
+	size := new(big.Int).Sub(max, min)
+	size.Add(size, big.NewInt(1))
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		value := new(big.Int).Rand(genParams.Rng, size)
+		genResult := gopter.NewGenResult(value.Add(value, min), BigIntShrinker)
+		genResult.Sieve = func(v interface{}) bool {
+			return v.(*big.Int).Cmp(min) >= 0 && v.(*big.Int).Cmp(max) <= 0
+		}
+		return genResult
+	}
+}
+
+// BigRat generates *big.Rat numbers with a numerator and denominator of up to
+// maxBits bits
+func BigRat(maxBits int) gopter.Gen {
+	if maxBits < 1 {
+		return Fail(reflect.TypeOf(&big.Rat{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		num := randomBits(genParams.Rng, genParams.Rng.Intn(maxBits+1))
+		if genParams.NextBool() {
+			num.Neg(num)
+		}
+		denom := randomBits(genParams.Rng, 1+genParams.Rng.Intn(maxBits))
+		genResult := gopter.NewGenResult(new(big.Rat).SetFrac(num, denom), BigRatShrinker)
+		genResult.Sieve = func(v interface{}) bool {
+			return v.(*big.Rat).Num().BitLen() <= maxBits && v.(*big.Rat).Denom().BitLen() <= maxBits
+		}
+		return genResult
+	}
+}
+
+// BigFloat generates finite *big.Float numbers with a precision between
+// minPrec and maxPrec bits (inclusive). All bits of the mantissa are random,
+// the binary exponent (see big.Float.MantExp) is between -maxPrec and
+// maxPrec.
+func BigFloat(minPrec, maxPrec uint) gopter.Gen {
+	if minPrec < 1 || maxPrec < minPrec {
+		return Fail(reflect.TypeOf(&big.Float{}))
+	}
+	maxExp := int(maxPrec)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		prec := minPrec + uint(genParams.Rng.Int63n(int64(maxPrec-minPrec+1)))
+		exp := genParams.Rng.Intn(2*maxExp+1) - maxExp
+		mant := new(big.Float).SetPrec(prec).SetInt(randomBits(genParams.Rng, int(prec)))
+		value := new(big.Float).SetPrec(prec).SetMantExp(mant, exp-int(prec))
+		if genParams.NextBool() {
+			value.Neg(value)
+		}
+		genResult := gopter.NewGenResult(value, BigFloatShrinker)
+		genResult.Sieve = func(v interface{}) bool {
+			value := v.(*big.Float)
+			if value.Prec() < minPrec || value.Prec() > maxPrec || value.IsInf() {
+				return false
+			}
+			exp := value.MantExp(nil)
+			return value.Sign() == 0 || (exp >= -maxExp && exp <= maxExp)
+		}
+		return genResult
+	}
+}
+
+// randomBits creates a random number with the given bit length
+func randomBits(rng *rand.Rand, bits int) *big.Int {
+	if bits == 0 {
+		return new(big.Int)
+	}
+	value := new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(bits-1)))
+	return value.SetBit(value, bits-1, 1)
+}
@@ -0,0 +1,98 @@
+package gen
+
+import (
+	"math/big"
+
+	"github.com/leanovate/gopter"
+)
+
+// BigIntShrinker is a shrinker for *big.Int numbers.
+// A number shrinks to 0, its absolute value, the smallest number with the
+// same bit length and then towards 0 like Int64Shrinker.
+func BigIntShrinker(v interface{}) gopter.Shrink {
+	value := v.(*big.Int)
+	if value.Sign() == 0 {
+		return gopter.NoShrink
+	}
+	abs := new(big.Int).Abs(value)
+	candidates := []interface{}{new(big.Int)}
+	if value.Sign() < 0 {
+		candidates = append(candidates, abs)
+	}
+	if lowest := new(big.Int).Lsh(big.NewInt(1), uint(abs.BitLen()-1)); lowest.Cmp(abs) != 0 {
+		if value.Sign() < 0 {
+			lowest.Neg(lowest)
+		}
+		candidates = append(candidates, lowest)
+	}
+	half := new(big.Int).Quo(value, big.NewInt(2))
+	return gopter.ConcatShrinks(valuesShrink(candidates), func() (interface{}, bool) {
+		if half.Sign() == 0 {
+			return nil, false
+		}
+		shrunk := new(big.Int).Sub(value, half)
+		half.Quo(half, big.NewInt(2))
+		return shrunk, true
+	})
+}
+
+// BigRatShrinker is a shrinker for *big.Rat numbers.
+// A number shrinks to 0, its absolute value, its integer part and then by
+// shrinking its numerator and denominator.
+func BigRatShrinker(v interface{}) gopter.Shrink {
+	value := v.(*big.Rat)
+	if value.Sign() == 0 {
+		return gopter.NoShrink
+	}
+	candidates := []interface{}{new(big.Rat)}
+	if value.Sign() < 0 {
+		candidates = append(candidates, new(big.Rat).Abs(value))
+	}
+	if !value.IsInt() {
+		if integer := new(big.Int).Quo(value.Num(), value.Denom()); integer.Sign() != 0 {
+			candidates = append(candidates, new(big.Rat).SetInt(integer))
+		}
+	}
+	num, denom := new(big.Int).Set(value.Num()), new(big.Int).Set(value.Denom())
+	numShrink := BigIntShrinker(num).Filter(func(v interface{}) bool {
+		return v.(*big.Int).Sign() != 0
+	}).Map(func(n *big.Int) *big.Rat {
+		return new(big.Rat).SetFrac(n, denom)
+	})
+	denomShrink := BigIntShrinker(denom).Filter(func(v interface{}) bool {
+		return v.(*big.Int).Sign() > 0
+	}).Map(func(d *big.Int) *big.Rat {
+		return new(big.Rat).SetFrac(num, d)
+	})
+	return gopter.ConcatShrinks(valuesShrink(candidates), numShrink.Interleave(denomShrink))
+}
+
+// BigFloatShrinker is a shrinker for *big.Float numbers (keeping their
+// precision).
+// A number shrinks to 0, its absolute value, its integer part, its mantissa
+// rounded to fewer bits and then by moving its exponent towards 0.
+func BigFloatShrinker(v interface{}) gopter.Shrink {
+	value := v.(*big.Float)
+	if value.Sign() == 0 || value.IsInf() {
+		return gopter.NoShrink
+	}
+	prec := value.Prec()
+	candidates := []interface{}{new(big.Float).SetPrec(prec)}
+	if value.Sign() < 0 {
+		candidates = append(candidates, new(big.Float).Abs(value))
+	}
+	if !value.IsInt() {
+		if integer, _ := value.Int(nil); integer.Sign() != 0 {
+			candidates = append(candidates, new(big.Float).SetPrec(prec).SetInt(integer))
+		}
+	}
+	for bits := uint(1); bits < value.MinPrec(); bits *= 2 {
+		rounded := new(big.Float).SetPrec(bits).SetMode(big.ToZero).Set(value)
+		candidates = append(candidates, rounded.SetPrec(prec).SetMode(value.Mode()))
+	}
+	exp := value.MantExp(nil)
+	for half := exp / 2; half != 0; half /= 2 {
+		candidates = append(candidates, new(big.Float).SetPrec(prec).SetMantExp(value, -half))
+	}
+	return valuesShrink(candidates)
+}
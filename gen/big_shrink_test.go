@@ -0,0 +1,59 @@
+package gen_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestBigIntShrinker(t *testing.T) {
+	if shrinks := gen.BigIntShrinker(new(big.Int)).All(); len(shrinks) != 0 {
+		t.Errorf("Invalid zero shrinks: %v", shrinks)
+	}
+	shrinks := gen.BigIntShrinker(big.NewInt(-10)).All()
+	expected := []string{"0", "10", "-8", "-5", "-8", "-9"}
+	if len(shrinks) != len(expected) {
+		t.Fatalf("Invalid shrinks: %v", shrinks)
+	}
+	for i, shrink := range shrinks {
+		if shrink.(*big.Int).String() != expected[i] {
+			t.Errorf("Invalid shrink %d: %v", i, shrinks)
+		}
+	}
+}
+
+func TestBigRatShrinker(t *testing.T) {
+	if shrinks := gen.BigRatShrinker(new(big.Rat)).All(); len(shrinks) != 0 {
+		t.Errorf("Invalid zero shrinks: %v", shrinks)
+	}
+	shrinks := gen.BigRatShrinker(big.NewRat(-7, 3)).All()
+	if len(shrinks) < 3 || shrinks[0].(*big.Rat).String() != "0/1" || shrinks[1].(*big.Rat).String() != "7/3" ||
+		shrinks[2].(*big.Rat).String() != "-2/1" {
+		t.Errorf("Invalid shrinks: %v", shrinks)
+	}
+	for _, shrink := range shrinks[3:] {
+		r := shrink.(*big.Rat)
+		if r.Sign() == 0 || r.Num().CmpAbs(big.NewInt(7)) > 0 || r.Denom().Cmp(big.NewInt(3)) > 0 {
+			t.Errorf("Invalid shrink: %v", r)
+		}
+	}
+}
+
+func TestBigFloatShrinker(t *testing.T) {
+	if shrinks := gen.BigFloatShrinker(new(big.Float)).All(); len(shrinks) != 0 {
+		t.Errorf("Invalid zero shrinks: %v", shrinks)
+	}
+	value := new(big.Float).SetPrec(100).SetFloat64(-1234.5678)
+	shrinks := gen.BigFloatShrinker(value).All()
+	expected := []string{"0", "1234.568", "-1234", "-1024", "-1024", "-1152", "-1232", "-1234.562", "-1234.568",
+		"-38.58024", "-308.642", "-617.2839"}
+	if len(shrinks) != len(expected) {
+		t.Fatalf("Invalid shrinks: %v", shrinks)
+	}
+	for i, shrink := range shrinks {
+		if shrink.(*big.Float).Prec() != 100 || shrink.(*big.Float).Text('g', 7) != expected[i] {
+			t.Errorf("Invalid shrink %d: %v", i, shrink.(*big.Float).Text('g', 10))
+		}
+	}
+}
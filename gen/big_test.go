@@ -0,0 +1,82 @@
+package gen_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestBigInt(t *testing.T) {
+	commonGeneratorTest(t, "big int", gen.BigInt(0, 300), func(value interface{}) bool {
+		v, ok := value.(*big.Int)
+		return ok && v.BitLen() <= 300
+	})
+	commonGeneratorTest(t, "big int 256 bit", gen.BigInt(256, 256), func(value interface{}) bool {
+		v, ok := value.(*big.Int)
+		return ok && v.BitLen() == 256
+	})
+	commonGeneratorTest(t, "big nat", gen.BigNat(10, 70), func(value interface{}) bool {
+		v, ok := value.(*big.Int)
+		return ok && v.Sign() > 0 && v.BitLen() >= 10 && v.BitLen() <= 70
+	})
+
+	fail := gen.BigInt(10, 9)
+	if value, ok := fail.Sample(); ok {
+		t.Errorf("Invalid bit range generated: %#v", value)
+	}
+}
+
+func TestBigIntRange(t *testing.T) {
+	min, _ := new(big.Int).SetString("-100000000000000000000000", 10)
+	max, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	commonGeneratorTest(t, "big int range", gen.BigIntRange(min, max), func(value interface{}) bool {
+		v, ok := value.(*big.Int)
+		return ok && v.Cmp(min) >= 0 && v.Cmp(max) <= 0
+	})
+	commonGeneratorTest(t, "big int single value", gen.BigIntRange(max, max), func(value interface{}) bool {
+		v, ok := value.(*big.Int)
+		return ok && v.Cmp(max) == 0
+	})
+
+	fail := gen.BigIntRange(max, min)
+	if value, ok := fail.Sample(); ok {
+		t.Errorf("Invalid range generated: %#v", value)
+	}
+}
+
+func TestBigRat(t *testing.T) {
+	commonGeneratorTest(t, "big rat", gen.BigRat(100), func(value interface{}) bool {
+		v, ok := value.(*big.Rat)
+		return ok && v.Num().BitLen() <= 100 && v.Denom().Sign() > 0 && v.Denom().BitLen() <= 100
+	})
+}
+
+func TestBigFloat(t *testing.T) {
+	commonGeneratorTest(t, "big float", gen.BigFloat(1, 200), func(value interface{}) bool {
+		v, ok := value.(*big.Float)
+		if !ok || v.IsInf() || v.Prec() < 1 || v.Prec() > 200 {
+			return false
+		}
+		exp := v.MantExp(nil)
+		return v.MinPrec() <= v.Prec() && exp >= -200 && exp <= 200
+	})
+
+	fail := gen.BigFloat(0, 10)
+	if value, ok := fail.Sample(); ok {
+		t.Errorf("Invalid precision generated: %#v", value)
+	}
+}
+
+func TestBigIntShrinks(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	threshold := new(big.Int).Lsh(big.NewInt(1), 100)
+	result := prop.ForAll(func(v *big.Int) bool {
+		return v.Cmp(threshold) < 0
+	}, gen.BigNat(0, 256)).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg.(*big.Int).Cmp(threshold) != 0 {
+		t.Errorf("Invalid result: %v", result.Args[0])
+	}
+}
@@ -2,9 +2,29 @@ package gen
 
 import "github.com/leanovate/gopter"
 
+var boolDomain = func() []interface{} {
+	return []interface{}{false, true}
+}
+
 // Bool generates an arbitrary bool value
 func Bool() gopter.Gen {
-	return func(genParams *gopter.GenParameters) *gopter.GenResult {
-		return gopter.NewGenResult(genParams.NextBool(), gopter.NoShrinker)
+	return gopter.NewBatchGen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+		genResult := gopter.NewGenResult(genParams.NextBool(), gopter.NoShrinker)
+		genResult.Domain = boolDomain
+		return genResult
+	}, batchBools)
+}
+
+func batchBools(genParams *gopter.GenParameters, buf interface{}, n int) interface{} {
+	if buf == nil {
+		buf = make([]bool, 0, n)
+	}
+	values, ok := buf.([]bool)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		values = append(values, genParams.NextBool())
 	}
+	return values
 }
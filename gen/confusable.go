@@ -0,0 +1,155 @@
+package gen
+
+import (
+	"github.com/leanovate/gopter"
+)
+
+// confusables maps ASCII characters to visually similar (homoglyph) runes.
+// This is a selection of the Unicode confusables (see Unicode TR #39) that
+// are most commonly abused.
+var confusables = map[rune][]rune{
+	'a': {'а', 'ɑ', 'α', 'ａ'},
+	'c': {'с', 'ϲ', 'ｃ'},
+	'd': {'ԁ', 'ｄ'},
+	'e': {'е', 'ｅ'},
+	'g': {'ɡ', 'ｇ'},
+	'h': {'һ', 'ｈ'},
+	'i': {'і', 'ɩ', 'ｉ'},
+	'j': {'ј', 'ｊ'},
+	'k': {'ｋ'},
+	'l': {'ӏ', 'ｌ', 'I', '1'},
+	'm': {'ｍ'},
+	'n': {'ո', 'ｎ'},
+	'o': {'о', 'ο', 'օ', 'ｏ', '0'},
+	'p': {'р', 'ρ', 'ｐ'},
+	'q': {'ԛ', 'ｑ'},
+	'r': {'г', 'ｒ'},
+	's': {'ѕ', 'ｓ'},
+	'u': {'ս', 'ｕ'},
+	'v': {'ν', 'ѵ', 'ｖ'},
+	'w': {'ԝ', 'ｗ'},
+	'x': {'х', 'ｘ'},
+	'y': {'у', 'ｙ'},
+	'z': {'ｚ'},
+	'A': {'А', 'Α', 'Ａ'},
+	'B': {'В', 'Β', 'Ｂ'},
+	'C': {'С', 'Ϲ', 'Ｃ'},
+	'E': {'Е', 'Ε', 'Ｅ'},
+	'H': {'Н', 'Η', 'Ｈ'},
+	'I': {'І', 'Ι', 'l', '1'},
+	'J': {'Ј', 'Ｊ'},
+	'K': {'К', 'Κ', 'Ｋ'},
+	'M': {'М', 'Μ', 'Ｍ'},
+	'N': {'Ν', 'Ｎ'},
+	'O': {'О', 'Ο', 'Ｏ', '0'},
+	'P': {'Р', 'Ρ', 'Ｐ'},
+	'S': {'Ѕ', 'Ｓ'},
+	'T': {'Т', 'Τ', 'Ｔ'},
+	'X': {'Х', 'Χ', 'Ｘ'},
+	'Y': {'Ү', 'Υ', 'Ｙ'},
+	'Z': {'Ζ', 'Ｚ'},
+	'0': {'O', 'o', 'О', 'о'},
+	'1': {'l', 'I', 'І', 'ӏ'},
+	'-': {'‐', '‑', '−'},
+	'.': {'․', '。'},
+	'/': {'∕', '⁄'},
+}
+
+// ConfusableOf generates visually similar variants of a given string by
+// replacing characters with homoglyphs from the Unicode confusables (e.g. a
+// latin "a" with a cyrillic "а").
+// Each generated variant differs from str in at least one character, unless str
+// does not contain any character with a known homoglyph.
+// This is useful to test normalization, username uniqueness or phishing
+// detection logic.
+// Variants are shrunk by reverting single replacements.
+func ConfusableOf(str string) gopter.Gen {
+	original := []rune(str)
+	positions := make([]int, 0, len(original))
+	for i, ch := range original {
+		if _, ok := confusables[ch]; ok {
+			positions = append(positions, i)
+		}
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		variant := make([]rune, len(original))
+		copy(variant, original)
+		replaced := 0
+		for _, pos := range positions {
+			if genParams.NextBool() {
+				variant[pos] = randomConfusable(original[pos], genParams)
+				replaced++
+			}
+		}
+		if replaced == 0 && len(positions) > 0 {
+			pos := positions[genParams.Rng.Intn(len(positions))]
+			variant[pos] = randomConfusable(original[pos], genParams)
+		}
+		genResult := gopter.NewGenResult(string(variant), confusableShrinker(original))
+		genResult.Sieve = func(v interface{}) bool {
+			variant := v.(string)
+			return (variant != str || len(positions) == 0) && isConfusableOf([]rune(variant), original)
+		}
+		return genResult
+	}
+}
+
+func randomConfusable(ch rune, genParams *gopter.GenParameters) rune {
+	candidates := confusables[ch]
+	return candidates[genParams.Rng.Intn(len(candidates))]
+}
+
+func isConfusableOf(variant, original []rune) bool {
+	if len(variant) != len(original) {
+		return false
+	}
+	for i, ch := range variant {
+		if ch == original[i] {
+			continue
+		}
+		found := false
+		for _, candidate := range confusables[original[i]] {
+			if candidate == ch {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type confusableShrink struct {
+	original []rune
+	variant  []rune
+	pos      int
+}
+
+func (s *confusableShrink) Next() (interface{}, bool) {
+	for ; s.pos < len(s.variant); s.pos++ {
+		if s.variant[s.pos] != s.original[s.pos] {
+			shrunk := make([]rune, len(s.variant))
+			copy(shrunk, s.variant)
+			shrunk[s.pos] = s.original[s.pos]
+			s.pos++
+			return string(shrunk), true
+		}
+	}
+	return nil, false
+}
+
+func confusableShrinker(original []rune) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		variant := []rune(v.(string))
+		if len(variant) != len(original) {
+			return gopter.NoShrink
+		}
+		shrink := &confusableShrink{
+			original: original,
+			variant:  variant,
+		}
+		return shrink.Next
+	}
+}
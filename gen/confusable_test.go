@@ -0,0 +1,39 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestConfusableOf(t *testing.T) {
+	commonGeneratorTest(t, "confusable of paypal.com", gen.ConfusableOf("paypal.com"), func(v interface{}) bool {
+		variant, ok := v.(string)
+		return ok && variant != "paypal.com" && len([]rune(variant)) == len([]rune("paypal.com"))
+	})
+
+	value, ok := gen.ConfusableOf("§$%").Sample()
+	if !ok || value.(string) != "§$%" {
+		t.Errorf("Invalid value: %#v", value)
+	}
+}
+
+func TestConfusableShrink(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(
+		func(variant string) bool {
+			return []rune(variant)[0] == 'a'
+		},
+		gen.ConfusableOf("admin"),
+	).Check(parameters)
+
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	shrunk := []rune(result.Args[0].Arg.(string))
+	if shrunk[0] == 'a' || string(shrunk[1:]) != "dmin" {
+		t.Errorf("Invalid shrunk value: %#v", string(shrunk))
+	}
+}
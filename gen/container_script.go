@@ -0,0 +1,150 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// Operations of a ContainerScript
+const (
+	OpPush    = "push"
+	OpPop     = "pop"
+	OpPeek    = "peek"
+	OpEnqueue = "enqueue"
+	OpDequeue = "dequeue"
+)
+
+// ContainerOp is a single operation of a ContainerScript with its expected
+// outcome according to the reference model
+type ContainerOp struct {
+	Op string
+	// Value to push/enqueue
+	Value interface{}
+	// Expected result of pop/peek/dequeue
+	Expected interface{}
+	// ExpectedOk is false if pop/peek/dequeue is expected to fail because the
+	// container is empty
+	ExpectedOk bool
+	// ExpectedLen is the expected length of the container after the operation
+	ExpectedLen int
+}
+
+func (o ContainerOp) String() string {
+	switch o.Op {
+	case OpPush, OpEnqueue:
+		return fmt.Sprintf("%s(%v)", o.Op, o.Value)
+	}
+	if !o.ExpectedOk {
+		return fmt.Sprintf("%s() -> empty", o.Op)
+	}
+	return fmt.Sprintf("%s() -> %v", o.Op, o.Expected)
+}
+
+// ContainerScript is a sequence of operations on a stack or a queue
+type ContainerScript struct {
+	// Kind is either "stack" or "queue"
+	Kind string
+	Ops  []ContainerOp
+}
+
+// Verify runs the script against an implementation.
+// "apply" has to execute an operation on the implementation and return the
+// result of pop/peek/dequeue (ok == false if the container is empty) as well
+// as its length after the operation.
+// The first deviation from the reference model is returned as error.
+func (s ContainerScript) Verify(apply func(op ContainerOp) (result interface{}, ok bool, length int)) error {
+	for i, op := range s.Ops {
+		result, ok, length := apply(op)
+		switch {
+		case (op.Op != OpPush && op.Op != OpEnqueue) && ok != op.ExpectedOk:
+			return fmt.Errorf("Step %d: %v returned ok=%v", i, op, ok)
+		case op.ExpectedOk && !reflect.DeepEqual(result, op.Expected):
+			return fmt.Errorf("Step %d: %v returned %v", i, op, result)
+		case length != op.ExpectedLen:
+			return fmt.Errorf("Step %d: %v resulted in length %d, expected %d", i, op, length, op.ExpectedLen)
+		}
+	}
+	return nil
+}
+
+// StackScript generates scripts of push/pop/peek operations with the values
+// generated by "elementGen". The expected outcome of each operation is
+// determined by executing a reference stack during generation.
+// The scripts shrink by removing operations (the expected outcomes are
+// recalculated).
+func StackScript(elementGen gopter.Gen) gopter.Gen {
+	return containerScript("stack", []string{OpPush, OpPop, OpPeek}, elementGen)
+}
+
+// QueueScript generates scripts of enqueue/dequeue/peek operations with the
+// values generated by "elementGen". The expected outcome of each operation is
+// determined by executing a reference queue during generation.
+// The scripts shrink by removing operations (the expected outcomes are
+// recalculated).
+func QueueScript(elementGen gopter.Gen) gopter.Gen {
+	return containerScript("queue", []string{OpEnqueue, OpDequeue, OpPeek}, elementGen)
+}
+
+func containerScript(kind string, ops []string, elementGen gopter.Gen) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		length := 0
+		if genParams.MaxSize > genParams.MinSize {
+			length = genParams.Rng.Intn(genParams.MaxSize-genParams.MinSize) + genParams.MinSize
+		} else {
+			length = genParams.MaxSize
+		}
+		script := ContainerScript{Kind: kind, Ops: make([]ContainerOp, 0, length)}
+		for i := 0; i < length; i++ {
+			op := ContainerOp{Op: ops[genParams.Rng.Intn(len(ops))]}
+			if op.Op == OpPush || op.Op == OpEnqueue {
+				value, ok := elementGen(genParams).Retrieve()
+				if !ok {
+					return gopter.NewEmptyResult(reflect.TypeOf(ContainerScript{}))
+				}
+				op.Value = value
+			}
+			script.Ops = append(script.Ops, op)
+		}
+		return gopter.NewGenResult(script.withExpectations(), ContainerScriptShrinker)
+	}
+}
+
+// withExpectations executes the reference model to set the expected outcome
+// of all operations
+func (s ContainerScript) withExpectations() ContainerScript {
+	result := ContainerScript{Kind: s.Kind, Ops: make([]ContainerOp, len(s.Ops))}
+	model := make([]interface{}, 0)
+	for i, op := range s.Ops {
+		op.Expected, op.ExpectedOk = nil, false
+		switch op.Op {
+		case OpPush, OpEnqueue:
+			model = append(model, op.Value)
+			op.ExpectedOk = true
+		case OpPop, OpPeek, OpDequeue:
+			if len(model) == 0 {
+				break
+			}
+			idx := 0
+			if s.Kind == "stack" {
+				idx = len(model) - 1
+			}
+			op.Expected, op.ExpectedOk = model[idx], true
+			if op.Op == OpPop || op.Op == OpDequeue {
+				model = append(model[:idx:idx], model[idx+1:]...)
+			}
+		}
+		op.ExpectedLen = len(model)
+		result.Ops[i] = op
+	}
+	return result
+}
+
+// ContainerScriptShrinker shrinks a ContainerScript by removing operations
+func ContainerScriptShrinker(v interface{}) gopter.Shrink {
+	script := v.(ContainerScript)
+	return SliceShrinker(gopter.NoShrinker)(script.Ops).Map(func(ops []ContainerOp) ContainerScript {
+		return ContainerScript{Kind: script.Kind, Ops: ops}.withExpectations()
+	})
+}
@@ -0,0 +1,76 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+type sliceContainer struct {
+	items []interface{}
+	fifo  bool
+}
+
+func (c *sliceContainer) apply(op gen.ContainerOp) (interface{}, bool, int) {
+	switch op.Op {
+	case gen.OpPush, gen.OpEnqueue:
+		c.items = append(c.items, op.Value)
+		return nil, true, len(c.items)
+	}
+	if len(c.items) == 0 {
+		return nil, false, 0
+	}
+	idx := len(c.items) - 1
+	if c.fifo {
+		idx = 0
+	}
+	value := c.items[idx]
+	if op.Op != gen.OpPeek {
+		c.items = append(c.items[:idx:idx], c.items[idx+1:]...)
+	}
+	return value, true, len(c.items)
+}
+
+func TestContainerScripts(t *testing.T) {
+	commonGeneratorTest(t, "stack script", gen.StackScript(gen.Int()), func(value interface{}) bool {
+		script, ok := value.(gen.ContainerScript)
+		return ok && script.Kind == "stack" && script.Verify((&sliceContainer{}).apply) == nil
+	})
+	commonGeneratorTest(t, "queue script", gen.QueueScript(gen.Int()), func(value interface{}) bool {
+		script, ok := value.(gen.ContainerScript)
+		return ok && script.Kind == "queue" && script.Verify((&sliceContainer{fifo: true}).apply) == nil
+	})
+
+}
+
+func TestContainerScriptShrink(t *testing.T) {
+	result := prop.ForAll(
+		func(script gen.ContainerScript) bool {
+			// a stack that ignores pushes when it has more than one element
+			items := make([]interface{}, 0)
+			return script.Verify(func(op gen.ContainerOp) (interface{}, bool, int) {
+				if op.Op == gen.OpPush {
+					if len(items) < 2 {
+						items = append(items, op.Value)
+					}
+					return nil, true, len(items)
+				}
+				c := &sliceContainer{items: items}
+				value, ok, length := c.apply(op)
+				items = c.items
+				return value, ok, length
+			}) == nil
+		},
+		gen.StackScript(gen.IntRange(0, 10)),
+	).Check(gopter.DefaultTestParameters())
+
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	script := result.Args[0].Arg.(gen.ContainerScript)
+	if len(script.Ops) != 3 {
+		t.Errorf("Script is not minimal: %v", script.Ops)
+	}
+}
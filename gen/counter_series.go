@@ -0,0 +1,138 @@
+package gen
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/leanovate/gopter"
+)
+
+// Events of a generated CounterObservation
+const (
+	CounterWrap  = "wrap"
+	CounterReset = "reset"
+	CounterSkip  = "skip"
+)
+
+// CounterObservation is an observed value of a monotone counter
+type CounterObservation struct {
+	// Time of the observation (relative to the first observation)
+	Time time.Duration
+	// Value is the observed value of the counter
+	Value uint64
+	// Increase is the actual increase of the counter since the previous
+	// observation (0 for the first)
+	Increase uint64
+	// Event is CounterWrap, CounterReset or CounterSkip if the counter wrapped
+	// around, was reset to zero or an observation was skipped since the
+	// previous observation (empty otherwise)
+	Event string
+}
+
+// CounterSeries is a generated sequence of observations of a monotone counter
+type CounterSeries struct {
+	// Bits is the width of the counter, i.e. it wraps at 2^Bits
+	Bits uint
+	// Interval is the regular interval of the observations
+	Interval     time.Duration
+	Observations []CounterObservation
+}
+
+// TotalIncrease is the sum of the actual increases of all observations
+func (s CounterSeries) TotalIncrease() uint64 {
+	var total uint64
+	for _, observation := range s.Observations {
+		total += observation.Increase
+	}
+	return total
+}
+
+// MonotoneCounter generates observation sequences (CounterSeries) of a
+// monotone counter with a width of bits (1 to 64) that occasionally wraps
+// around (starting close to its maximum), is reset to zero (e.g. by a process
+// restart) or misses observations, so that rate and delta computations can be
+// checked against the actual increases.
+// The events are added as labels.
+// Series shrink to their prefixes.
+func MonotoneCounter(bits uint) gopter.Gen {
+	if bits < 1 || bits > 64 {
+		return Fail(reflect.TypeOf(CounterSeries{}))
+	}
+	maxValue := ^uint64(0) >> (64 - bits)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		series := CounterSeries{
+			Bits:     bits,
+			Interval: []time.Duration{time.Second, 15 * time.Second, time.Minute}[genParams.Rng.Intn(3)],
+		}
+		value := genParams.NextUint64() & maxValue
+		if genParams.NextBool() {
+			// close to the maximum to provoke wraps
+			offset := genParams.NextUint64() % 1000
+			if offset > maxValue {
+				offset = maxValue
+			}
+			value = maxValue - offset
+		}
+		series.Observations = append(series.Observations, CounterObservation{Value: value})
+		labels := map[string]bool{}
+
+		count := 1 + genParams.Rng.Intn(genParams.MaxSize+1)
+		now := time.Duration(0)
+		for i := 1; i < count; i++ {
+			observation := CounterObservation{Increase: uint64(genParams.Rng.Intn(1000))}
+			if genParams.Rng.Intn(4) == 0 {
+				observation.Increase = 0
+			}
+			if observation.Increase > maxValue {
+				observation.Increase = maxValue
+			}
+			now += series.Interval
+			switch genParams.Rng.Intn(10) {
+			case 0:
+				observation.Event = CounterSkip
+				skipped := 1 + genParams.Rng.Intn(5)
+				now += time.Duration(skipped) * series.Interval
+			case 1:
+				observation.Event = CounterReset
+				value = 0
+			}
+			if maxValue-value < observation.Increase {
+				// a wrap takes precedence over a skip (a reset cannot wrap)
+				observation.Event = CounterWrap
+			}
+			value = (value + observation.Increase) & maxValue
+			observation.Value = value
+			observation.Time = now
+			if observation.Event != "" {
+				labels[observation.Event] = true
+			}
+			series.Observations = append(series.Observations, observation)
+		}
+
+		genResult := gopter.NewGenResult(series, counterSeriesShrinker)
+		genResult.Labels = sortedFeatures(labels)
+		return genResult
+	}
+}
+
+// counterSeriesShrinker shrinks to the prefixes with a length of 1, 2, 4 ...
+// and to the series without its last observation
+func counterSeriesShrinker(v interface{}) gopter.Shrink {
+	series := v.(CounterSeries)
+	var lengths []int
+	for length := 1; length < len(series.Observations)-1; length *= 2 {
+		lengths = append(lengths, length)
+	}
+	if len(series.Observations) > 1 {
+		lengths = append(lengths, len(series.Observations)-1)
+	}
+	return func() (interface{}, bool) {
+		if len(lengths) == 0 {
+			return nil, false
+		}
+		shrunk := series
+		shrunk.Observations = series.Observations[:lengths[0]]
+		lengths = lengths[1:]
+		return shrunk, true
+	}
+}
@@ -0,0 +1,65 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+// counterDelta is the increase between two observations assuming the
+// counter wrapped if it decreased close to its maximum (reset otherwise)
+func counterDelta(bits uint, previous, current uint64) uint64 {
+	if current >= previous {
+		return current - previous
+	}
+	maxValue := ^uint64(0) >> (64 - bits)
+	if wrapped := maxValue - previous + current + 1; wrapped < 1000 {
+		return wrapped
+	}
+	return current
+}
+
+func TestMonotoneCounter(t *testing.T) {
+	for _, bits := range []uint{8, 32, 64} {
+		events := map[string]bool{}
+		commonGeneratorTest(t, "monotone counter", gen.MonotoneCounter(bits), func(value interface{}) bool {
+			series, ok := value.(gen.CounterSeries)
+			if !ok || series.Bits != bits || len(series.Observations) == 0 || series.Observations[0].Increase != 0 {
+				return false
+			}
+			var total uint64
+			for i := 1; i < len(series.Observations); i++ {
+				previous, current := series.Observations[i-1], series.Observations[i]
+				events[current.Event] = true
+				if current.Time <= previous.Time || current.Value > ^uint64(0)>>(64-bits) {
+					return false
+				}
+				if current.Event == gen.CounterSkip && current.Time-previous.Time <= series.Interval {
+					return false
+				}
+				if current.Event != gen.CounterReset && counterDelta(bits, previous.Value, current.Value) != current.Increase {
+					t.Errorf("Invalid increase at %d: %#v %#v", i, previous, current)
+					return false
+				}
+				total += current.Increase
+			}
+			return total == series.TotalIncrease()
+		})
+		if bits < 64 && (!events[gen.CounterWrap] || !events[gen.CounterReset] || !events[gen.CounterSkip]) {
+			t.Errorf("Not all events generated for %d bits: %v", bits, events)
+		}
+	}
+
+	series := gen.CounterSeries{Bits: 8, Observations: make([]gen.CounterObservation, 10)}
+	var lengths []int
+	for _, shrunk := range gen.MonotoneCounter(8)(gopter.DefaultGenParameters()).Shrinker(series).All() {
+		lengths = append(lengths, len(shrunk.(gen.CounterSeries).Observations))
+	}
+	if len(lengths) != 5 || lengths[0] != 1 || lengths[3] != 8 || lengths[4] != 9 {
+		t.Errorf("Invalid shrinks: %v", lengths)
+	}
+	if value, ok := gen.MonotoneCounter(65).Sample(); ok {
+		t.Errorf("Invalid value for 65 bits: %#v", value)
+	}
+}
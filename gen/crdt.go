@@ -0,0 +1,263 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// Kinds of a CRDTOp
+const (
+	CRDTIncrement = "increment"
+	CRDTAdd       = "add"
+	CRDTRemove    = "remove"
+)
+
+// CRDTOp is an operation on a CRDT-like structure issued by one replica
+type CRDTOp struct {
+	// ID is the unique id of the operation
+	ID int
+	// Origin is the replica that issued the operation
+	Origin int
+	// Kind is one of CRDTIncrement, CRDTAdd or CRDTRemove
+	Kind string
+	// Amount of a CRDTIncrement
+	Amount uint64
+	// Element of a CRDTAdd or CRDTRemove
+	Element interface{}
+	// Tag is the unique tag of a CRDTAdd
+	Tag string
+	// RemovedTags are the tags of the adds observed by a CRDTRemove
+	RemovedTags []string
+}
+
+func (o CRDTOp) String() string {
+	switch o.Kind {
+	case CRDTIncrement:
+		return fmt.Sprintf("#%d@%d increment(%d)", o.ID, o.Origin, o.Amount)
+	case CRDTAdd:
+		return fmt.Sprintf("#%d@%d add(%v, %s)", o.ID, o.Origin, o.Element, o.Tag)
+	}
+	return fmt.Sprintf("#%d@%d remove(%v, %v)", o.ID, o.Origin, o.Element, o.RemovedTags)
+}
+
+// CRDTStream is a set of operations together with the order they are
+// delivered to each replica
+type CRDTStream struct {
+	Replicas int
+	// Ops are all operations in the order they have been issued
+	Ops []CRDTOp
+	// Deliveries contains the operations in the order they are delivered to
+	// each replica. Every replica receives every operation (including its
+	// own) at least once, some are delivered multiple times.
+	Deliveries [][]CRDTOp
+}
+
+// ExpectedCount is the value of a G-Counter after all increments have been
+// applied
+func (s *CRDTStream) ExpectedCount() uint64 {
+	var count uint64
+	for _, op := range s.Ops {
+		if op.Kind == CRDTIncrement {
+			count += op.Amount
+		}
+	}
+	return count
+}
+
+// ExpectedElements are the elements of an OR-Set after all operations have
+// been applied, i.e. all elements with at least one tag that has not been
+// removed (add wins)
+func (s *CRDTStream) ExpectedElements() map[interface{}]bool {
+	removed := map[string]bool{}
+	for _, op := range s.Ops {
+		if op.Kind == CRDTRemove {
+			for _, tag := range op.RemovedTags {
+				removed[tag] = true
+			}
+		}
+	}
+	elements := map[interface{}]bool{}
+	for _, op := range s.Ops {
+		if op.Kind == CRDTAdd && !removed[op.Tag] {
+			elements[op.Element] = true
+		}
+	}
+	return elements
+}
+
+// GCounterOps generates streams of increments of a grow-only counter issued by
+// a number of replicas and delivered to all replicas in arbitrary orders with
+// duplicates. After all deliveries all replicas are expected to converge to
+// ExpectedCount.
+func GCounterOps(replicas int) gopter.Gen {
+	if replicas < 1 {
+		return Fail(reflect.TypeOf(&CRDTStream{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		stream := &CRDTStream{Replicas: replicas}
+		count := genCRDTOpCount(genParams)
+		for i := 0; i < count; i++ {
+			stream.Ops = append(stream.Ops, CRDTOp{
+				ID:     i,
+				Origin: genParams.Rng.Intn(replicas),
+				Kind:   CRDTIncrement,
+				Amount: uint64(genParams.Rng.Intn(100)),
+			})
+		}
+		return newCRDTStreamResult(genParams, stream, "G-Counter")
+	}
+}
+
+// ORSetOps generates streams of adds and removes of an observed-remove set
+// issued by a number of replicas and delivered to all replicas in arbitrary
+// orders with duplicates. Each add has a unique tag, each remove carries the
+// tags of the adds of its element issued before (adds issued concurrently on
+// other replicas may be missing). Note that removes might be delivered before
+// the adds they remove.
+// After all deliveries all replicas are expected to converge to
+// ExpectedElements. The elements generated by elementGen have to be comparable.
+func ORSetOps(replicas int, elementGen gopter.Gen) gopter.Gen {
+	if replicas < 1 {
+		return Fail(reflect.TypeOf(&CRDTStream{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		stream := &CRDTStream{Replicas: replicas}
+		var adds []CRDTOp
+		count := genCRDTOpCount(genParams)
+		for i := 0; i < count; i++ {
+			op := CRDTOp{
+				ID:     i,
+				Origin: genParams.Rng.Intn(replicas),
+			}
+			if len(adds) > 0 && genParams.Rng.Intn(3) == 0 {
+				op.Kind = CRDTRemove
+				op.Element = adds[genParams.Rng.Intn(len(adds))].Element
+				for _, add := range adds {
+					// concurrent adds of other replicas might not have been observed
+					if add.Element == op.Element && (add.Origin == op.Origin || genParams.NextBool()) {
+						op.RemovedTags = append(op.RemovedTags, add.Tag)
+					}
+				}
+			} else {
+				element, ok := elementGen(genParams).Retrieve()
+				if !ok {
+					return gopter.NewEmptyResult(reflect.TypeOf(&CRDTStream{}))
+				}
+				op.Kind = CRDTAdd
+				op.Element = element
+				op.Tag = fmt.Sprintf("%d.%d", op.Origin, op.ID)
+				adds = append(adds, op)
+			}
+			stream.Ops = append(stream.Ops, op)
+		}
+		return newCRDTStreamResult(genParams, stream, "OR-Set")
+	}
+}
+
+func genCRDTOpCount(genParams *gopter.GenParameters) int {
+	if genParams.MaxSize <= 0 {
+		return 0
+	}
+	return genParams.Rng.Intn(genParams.MaxSize + 1)
+}
+
+func newCRDTStreamResult(genParams *gopter.GenParameters, stream *CRDTStream, label string) *gopter.GenResult {
+	duplicates := false
+	stream.Deliveries = make([][]CRDTOp, stream.Replicas)
+	for replica := range stream.Deliveries {
+		delivery := make([]CRDTOp, 0, len(stream.Ops))
+		for _, idx := range genParams.Rng.Perm(len(stream.Ops)) {
+			delivery = append(delivery, stream.Ops[idx])
+		}
+		for len(stream.Ops) > 0 && genParams.Rng.Intn(3) == 0 {
+			duplicate := delivery[genParams.Rng.Intn(len(delivery))]
+			pos := genParams.Rng.Intn(len(delivery) + 1)
+			delivery = append(delivery[:pos], append([]CRDTOp{duplicate}, delivery[pos:]...)...)
+			duplicates = true
+		}
+		stream.Deliveries[replica] = delivery
+	}
+	genResult := gopter.NewGenResult(stream, CRDTStreamShrinker)
+	genResult.Labels = []string{label}
+	if duplicates {
+		genResult.Labels = append(genResult.Labels, "duplicates")
+	}
+	return genResult
+}
+
+// CRDTStreamShrinker shrinks a CRDTStream by removing duplicate deliveries and
+// operations (removing the tags of removed adds from the removes)
+func CRDTStreamShrinker(v interface{}) gopter.Shrink {
+	stream := v.(*CRDTStream)
+	dropDuplicates := gopter.NoShrink
+	if crdtHasDuplicates(stream) {
+		done := false
+		dropDuplicates = func() (interface{}, bool) {
+			if done {
+				return nil, false
+			}
+			done = true
+			return crdtWithOps(stream, stream.Ops, true), true
+		}
+	}
+	return gopter.ConcatShrinks(
+		dropDuplicates,
+		SliceShrinker(gopter.NoShrinker)(stream.Ops).Map(func(ops []CRDTOp) *CRDTStream {
+			return crdtWithOps(stream, ops, false)
+		}),
+	)
+}
+
+func crdtHasDuplicates(stream *CRDTStream) bool {
+	for _, delivery := range stream.Deliveries {
+		if len(delivery) > len(stream.Ops) {
+			return true
+		}
+	}
+	return false
+}
+
+// crdtWithOps creates a copy of a stream only containing the given operations
+func crdtWithOps(stream *CRDTStream, ops []CRDTOp, dropDuplicates bool) *CRDTStream {
+	kept := map[int]bool{}
+	tags := map[string]bool{}
+	for _, op := range ops {
+		kept[op.ID] = true
+		if op.Kind == CRDTAdd {
+			tags[op.Tag] = true
+		}
+	}
+	fixOp := func(op CRDTOp) CRDTOp {
+		if op.Kind != CRDTRemove {
+			return op
+		}
+		removedTags := make([]string, 0, len(op.RemovedTags))
+		for _, tag := range op.RemovedTags {
+			if tags[tag] {
+				removedTags = append(removedTags, tag)
+			}
+		}
+		op.RemovedTags = removedTags
+		return op
+	}
+	result := &CRDTStream{
+		Replicas:   stream.Replicas,
+		Ops:        make([]CRDTOp, 0, len(ops)),
+		Deliveries: make([][]CRDTOp, len(stream.Deliveries)),
+	}
+	for _, op := range ops {
+		result.Ops = append(result.Ops, fixOp(op))
+	}
+	for replica, delivery := range stream.Deliveries {
+		delivered := map[int]bool{}
+		for _, op := range delivery {
+			if kept[op.ID] && !(dropDuplicates && delivered[op.ID]) {
+				result.Deliveries[replica] = append(result.Deliveries[replica], fixOp(op))
+				delivered[op.ID] = true
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package gen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+type gCounter struct {
+	applied map[int]bool
+	count   uint64
+}
+
+func (c *gCounter) apply(op gen.CRDTOp) {
+	if !c.applied[op.ID] {
+		c.applied[op.ID] = true
+		c.count += op.Amount
+	}
+}
+
+type orSet struct {
+	tags      map[string]interface{}
+	tombstone map[string]bool
+}
+
+func (s *orSet) apply(op gen.CRDTOp) {
+	switch op.Kind {
+	case gen.CRDTAdd:
+		if !s.tombstone[op.Tag] {
+			s.tags[op.Tag] = op.Element
+		}
+	case gen.CRDTRemove:
+		for _, tag := range op.RemovedTags {
+			delete(s.tags, tag)
+			s.tombstone[tag] = true
+		}
+	}
+}
+
+func (s *orSet) elements() map[interface{}]bool {
+	elements := map[interface{}]bool{}
+	for _, element := range s.tags {
+		elements[element] = true
+	}
+	return elements
+}
+
+func deliversAll(stream *gen.CRDTStream) bool {
+	if len(stream.Deliveries) != stream.Replicas {
+		return false
+	}
+	for _, delivery := range stream.Deliveries {
+		delivered := map[int]bool{}
+		for _, op := range delivery {
+			delivered[op.ID] = true
+		}
+		if len(delivered) != len(stream.Ops) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGCounterOps(t *testing.T) {
+	commonGeneratorTest(t, "g-counter ops", gen.GCounterOps(3), func(value interface{}) bool {
+		stream, ok := value.(*gen.CRDTStream)
+		if !ok || !deliversAll(stream) {
+			return false
+		}
+		for _, delivery := range stream.Deliveries {
+			counter := &gCounter{applied: map[int]bool{}}
+			for _, op := range delivery {
+				counter.apply(op)
+			}
+			if counter.count != stream.ExpectedCount() {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestORSetOps(t *testing.T) {
+	commonGeneratorTest(t, "or-set ops", gen.ORSetOps(3, gen.IntRange(0, 5)), func(value interface{}) bool {
+		stream, ok := value.(*gen.CRDTStream)
+		if !ok || !deliversAll(stream) {
+			return false
+		}
+		for _, delivery := range stream.Deliveries {
+			set := &orSet{tags: map[string]interface{}{}, tombstone: map[string]bool{}}
+			for _, op := range delivery {
+				set.apply(op)
+			}
+			if !reflect.DeepEqual(set.elements(), stream.ExpectedElements()) {
+				return false
+			}
+		}
+		return true
+	})
+
+	if value, ok := gen.ORSetOps(0, gen.Int()).Sample(); ok {
+		t.Errorf("Invalid replicas: %#v", value)
+	}
+}
+
+func TestCRDTStreamShrinker(t *testing.T) {
+	// without tombstones a remove delivered before its add is lost
+	converges := func(stream *gen.CRDTStream) bool {
+		for _, delivery := range stream.Deliveries {
+			tags := map[string]interface{}{}
+			for _, op := range delivery {
+				if op.Kind == gen.CRDTAdd {
+					tags[op.Tag] = op.Element
+				}
+				for _, tag := range op.RemovedTags {
+					delete(tags, tag)
+				}
+			}
+			elements := map[interface{}]bool{}
+			for _, element := range tags {
+				elements[element] = true
+			}
+			if !reflect.DeepEqual(elements, stream.ExpectedElements()) {
+				return false
+			}
+		}
+		return true
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(converges, gen.ORSetOps(2, gen.IntRange(0, 3))).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if stream := result.Args[0].Arg.(*gen.CRDTStream); len(stream.Ops) != 2 {
+		t.Errorf("Stream has not been shrunk: %v", stream.Ops)
+	}
+}
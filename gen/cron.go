@@ -0,0 +1,268 @@
+package gen
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leanovate/gopter"
+)
+
+// cronHorizon is the number of years CronSchedule.Next searches for the next
+// fire time (covering the 28 year cycle of weekdays and leap days)
+const cronHorizon = 30
+
+// CronSchedule is a generated cron expression with the five fields minute,
+// hour, day of month, month and day of week (e.g. "*/15 9-17 * * MON-FRI")
+// and the values matched by each field.
+type CronSchedule struct {
+	Expr        string
+	Minutes     []int
+	Hours       []int
+	DaysOfMonth []int
+	Months      []int
+	// DaysOfWeek are the matched weekdays with 0 for Sunday (7 is normalized
+	// to 0)
+	DaysOfWeek []int
+	// LastDayOfMonth is true if the day of month field is "L"
+	LastDayOfMonth bool
+}
+
+// Matches checks if the schedule fires in the minute of t (in the location of
+// t).
+// Like Vixie cron a day matches both the day of month and the day of week
+// field, unless none of them starts with "*", then it has to match either of
+// them.
+func (s CronSchedule) Matches(t time.Time) bool {
+	return s.matchesDay(t) && containsInt(s.Hours, t.Hour()) && containsInt(s.Minutes, t.Minute())
+}
+
+// Next is the reference computation of the first time after t (at the start
+// of a minute in the location of t) at which the schedule fires.
+// Local times that are skipped by a daylight saving time transition do not
+// fire. The zero time is returned if the schedule does not fire within the
+// next 30 years (e.g. "0 0 30 2 *").
+func (s CronSchedule) Next(t time.Time) time.Time {
+	start := t.Truncate(time.Minute).Add(time.Minute)
+	for i := 0; i < cronHorizon*366; i++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, t.Location())
+		if !s.matchesDay(day) {
+			continue
+		}
+		for _, hour := range s.Hours {
+			for _, minute := range s.Minutes {
+				candidate := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, t.Location())
+				if !candidate.Before(start) && candidate.Hour() == hour && candidate.Minute() == minute {
+					return candidate
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	if !containsInt(s.Months, int(t.Month())) {
+		return false
+	}
+	dayOfMonth := containsInt(s.DaysOfMonth, t.Day())
+	if s.LastDayOfMonth {
+		dayOfMonth = t.AddDate(0, 0, 1).Day() == 1
+	}
+	dayOfWeek := containsInt(s.DaysOfWeek, int(t.Weekday()))
+	fields := strings.Fields(s.Expr)
+	if len(fields) == 5 && !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*") {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+func containsInt(values []int, value int) bool {
+	idx := sort.SearchInts(values, value)
+	return idx < len(values) && values[idx] == value
+}
+
+// cronField is the range of values of a field of a cron expression
+type cronField struct {
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{0, 59, nil},
+	{0, 23, nil},
+	{1, 31, nil},
+	{1, 12, []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{0, 7, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// CronExpr generates valid cron expressions (see CronSchedule) with single
+// values, ranges, lists, step values (e.g. "*/5" or "10-40/10") and month
+// and weekday names, biased towards the boundaries of the fields (e.g. 7 for
+// Sunday or the 31st day of a month).
+// The used features ("step", "names", "list", "day of month or week") are
+// added as labels.
+// The expressions shrink by replacing fields with "*".
+func CronExpr() gopter.Gen {
+	return cronExpr(false)
+}
+
+// CronExprWithLastDay is like CronExpr, but also generates the "L" (last
+// day of month) extension in the day of month field.
+func CronExprWithLastDay() gopter.Gen {
+	return cronExpr(true)
+}
+
+func cronExpr(lastDay bool) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		fields := make([]string, len(cronFields))
+		for i, field := range cronFields {
+			if i == 2 && lastDay && genParams.Rng.Intn(5) == 0 {
+				fields[i] = "L"
+				features["last day of month"] = true
+				continue
+			}
+			fields[i] = field.generate(genParams, features)
+		}
+		schedule := newCronSchedule(fields)
+		if !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*") {
+			features["day of month or week"] = true
+		}
+
+		genResult := gopter.NewGenResult(schedule, CronScheduleShrinker)
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+// generate creates a field with a single item or a list of 2 or 3 values and
+// ranges
+func (f cronField) generate(genParams *gopter.GenParameters, features map[string]bool) string {
+	useNames := f.names != nil && genParams.Rng.Intn(3) == 0
+	if genParams.Rng.Intn(4) != 0 {
+		return f.item(genParams, genParams.Rng.Intn(5), useNames, features)
+	}
+	features["list"] = true
+	items := make([]string, 2+genParams.Rng.Intn(2))
+	for i := range items {
+		items[i] = f.item(genParams, 2+genParams.Rng.Intn(3), useNames, features)
+	}
+	return strings.Join(items, ",")
+}
+
+func (f cronField) item(genParams *gopter.GenParameters, kind int, useNames bool, features map[string]bool) string {
+	switch kind {
+	case 0:
+		return "*"
+	case 1:
+		features["step"] = true
+		return "*/" + strconv.Itoa(1+genParams.Rng.Intn(f.max-f.min+1))
+	case 2:
+		return f.format(f.value(genParams), useNames, features)
+	}
+	from, to := f.value(genParams), f.value(genParams)
+	if from > to {
+		from, to = to, from
+	}
+	item := f.format(from, useNames, features) + "-" + f.format(to, useNames, features)
+	if kind == 4 {
+		features["step"] = true
+		item += "/" + strconv.Itoa(1+genParams.Rng.Intn(to-from+1))
+	}
+	return item
+}
+
+// value is a random value of the field biased towards its boundaries
+func (f cronField) value(genParams *gopter.GenParameters) int {
+	switch genParams.Rng.Intn(4) {
+	case 0:
+		return f.min
+	case 1:
+		return f.max
+	}
+	return f.min + genParams.Rng.Intn(f.max-f.min+1)
+}
+
+func (f cronField) format(value int, useNames bool, features map[string]bool) string {
+	if useNames && value-f.min < len(f.names) {
+		features["names"] = true
+		return f.names[value-f.min]
+	}
+	return strconv.Itoa(value)
+}
+
+// values evaluates a generated field
+func (f cronField) values(field string) []int {
+	matched := map[int]bool{}
+	for _, item := range strings.Split(field, ",") {
+		from, to, step := f.min, f.max, 1
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			step, _ = strconv.Atoi(item[idx+1:])
+			item = item[:idx]
+		}
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			from = f.parse(bounds[0])
+			to = from
+			if len(bounds) == 2 {
+				to = f.parse(bounds[1])
+			}
+		}
+		for value := from; value <= to; value += step {
+			if f.max == 7 && value == 7 {
+				matched[0] = true
+			} else {
+				matched[value] = true
+			}
+		}
+	}
+	values := make([]int, 0, len(matched))
+	for value := range matched {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+	return values
+}
+
+func (f cronField) parse(value string) int {
+	for i, name := range f.names {
+		if name == value {
+			return f.min + i
+		}
+	}
+	result, _ := strconv.Atoi(value)
+	return result
+}
+
+func newCronSchedule(fields []string) CronSchedule {
+	schedule := CronSchedule{
+		Expr:       strings.Join(fields, " "),
+		Minutes:    cronFields[0].values(fields[0]),
+		Hours:      cronFields[1].values(fields[1]),
+		Months:     cronFields[3].values(fields[3]),
+		DaysOfWeek: cronFields[4].values(fields[4]),
+	}
+	if fields[2] == "L" {
+		schedule.LastDayOfMonth = true
+	} else {
+		schedule.DaysOfMonth = cronFields[2].values(fields[2])
+	}
+	return schedule
+}
+
+// CronScheduleShrinker shrinks a CronSchedule by replacing single fields with
+// "*"
+func CronScheduleShrinker(v interface{}) gopter.Shrink {
+	fields := strings.Fields(v.(CronSchedule).Expr)
+	var candidates []interface{}
+	for i, field := range fields {
+		if field != "*" {
+			shrunk := append([]string{}, fields...)
+			shrunk[i] = "*"
+			candidates = append(candidates, newCronSchedule(shrunk))
+		}
+	}
+	return valuesShrink(candidates)
+}
@@ -0,0 +1,93 @@
+package gen_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestCronExpr(t *testing.T) {
+	now := time.Date(2024, 2, 28, 23, 59, 30, 0, time.UTC)
+	check := func(value interface{}) bool {
+		schedule, ok := value.(gen.CronSchedule)
+		if !ok || len(strings.Fields(schedule.Expr)) != 5 || len(schedule.Minutes) == 0 || len(schedule.Hours) == 0 ||
+			len(schedule.Months) == 0 || len(schedule.DaysOfWeek) == 0 || (len(schedule.DaysOfMonth) == 0) != schedule.LastDayOfMonth {
+			return false
+		}
+		if schedule.Minutes[len(schedule.Minutes)-1] > 59 || schedule.DaysOfWeek[len(schedule.DaysOfWeek)-1] > 6 {
+			return false
+		}
+		next := schedule.Next(now)
+		if next.IsZero() {
+			return true
+		}
+		if !next.After(now) || next.Second() != 0 || !schedule.Matches(next) {
+			return false
+		}
+		// no earlier minute within the next 3 days fires
+		for t := now.Truncate(time.Minute).Add(time.Minute); t.Before(next) && t.Before(now.AddDate(0, 0, 3)); t = t.Add(time.Minute) {
+			if schedule.Matches(t) {
+				return false
+			}
+		}
+		return true
+	}
+	commonGeneratorTest(t, "cron expr", gen.CronExpr(), check)
+	commonGeneratorTest(t, "cron expr with last day", gen.CronExprWithLastDay(), check)
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	weekdays := []int{0, 1, 2, 3, 4, 5, 6}
+	everyDay := make([]int, 31)
+	for i := range everyDay {
+		everyDay[i] = i + 1
+	}
+	now := time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC)
+	for _, check := range []struct {
+		schedule gen.CronSchedule
+		expected time.Time
+	}{
+		{gen.CronSchedule{Expr: "0 0 30 2 *", Minutes: []int{0}, Hours: []int{0}, DaysOfMonth: []int{30},
+			Months: []int{2}, DaysOfWeek: weekdays}, time.Time{}},
+		{gen.CronSchedule{Expr: "0 0 29 FEB *", Minutes: []int{0}, Hours: []int{0}, DaysOfMonth: []int{29},
+			Months: []int{2}, DaysOfWeek: weekdays}, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{gen.CronSchedule{Expr: "30 12 L 2 *", Minutes: []int{30}, Hours: []int{12}, LastDayOfMonth: true,
+			Months: []int{2}, DaysOfWeek: weekdays}, time.Date(2023, 2, 28, 12, 30, 0, 0, time.UTC)},
+		// day of month or day of week
+		{gen.CronSchedule{Expr: "0 8 13 * 5", Minutes: []int{0}, Hours: []int{8}, DaysOfMonth: []int{13},
+			Months: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, DaysOfWeek: []int{5}}, time.Date(2023, 2, 3, 8, 0, 0, 0, time.UTC)},
+		// day of month and day of week
+		{gen.CronSchedule{Expr: "0 8 */13 * 5", Minutes: []int{0}, Hours: []int{8}, DaysOfMonth: []int{1, 14, 27},
+			Months: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, DaysOfWeek: []int{5}}, time.Date(2023, 4, 14, 8, 0, 0, 0, time.UTC)},
+		{gen.CronSchedule{Expr: "* * * * *", Minutes: []int{0, 59}, Hours: []int{12}, DaysOfMonth: everyDay,
+			Months: []int{1}, DaysOfWeek: weekdays}, time.Date(2023, 1, 31, 12, 59, 0, 0, time.UTC)},
+	} {
+		if next := check.schedule.Next(now); !next.Equal(check.expected) {
+			t.Errorf("Invalid next of %s: %v", check.schedule.Expr, next)
+		}
+	}
+}
+
+func TestCronScheduleShrinker(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(schedule gen.CronSchedule) bool {
+		return !strings.Contains(schedule.Expr, "/")
+	}, gen.CronExpr()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	fields := strings.Fields(result.Args[0].Arg.(gen.CronSchedule).Expr)
+	restricted := 0
+	for _, field := range fields {
+		if field != "*" {
+			restricted++
+		}
+	}
+	if restricted != 1 {
+		t.Errorf("Invalid shrunk schedule: %v", fields)
+	}
+}
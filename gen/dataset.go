@@ -0,0 +1,150 @@
+package gen
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+// DatasetIDColumn is the name of the implicit primary key column of every
+// row of a Dataset.
+// Ids are positive ints unique per table, foreign key columns refer to them.
+const DatasetIDColumn = "id"
+
+// Table defines a table of a generated Dataset
+type Table struct {
+	// Name of the table
+	Name string
+	// Columns contains a generator for each column of the table
+	Columns map[string]gopter.Gen
+	// References defines the foreign keys of the table, i.e. maps a column
+	// to the name of the table it refers to. Foreign key columns do not need
+	// a generator, they will always contain the id of an existing row of the
+	// referenced table.
+	References map[string]string
+	// MaxRows limits the number of rows in the table, if 0 the size of the
+	// GenParameters is used
+	MaxRows int
+}
+
+// Row is a single row of a table in a Dataset
+type Row map[string]interface{}
+
+// Dataset is a generated set of tables, i.e. maps table names to rows.
+type Dataset map[string][]Row
+
+// DatasetOf generates consistent multi-table datasets: Rows of referenced
+// tables are generated first and every foreign key refers to an existing row
+// of the referenced table. The generator fails if a table refers to an
+// unknown table or the references are cyclic.
+// Datasets are shrunk by deleting rows, deleting a row also deletes all rows
+// referring to it (i.e. the referential integrity is kept).
+func DatasetOf(tables ...Table) gopter.Gen {
+	ordered, ok := orderTables(tables)
+	if !ok {
+		return Fail(reflect.TypeOf(Dataset{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		dataset := Dataset{}
+		for _, table := range ordered {
+			rowCount := tableRowCount(table, genParams)
+			rows := make([]Row, 0, rowCount)
+			// the columns in a stable order, so that a seed always generates
+			// the same dataset
+			columns := sortedColumns(table.Columns)
+			referenceColumns := sortedColumns(table.References)
+			for i := 0; i < rowCount; i++ {
+				row := Row{DatasetIDColumn: i + 1}
+				for _, column := range columns {
+					value, ok := table.Columns[column](genParams).Retrieve()
+					if !ok {
+						return gopter.NewEmptyResult(reflect.TypeOf(Dataset{}))
+					}
+					row[column] = value
+				}
+				for _, column := range referenceColumns {
+					referenced := table.References[column]
+					parents := dataset[referenced]
+					if len(parents) == 0 {
+						row = nil
+						break
+					}
+					row[column] = parents[genParams.Rng.Intn(len(parents))][DatasetIDColumn]
+				}
+				if row == nil {
+					// Without rows in a referenced table there cannot be any rows
+					break
+				}
+				rows = append(rows, row)
+			}
+			dataset[table.Name] = rows
+		}
+		return gopter.NewGenResult(dataset, DatasetShrinker(tables...))
+	}
+}
+
+func tableRowCount(table Table, genParams *gopter.GenParameters) int {
+	maxRows := genParams.MaxSize
+	if table.MaxRows > 0 && table.MaxRows < maxRows {
+		maxRows = table.MaxRows
+	}
+	if maxRows <= genParams.MinSize {
+		return maxRows
+	}
+	return genParams.Rng.Intn(maxRows-genParams.MinSize) + genParams.MinSize
+}
+
+// sortedColumns gets the (sorted) column names of the generators or
+// references of a table
+func sortedColumns(columns interface{}) []string {
+	keys := sortedMapKeys(reflect.ValueOf(columns))
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.String()
+	}
+	return names
+}
+
+// orderTables sorts the tables so that referenced tables come first
+func orderTables(tables []Table) ([]Table, bool) {
+	byName := make(map[string]Table, len(tables))
+	for _, table := range tables {
+		if _, ok := byName[table.Name]; ok {
+			return nil, false
+		}
+		byName[table.Name] = table
+	}
+	ordered := make([]Table, 0, len(tables))
+	visited := map[string]int{} // 1: in progress, 2: done
+	var visit func(table Table) bool
+	visit = func(table Table) bool {
+		switch visited[table.Name] {
+		case 1:
+			return false
+		case 2:
+			return true
+		}
+		visited[table.Name] = 1
+		referencedNames := make([]string, 0, len(table.References))
+		for _, referenced := range table.References {
+			referencedNames = append(referencedNames, referenced)
+		}
+		sort.Strings(referencedNames)
+		for _, referenced := range referencedNames {
+			referencedTable, ok := byName[referenced]
+			if !ok || !visit(referencedTable) {
+				return false
+			}
+		}
+		visited[table.Name] = 2
+		ordered = append(ordered, table)
+		return true
+	}
+	for _, table := range tables {
+		if !visit(table) {
+			return nil, false
+		}
+	}
+	return ordered, true
+}
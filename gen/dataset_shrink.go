@@ -0,0 +1,111 @@
+package gen
+
+import (
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+type datasetShrink struct {
+	dataset    Dataset
+	tables     []Table
+	tableIdx   int
+	rowIdx     int
+	cascadeTo  map[string][]Table
+	lastResult Dataset
+}
+
+func (s *datasetShrink) Next() (interface{}, bool) {
+	for s.tableIdx < len(s.tables) {
+		table := s.tables[s.tableIdx]
+		rows := s.dataset[table.Name]
+		if s.rowIdx < len(rows) {
+			id := rows[s.rowIdx][DatasetIDColumn]
+			s.rowIdx++
+			return s.deleteRow(table.Name, id), true
+		}
+		s.tableIdx++
+		s.rowIdx = 0
+	}
+	return nil, false
+}
+
+// deleteRow creates a copy of the dataset without a row and all rows
+// (transitively) referring to it
+func (s *datasetShrink) deleteRow(tableName string, id interface{}) Dataset {
+	result := make(Dataset, len(s.dataset))
+	for name, rows := range s.dataset {
+		result[name] = rows
+	}
+	deleted := map[string]map[interface{}]bool{tableName: {id: true}}
+	pending := []string{tableName}
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		result[name] = withoutIds(result[name], deleted[name])
+		for _, child := range s.cascadeTo[name] {
+			for column, referenced := range child.References {
+				if referenced != name {
+					continue
+				}
+				for _, row := range result[child.Name] {
+					if deleted[name][row[column]] {
+						if deleted[child.Name] == nil {
+							deleted[child.Name] = map[interface{}]bool{}
+						}
+						deleted[child.Name][row[DatasetIDColumn]] = true
+					}
+				}
+			}
+			if len(deleted[child.Name]) > 0 {
+				pending = append(pending, child.Name)
+			}
+		}
+	}
+	return result
+}
+
+func withoutIds(rows []Row, ids map[interface{}]bool) []Row {
+	result := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		if !ids[row[DatasetIDColumn]] {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// DatasetShrinker creates a shrinker for Datasets generated by DatasetOf for
+// the given tables.
+// Rows are deleted one after the other (tables referring to others first),
+// the deletion of a row cascades to all rows referring to it.
+func DatasetShrinker(tables ...Table) gopter.Shrinker {
+	cascadeTo := map[string][]Table{}
+	for _, table := range tables {
+		referenced := map[string]bool{}
+		for _, name := range table.References {
+			referenced[name] = true
+		}
+		for name := range referenced {
+			cascadeTo[name] = append(cascadeTo[name], table)
+		}
+	}
+	ordered, _ := orderTables(tables)
+	reversed := make([]Table, len(ordered))
+	for i, table := range ordered {
+		reversed[len(ordered)-1-i] = table
+	}
+	for _, children := range cascadeTo {
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].Name < children[j].Name
+		})
+	}
+	return func(v interface{}) gopter.Shrink {
+		shrink := &datasetShrink{
+			dataset:   v.(Dataset),
+			tables:    reversed,
+			cascadeTo: cascadeTo,
+		}
+		return shrink.Next
+	}
+}
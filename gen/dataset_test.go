@@ -0,0 +1,100 @@
+package gen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+var datasetTables = []gen.Table{
+	{
+		Name:       "orders",
+		Columns:    map[string]gopter.Gen{"amount": gen.IntRange(1, 1000)},
+		References: map[string]string{"customer_id": "customers"},
+	},
+	{
+		Name:    "customers",
+		Columns: map[string]gopter.Gen{"name": gen.AlphaString()},
+	},
+	{
+		Name:       "items",
+		Columns:    map[string]gopter.Gen{"quantity": gen.IntRange(1, 10)},
+		References: map[string]string{"order_id": "orders"},
+		MaxRows:    5,
+	},
+}
+
+func datasetIntegrity(dataset gen.Dataset) bool {
+	ids := map[string]map[interface{}]bool{}
+	for name, rows := range dataset {
+		ids[name] = map[interface{}]bool{}
+		for _, row := range rows {
+			ids[name][row[gen.DatasetIDColumn]] = true
+		}
+	}
+	for _, table := range datasetTables {
+		for _, row := range dataset[table.Name] {
+			for column, referenced := range table.References {
+				if !ids[referenced][row[column]] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func TestDatasetOf(t *testing.T) {
+	commonGeneratorTest(t, "dataset", gen.DatasetOf(datasetTables...), func(value interface{}) bool {
+		dataset, ok := value.(gen.Dataset)
+		return ok && len(dataset) == 3 && len(dataset["items"]) <= 5 && datasetIntegrity(dataset)
+	})
+
+	invalids := [][]gen.Table{
+		{{Name: "a", References: map[string]string{"b_id": "b"}}},
+		{
+			{Name: "a", References: map[string]string{"b_id": "b"}},
+			{Name: "b", References: map[string]string{"a_id": "a"}},
+		},
+		{{Name: "a"}, {Name: "a"}},
+	}
+	for _, invalid := range invalids {
+		if value, ok := gen.DatasetOf(invalid...).Sample(); ok {
+			t.Errorf("Tables %#v should fail: %#v", invalid, value)
+		}
+	}
+
+	// the same seed generates the same dataset (independent of the map
+	// iteration over the columns)
+	tables := []gen.Table{{Name: "a", Columns: map[string]gopter.Gen{"x": gen.Int(), "y": gen.Int(), "z": gen.Int()}}}
+	expected, _ := gen.DatasetOf(tables...)(gopter.DefaultGenParameters().CloneWithSeed(1234)).Retrieve()
+	for i := 0; i < 10; i++ {
+		if dataset, _ := gen.DatasetOf(tables...)(gopter.DefaultGenParameters().CloneWithSeed(1234)).Retrieve(); !reflect.DeepEqual(dataset, expected) {
+			t.Fatalf("Invalid dataset: %#v != %#v", dataset, expected)
+		}
+	}
+}
+
+func TestDatasetShrink(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(
+		func(dataset gen.Dataset) bool {
+			return len(dataset["items"]) < 2
+		},
+		gen.DatasetOf(datasetTables...),
+	).Check(parameters)
+
+	if result.Status != gopter.TestFailed || len(result.Args) != 1 {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	shrunk := result.Args[0].Arg.(gen.Dataset)
+	if !datasetIntegrity(shrunk) {
+		t.Errorf("Shrunk dataset violates integrity: %#v", shrunk)
+	}
+	if len(shrunk["items"]) != 2 || len(shrunk["orders"]) > 2 || len(shrunk["customers"]) > 2 {
+		t.Errorf("Shrunk dataset is not minimal: %#v", shrunk)
+	}
+}
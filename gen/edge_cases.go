@@ -0,0 +1,122 @@
+package gen
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// EdgeCasesOf generates the canonical edge values of a type: The zero value,
+// min/max (as well as NaN and infinities) of numbers, nil, empty and one
+// element slices and maps, nil pointers and pointers to the zero value and
+// NUL or invalid UTF-8 strings.
+// The edge values form the domain of the generator, i.e. they are checked
+// exhaustively if possible (see gopter.TestParameters.ExhaustiveLimit).
+// Otherwise an edge value is picked at random, which makes this generator
+// suitable to mix edge values into any other generator of the same type via
+// Frequency or Weighted.
+// All edge values shrink to the zero value of the type.
+func EdgeCasesOf(t reflect.Type) gopter.Gen {
+	if t == nil {
+		return Fail(reflect.TypeOf(nil))
+	}
+	edgeCases := edgeValuesOf(t)
+	domain := func() []interface{} {
+		return edgeCases
+	}
+	shrinker := edgeCaseShrinker(t)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		idx := genParams.Rng.Intn(len(edgeCases))
+		genResult := gopter.NewGenResult(edgeCases[idx], shrinker)
+		genResult.ResultType = t
+		genResult.Domain = domain
+		return genResult
+	}
+}
+
+type zeroShrink struct {
+	zero interface{}
+	done bool
+}
+
+func (s *zeroShrink) Next() (interface{}, bool) {
+	if !s.done {
+		s.done = true
+		return s.zero, true
+	}
+	return nil, false
+}
+
+func edgeCaseShrinker(t reflect.Type) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		value := reflect.ValueOf(v)
+		if !value.IsValid() || value.IsZero() {
+			return gopter.NoShrink
+		}
+		zeroShrink := &zeroShrink{zero: reflect.Zero(t).Interface()}
+		return zeroShrink.Next
+	}
+}
+
+func edgeValuesOf(t reflect.Type) []interface{} {
+	values := []reflect.Value{reflect.Zero(t)}
+	add := func(set func(v reflect.Value)) {
+		v := reflect.New(t).Elem()
+		set(v)
+		values = append(values, v)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		add(func(v reflect.Value) { v.SetBool(true) })
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := uint(t.Bits())
+		for _, i := range []int64{1, -1, -1 << (bits - 1), 1<<(bits-1) - 1} {
+			i := i
+			add(func(v reflect.Value) { v.SetInt(i) })
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		bits := uint(t.Bits())
+		for _, u := range []uint64{1, math.MaxUint64 >> (64 - bits)} {
+			u := u
+			add(func(v reflect.Value) { v.SetUint(u) })
+		}
+	case reflect.Float32, reflect.Float64:
+		smallest, max := math.SmallestNonzeroFloat64, math.MaxFloat64
+		if t.Kind() == reflect.Float32 {
+			smallest, max = math.SmallestNonzeroFloat32, math.MaxFloat32
+		}
+		for _, f := range []float64{math.Copysign(0, -1), 1, -1, smallest, -smallest, max, -max, math.Inf(1), math.Inf(-1), math.NaN()} {
+			f := f
+			add(func(v reflect.Value) { v.SetFloat(f) })
+		}
+	case reflect.Complex64, reflect.Complex128:
+		for _, c := range []complex128{complex(1, 0), complex(0, 1), complex(math.Inf(1), math.Inf(-1)), complex(math.NaN(), math.NaN())} {
+			c := c
+			add(func(v reflect.Value) { v.SetComplex(c) })
+		}
+	case reflect.String:
+		for _, s := range []string{" ", "\x00", "\xff"} {
+			s := s
+			add(func(v reflect.Value) { v.SetString(s) })
+		}
+	case reflect.Slice:
+		add(func(v reflect.Value) { v.Set(reflect.MakeSlice(t, 0, 0)) })
+		add(func(v reflect.Value) { v.Set(reflect.MakeSlice(t, 1, 1)) })
+	case reflect.Map:
+		add(func(v reflect.Value) { v.Set(reflect.MakeMap(t)) })
+		add(func(v reflect.Value) {
+			v.Set(reflect.MakeMap(t))
+			v.SetMapIndex(reflect.Zero(t.Key()), reflect.Zero(t.Elem()))
+		})
+	case reflect.Ptr:
+		add(func(v reflect.Value) { v.Set(reflect.New(t.Elem())) })
+	}
+
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value.Interface()
+	}
+	return result
+}
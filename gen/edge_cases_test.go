@@ -0,0 +1,70 @@
+package gen_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+type edgeCaseID int16
+
+func TestEdgeCasesOf(t *testing.T) {
+	commonGeneratorTest(t, "int edge cases", gen.EdgeCasesOf(reflect.TypeOf(0)), func(value interface{}) bool {
+		v, ok := value.(int)
+		return ok && (v == 0 || v == 1 || v == -1 || v == math.MinInt64 || v == math.MaxInt64 || v == math.MinInt32 || v == math.MaxInt32)
+	})
+	commonGeneratorTest(t, "named edge cases", gen.EdgeCasesOf(reflect.TypeOf(edgeCaseID(0))), func(value interface{}) bool {
+		v, ok := value.(edgeCaseID)
+		return ok && (v == 0 || v == 1 || v == -1 || v == math.MinInt16 || v == math.MaxInt16)
+	})
+	commonGeneratorTest(t, "slice edge cases", gen.EdgeCasesOf(reflect.TypeOf([]string{})), func(value interface{}) bool {
+		v, ok := value.([]string)
+		return ok && (v == nil || len(v) == 0 || (len(v) == 1 && v[0] == ""))
+	})
+	commonGeneratorTest(t, "ptr edge cases", gen.EdgeCasesOf(reflect.TypeOf((*uint8)(nil))), func(value interface{}) bool {
+		v, ok := value.(*uint8)
+		return ok && (v == nil || *v == 0)
+	})
+
+	domain := gen.EdgeCasesOf(reflect.TypeOf(uint8(0)))(gopter.DefaultGenParameters()).DomainValues()
+	if !reflect.DeepEqual(domain, []interface{}{uint8(0), uint8(1), uint8(math.MaxUint8)}) {
+		t.Errorf("Invalid domain: %#v", domain)
+	}
+
+	floats := gen.EdgeCasesOf(reflect.TypeOf(float32(0)))(gopter.DefaultGenParameters()).DomainValues()
+	foundNaN, foundInf := false, false
+	for _, f := range floats {
+		foundNaN = foundNaN || math.IsNaN(float64(f.(float32)))
+		foundInf = foundInf || math.IsInf(float64(f.(float32)), 1)
+	}
+	if !foundNaN || !foundInf {
+		t.Errorf("Invalid float edge cases: %#v", floats)
+	}
+
+	if value, ok := gen.EdgeCasesOf(nil).Sample(); ok {
+		t.Errorf("Invalid nil type: %#v", value)
+	}
+}
+
+func TestEdgeCasesOfFrequency(t *testing.T) {
+	mixed := gen.Frequency(map[int]gopter.Gen{
+		1: gen.EdgeCasesOf(reflect.TypeOf(int32(0))),
+		9: gen.Int32Range(-10, 10),
+	})
+	edges := 0
+	for i := 0; i < 1000; i++ {
+		value, ok := mixed.Sample()
+		if !ok {
+			t.Fatalf("Invalid sample: %#v", value)
+		}
+		if v := value.(int32); v == math.MinInt32 || v == math.MaxInt32 {
+			edges++
+		}
+	}
+	if edges == 0 {
+		t.Error("No edge cases mixed in")
+	}
+}
@@ -0,0 +1,141 @@
+package gen
+
+import (
+	"net"
+	"net/mail"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// Maximum lengths of email addresses (RFC 5321)
+const (
+	EmailLocalPartMaxLength = 64
+	EmailAddressMaxLength   = 254
+)
+
+// emailAtomChars are the characters of unquoted local parts (atext)
+const emailAtomChars = "abcdefghijklmnopqrstuvwxyzABCXYZ0123456789!#$%&'*+/=?^_`{|}~-"
+
+// emailQuotedChars are characters of quoted local parts (some of them are
+// escaped)
+const emailQuotedChars = "abcxyz019 .,:;@<>()[]\"\\"
+
+func validEmailAddress(str string) bool {
+	at := strings.LastIndex(str, "@")
+	if at < 0 || at > EmailLocalPartMaxLength || len(str) > EmailAddressMaxLength {
+		return false
+	}
+	address, err := mail.ParseAddress(str)
+	return err == nil && address.Name == ""
+}
+
+// EmailAddress generates valid email addresses (as string) including
+// boundary cases: special characters, subaddresses (e.g. "john+tag"), quoted
+// local parts with spaces and escapes, IP literal domains (e.g.
+// "[IPv6:2001:db8::1]") and local parts and addresses with the maximum length
+// of 64 and 254 characters.
+// The used features are added as labels.
+// The addresses shrink by simplifying the local part and removing labels of
+// the domain (as long as they stay valid).
+func EmailAddress() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		local := genEmailLocalPart(genParams, features)
+		var domain string
+		switch genParams.Rng.Intn(8) {
+		case 0:
+			features["IP literal"] = true
+			if genParams.NextBool() {
+				ip, _ := randomIP(genParams, net.IPv4len, ipv4Categories)
+				domain = "[" + ip.String() + "]"
+			} else {
+				domain = "[IPv6:2001:db8::" + genFromChars(genParams, 1+genParams.Rng.Intn(4), "123456789abcdef", "0123456789abcdef") + "]"
+			}
+		case 1:
+			features["max length"] = true
+			local = genFromChars(genParams, EmailLocalPartMaxLength, "abcxyz0189", "abcxyz0189.-_")
+			local = strings.Replace(local, "..", ".a", -1)
+			domain = strings.Join([]string{
+				genFromChars(genParams, 63, dns1123EdgeChars, dns1123InnerChars),
+				genFromChars(genParams, 63, dns1123EdgeChars, dns1123InnerChars),
+				genFromChars(genParams, 61, dns1123EdgeChars, dns1123InnerChars),
+			}, ".")
+		default:
+			domain = genDNS1123Subdomain(genParams)
+			if len(local)+1+len(domain) > EmailAddressMaxLength {
+				domain = "example.com"
+			}
+		}
+		address := local + "@" + domain
+
+		genResult := gopter.NewGenResult(address, EmailAddressShrinker)
+		genResult.Sieve = func(v interface{}) bool {
+			return validEmailAddress(v.(string))
+		}
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+func genEmailLocalPart(genParams *gopter.GenParameters, features map[string]bool) string {
+	switch genParams.Rng.Intn(6) {
+	case 0:
+		features["quoted local part"] = true
+		chars := make([]string, 1+genParams.Rng.Intn(20))
+		for i := range chars {
+			ch := string(emailQuotedChars[genParams.Rng.Intn(len(emailQuotedChars))])
+			if ch == `"` || ch == `\` {
+				ch = `\` + ch
+			}
+			chars[i] = ch
+		}
+		return `"` + strings.Join(chars, "") + `"`
+	case 1:
+		features["max length local part"] = true
+		return genFromChars(genParams, EmailLocalPartMaxLength, "abcxyz0189", "abcxyz0189_-")
+	case 2:
+		features["subaddress"] = true
+		return genFromChars(genParams, 1+genParams.Rng.Intn(10), "abcxyz", "abcxyz0189") + "+" +
+			genFromChars(genParams, genParams.Rng.Intn(10), "abcxyz0189-", "abcxyz0189-")
+	}
+	atoms := make([]string, 1+genParams.Rng.Intn(3))
+	for i := range atoms {
+		atoms[i] = genFromChars(genParams, 1+genParams.Rng.Intn(10), emailAtomChars, emailAtomChars)
+		if strings.Trim(atoms[i], "abcdefghijklmnopqrstuvwxyzABCXYZ0123456789") != "" {
+			features["special characters"] = true
+		}
+	}
+	return strings.Join(atoms, ".")
+}
+
+// EmailAddressShrinker shrinks an email address by removing labels of the
+// domain (IP literals are replaced by "example.com"), removing the
+// subaddress and shortening the local part
+func EmailAddressShrinker(v interface{}) gopter.Shrink {
+	address := v.(string)
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return gopter.NoShrink
+	}
+	local, domain := address[:at], address[at+1:]
+	var candidates []interface{}
+	if strings.HasPrefix(domain, "[") {
+		candidates = append(candidates, local+"@example.com")
+	} else if dot := strings.Index(domain, "."); dot >= 0 {
+		candidates = append(candidates, local+"@"+domain[dot+1:])
+	}
+	if plus := strings.Index(local, "+"); plus > 0 && !strings.HasPrefix(local, `"`) {
+		candidates = append(candidates, local[:plus]+"@"+domain)
+	}
+	if strings.HasPrefix(local, `"`) {
+		candidates = append(candidates, "a@"+domain)
+	}
+	return valuesShrink(candidates).Filter(func(v interface{}) bool {
+		return validEmailAddress(v.(string))
+	}).Interleave(StringShrinker(local).Map(func(shrunk string) string {
+		return shrunk + "@" + domain
+	}).Filter(func(v interface{}) bool {
+		return validEmailAddress(v.(string))
+	}))
+}
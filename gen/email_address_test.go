@@ -0,0 +1,40 @@
+package gen_test
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func isValidMailAddress(str string) bool {
+	address, err := mail.ParseAddress(str)
+	return err == nil && address.Name == ""
+}
+
+func TestEmailAddress(t *testing.T) {
+	commonGeneratorTest(t, "email address", gen.EmailAddress(), func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok || len(str) > gen.EmailAddressMaxLength {
+			return false
+		}
+		at := strings.LastIndex(str, "@")
+		return at > 0 && at <= gen.EmailLocalPartMaxLength && isValidMailAddress(str)
+	})
+}
+
+func TestEmailAddressShrinker(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(address string) bool {
+		return !strings.Contains(address, "+")
+	}, gen.EmailAddress()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if address := result.Args[0].Arg.(string); len(address) > 10 || !isValidMailAddress(address) {
+		t.Errorf("Invalid shrunk address: %v", address)
+	}
+}
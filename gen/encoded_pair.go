@@ -0,0 +1,175 @@
+package gen
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// EncodedPair is a pair of raw data and its encoded form as generated by
+// Base64Pair, HexPair, GzipPair or URLEncodedPair.
+// If Corrupted is true, Encoded is an invalid encoding that is supposed to be
+// rejected by a decoder.
+type EncodedPair struct {
+	Encoding  string
+	Raw       []byte
+	Encoded   []byte
+	Corrupted bool
+}
+
+type pairEncoding struct {
+	name    string
+	encode  func(raw []byte) []byte
+	corrupt func(encoded []byte, pos int) []byte
+}
+
+var base64Encoding = &pairEncoding{
+	name: "base64",
+	encode: func(raw []byte) []byte {
+		return []byte(base64.StdEncoding.EncodeToString(raw))
+	},
+	corrupt: replaceOrAppend('*'),
+}
+
+var hexEncoding = &pairEncoding{
+	name: "hex",
+	encode: func(raw []byte) []byte {
+		return []byte(hex.EncodeToString(raw))
+	},
+	corrupt: replaceOrAppend('g'),
+}
+
+var urlEncoding = &pairEncoding{
+	name: "url",
+	encode: func(raw []byte) []byte {
+		return []byte(url.QueryEscape(string(raw)))
+	},
+	corrupt: func(encoded []byte, pos int) []byte {
+		// "%" not followed by two hex digits is an invalid escape
+		return insertAt(encoded, pos, []byte("%z"))
+	},
+}
+
+var gzipEncoding = &pairEncoding{
+	name: "gzip",
+	encode: func(raw []byte) []byte {
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		writer.Write(raw)
+		writer.Close()
+		return buffer.Bytes()
+	},
+	corrupt: func(encoded []byte, pos int) []byte {
+		// The first 10 bytes are the header, where some fields (like mtime)
+		// are not validated. Everything after is protected by the checksum,
+		// unless it is truncated.
+		if pos%2 == 0 {
+			return append([]byte{}, encoded[:10+pos%(len(encoded)-10)]...)
+		}
+		corrupted := append([]byte{}, encoded...)
+		corrupted[10+pos%(len(encoded)-10)] ^= 0xff
+		return corrupted
+	},
+}
+
+func replaceOrAppend(invalid byte) func(encoded []byte, pos int) []byte {
+	return func(encoded []byte, pos int) []byte {
+		corrupted := append([]byte{}, encoded...)
+		if len(corrupted) == 0 {
+			return append(corrupted, invalid)
+		}
+		corrupted[pos%len(corrupted)] = invalid
+		return corrupted
+	}
+}
+
+func insertAt(data []byte, pos int, insert []byte) []byte {
+	pos = pos % (len(data) + 1)
+	result := make([]byte, 0, len(data)+len(insert))
+	result = append(result, data[:pos]...)
+	result = append(result, insert...)
+	return append(result, data[pos:]...)
+}
+
+// Base64Pair generates pairs of arbitrary bytes and their standard base64
+// encoding. About 10% of the pairs are corrupted (i.e. not valid base64),
+// which is reflected by EncodedPair.Corrupted and the label of the result.
+func Base64Pair() gopter.Gen {
+	return genEncodedPair(base64Encoding)
+}
+
+// HexPair generates pairs of arbitrary bytes and their hex encoding.
+// About 10% of the pairs are corrupted (i.e. not valid hex), which is
+// reflected by EncodedPair.Corrupted and the label of the result.
+func HexPair() gopter.Gen {
+	return genEncodedPair(hexEncoding)
+}
+
+// GzipPair generates pairs of arbitrary bytes and their gzip compressed form.
+// About 10% of the pairs are corrupted (truncated or with a flipped byte),
+// which is reflected by EncodedPair.Corrupted and the label of the result.
+func GzipPair() gopter.Gen {
+	return genEncodedPair(gzipEncoding)
+}
+
+// URLEncodedPair generates pairs of arbitrary bytes and their URL query
+// escaped form. About 10% of the pairs are corrupted (i.e. contain an invalid
+// escape sequence), which is reflected by EncodedPair.Corrupted and the label
+// of the result.
+func URLEncodedPair() gopter.Gen {
+	return genEncodedPair(urlEncoding)
+}
+
+func genEncodedPair(encoding *pairEncoding) gopter.Gen {
+	rawGen := SliceOf(UInt8())
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		rawResult := rawGen(genParams)
+		rawValue, ok := rawResult.Retrieve()
+		if !ok {
+			return gopter.NewEmptyResult(reflect.TypeOf(EncodedPair{}))
+		}
+		corrupted := genParams.Rng.Intn(10) == 0
+		pos := genParams.Rng.Intn(1 << 16)
+		pair := encodePair(encoding, rawValue.([]byte), corrupted, pos)
+
+		genResult := gopter.NewGenResult(pair, encodedPairShrinker(encoding, rawResult.Shrinker, pos))
+		genResult.Labels = []string{encodedPairLabel(pair)}
+		return genResult
+	}
+}
+
+func encodePair(encoding *pairEncoding, raw []byte, corrupted bool, pos int) EncodedPair {
+	encoded := encoding.encode(raw)
+	if corrupted {
+		encoded = encoding.corrupt(encoded, pos)
+	}
+	return EncodedPair{
+		Encoding:  encoding.name,
+		Raw:       raw,
+		Encoded:   encoded,
+		Corrupted: corrupted,
+	}
+}
+
+func encodedPairLabel(pair EncodedPair) string {
+	if pair.Corrupted {
+		return "corrupted " + pair.Encoding
+	}
+	return "valid " + pair.Encoding
+}
+
+// encodedPairShrinker shrinks the raw data and re-encodes it, corrupted
+// pairs stay corrupted
+func encodedPairShrinker(encoding *pairEncoding, rawShrinker gopter.Shrinker, pos int) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		pair := v.(EncodedPair)
+		return rawShrinker(pair.Raw).Map(func(raw []byte) EncodedPair {
+			return encodePair(encoding, raw, pair.Corrupted, pos)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package gen_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"net/url"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func decodePair(pair gen.EncodedPair) ([]byte, error) {
+	switch pair.Encoding {
+	case "base64":
+		return base64.StdEncoding.DecodeString(string(pair.Encoded))
+	case "hex":
+		return hex.DecodeString(string(pair.Encoded))
+	case "url":
+		decoded, err := url.QueryUnescape(string(pair.Encoded))
+		return []byte(decoded), err
+	case "gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(pair.Encoded))
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(reader)
+	}
+	return nil, nil
+}
+
+func TestEncodedPairs(t *testing.T) {
+	gens := map[string]gopter.Gen{
+		"base64": gen.Base64Pair(),
+		"hex":    gen.HexPair(),
+		"gzip":   gen.GzipPair(),
+		"url":    gen.URLEncodedPair(),
+	}
+	for name, pairGen := range gens {
+		corrupted := 0
+		commonGeneratorTest(t, name, pairGen, func(v interface{}) bool {
+			pair, ok := v.(gen.EncodedPair)
+			if !ok || pair.Encoding != name {
+				return false
+			}
+			decoded, err := decodePair(pair)
+			if pair.Corrupted {
+				corrupted++
+				return err != nil
+			}
+			return err == nil && bytes.Equal(decoded, pair.Raw)
+		})
+		if corrupted == 0 {
+			t.Errorf("No corrupted pairs for %s", name)
+		}
+
+		genResult := pairGen(gopter.DefaultGenParameters())
+		pair := genResult.Result.(gen.EncodedPair)
+		if len(genResult.Labels) != 1 || (pair.Corrupted && genResult.Labels[0] != "corrupted "+name) ||
+			(!pair.Corrupted && genResult.Labels[0] != "valid "+name) {
+			t.Errorf("Invalid labels for %#v: %#v", pair, genResult.Labels)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/leanovate/gopter"
+)
+
+// Kinds of a generated ErrorSequence
+const (
+	ErrorSequenceTransient = "transient errors then success"
+	ErrorSequencePermanent = "permanent failure"
+	ErrorSequenceMixed     = "mixed"
+)
+
+// StepError is an error of a generated ErrorSequence
+type StepError struct {
+	// Step is the (0-based) call that returned the error
+	Step int
+	// Transient errors may succeed when retried, permanent ones will not
+	Transient bool
+}
+
+func (e *StepError) Error() string {
+	if e.Transient {
+		return fmt.Sprintf("Transient error at step %d", e.Step)
+	}
+	return fmt.Sprintf("Permanent error at step %d", e.Step)
+}
+
+// Temporary reports if the error is transient (like net.Error)
+func (e *StepError) Temporary() bool {
+	return e.Transient
+}
+
+// ErrorSequence describes the results of consecutive calls of an operation:
+// a number of transient errors followed by either a success or a permanent
+// error. All calls after the final step repeat its result.
+type ErrorSequence struct {
+	Kind string
+	// TransientErrors is the number of transient errors before the final step
+	TransientErrors int
+	// Succeeds is true if the final step is a success, false if it is a
+	// permanent error
+	Succeeds bool
+}
+
+// Result is the result of the step-th (0-based) call
+func (s ErrorSequence) Result(step int) error {
+	switch {
+	case step < s.TransientErrors:
+		return &StepError{Step: step, Transient: true}
+	case s.Succeeds:
+		return nil
+	}
+	return &StepError{Step: step}
+}
+
+// Stub creates an ErrorStub returning the results of the sequence
+func (s ErrorSequence) Stub() *ErrorStub {
+	return &ErrorStub{sequence: s}
+}
+
+// ErrorStub is a stub operation driven by an ErrorSequence, it is safe for
+// concurrent use
+type ErrorStub struct {
+	sequence ErrorSequence
+	mutex    sync.Mutex
+	calls    int
+}
+
+// Call returns the result of the next step of the sequence
+func (s *ErrorStub) Call() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls++
+	return s.sequence.Result(s.calls - 1)
+}
+
+// Wrap creates a function that returns the result of the next step of the
+// sequence, calling f only when the step is a success
+func (s *ErrorStub) Wrap(f func() error) func() error {
+	return func() error {
+		if err := s.Call(); err != nil {
+			return err
+		}
+		return f()
+	}
+}
+
+// Calls is the number of calls so far
+func (s *ErrorStub) Calls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+// ErrorSequences generates sequences of up to maxErrors transient errors
+// followed by a success (ErrorSequenceTransient), a permanent error
+// (ErrorSequencePermanent) or either of them (ErrorSequenceMixed), i.e. the
+// sequences test both eventual success and giving up of retry logic.
+// The kind of the sequence is added as label.
+// Sequences shrink to fewer transient errors.
+func ErrorSequences(maxErrors int) gopter.Gen {
+	if maxErrors < 0 {
+		return Fail(reflect.TypeOf(ErrorSequence{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		sequence := ErrorSequence{
+			Kind:            []string{ErrorSequenceTransient, ErrorSequencePermanent, ErrorSequenceMixed}[genParams.Rng.Intn(3)],
+			TransientErrors: genParams.Rng.Intn(maxErrors + 1),
+		}
+		switch sequence.Kind {
+		case ErrorSequenceTransient:
+			sequence.Succeeds = true
+		case ErrorSequenceMixed:
+			sequence.Succeeds = genParams.NextBool()
+		}
+		genResult := gopter.NewGenResult(sequence, errorSequenceShrinker)
+		genResult.Labels = []string{sequence.Kind}
+		return genResult
+	}
+}
+
+func errorSequenceShrinker(v interface{}) gopter.Shrink {
+	sequence := v.(ErrorSequence)
+	return UIntShrinker(uint(sequence.TransientErrors)).Map(func(transientErrors uint) ErrorSequence {
+		shrunk := sequence
+		shrunk.TransientErrors = int(transientErrors)
+		return shrunk
+	})
+}
@@ -0,0 +1,78 @@
+package gen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+// retry calls op until it succeeds, fails permanently or maxAttempts is reached
+func retry(op func() error, maxAttempts int) error {
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err = op(); err == nil {
+			return nil
+		}
+		if temporary, ok := err.(interface{ Temporary() bool }); !ok || !temporary.Temporary() {
+			return err
+		}
+	}
+	return err
+}
+
+func TestErrorSequences(t *testing.T) {
+	kinds := map[string]bool{}
+	commonGeneratorTest(t, "error sequence", gen.ErrorSequences(5), func(value interface{}) bool {
+		sequence, ok := value.(gen.ErrorSequence)
+		if !ok || sequence.TransientErrors < 0 || sequence.TransientErrors > 5 {
+			return false
+		}
+		kinds[sequence.Kind] = true
+		switch sequence.Kind {
+		case gen.ErrorSequenceTransient:
+			if !sequence.Succeeds {
+				return false
+			}
+		case gen.ErrorSequencePermanent:
+			if sequence.Succeeds {
+				return false
+			}
+		}
+
+		stub := sequence.Stub()
+		err := retry(stub.Call, 10)
+		if sequence.Succeeds {
+			return err == nil && stub.Calls() == sequence.TransientErrors+1
+		}
+		var stepErr *gen.StepError
+		return errors.As(err, &stepErr) && !stepErr.Transient && stepErr.Step == sequence.TransientErrors &&
+			stub.Calls() == sequence.TransientErrors+1
+	})
+	if len(kinds) != 3 {
+		t.Errorf("Not all kinds generated: %v", kinds)
+	}
+
+	sequence := gen.ErrorSequence{Kind: gen.ErrorSequenceTransient, TransientErrors: 4, Succeeds: true}
+	stub := sequence.Stub()
+	if err := retry(stub.Call, 3); err == nil || err.Error() != "Transient error at step 2" || stub.Calls() != 3 {
+		t.Errorf("Invalid give-up: %v %d", err, stub.Calls())
+	}
+	called := 0
+	wrapped := sequence.Stub().Wrap(func() error {
+		called++
+		return nil
+	})
+	if err := retry(wrapped, 10); err != nil || called != 1 {
+		t.Errorf("Invalid wrapped result: %v %d", err, called)
+	}
+
+	shrinks := gen.ErrorSequences(5)(gopter.DefaultGenParameters()).Shrinker(sequence).All()
+	if len(shrinks) == 0 || shrinks[0] != (gen.ErrorSequence{Kind: gen.ErrorSequenceTransient, TransientErrors: 0, Succeeds: true}) {
+		t.Errorf("Invalid shrinks: %v", shrinks)
+	}
+	if value, ok := gen.ErrorSequences(-1).Sample(); ok {
+		t.Errorf("Invalid value for negative maxErrors: %#v", value)
+	}
+}
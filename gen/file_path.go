@@ -0,0 +1,175 @@
+package gen
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// DefaultMaxPathComponentLength is the maximum length of a path component
+// (file name) of most filesystems
+const DefaultMaxPathComponentLength = 255
+
+// PathOptions configures the paths generated by FilePathWithOptions and
+// DirPathWithOptions
+type PathOptions struct {
+	// Windows generates Windows paths with drive letters, UNC and long path
+	// prefixes and "\" (mixed with "/") as separators
+	Windows bool
+	// MaxComponentLength is the length of "very long" components (if 0
+	// DefaultMaxPathComponentLength)
+	MaxComponentLength int
+}
+
+var (
+	pathDirNames      = []string{"src", "docs", "home", "user", "tmp", "var", "log", "data", "node_modules", "My Documents"}
+	pathFileNames     = []string{"main.go", "README.md", "archive.tar.gz", "Makefile", "notes.txt", "image.JPG", "file.", "data.json"}
+	pathHiddenNames   = []string{".git", ".config", ".bashrc", ".env"}
+	pathUnicodeNames  = []string{"r\u00e9sum\u00e9.pdf", "\u65e5\u672c\u8a9e", "Unicode\u0308", "e\u0301", "\U0001F600", "\u03a9mega", "\u0627\u0644\u0645\u0644\u0641"}
+	pathSpecialNames  = []string{"a b", " leading", "trailing ", "#hash", "100%", "it's", "a&b", "[1]", "~", "$HOME", "semi;colon"}
+	pathPosixSpecials = []string{"back\\slash", "colon:", "star*", "what?", "pipe|", "\"quoted\"", "<angle>"}
+	pathWindowsRoots  = []string{"C:\\", "c:\\", "D:\\", "\\\\server\\share\\", "\\\\?\\C:\\"}
+
+	windowsPathRoot = regexp.MustCompile(`^(\\\\\?\\[A-Za-z]:\\|[A-Za-z]:\\|\\\\[^\\/]+\\[^\\/]+\\)`)
+)
+
+// FilePath generates realistic and adversarial (Unix) paths of files:
+// absolute and relative paths with dot segments, duplicate separators,
+// hidden, unicode and special names and very long components.
+// The used features are added as labels.
+// The paths shrink by removing components.
+func FilePath() gopter.Gen {
+	return FilePathWithOptions(PathOptions{})
+}
+
+// DirPath generates paths of directories like FilePath, which may end with
+// a separator
+func DirPath() gopter.Gen {
+	return DirPathWithOptions(PathOptions{})
+}
+
+// FilePathWithOptions generates paths of files like FilePath configured by
+// the options
+func FilePathWithOptions(options PathOptions) gopter.Gen {
+	return genPath(options, false)
+}
+
+// DirPathWithOptions generates paths of directories like DirPath configured
+// by the options
+func DirPathWithOptions(options PathOptions) gopter.Gen {
+	return genPath(options, true)
+}
+
+func genPath(options PathOptions, dir bool) gopter.Gen {
+	maxLength := options.MaxComponentLength
+	if maxLength <= 0 {
+		maxLength = DefaultMaxPathComponentLength
+	}
+	separator := "/"
+	if options.Windows {
+		separator = "\\"
+	}
+	valid := func(v interface{}) bool {
+		path := v.(string)
+		return path != "" && (dir || !strings.HasSuffix(path, "/") && !strings.HasSuffix(path, separator))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		var builder strings.Builder
+		if genParams.NextBool() {
+			features["absolute"] = true
+			if options.Windows {
+				builder.WriteString(pathWindowsRoots[genParams.Rng.Intn(len(pathWindowsRoots))])
+			} else {
+				builder.WriteString("/")
+			}
+		}
+		count := 1 + genParams.Rng.Intn(5)
+		for i := 0; i < count; i++ {
+			last := i == count-1
+			if last && !dir {
+				builder.WriteString(genPathComponent(genParams, options.Windows, maxLength, pathFileNames, features))
+				break
+			}
+			builder.WriteString(genPathComponent(genParams, options.Windows, maxLength, pathDirNames, features))
+			if last && !(dir && genParams.NextBool()) {
+				break
+			}
+			if last {
+				features["trailing separator"] = true
+			}
+			switch {
+			case options.Windows && genParams.Rng.Intn(6) == 0:
+				features["mixed separators"] = true
+				builder.WriteString("/")
+			case genParams.Rng.Intn(8) == 0:
+				features["duplicate separator"] = true
+				builder.WriteString(separator + separator)
+			default:
+				builder.WriteString(separator)
+			}
+		}
+
+		genResult := gopter.NewGenResult(builder.String(), filteredShrinker(pathShrinker(options.Windows), valid))
+		genResult.Sieve = valid
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+func genPathComponent(genParams *gopter.GenParameters, windows bool, maxLength int, names []string, features map[string]bool) string {
+	switch genParams.Rng.Intn(12) {
+	case 0:
+		features["dot segment"] = true
+		return []string{".", ".."}[genParams.Rng.Intn(2)]
+	case 1:
+		features["hidden"] = true
+		return pathHiddenNames[genParams.Rng.Intn(len(pathHiddenNames))]
+	case 2:
+		features["unicode"] = true
+		return pathUnicodeNames[genParams.Rng.Intn(len(pathUnicodeNames))]
+	case 3:
+		features["special characters"] = true
+		if !windows && genParams.Rng.Intn(3) == 0 {
+			return pathPosixSpecials[genParams.Rng.Intn(len(pathPosixSpecials))]
+		}
+		return pathSpecialNames[genParams.Rng.Intn(len(pathSpecialNames))]
+	case 4:
+		features["long component"] = true
+		return genFromChars(genParams, maxLength, "abcxyz0189", "abcxyz0189-_. ")
+	}
+	return names[genParams.Rng.Intn(len(names))]
+}
+
+// pathShrinker shrinks paths by removing components (each with its
+// following separator), the root of an absolute path is kept
+func pathShrinker(windows bool) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		path := v.(string)
+		root := ""
+		if windows {
+			root = windowsPathRoot.FindString(path)
+		} else if strings.HasPrefix(path, "/") {
+			root = "/"
+		}
+		var components []string
+		start := len(root)
+		for i := start; i < len(path); i++ {
+			if path[i] == '/' || windows && path[i] == '\\' {
+				// a component includes its separators
+				if i+1 < len(path) && (path[i+1] == '/' || windows && path[i+1] == '\\') {
+					continue
+				}
+				components = append(components, path[start:i+1])
+				start = i + 1
+			}
+		}
+		if start < len(path) {
+			components = append(components, path[start:])
+		}
+		return SliceShrinker(gopter.NoShrinker)(components).Map(func(shrunk []string) string {
+			return root + strings.Join(shrunk, "")
+		})
+	}
+}
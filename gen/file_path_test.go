@@ -0,0 +1,70 @@
+package gen_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestFilePath(t *testing.T) {
+	commonGeneratorTest(t, "file path", gen.FilePath(), func(value interface{}) bool {
+		path, ok := value.(string)
+		return ok && path != "" && !strings.HasSuffix(path, "/") && !strings.Contains(path, "\\\\")
+	})
+	commonGeneratorTest(t, "dir path", gen.DirPath(), func(value interface{}) bool {
+		path, ok := value.(string)
+		return ok && path != "" && filepath.Clean(path) != ""
+	})
+	commonGeneratorTest(t, "windows file path", gen.FilePathWithOptions(gen.PathOptions{Windows: true}), func(value interface{}) bool {
+		path, ok := value.(string)
+		return ok && path != "" && !strings.HasSuffix(path, "\\") && !strings.HasSuffix(path, "/") &&
+			!strings.ContainsAny(strings.TrimPrefix(path, `\\?\`), "*?|<>\"")
+	})
+
+	labels := map[string]bool{}
+	parameters := gopter.DefaultGenParameters()
+	for i := 0; i < 500; i++ {
+		result := gen.DirPathWithOptions(gen.PathOptions{Windows: true, MaxComponentLength: 32})(parameters)
+		for _, component := range strings.FieldsFunc(result.Result.(string), func(r rune) bool { return r == '/' || r == '\\' }) {
+			if len(component) > 32 {
+				t.Errorf("Invalid component: %#v", component)
+			}
+		}
+		for _, label := range result.Labels {
+			labels[label] = true
+		}
+	}
+	for _, label := range []string{"absolute", "dot segment", "hidden", "unicode", "special characters", "long component",
+		"trailing separator", "mixed separators", "duplicate separator"} {
+		if !labels[label] {
+			t.Errorf("Label %#v never generated", label)
+		}
+	}
+}
+
+func TestFilePathShrinker(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(path string) bool {
+		return !strings.Contains(path, "..")
+	}, gen.FilePath()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if path := result.Args[0].Arg.(string); path != "../main.go" && path != "/../main.go" && strings.Count(path, "/") > 2 {
+		t.Errorf("Invalid shrunk path: %#v", path)
+	}
+
+	result = prop.ForAll(func(path string) bool {
+		return !strings.HasPrefix(path, "\\\\server")
+	}, gen.DirPathWithOptions(gen.PathOptions{Windows: true})).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if path := result.Args[0].Arg.(string); strings.ContainsAny(strings.TrimPrefix(path, "\\\\server\\share\\"), "\\/") {
+		t.Errorf("Invalid shrunk path: %#v", path)
+	}
+}
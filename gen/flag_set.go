@@ -0,0 +1,238 @@
+package gen
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+// FlagSet generates feature-flag configurations, i.e. maps of all given flag
+// names to a bool that is true with the probability of the flag (0 for always
+// off, 1 for always on).
+// If there are few enough flags, all combinations form the domain of the
+// generator, i.e. they are checked exhaustively if possible (see
+// gopter.TestParameters.ExhaustiveLimit).
+// Configurations shrink by switching off the flags one by one.
+func FlagSet(flags map[string]float64) gopter.Gen {
+	names, ok := sortedFlagNames(flags)
+	if !ok {
+		return Fail(reflect.TypeOf(map[string]bool{}))
+	}
+	var domain func() []interface{}
+	if free := freeFlags(names, flags); len(free) < 31 && 1<<uint(len(free)) <= gopter.MaxEnumerableDomainSize {
+		domain = func() []interface{} {
+			values := make([]interface{}, 0, 1<<uint(len(free)))
+			for bits := 0; bits < 1<<uint(len(free)); bits++ {
+				values = append(values, newFlagSet(names, flags, func(i int) bool {
+					return bits&(1<<uint(i)) != 0
+				}))
+			}
+			return values
+		}
+	}
+	shrinker := flagSetShrinker(names, flags)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		flagSet := make(map[string]bool, len(names))
+		for _, name := range names {
+			flagSet[name] = genParams.Rng.Float64() < flags[name]
+		}
+		genResult := gopter.NewGenResult(flagSet, shrinker)
+		genResult.Domain = domain
+		return genResult
+	}
+}
+
+// FlagSetPairwise generates feature-flag configurations like FlagSet, but
+// picks them from a small set of configurations that contains all 2-way
+// combinations of the flags, i.e. for any two flags (with a probability
+// between 0 and 1) all four combinations of their values occur. These
+// configurations form the domain of the generator, so an exhaustive check
+// covers all pairs within PairwiseFlagSets(flags) cases.
+// Flags with a probability of 0 or 1 are always off or on.
+func FlagSetPairwise(flags map[string]float64) gopter.Gen {
+	names, ok := sortedFlagNames(flags)
+	if !ok {
+		return Fail(reflect.TypeOf(map[string]bool{}))
+	}
+	flagSets := PairwiseFlagSets(flags)
+	domain := func() []interface{} {
+		values := make([]interface{}, len(flagSets))
+		for i, flagSet := range flagSets {
+			values[i] = copyFlagSet(flagSet)
+		}
+		return values
+	}
+	shrinker := flagSetShrinker(names, flags)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		flagSet := flagSets[genParams.Rng.Intn(len(flagSets))]
+		genResult := gopter.NewGenResult(copyFlagSet(flagSet), shrinker)
+		genResult.Domain = domain
+		return genResult
+	}
+}
+
+// PairwiseFlagSets calculates a small set of flag configurations that covers
+// all 2-way combinations of the flags with a probability between 0 and 1
+// (flags with a probability of 0 or 1 are always off or on).
+// The configurations are calculated greedily and deterministically, their
+// number grows logarithmically with the number of flags.
+func PairwiseFlagSets(flags map[string]float64) []map[string]bool {
+	names, _ := sortedFlagNames(flags)
+	free := freeFlags(names, flags)
+	if len(free) < 2 {
+		result := []map[string]bool{newFlagSet(names, flags, func(int) bool { return false })}
+		if len(free) == 1 {
+			result = append(result, newFlagSet(names, flags, func(int) bool { return true }))
+		}
+		return result
+	}
+
+	// uncovered[i][j][vi][vj] for i < j
+	uncovered := make([][][2][2]bool, len(free))
+	remaining := 0
+	for i := range free {
+		uncovered[i] = make([][2][2]bool, len(free))
+		for j := i + 1; j < len(free); j++ {
+			uncovered[i][j] = [2][2]bool{{true, true}, {true, true}}
+			remaining += 4
+		}
+	}
+	toInt := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	var result []map[string]bool
+	for remaining > 0 {
+		// start with the first uncovered pair, assign the others greedily
+		values := make([]bool, len(free))
+		assigned := make([]bool, len(free))
+	first:
+		for i := range free {
+			for j := i + 1; j < len(free); j++ {
+				for vi := 0; vi < 2; vi++ {
+					for vj := 0; vj < 2; vj++ {
+						if uncovered[i][j][vi][vj] {
+							values[i], values[j] = vi == 1, vj == 1
+							assigned[i], assigned[j] = true, true
+							break first
+						}
+					}
+				}
+			}
+		}
+		for k := range free {
+			if assigned[k] {
+				continue
+			}
+			gains := [2]int{}
+			for v := 0; v < 2; v++ {
+				for other := range free {
+					if !assigned[other] {
+						continue
+					}
+					if other < k && uncovered[other][k][toInt(values[other])][v] ||
+						other > k && uncovered[k][other][v][toInt(values[other])] {
+						gains[v]++
+					}
+				}
+			}
+			values[k] = gains[1] > gains[0] || gains[1] == gains[0] && len(result)%2 == 1
+			assigned[k] = true
+		}
+		for i := range free {
+			for j := i + 1; j < len(free); j++ {
+				if uncovered[i][j][toInt(values[i])][toInt(values[j])] {
+					uncovered[i][j][toInt(values[i])][toInt(values[j])] = false
+					remaining--
+				}
+			}
+		}
+		result = append(result, newFlagSet(names, flags, func(i int) bool { return values[i] }))
+	}
+	return result
+}
+
+func sortedFlagNames(flags map[string]float64) ([]string, bool) {
+	names := make([]string, 0, len(flags))
+	for name, probability := range flags {
+		if !(probability >= 0 && probability <= 1) {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+// freeFlags are the names of the flags that are neither always on nor off
+func freeFlags(names []string, flags map[string]float64) []string {
+	var free []string
+	for _, name := range names {
+		if flags[name] > 0 && flags[name] < 1 {
+			free = append(free, name)
+		}
+	}
+	return free
+}
+
+// newFlagSet creates a flag set with the fixed flags set to their value and
+// the i-th free flag set to value(i)
+func newFlagSet(names []string, flags map[string]float64, value func(i int) bool) map[string]bool {
+	flagSet := make(map[string]bool, len(names))
+	i := 0
+	for _, name := range names {
+		switch flags[name] {
+		case 0:
+			flagSet[name] = false
+		case 1:
+			flagSet[name] = true
+		default:
+			flagSet[name] = value(i)
+			i++
+		}
+	}
+	return flagSet
+}
+
+func copyFlagSet(flagSet map[string]bool) map[string]bool {
+	result := make(map[string]bool, len(flagSet))
+	for name, value := range flagSet {
+		result[name] = value
+	}
+	return result
+}
+
+type flagSetShrink struct {
+	flagSet map[string]bool
+	names   []string
+}
+
+func (s *flagSetShrink) Next() (interface{}, bool) {
+	for len(s.names) > 0 {
+		name := s.names[0]
+		s.names = s.names[1:]
+		if s.flagSet[name] {
+			result := copyFlagSet(s.flagSet)
+			result[name] = false
+			return result, true
+		}
+	}
+	return nil, false
+}
+
+func flagSetShrinker(names []string, flags map[string]float64) gopter.Shrinker {
+	switchable := make([]string, 0, len(names))
+	for _, name := range names {
+		if flags[name] < 1 {
+			switchable = append(switchable, name)
+		}
+	}
+	return func(v interface{}) gopter.Shrink {
+		shrink := &flagSetShrink{flagSet: v.(map[string]bool), names: switchable}
+		return shrink.Next
+	}
+}
@@ -0,0 +1,78 @@
+package gen_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestFlagSet(t *testing.T) {
+	flags := map[string]float64{"always": 1, "never": 0, "rare": 0.1, "often": 0.9}
+	commonGeneratorTest(t, "flag set", gen.FlagSet(flags), func(value interface{}) bool {
+		flagSet, ok := value.(map[string]bool)
+		return ok && len(flagSet) == 4 && flagSet["always"] && !flagSet["never"]
+	})
+	rareCount := 0
+	for i := 0; i < 1000; i++ {
+		if value, ok := gen.FlagSet(flags).Sample(); ok && value.(map[string]bool)["rare"] {
+			rareCount++
+		}
+	}
+	if rareCount < 50 || rareCount > 150 {
+		t.Errorf("Rare flag has an invalid frequency: %d", rareCount)
+	}
+
+	genResult := gen.FlagSet(flags)(gopter.DefaultGenParameters())
+	if domain := genResult.DomainValues(); len(domain) != 4 {
+		t.Errorf("Invalid domain: %v", domain)
+	}
+	shrinks := genResult.Shrinker(map[string]bool{"always": true, "never": false, "rare": true, "often": true}).All()
+	if len(shrinks) != 2 {
+		t.Errorf("Invalid shrinks: %v", shrinks)
+	}
+
+	if value, ok := gen.FlagSet(map[string]float64{"invalid": 1.5}).Sample(); ok {
+		t.Errorf("Invalid probability: %#v", value)
+	}
+}
+
+func TestPairwiseFlagSets(t *testing.T) {
+	for _, count := range []int{0, 1, 2, 3, 10, 50} {
+		flags := map[string]float64{"fixed": 1}
+		for i := 0; i < count; i++ {
+			flags[fmt.Sprintf("flag%d", i)] = 0.5
+		}
+		flagSets := gen.PairwiseFlagSets(flags)
+		for i := 0; i < count; i++ {
+			for j := i + 1; j < count; j++ {
+				covered := map[[2]bool]bool{}
+				for _, flagSet := range flagSets {
+					covered[[2]bool{flagSet[fmt.Sprintf("flag%d", i)], flagSet[fmt.Sprintf("flag%d", j)]}] = true
+				}
+				if len(covered) != 4 {
+					t.Errorf("Pair %d/%d is not covered: %v", i, j, covered)
+				}
+			}
+		}
+		for _, flagSet := range flagSets {
+			if !flagSet["fixed"] {
+				t.Errorf("Fixed flag is not set: %v", flagSet)
+			}
+		}
+		if count == 50 && len(flagSets) > 20 {
+			t.Errorf("Too many flag sets for %d flags: %d", count, len(flagSets))
+		}
+	}
+
+	flags := map[string]float64{"a": 0.5, "b": 0.5, "c": 0.5, "d": 0.5}
+	commonGeneratorTest(t, "pairwise flag set", gen.FlagSetPairwise(flags), func(value interface{}) bool {
+		flagSet, ok := value.(map[string]bool)
+		return ok && len(flagSet) == 4
+	})
+	domain := gen.FlagSetPairwise(flags)(gopter.DefaultGenParameters()).DomainValues()
+	if len(domain) != len(gen.PairwiseFlagSets(flags)) || len(domain) >= 16 {
+		t.Errorf("Invalid domain: %v", domain)
+	}
+}
@@ -0,0 +1,118 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// FloatStats are the statistics of a float64 slice
+type FloatStats struct {
+	// Mean is the arithmetic mean of all values (including zeros)
+	Mean float64
+	// StdDev is the population standard deviation of all values
+	StdDev float64
+	// Sparsity is the fraction of values that are zero
+	Sparsity float64
+}
+
+func (s FloatStats) String() string {
+	return fmt.Sprintf("mean=%.4g stddev=%.4g sparsity=%.4g", s.Mean, s.StdDev, s.Sparsity)
+}
+
+// StatsOf calculates the statistics of a float64 slice
+func StatsOf(values []float64) FloatStats {
+	if len(values) == 0 {
+		return FloatStats{}
+	}
+	var sum, zeros float64
+	for _, v := range values {
+		sum += v
+		if v == 0 {
+			zeros++
+		}
+	}
+	n := float64(len(values))
+	mean := sum / n
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return FloatStats{
+		Mean:     mean,
+		StdDev:   math.Sqrt(squares / n),
+		Sparsity: zeros / n,
+	}
+}
+
+// FloatSliceWithStats generates float64 slices (sized like SliceOf) whose
+// statistics match the target: The number of zeros is the target sparsity
+// (rounded), the non-zero values are normally distributed random values that
+// are scaled so that mean and standard deviation of the whole slice match the
+// target (up to rounding errors).
+// If the target standard deviation can not be reached (with less than two
+// non-zero values, or if the zeros alone spread further than the target) the
+// non-zero values are all equal.
+// The actual statistics are added as label.
+func FloatSliceWithStats(target FloatStats) gopter.Gen {
+	if target.StdDev < 0 || target.Sparsity < 0 || target.Sparsity > 1 ||
+		math.IsNaN(target.Mean) || math.IsInf(target.Mean, 0) || math.IsInf(target.StdDev, 0) {
+		return Fail(reflect.TypeOf([]float64{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		length := 0
+		if genParams.MaxSize > genParams.MinSize {
+			length = genParams.Rng.Intn(genParams.MaxSize-genParams.MinSize) + genParams.MinSize
+		} else {
+			length = genParams.MaxSize
+		}
+		values := genFloatsWithStats(genParams, length, target)
+		genResult := gopter.NewGenResult(values, gopter.NoShrinker)
+		genResult.Labels = []string{StatsOf(values).String()}
+		return genResult
+	}
+}
+
+func genFloatsWithStats(genParams *gopter.GenParameters, length int, target FloatStats) []float64 {
+	values := make([]float64, length)
+	zeros := int(math.Round(target.Sparsity * float64(length)))
+	nonZeros := length - zeros
+	if nonZeros == 0 {
+		return values
+	}
+
+	// standard normal values with a sample mean of 0 and a variance of 1
+	normals := make([]float64, nonZeros)
+	var sum float64
+	for i := range normals {
+		normals[i] = genParams.Rng.NormFloat64()
+		sum += normals[i]
+	}
+	var squares float64
+	for i := range normals {
+		normals[i] -= sum / float64(nonZeros)
+		squares += normals[i] * normals[i]
+	}
+	deviation := math.Sqrt(squares / float64(nonZeros))
+
+	// the non-zero values (offset + scale * normal) have to sum up to
+	// n * mean and their squares to n * (stddev^2 + mean^2)
+	n, m := float64(length), float64(nonZeros)
+	offset := n * target.Mean / m
+	scale := 0.0
+	if variance := (n*(target.StdDev*target.StdDev+target.Mean*target.Mean) - m*offset*offset) / m; variance > 0 && deviation > 0 {
+		scale = math.Sqrt(variance) / deviation
+	}
+
+	positions := genParams.Rng.Perm(length)[:nonZeros]
+	for i, pos := range positions {
+		values[pos] = offset + scale*normals[i]
+		if values[pos] == 0 {
+			// keep the sparsity exact
+			values[pos] = math.SmallestNonzeroFloat64
+		}
+	}
+	return values
+}
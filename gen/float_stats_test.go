@@ -0,0 +1,54 @@
+package gen_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestStatsOf(t *testing.T) {
+	stats := gen.StatsOf([]float64{0, 0, 2, 4, 4, 6})
+	if stats.Mean != 8.0/3 || math.Abs(stats.StdDev-math.Sqrt(44.0/9)) > 1e-9 || stats.Sparsity != 1.0/3 {
+		t.Errorf("Invalid stats: %v", stats)
+	}
+	if stats := gen.StatsOf(nil); stats != (gen.FloatStats{}) {
+		t.Errorf("Invalid stats: %v", stats)
+	}
+}
+
+func TestFloatSliceWithStats(t *testing.T) {
+	for _, target := range []gen.FloatStats{
+		{Mean: 0, StdDev: 1},
+		{Mean: 100, StdDev: 0.5, Sparsity: 0.2},
+		{Mean: 10, StdDev: 20, Sparsity: 0.2},
+		{Mean: -3, StdDev: 20, Sparsity: 0.9},
+	} {
+		target := target
+		commonGeneratorTest(t, "float slice with stats", gen.FloatSliceWithStats(target), func(value interface{}) bool {
+			values, ok := value.([]float64)
+			if !ok {
+				return false
+			}
+			stats := gen.StatsOf(values)
+			zeros := math.Round(target.Sparsity * float64(len(values)))
+			if len(values) == 0 || float64(len(values)) == zeros {
+				return stats.Mean == 0 && stats.StdDev == 0
+			}
+			if math.Round(stats.Sparsity*float64(len(values))) != zeros || math.Abs(stats.Mean-target.Mean) > 1e-6*(1+math.Abs(target.Mean)) {
+				t.Logf("Invalid stats %v for %v", stats, target)
+				return false
+			}
+			n, m := float64(len(values)), float64(len(values))-zeros
+			if m < 2 || m*(target.StdDev*target.StdDev+target.Mean*target.Mean) < n*target.Mean*target.Mean {
+				// target is not reachable
+				return true
+			}
+			return math.Abs(stats.StdDev-target.StdDev) < 1e-6*(1+target.StdDev)
+		})
+	}
+
+	if value, ok := gen.FloatSliceWithStats(gen.FloatStats{StdDev: -1}).Sample(); ok {
+		t.Errorf("Invalid target: %#v", value)
+	}
+}
@@ -14,28 +14,37 @@ func Float64Range(min, max float64) gopter.Gen {
 		return Fail(reflect.TypeOf(float64(0)))
 	}
 
-	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+	sieve := func(v interface{}) bool {
+		return v.(float64) >= min && v.(float64) <= max
+	}
+	return gopter.NewBatchGen(func(genParams *gopter.GenParameters) *gopter.GenResult {
 		genResult := gopter.NewGenResult(min+genParams.Rng.Float64()*d, Float64Shrinker)
-		genResult.Sieve = func(v interface{}) bool {
-			return v.(float64) >= min && v.(float64) <= max
-		}
+		genResult.Sieve = sieve
 		return genResult
-	}
+	}, func(genParams *gopter.GenParameters, buf interface{}, n int) interface{} {
+		if buf == nil {
+			buf = make([]float64, 0, n)
+		}
+		values, ok := buf.([]float64)
+		if !ok {
+			return nil
+		}
+		for i := 0; i < n; i++ {
+			values = append(values, min+genParams.Rng.Float64()*d)
+		}
+		return values
+	})
 }
 
 // Float64 generates arbitrary float64 numbers that do not contain NaN or Inf
 func Float64() gopter.Gen {
-	return gopter.CombineGens(
-		Int64Range(0, 1),
-		Int64Range(0, 0x7fe),
-		Int64Range(0, 0xfffffffffffff),
-	).Map(func(values []interface{}) float64 {
-		sign := uint64(values[0].(int64))
-		exponent := uint64(values[1].(int64))
-		mantissa := uint64(values[2].(int64))
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		sign := genParams.NextUint64() % 2
+		exponent := genParams.NextUint64() % 0x7ff
+		mantissa := genParams.NextUint64() % 0x10000000000000
 
-		return math.Float64frombits((sign << 63) | (exponent << 52) | mantissa)
-	}).WithShrinker(Float64Shrinker)
+		return gopter.NewGenResult(math.Float64frombits((sign<<63)|(exponent<<52)|mantissa), Float64Shrinker)
+	}
 }
 
 // Float32Range generates float32 numbers within a given range
@@ -44,26 +53,102 @@ func Float32Range(min, max float32) gopter.Gen {
 	if d < 0 || d > math.MaxFloat32 {
 		return Fail(reflect.TypeOf(float32(0)))
 	}
-	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+	sieve := func(v interface{}) bool {
+		return v.(float32) >= min && v.(float32) <= max
+	}
+	return gopter.NewBatchGen(func(genParams *gopter.GenParameters) *gopter.GenResult {
 		genResult := gopter.NewGenResult(min+genParams.Rng.Float32()*d, Float32Shrinker)
-		genResult.Sieve = func(v interface{}) bool {
-			return v.(float32) >= min && v.(float32) <= max
-		}
+		genResult.Sieve = sieve
 		return genResult
-	}
+	}, func(genParams *gopter.GenParameters, buf interface{}, n int) interface{} {
+		if buf == nil {
+			buf = make([]float32, 0, n)
+		}
+		values, ok := buf.([]float32)
+		if !ok {
+			return nil
+		}
+		for i := 0; i < n; i++ {
+			values = append(values, min+genParams.Rng.Float32()*d)
+		}
+		return values
+	})
 }
 
 // Float32 generates arbitrary float32 numbers that do not contain NaN or Inf
 func Float32() gopter.Gen {
-	return gopter.CombineGens(
-		Int32Range(0, 1),
-		Int32Range(0, 0xfe),
-		Int32Range(0, 0x7fffff),
-	).Map(func(values []interface{}) float32 {
-		sign := uint32(values[0].(int32))
-		exponent := uint32(values[1].(int32))
-		mantissa := uint32(values[2].(int32))
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		sign := uint32(genParams.NextUint64() % 2)
+		exponent := uint32(genParams.NextUint64() % 0xff)
+		mantissa := uint32(genParams.NextUint64() % 0x800000)
 
-		return math.Float32frombits((sign << 31) | (exponent << 23) | mantissa)
-	}).WithShrinker(Float32Shrinker)
+		return gopter.NewGenResult(math.Float32frombits((sign<<31)|(exponent<<23)|mantissa), Float32Shrinker)
+	}
+}
+
+// float64Specials are the special float64 values generated by Float64Full
+var float64Specials = []float64{
+	math.NaN(), math.Inf(1), math.Inf(-1), 0, math.Copysign(0, -1), 1, -1,
+	math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64,
+	0x1p-1022, -0x1p-1022, // smallest normal
+	math.MaxFloat64, -math.MaxFloat64,
+}
+
+// float32Specials are the special float32 values generated by Float32Full
+var float32Specials = []float32{
+	float32(math.NaN()), float32(math.Inf(1)), float32(math.Inf(-1)), 0, float32(math.Copysign(0, -1)), 1, -1,
+	math.SmallestNonzeroFloat32, -math.SmallestNonzeroFloat32,
+	0x1p-126, -0x1p-126, // smallest normal
+	math.MaxFloat32, -math.MaxFloat32,
+}
+
+// Float64Full generates arbitrary float64 numbers including the special
+// values that Float64 never generates: In about every fifth value NaN, ±Inf,
+// ±0, ±1, subnormals or the smallest/largest magnitudes are generated.
+// The values shrink towards 0 and simple (integer) values, NaN and ±Inf shrink
+// to finite values (see Float64FullShrinker).
+func Float64Full() gopter.Gen {
+	finite := Float64()
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		if genParams.Rng.Intn(5) != 0 {
+			genResult := finite(genParams)
+			genResult.Shrinker = Float64FullShrinker
+			return genResult
+		}
+		var value float64
+		if genParams.Rng.Intn(len(float64Specials)+1) == 0 {
+			// arbitrary subnormal
+			value = math.Float64frombits(1 + genParams.NextUint64()%(1<<52-1))
+			if genParams.NextBool() {
+				value = -value
+			}
+		} else {
+			value = float64Specials[genParams.Rng.Intn(len(float64Specials))]
+		}
+		return gopter.NewGenResult(value, Float64FullShrinker)
+	}
+}
+
+// Float32Full generates arbitrary float32 numbers including the special
+// values (see Float64Full)
+func Float32Full() gopter.Gen {
+	finite := Float32()
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		if genParams.Rng.Intn(5) != 0 {
+			genResult := finite(genParams)
+			genResult.Shrinker = Float32FullShrinker
+			return genResult
+		}
+		var value float32
+		if genParams.Rng.Intn(len(float32Specials)+1) == 0 {
+			// arbitrary subnormal
+			value = math.Float32frombits(uint32(1 + genParams.NextUint64()%(1<<23-1)))
+			if genParams.NextBool() {
+				value = -value
+			}
+		} else {
+			value = float32Specials[genParams.Rng.Intn(len(float32Specials))]
+		}
+		return gopter.NewGenResult(value, Float32FullShrinker)
+	}
 }
@@ -47,3 +47,47 @@ func Float32Shrinker(v interface{}) gopter.Shrink {
 		return float32(e)
 	})
 }
+
+// Float64FullShrinker is a shrinker for float64 numbers including special
+// values: NaN and ±Inf shrink to 0, 1 and ±MaxFloat64, finite numbers shrink
+// to 0, their integer part and then like Float64Shrinker
+func Float64FullShrinker(v interface{}) gopter.Shrink {
+	value := v.(float64)
+	switch {
+	case math.IsNaN(value):
+		return valuesShrink([]interface{}{0.0, 1.0})
+	case math.IsInf(value, 0):
+		return valuesShrink([]interface{}{0.0, 1.0, math.Copysign(math.MaxFloat64, value)})
+	case value == 0:
+		return gopter.NoShrink
+	}
+	var simple []interface{}
+	simple = append(simple, 0.0)
+	if trunc := math.Trunc(value); trunc != value && trunc != 0 {
+		simple = append(simple, trunc)
+	}
+	return gopter.ConcatShrinks(valuesShrink(simple), Float64Shrinker(value))
+}
+
+// Float32FullShrinker is a shrinker for float32 numbers including special
+// values (see Float64FullShrinker)
+func Float32FullShrinker(v interface{}) gopter.Shrink {
+	value := float64(v.(float32))
+	if math.IsInf(value, 0) {
+		return valuesShrink([]interface{}{float32(0), float32(1), float32(math.Copysign(math.MaxFloat32, value))})
+	}
+	return Float64FullShrinker(value).Map(func(e float64) float32 {
+		return float32(e)
+	})
+}
+
+func valuesShrink(values []interface{}) gopter.Shrink {
+	return func() (interface{}, bool) {
+		if len(values) == 0 {
+			return nil, false
+		}
+		value := values[0]
+		values = values[1:]
+		return value, true
+	}
+}
@@ -1,6 +1,7 @@
 package gen_test
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -152,3 +153,22 @@ func TestFloat32Shrinker(t *testing.T) {
 	}
 
 }
+
+func TestFloat64FullShrinker(t *testing.T) {
+	if shrinks := gen.Float64FullShrinker(math.NaN()).All(); !reflect.DeepEqual(shrinks, []interface{}{0.0, 1.0}) {
+		t.Errorf("Invalid NaN shrinks: %#v", shrinks)
+	}
+	if shrinks := gen.Float64FullShrinker(math.Inf(-1)).All(); !reflect.DeepEqual(shrinks, []interface{}{0.0, 1.0, -math.MaxFloat64}) {
+		t.Errorf("Invalid -Inf shrinks: %#v", shrinks)
+	}
+	if shrinks := gen.Float64FullShrinker(math.Copysign(0, -1)).All(); len(shrinks) != 0 {
+		t.Errorf("Invalid -0 shrinks: %#v", shrinks)
+	}
+	shrinks := gen.Float64FullShrinker(12.5).All()
+	if len(shrinks) < 3 || shrinks[0] != 0.0 || shrinks[1] != 12.0 {
+		t.Errorf("Invalid shrinks: %#v", shrinks)
+	}
+	if shrinks := gen.Float32FullShrinker(float32(math.Inf(1))).All(); !reflect.DeepEqual(shrinks, []interface{}{float32(0), float32(1), float32(math.MaxFloat32)}) {
+		t.Errorf("Invalid float32 Inf shrinks: %#v", shrinks)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 )
 
@@ -46,3 +47,48 @@ func TestFloat32Range(t *testing.T) {
 		return ok && v >= -1234.5 && v <= 56789.123
 	})
 }
+
+func TestFloat64Full(t *testing.T) {
+	commonGeneratorTest(t, "float 64 full", gen.Float64Full(), func(value interface{}) bool {
+		_, ok := value.(float64)
+		return ok
+	})
+
+	specials := map[string]bool{}
+	genParams := gopter.DefaultGenParameters().CloneWithSeed(1234)
+	for i := 0; i < 2000; i++ {
+		value, _ := gen.Float64Full()(genParams).Retrieve()
+		switch v := value.(float64); {
+		case math.IsNaN(v):
+			specials["NaN"] = true
+		case math.IsInf(v, 0):
+			specials["Inf"] = true
+		case v == 0 && math.Signbit(v):
+			specials["-0"] = true
+		case v != 0 && math.Abs(v) < 0x1p-1022:
+			specials["subnormal"] = true
+		}
+	}
+	if len(specials) != 4 {
+		t.Errorf("Special values not generated: %v", specials)
+	}
+}
+
+func TestFloat32Full(t *testing.T) {
+	commonGeneratorTest(t, "float 32 full", gen.Float32Full(), func(value interface{}) bool {
+		_, ok := value.(float32)
+		return ok
+	})
+
+	specials := 0
+	genParams := gopter.DefaultGenParameters().CloneWithSeed(1234)
+	for i := 0; i < 2000; i++ {
+		value, _ := gen.Float32Full()(genParams).Retrieve()
+		if v := float64(value.(float32)); math.IsNaN(v) || math.IsInf(v, 0) {
+			specials++
+		}
+	}
+	if specials == 0 {
+		t.Error("Special values not generated")
+	}
+}
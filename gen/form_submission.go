@@ -0,0 +1,172 @@
+package gen
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+// FormErrorRequired is the expected validation error of a missing required
+// field of a FormSubmission
+const FormErrorRequired = "required"
+
+// FormField defines a field of generated FormSubmissions
+type FormField struct {
+	// Name of the field
+	Name string
+	// Required fields are always submitted by valid submissions, a missing
+	// required field is expected to fail with FormErrorRequired
+	Required bool
+	// Valid generates the valid values of the field (as string)
+	Valid gopter.Gen
+	// Invalid contains a generator of invalid values (as string) for each
+	// validation error of the field (e.g. "too_long" for values exceeding the
+	// maximum length). The generators should have a sieve that rejects valid
+	// values (e.g. by SuchThat), so that shrunk values stay invalid.
+	Invalid map[string]gopter.Gen
+}
+
+// FormSubmission is a generated submission of a form with the validation
+// errors a validator is expected to find
+type FormSubmission struct {
+	// Values are the submitted fields (at most one value per field)
+	Values url.Values
+	// Errors maps the names of invalid fields to their expected validation
+	// error, i.e. it is empty for a valid submission
+	Errors map[string]string
+}
+
+// Valid checks if no validation errors are expected
+func (f FormSubmission) Valid() bool {
+	return len(f.Errors) == 0
+}
+
+// formFieldGen is the generator of the values of a field for a validation
+// error ("" for the valid values)
+type formFieldGen struct {
+	field string
+	code  string
+	gen   gopter.Gen
+}
+
+// FormSubmissionOf generates submissions of a form with the given fields,
+// where each field is valid or invalid by construction: One out of four
+// fields is invalid (missing if required, otherwise an invalid value for one
+// of its validation errors) and optional valid fields are sometimes omitted.
+// The expected validation errors are part of the generated FormSubmission, so
+// that a validator can be checked for the exact set of errors.
+// Fails if the generators of a field do not generate strings.
+// The submissions shrink by omitting optional valid fields and shrinking the
+// values of the fields (which stay valid or invalid for the same error).
+// The label is "valid" or "invalid".
+func FormSubmissionOf(fields ...FormField) gopter.Gen {
+	var fieldGens [][]formFieldGen
+	for _, field := range fields {
+		gens := []formFieldGen{{field: field.Name, gen: field.Valid}}
+		codes := make([]string, 0, len(field.Invalid))
+		for code := range field.Invalid {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			gens = append(gens, formFieldGen{field: field.Name, code: code, gen: field.Invalid[code]})
+		}
+		for _, fieldGen := range gens {
+			if fieldGen.gen == nil || fieldGen.gen(gopter.MinGenParams).ResultType.Kind() != reflect.String {
+				return Fail(reflect.TypeOf(FormSubmission{}))
+			}
+		}
+		fieldGens = append(fieldGens, gens)
+	}
+
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		submission := FormSubmission{Values: url.Values{}, Errors: map[string]string{}}
+		// the shrinkers of the generated values by field
+		shrinkers := map[string]gopter.Shrinker{}
+		for i, field := range fields {
+			gens := fieldGens[i]
+			fieldGen := gens[0]
+			if genParams.Rng.Intn(4) == 0 {
+				if len(gens) == 1 || field.Required && genParams.Rng.Intn(len(gens)) == 0 {
+					if field.Required {
+						submission.Errors[field.Name] = FormErrorRequired
+					}
+					// missing
+					continue
+				}
+				fieldGen = gens[1+genParams.Rng.Intn(len(gens)-1)]
+			} else if !field.Required && genParams.Rng.Intn(4) == 0 {
+				continue
+			}
+			result := fieldGen.gen(genParams)
+			value, ok := result.Retrieve()
+			if !ok {
+				return gopter.NewEmptyResult(reflect.TypeOf(FormSubmission{}))
+			}
+			submission.Values.Set(field.Name, reflect.ValueOf(value).String())
+			if fieldGen.code != "" {
+				submission.Errors[field.Name] = fieldGen.code
+			}
+			shrinker := result.Shrinker
+			if shrinker == nil {
+				shrinker = gopter.NoShrinker
+			}
+			shrinkers[field.Name] = filteredShrinker(shrinker, func(v interface{}) bool {
+				return result.Sieve == nil || result.Sieve(v)
+			})
+		}
+
+		genResult := gopter.NewGenResult(submission, formSubmissionShrinker(fields, shrinkers))
+		if submission.Valid() {
+			genResult.Labels = []string{"valid"}
+		} else {
+			genResult.Labels = []string{"invalid"}
+		}
+		return genResult
+	}
+}
+
+// formSubmissionShrinker omits optional valid fields first and then shrinks
+// the values of the fields (in the order of the fields)
+func formSubmissionShrinker(fields []FormField, shrinkers map[string]gopter.Shrinker) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		submission := v.(FormSubmission)
+		var omitted []interface{}
+		var shrinks []gopter.Shrink
+		for _, field := range fields {
+			if _, ok := submission.Values[field.Name]; !ok {
+				continue
+			}
+			if _, invalid := submission.Errors[field.Name]; !invalid && !field.Required {
+				omitted = append(omitted, submission.withValue(field.Name, nil))
+			}
+			name := field.Name
+			valueShrink := shrinkers[name](submission.Values.Get(name))
+			shrinks = append(shrinks, func() (interface{}, bool) {
+				value, ok := valueShrink()
+				if !ok {
+					return nil, false
+				}
+				shrunk := reflect.ValueOf(value).String()
+				return submission.withValue(name, &shrunk), true
+			})
+		}
+		return gopter.ConcatShrinks(append([]gopter.Shrink{valuesShrink(omitted)}, shrinks...)...)
+	}
+}
+
+// withValue copies a submission with a changed or omitted (nil) value
+func (f FormSubmission) withValue(name string, value *string) FormSubmission {
+	result := FormSubmission{Values: url.Values{}, Errors: f.Errors}
+	for key, values := range f.Values {
+		result.Values[key] = values
+	}
+	if value == nil {
+		delete(result.Values, name)
+	} else {
+		result.Values.Set(name, *value)
+	}
+	return result
+}
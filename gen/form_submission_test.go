@@ -0,0 +1,97 @@
+package gen_test
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+var signUpFields = []gen.FormField{
+	{
+		Name:     "username",
+		Required: true,
+		Valid:    gen.Username(),
+		Invalid: map[string]gopter.Gen{
+			"too_long": gen.AlphaString().Map(func(s string) string {
+				return strings.Repeat("x", 33) + s
+			}).SuchThat(func(s string) bool { return len(s) > 32 }),
+		},
+	},
+	{
+		Name:     "age",
+		Required: false,
+		Valid:    gen.IntRange(18, 120).Map(strconv.Itoa),
+		Invalid: map[string]gopter.Gen{
+			"not_a_number": gen.Identifier(),
+			"empty":        gen.Const(""),
+		},
+	},
+	{
+		Name:  "newsletter",
+		Valid: gen.OneConstOf("yes", "no"),
+	},
+}
+
+// validateSignUp is the validator under test
+func validateSignUp(values url.Values) map[string]string {
+	errors := map[string]string{}
+	if username, ok := values["username"]; !ok {
+		errors["username"] = gen.FormErrorRequired
+	} else if len(username[0]) > 32 {
+		errors["username"] = "too_long"
+	}
+	if age, ok := values["age"]; ok {
+		if age[0] == "" {
+			errors["age"] = "empty"
+		} else if strings.Trim(age[0], "0123456789") != "" {
+			errors["age"] = "not_a_number"
+		}
+	}
+	return errors
+}
+
+func TestFormSubmissionOf(t *testing.T) {
+	var valid, invalid int
+	commonGeneratorTest(t, "form submission", gen.FormSubmissionOf(signUpFields...), func(value interface{}) bool {
+		submission, ok := value.(gen.FormSubmission)
+		if submission.Valid() {
+			valid++
+		} else {
+			invalid++
+		}
+		return ok && reflect.DeepEqual(validateSignUp(submission.Values), submission.Errors)
+	})
+	if valid == 0 || invalid == 0 {
+		t.Errorf("Invalid distribution: %d valid, %d invalid", valid, invalid)
+	}
+
+	// a validator that ignores empty ages is caught and the submission is
+	// shrunk to the relevant field
+	result := prop.ForAll(func(submission gen.FormSubmission) bool {
+		errors := validateSignUp(submission.Values)
+		if errors["age"] == "empty" {
+			delete(errors, "age")
+		}
+		return reflect.DeepEqual(errors, submission.Errors)
+	}, gen.FormSubmissionOf(signUpFields...)).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	submission := result.Args[0].Arg.(gen.FormSubmission)
+	if submission.Errors["age"] != "empty" || submission.Values.Get("age") != "" || len(submission.Values["newsletter"]) != 0 {
+		t.Errorf("Invalid shrunk submission: %#v", submission)
+	}
+
+	if _, ok := gen.FormSubmissionOf(gen.FormField{Name: "number", Valid: gen.Int()}).Sample(); ok {
+		t.Error("Non-string fields should fail")
+	}
+	if _, ok := gen.FormSubmissionOf(gen.FormField{Name: "missing"}).Sample(); ok {
+		t.Error("Fields without generator should fail")
+	}
+}
@@ -0,0 +1,265 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// Pathologies of a generated printf FormatText
+const (
+	FormatUnknownVerb = "unknown verb"
+	FormatMissingArg  = "missing argument"
+	FormatExtraArg    = "extra argument"
+	FormatWrongType   = "wrong argument type"
+	FormatNoVerb      = "no verb"
+	FormatBadWidth    = "bad width"
+)
+
+// Pathologies of a generated text/template FormatText
+const (
+	TemplateUnclosedAction    = "unclosed action"
+	TemplateMissingEnd        = "missing end"
+	TemplateUnexpectedEnd     = "unexpected end"
+	TemplateUndefinedFunction = "undefined function"
+	TemplateUndefinedTemplate = "undefined template"
+	TemplateInvalidField      = "invalid field"
+)
+
+// FormatText is a generated printf-style format string or text/template
+type FormatText struct {
+	// Text is the format string or template text
+	Text string
+	// Args are the arguments of a printf-style format string
+	Args []interface{}
+	// Data is the data a template is executed with
+	Data map[string]interface{}
+	// Pathology describes how the text was broken (empty for valid texts)
+	Pathology string
+}
+
+// PrintfFormat generates printf-style format strings (as accepted by
+// fmt.Sprintf) with matching arguments. The verbs use random flags, widths
+// and precisions (including '*' widths). If valid is false the format string
+// is broken by an unknown verb, a missing, extra or mistyped argument, a
+// trailing '%' or a non-int '*' width, i.e. fmt.Sprintf will report an
+// error like "%!d(MISSING)" in its output.
+// The used verbs and the pathology are added as labels.
+func PrintfFormat(valid bool) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		format := &FormatText{}
+		labels, lastVerb := genPrintfFormat(genParams, format, !valid)
+		if !valid {
+			breakPrintfFormat(genParams, format, lastVerb)
+			labels = append(labels, format.Pathology)
+		}
+		genResult := gopter.NewGenResult(format, gopter.NoShrinker)
+		genResult.Labels = labels
+		return genResult
+	}
+}
+
+// TextTemplate generates templates for text/template using fields, pipelines,
+// if/else, range, with and nested templates (define/template) together with
+// the data the template can be executed with. If valid is false the template
+// is broken so that either parsing fails (unclosed actions, missing or
+// unexpected ends, undefined functions) or its execution fails (undefined
+// templates, fields of non-struct values).
+// The used actions and the pathology are added as labels.
+func TextTemplate(valid bool) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		format := &FormatText{
+			Data: map[string]interface{}{
+				"Name": formatLiteral(genParams),
+				"Num":  genParams.Rng.Intn(1000),
+				"Flag": genParams.NextBool(),
+				"List": genParams.Rng.Perm(genParams.Rng.Intn(4)),
+			},
+		}
+		tmpl := &templateBuilder{genParams: genParams, labels: map[string]bool{}}
+		format.Text = tmpl.actions(2) + strings.Join(tmpl.defines, "")
+		if !valid {
+			breakTextTemplate(genParams, format)
+			tmpl.labels[format.Pathology] = true
+		}
+		genResult := gopter.NewGenResult(format, gopter.NoShrinker)
+		for label := range tmpl.labels {
+			genResult.Labels = append(genResult.Labels, label)
+		}
+		sort.Strings(genResult.Labels)
+		return genResult
+	}
+}
+
+// formatLiteral generates literal text that is neither a printf verb nor a
+// template action
+func formatLiteral(genParams *gopter.GenParameters) string {
+	const alphabet = "abcxyz ABC-:,.()[]äß€"
+	runes := []rune(alphabet)
+	result := make([]rune, genParams.Rng.Intn(8))
+	for i := range result {
+		result[i] = runes[genParams.Rng.Intn(len(runes))]
+	}
+	return string(result)
+}
+
+var printfVerbs = []struct {
+	verbs string
+	arg   func(genParams *gopter.GenParameters) interface{}
+}{
+	{"dxXobv", func(genParams *gopter.GenParameters) interface{} { return genParams.Rng.Intn(2000) - 1000 }},
+	{"sqvx", func(genParams *gopter.GenParameters) interface{} { return formatLiteral(genParams) }},
+	{"fegEGv", func(genParams *gopter.GenParameters) interface{} { return genParams.Rng.NormFloat64() * 100 }},
+	{"tv", func(genParams *gopter.GenParameters) interface{} { return genParams.NextBool() }},
+}
+
+// genPrintfFormat generates a valid format string (with at least one verb if
+// atLeastOneVerb is true) and returns the position of its last verb
+func genPrintfFormat(genParams *gopter.GenParameters, format *FormatText, atLeastOneVerb bool) ([]string, int) {
+	var text strings.Builder
+	var labels []string
+	lastVerb := -1
+	count := genParams.Rng.Intn(5)
+	if atLeastOneVerb && count == 0 {
+		count = 1
+	}
+	for i := 0; i < count; i++ {
+		text.WriteString(formatLiteral(genParams))
+		if genParams.Rng.Intn(4) == 0 {
+			text.WriteString("%%")
+		}
+		kind := printfVerbs[genParams.Rng.Intn(len(printfVerbs))]
+		verb := kind.verbs[genParams.Rng.Intn(len(kind.verbs))]
+		text.WriteByte('%')
+		text.WriteString([]string{"", "-", "+", "0", " ", "#"}[genParams.Rng.Intn(6)])
+		switch genParams.Rng.Intn(3) {
+		case 1:
+			fmt.Fprintf(&text, "%d", genParams.Rng.Intn(20))
+		case 2:
+			text.WriteByte('*')
+			format.Args = append(format.Args, genParams.Rng.Intn(20))
+		}
+		if genParams.NextBool() {
+			fmt.Fprintf(&text, ".%d", genParams.Rng.Intn(10))
+		}
+		lastVerb = text.Len()
+		text.WriteByte(verb)
+		format.Args = append(format.Args, kind.arg(genParams))
+		labels = append(labels, "%"+string(verb))
+	}
+	text.WriteString(formatLiteral(genParams))
+	format.Text = text.String()
+	return labels, lastVerb
+}
+
+func breakPrintfFormat(genParams *gopter.GenParameters, format *FormatText, lastVerb int) {
+	format.Pathology = []string{
+		FormatUnknownVerb, FormatMissingArg, FormatExtraArg, FormatWrongType, FormatNoVerb, FormatBadWidth,
+	}[genParams.Rng.Intn(6)]
+	switch format.Pathology {
+	case FormatUnknownVerb:
+		unknown := "jkmnryz"[genParams.Rng.Intn(7)]
+		format.Text = format.Text[:lastVerb] + string(unknown) + format.Text[lastVerb+1:]
+	case FormatMissingArg:
+		format.Args = format.Args[:len(format.Args)-1]
+	case FormatExtraArg:
+		format.Args = append(format.Args, genParams.Rng.Intn(100))
+	case FormatWrongType:
+		format.Text += "%d"
+		format.Args = append(format.Args, formatLiteral(genParams))
+	case FormatNoVerb:
+		format.Text += "%"
+	case FormatBadWidth:
+		format.Text += "%*d"
+		format.Args = append(format.Args, formatLiteral(genParams), genParams.Rng.Intn(100))
+	}
+}
+
+type templateBuilder struct {
+	genParams *gopter.GenParameters
+	defines   []string
+	labels    map[string]bool
+}
+
+// actions generates a sequence of literals and actions nested up to depth
+func (b *templateBuilder) actions(depth int) string {
+	var text strings.Builder
+	count := b.genParams.Rng.Intn(4)
+	for i := 0; i < count; i++ {
+		text.WriteString(formatLiteral(b.genParams))
+		text.WriteString(b.action(depth))
+	}
+	text.WriteString(formatLiteral(b.genParams))
+	return text.String()
+}
+
+func (b *templateBuilder) action(depth int) string {
+	choice := b.genParams.Rng.Intn(8)
+	if depth <= 0 {
+		choice = b.genParams.Rng.Intn(3)
+	}
+	switch choice {
+	case 0:
+		b.labels["field"] = true
+		return []string{"{{.Name}}", "{{.Num}}", "{{.Flag}}", "{{.List}}", "{{- .Name -}}"}[b.genParams.Rng.Intn(5)]
+	case 1:
+		b.labels["pipeline"] = true
+		return []string{`{{.Name | printf "%q"}}`, `{{printf "%05d" .Num}}`, "{{len .List}}", "{{.Name | len | print}}"}[b.genParams.Rng.Intn(4)]
+	case 2:
+		b.labels["comment"] = true
+		return "{{/* " + formatLiteral(b.genParams) + " */}}"
+	case 3:
+		b.labels["if"] = true
+		if b.genParams.NextBool() {
+			return "{{if .Flag}}" + b.actions(depth-1) + "{{else}}" + b.actions(depth-1) + "{{end}}"
+		}
+		return "{{if and .Flag (gt .Num 500)}}" + b.actions(depth-1) + "{{end}}"
+	case 4:
+		b.labels["range"] = true
+		return "{{range $i, $e := .List}}{{$i}}={{$e}}" + formatLiteral(b.genParams) + "{{else}}" + b.actions(depth-1) + "{{end}}"
+	case 5:
+		b.labels["with"] = true
+		return "{{with .Name}}{{.}}{{else}}" + b.actions(depth-1) + "{{end}}"
+	case 6:
+		b.labels["variable"] = true
+		return "{{$n := .Num}}{{$n}}"
+	}
+	b.labels["nested template"] = true
+	idx := len(b.defines)
+	name := fmt.Sprintf("t%d", idx)
+	// reserve the name before generating the body (which might define more)
+	b.defines = append(b.defines, "")
+	b.defines[idx] = `{{define "` + name + `"}}` + b.actions(depth-1) + "{{end}}"
+	return `{{template "` + name + `" .}}`
+}
+
+func breakTextTemplate(genParams *gopter.GenParameters, format *FormatText) {
+	format.Pathology = []string{
+		TemplateUnclosedAction, TemplateMissingEnd, TemplateUnexpectedEnd,
+		TemplateUndefinedFunction, TemplateUndefinedTemplate, TemplateInvalidField,
+	}[genParams.Rng.Intn(6)]
+	var broken string
+	switch format.Pathology {
+	case TemplateUnclosedAction:
+		broken = "{{.Name"
+	case TemplateMissingEnd:
+		broken = []string{"{{if .Flag}}", "{{range .List}}", "{{with .Name}}"}[genParams.Rng.Intn(3)]
+	case TemplateUnexpectedEnd:
+		broken = "{{end}}"
+	case TemplateUndefinedFunction:
+		broken = "{{undefined .Num}}"
+	case TemplateUndefinedTemplate:
+		broken = `{{template "undefined" .}}`
+	case TemplateInvalidField:
+		broken = "{{.Num.Value}}"
+	}
+	// insert at the start or end, so that the broken part is not hidden in an
+	// action or a branch that is not executed
+	if format.Pathology != TemplateUnclosedAction && genParams.NextBool() {
+		format.Text = broken + format.Text
+	} else {
+		format.Text += broken
+	}
+}
@@ -0,0 +1,65 @@
+package gen_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestPrintfFormat(t *testing.T) {
+	for _, valid := range []bool{true, false} {
+		valid := valid
+		pathologies := map[string]bool{}
+		commonGeneratorTest(t, "printf format", gen.PrintfFormat(valid), func(value interface{}) bool {
+			format, ok := value.(*gen.FormatText)
+			if !ok || (format.Pathology == "") != valid {
+				return false
+			}
+			pathologies[format.Pathology] = true
+			output := fmt.Sprintf(format.Text, format.Args...)
+			if strings.Contains(output, "%!") == valid {
+				t.Logf("Invalid format %q %v: %s", format.Text, format.Args, output)
+				return false
+			}
+			return true
+		})
+		if !valid && len(pathologies) != 6 {
+			t.Errorf("Not all pathologies generated: %v", pathologies)
+		}
+	}
+}
+
+func TestTextTemplate(t *testing.T) {
+	for _, valid := range []bool{true, false} {
+		valid := valid
+		pathologies := map[string]bool{}
+		nested := false
+		commonGeneratorTest(t, "text template", gen.TextTemplate(valid), func(value interface{}) bool {
+			format, ok := value.(*gen.FormatText)
+			if !ok || (format.Pathology == "") != valid {
+				return false
+			}
+			pathologies[format.Pathology] = true
+			nested = nested || strings.Contains(format.Text, "{{define")
+			tmpl, err := template.New("test").Parse(format.Text)
+			if err == nil {
+				err = tmpl.Execute(&bytes.Buffer{}, format.Data)
+			}
+			if (err == nil) != valid {
+				t.Logf("Invalid template %q: %v", format.Text, err)
+				return false
+			}
+			return true
+		})
+		if !valid && len(pathologies) != 6 {
+			t.Errorf("Not all pathologies generated: %v", pathologies)
+		}
+		if !nested {
+			t.Error("No nested templates generated")
+		}
+	}
+}
@@ -9,6 +9,10 @@ import (
 // Frequency combines multiple weighted generators of the the same result type
 // The generators from weightedGens will be used accrding to the weight, i.e. generators
 // with a hight weight will be used more often than generators with a low weight.
+// Note: The weights are cumulative thresholds, i.e. for the weights 2 and 9 the
+// generators are used with a probability of 3/10 and 7/10. Use Weighted for
+// weights that are proportional to the probabilities (e.g. 90% valid inputs
+// and 10% edge cases).
 func Frequency(weightedGens map[int]gopter.Gen) gopter.Gen {
 	if len(weightedGens) == 0 {
 		return Fail(nil)
@@ -28,6 +32,8 @@ func Frequency(weightedGens map[int]gopter.Gen) gopter.Gen {
 
 		result := gen(genParams)
 		result.Sieve = nil
+		// the domain is the one of the chosen generator only
+		result.Domain = nil
 		return result
 	}
 }
@@ -0,0 +1,197 @@
+package gen
+
+import (
+	"math"
+
+	"github.com/leanovate/gopter"
+)
+
+// GeoJSONGeometry is a generated GeoJSON geometry (RFC 7946)
+type GeoJSONGeometry struct {
+	// Type is "Point", "LineString" or "Polygon"
+	Type string `json:"type"`
+	// Coordinates are a position ([]float64 with longitude and latitude) for
+	// points, a [][]float64 for line strings and a [][][]float64 of linear
+	// rings for polygons
+	Coordinates interface{} `json:"coordinates"`
+	// Valid is false for self-intersecting polygons
+	Valid bool `json:"-"`
+}
+
+// GeoJSONFeature is a generated GeoJSON feature
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   GeoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSONFeatureCollection is a generated GeoJSON feature collection
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// geoEdgePositions are positions at the poles, the antimeridian and the null
+// island
+var geoEdgePositions = [][]float64{{0, 0}, {180, 0}, {-180, 0}, {0, 90}, {0, -90}, {180, 90}, {-180, -90}}
+
+// GeoJSONPoint generates GeoJSON points with longitudes in [-180, 180] and
+// latitudes in [-90, 90] (6 decimal places) including the edge cases of the
+// poles and the antimeridian.
+func GeoJSONPoint() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var labels []string
+		position := []float64{geoRound(360*genParams.Rng.Float64() - 180), geoRound(180*genParams.Rng.Float64() - 90)}
+		if genParams.Rng.Intn(5) == 0 {
+			labels = append(labels, "edge position")
+			position = append([]float64{}, geoEdgePositions[genParams.Rng.Intn(len(geoEdgePositions))]...)
+		}
+		genResult := gopter.NewGenResult(GeoJSONGeometry{Type: "Point", Coordinates: position, Valid: true}, gopter.NoShrinker)
+		genResult.Labels = labels
+		return genResult
+	}
+}
+
+// GeoJSONLineString generates GeoJSON line strings of 2 to 10 positions
+// within a region of a few degrees.
+// The line strings shrink by removing positions.
+func GeoJSONLineString() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		center := genGeoCenter(genParams)
+		positions := make([][]float64, 2+genParams.Rng.Intn(9))
+		for i := range positions {
+			positions[i] = geoOffset(center, 10*genParams.Rng.Float64()-5, 10*genParams.Rng.Float64()-5)
+		}
+		return gopter.NewGenResult(GeoJSONGeometry{Type: "LineString", Coordinates: positions, Valid: true}, GeoJSONGeometryShrinker)
+	}
+}
+
+// GeoJSONPolygon generates GeoJSON polygons with an exterior ring in
+// counterclockwise and an optional hole in clockwise order (right-hand rule of
+// RFC 7946). All rings are closed and simple.
+// If selfIntersections is true, self-intersecting ("bowtie") polygons are
+// generated as well, which are not Valid and labeled as "invalid".
+// The polygons shrink by removing holes.
+func GeoJSONPolygon(selfIntersections bool) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		polygon := genGeoPolygon(genParams, selfIntersections && genParams.Rng.Intn(4) == 0)
+		genResult := gopter.NewGenResult(polygon, GeoJSONGeometryShrinker)
+		if !polygon.Valid {
+			genResult.Labels = []string{"invalid"}
+		} else if rings := polygon.Coordinates.([][][]float64); len(rings) > 1 {
+			genResult.Labels = []string{"hole"}
+		}
+		return genResult
+	}
+}
+
+// GeoJSONFeatureCollections generates feature collections of up to 5
+// features with points, line strings and (valid) polygons and properties of
+// scalar JSON values.
+// The collections shrink by removing features and shrinking their
+// geometries.
+func GeoJSONFeatureCollections() gopter.Gen {
+	geometries := []gopter.Gen{GeoJSONPoint(), GeoJSONLineString(), GeoJSONPolygon(false)}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		collection := GeoJSONFeatureCollection{Type: "FeatureCollection", Features: []GeoJSONFeature{}}
+		count := genParams.Rng.Intn(6)
+		for i := 0; i < count; i++ {
+			geometry, _ := geometries[genParams.Rng.Intn(len(geometries))](genParams).Retrieve()
+			properties := map[string]interface{}{}
+			for j := genParams.Rng.Intn(3); j > 0; j-- {
+				properties[genJSONString(genParams)] = genJSONValue(genParams, 0, map[string]bool{})
+			}
+			collection.Features = append(collection.Features, GeoJSONFeature{
+				Type:       "Feature",
+				Geometry:   geometry.(GeoJSONGeometry),
+				Properties: properties,
+			})
+		}
+		return gopter.NewGenResult(collection, GeoJSONFeatureCollectionShrinker)
+	}
+}
+
+// genGeoCenter generates a position at least 10 degrees away from the poles
+// and the antimeridian
+func genGeoCenter(genParams *gopter.GenParameters) []float64 {
+	return []float64{340*genParams.Rng.Float64() - 170, 160*genParams.Rng.Float64() - 80}
+}
+
+func geoOffset(center []float64, dLon, dLat float64) []float64 {
+	return []float64{geoRound(center[0] + dLon), geoRound(center[1] + dLat)}
+}
+
+func geoRound(degrees float64) float64 {
+	return math.Round(degrees*1e6) / 1e6
+}
+
+// genGeoPolygon creates star shaped rings: n vertices at evenly spaced
+// (jittered) angles around the center, the exterior vertices in a distance
+// of [r/2, r] and the hole within r/8 of the center (which is inside the
+// exterior ring as the angles between its vertices are at most 108 degrees)
+func genGeoPolygon(genParams *gopter.GenParameters, bowtie bool) GeoJSONGeometry {
+	center := genGeoCenter(genParams)
+	radius := 0.1 + 5*genParams.Rng.Float64()
+	if bowtie {
+		dLon, dLat := radius*(0.1+genParams.Rng.Float64()), radius*(0.1+genParams.Rng.Float64())
+		ring := [][]float64{
+			geoOffset(center, -dLon, -dLat), geoOffset(center, dLon, dLat),
+			geoOffset(center, dLon, -dLat), geoOffset(center, -dLon, dLat),
+		}
+		return GeoJSONGeometry{Type: "Polygon", Coordinates: [][][]float64{append(ring, ring[0])}, Valid: false}
+	}
+	rings := [][][]float64{genGeoRing(genParams, center, radius/2, radius, false)}
+	if genParams.NextBool() {
+		holeCenter := geoOffset(center, radius/8*(genParams.Rng.Float64()-0.5), radius/8*(genParams.Rng.Float64()-0.5))
+		rings = append(rings, genGeoRing(genParams, holeCenter, radius/32, radius/16, true))
+	}
+	return GeoJSONGeometry{Type: "Polygon", Coordinates: rings, Valid: true}
+}
+
+func genGeoRing(genParams *gopter.GenParameters, center []float64, minRadius, maxRadius float64, clockwise bool) [][]float64 {
+	n := 5 + genParams.Rng.Intn(6)
+	ring := make([][]float64, n, n+1)
+	for i := range ring {
+		angle := (float64(i) + 0.5*genParams.Rng.Float64() - 0.25) * 2 * math.Pi / float64(n)
+		if clockwise {
+			angle = -angle
+		}
+		distance := minRadius + (maxRadius-minRadius)*genParams.Rng.Float64()
+		ring[i] = geoOffset(center, distance*math.Cos(angle), distance*math.Sin(angle))
+	}
+	return append(ring, append([]float64{}, ring[0]...))
+}
+
+// GeoJSONGeometryShrinker shrinks line strings by removing positions and
+// polygons by removing holes
+func GeoJSONGeometryShrinker(v interface{}) gopter.Shrink {
+	geometry := v.(GeoJSONGeometry)
+	switch coordinates := geometry.Coordinates.(type) {
+	case [][]float64:
+		return SliceShrinker(gopter.NoShrinker)(coordinates).Filter(func(v interface{}) bool {
+			return len(v.([][]float64)) >= 2
+		}).Map(func(positions [][]float64) GeoJSONGeometry {
+			return GeoJSONGeometry{Type: geometry.Type, Coordinates: positions, Valid: geometry.Valid}
+		})
+	case [][][]float64:
+		if len(coordinates) > 1 {
+			return valuesShrink([]interface{}{GeoJSONGeometry{Type: geometry.Type, Coordinates: coordinates[:1], Valid: geometry.Valid}})
+		}
+	}
+	return gopter.NoShrink
+}
+
+// GeoJSONFeatureCollectionShrinker shrinks a feature collection by removing
+// features and shrinking their geometries
+func GeoJSONFeatureCollectionShrinker(v interface{}) gopter.Shrink {
+	collection := v.(GeoJSONFeatureCollection)
+	featureShrinker := func(v interface{}) gopter.Shrink {
+		feature := v.(GeoJSONFeature)
+		return GeoJSONGeometryShrinker(feature.Geometry).Map(func(geometry GeoJSONGeometry) GeoJSONFeature {
+			return GeoJSONFeature{Type: feature.Type, Geometry: geometry, Properties: feature.Properties}
+		})
+	}
+	return SliceShrinker(featureShrinker)(collection.Features).Map(func(features []GeoJSONFeature) GeoJSONFeatureCollection {
+		return GeoJSONFeatureCollection{Type: collection.Type, Features: features}
+	})
+}
@@ -0,0 +1,128 @@
+package gen_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func validGeoPosition(position []float64) bool {
+	return len(position) == 2 && position[0] >= -180 && position[0] <= 180 && position[1] >= -90 && position[1] <= 90
+}
+
+// ringArea is the signed area of a closed ring (positive if
+// counterclockwise)
+func ringArea(ring [][]float64) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+func segmentsIntersect(a, b, c, d []float64) bool {
+	orientation := func(p, q, r []float64) float64 {
+		return (q[0]-p[0])*(r[1]-p[1]) - (q[1]-p[1])*(r[0]-p[0])
+	}
+	return orientation(a, b, c)*orientation(a, b, d) < 0 && orientation(c, d, a)*orientation(c, d, b) < 0
+}
+
+func selfIntersecting(ring [][]float64) bool {
+	for i := 0; i < len(ring)-1; i++ {
+		for j := i + 2; j < len(ring)-1; j++ {
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validPolygon(rings [][][]float64) bool {
+	for i, ring := range rings {
+		if len(ring) < 4 || ring[0][0] != ring[len(ring)-1][0] || ring[0][1] != ring[len(ring)-1][1] ||
+			selfIntersecting(ring) || (ringArea(ring) > 0) != (i == 0) {
+			return false
+		}
+		for _, position := range ring {
+			if !validGeoPosition(position) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestGeoJSONGeometries(t *testing.T) {
+	commonGeneratorTest(t, "GeoJSON point", gen.GeoJSONPoint(), func(value interface{}) bool {
+		point, ok := value.(gen.GeoJSONGeometry)
+		return ok && point.Type == "Point" && point.Valid && validGeoPosition(point.Coordinates.([]float64))
+	})
+	commonGeneratorTest(t, "GeoJSON line string", gen.GeoJSONLineString(), func(value interface{}) bool {
+		line, ok := value.(gen.GeoJSONGeometry)
+		if !ok || line.Type != "LineString" || len(line.Coordinates.([][]float64)) < 2 {
+			return false
+		}
+		for _, position := range line.Coordinates.([][]float64) {
+			if !validGeoPosition(position) {
+				return false
+			}
+		}
+		return true
+	})
+	commonGeneratorTest(t, "GeoJSON polygon", gen.GeoJSONPolygon(false), func(value interface{}) bool {
+		polygon, ok := value.(gen.GeoJSONGeometry)
+		return ok && polygon.Type == "Polygon" && polygon.Valid && validPolygon(polygon.Coordinates.([][][]float64))
+	})
+
+	invalid := 0
+	parameters := gopter.DefaultGenParameters()
+	for i := 0; i < 200; i++ {
+		polygon := gen.GeoJSONPolygon(true)(parameters).Result.(gen.GeoJSONGeometry)
+		rings := polygon.Coordinates.([][][]float64)
+		if polygon.Valid != validPolygon(rings) {
+			t.Errorf("Invalid polygon: %#v", polygon)
+		}
+		if !polygon.Valid {
+			invalid++
+			if !selfIntersecting(rings[0]) {
+				t.Errorf("Polygon should be self-intersecting: %#v", polygon)
+			}
+		}
+	}
+	if invalid == 0 {
+		t.Error("No self-intersecting polygons generated")
+	}
+}
+
+func TestGeoJSONFeatureCollections(t *testing.T) {
+	commonGeneratorTest(t, "GeoJSON feature collection", gen.GeoJSONFeatureCollections(), func(value interface{}) bool {
+		collection, ok := value.(gen.GeoJSONFeatureCollection)
+		if !ok || collection.Type != "FeatureCollection" || len(collection.Features) > 5 {
+			return false
+		}
+		data, err := json.Marshal(collection)
+		var decoded map[string]interface{}
+		return err == nil && json.Unmarshal(data, &decoded) == nil && len(decoded["features"].([]interface{})) == len(collection.Features)
+	})
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(collection gen.GeoJSONFeatureCollection) bool {
+		for _, feature := range collection.Features {
+			if feature.Geometry.Type == "LineString" {
+				return false
+			}
+		}
+		return true
+	}, gen.GeoJSONFeatureCollections()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	collection := result.Args[0].Arg.(gen.GeoJSONFeatureCollection)
+	if len(collection.Features) != 1 || len(collection.Features[0].Geometry.Coordinates.([][]float64)) != 2 {
+		t.Errorf("Invalid shrunk collection: %#v", collection)
+	}
+}
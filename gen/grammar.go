@@ -0,0 +1,435 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/leanovate/gopter"
+)
+
+// DefaultGrammarDepth is the maximum derivation depth used by GrammarMatch
+const DefaultGrammarDepth = 10
+
+type grammarExprKind int
+
+const (
+	grammarTerminal grammarExprKind = iota
+	grammarRef
+	grammarSeq
+	grammarAlt
+	grammarRep
+	grammarOpt
+)
+
+type grammarExpr struct {
+	kind    grammarExprKind
+	text    string
+	subs    []*grammarExpr
+	weights []int
+}
+
+// Grammar is a parsed EBNF-like grammar that can be used to generate strings
+// of its language.
+//
+// The supported syntax is a small subset of EBNF:
+//
+//	rule    = name ( "=" | "::=" ) expr [ ";" | "." ]
+//	expr    = [ "<" weight ">" ] seq { "|" [ "<" weight ">" ] seq }
+//	seq     = { term }
+//	term    = name | '"' text '"' | "'" text "'" |
+//	          "(" expr ")" | "[" expr "]" | "{" expr "}"
+//
+// where "[ ... ]" is optional, "{ ... }" is repeated zero or more times and
+// the optional "<weight>" prefix of an alternative defines how often it is
+// chosen relative to its siblings (default 1).
+type Grammar struct {
+	rules     map[string]*grammarExpr
+	ruleNames []string
+	minDepths map[string]int
+}
+
+// ParseGrammar parses an EBNF-like grammar (see Grammar for the syntax).
+func ParseGrammar(grammarStr string) (*Grammar, error) {
+	tokens, err := tokenizeGrammar(grammarStr)
+	if err != nil {
+		return nil, err
+	}
+	parser := &grammarParser{tokens: tokens}
+	grammar := &Grammar{
+		rules:     make(map[string]*grammarExpr),
+		ruleNames: make([]string, 0),
+	}
+	for !parser.done() {
+		name, expr, err := parser.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := grammar.rules[name]; ok {
+			return nil, fmt.Errorf("Rule %s is defined more than once", name)
+		}
+		grammar.rules[name] = expr
+		grammar.ruleNames = append(grammar.ruleNames, name)
+	}
+	if len(grammar.ruleNames) == 0 {
+		return nil, fmt.Errorf("Grammar does not contain any rule")
+	}
+	for _, name := range grammar.ruleNames {
+		if undefined := grammar.undefinedRef(grammar.rules[name]); undefined != "" {
+			return nil, fmt.Errorf("Rule %s refers to undefined rule %s", name, undefined)
+		}
+	}
+	grammar.calculateMinDepths()
+	return grammar, nil
+}
+
+// Gen creates a generator for strings derived from the rule "start".
+// maxDepth limits the nesting of rule expansions, once reached only the
+// shortest derivations are chosen.
+// Generated strings are shrunk by pruning their derivation tree, hence shrunk
+// values are still part of the language.
+func (g *Grammar) Gen(start string, maxDepth int) gopter.Gen {
+	if minDepth, ok := g.minDepths[start]; !ok || minDepth == math.MaxInt32 {
+		return Fail(reflect.TypeOf(""))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		root := g.derive(&grammarExpr{kind: grammarRef, text: start}, genParams, 0, maxDepth)
+		shrinker := &grammarShrinker{
+			trees: map[string]*grammarNode{},
+		}
+		value := root.String()
+		shrinker.trees[value] = root
+		return gopter.NewGenResult(value, shrinker.Shrink)
+	}
+}
+
+// GrammarMatch generates strings of the language defined by an EBNF-like
+// grammar (see Grammar for the syntax). The first rule of the grammar is used
+// as start symbol and the derivation depth is limited to DefaultGrammarDepth.
+// Like RegexMatch an invalid grammar will result in a failing generator.
+func GrammarMatch(grammarStr string) gopter.Gen {
+	grammar, err := ParseGrammar(grammarStr)
+	if err != nil {
+		return Fail(reflect.TypeOf(""))
+	}
+	return grammar.Gen(grammar.ruleNames[0], DefaultGrammarDepth)
+}
+
+func (g *Grammar) undefinedRef(expr *grammarExpr) string {
+	if expr.kind == grammarRef {
+		if _, ok := g.rules[expr.text]; !ok {
+			return expr.text
+		}
+	}
+	for _, sub := range expr.subs {
+		if undefined := g.undefinedRef(sub); undefined != "" {
+			return undefined
+		}
+	}
+	return ""
+}
+
+// calculateMinDepths determines the minimal derivation depth of each rule
+// by fixpoint iteration. Rules that cannot terminate keep math.MaxInt32.
+func (g *Grammar) calculateMinDepths() {
+	g.minDepths = make(map[string]int, len(g.rules))
+	for name := range g.rules {
+		g.minDepths[name] = math.MaxInt32
+	}
+	for changed := true; changed; {
+		changed = false
+		for name, expr := range g.rules {
+			if depth := g.minDepth(expr); depth < g.minDepths[name] {
+				g.minDepths[name] = depth
+				changed = true
+			}
+		}
+	}
+}
+
+func (g *Grammar) minDepth(expr *grammarExpr) int {
+	switch expr.kind {
+	case grammarRef:
+		depth := g.minDepths[expr.text]
+		if depth == math.MaxInt32 {
+			return depth
+		}
+		return depth + 1
+	case grammarSeq:
+		max := 0
+		for _, sub := range expr.subs {
+			if depth := g.minDepth(sub); depth > max {
+				max = depth
+			}
+		}
+		return max
+	case grammarAlt:
+		min := math.MaxInt32
+		for _, sub := range expr.subs {
+			if depth := g.minDepth(sub); depth < min {
+				min = depth
+			}
+		}
+		return min
+	}
+	return 0
+}
+
+func (g *Grammar) derive(expr *grammarExpr, genParams *gopter.GenParameters, depth, maxDepth int) *grammarNode {
+	node := &grammarNode{expr: expr}
+	switch expr.kind {
+	case grammarTerminal:
+		node.text = expr.text
+	case grammarRef:
+		node.children = []*grammarNode{g.derive(g.rules[expr.text], genParams, depth+1, maxDepth)}
+	case grammarSeq:
+		node.children = make([]*grammarNode, len(expr.subs))
+		for i, sub := range expr.subs {
+			node.children[i] = g.derive(sub, genParams, depth, maxDepth)
+		}
+	case grammarAlt:
+		node.children = []*grammarNode{g.derive(g.chooseAlternative(expr, genParams, depth, maxDepth), genParams, depth, maxDepth)}
+	case grammarRep:
+		count := 0
+		if depth < maxDepth && g.minDepth(expr.subs[0]) != math.MaxInt32 {
+			count = genParams.Rng.Intn(maxDepth - depth + 1)
+		}
+		node.children = make([]*grammarNode, count)
+		for i := range node.children {
+			node.children[i] = g.derive(expr.subs[0], genParams, depth, maxDepth)
+		}
+	case grammarOpt:
+		if depth < maxDepth && g.minDepth(expr.subs[0]) != math.MaxInt32 && genParams.NextBool() {
+			node.children = []*grammarNode{g.derive(expr.subs[0], genParams, depth, maxDepth)}
+		}
+	}
+	return node
+}
+
+func (g *Grammar) chooseAlternative(expr *grammarExpr, genParams *gopter.GenParameters, depth, maxDepth int) *grammarExpr {
+	if depth >= maxDepth {
+		// Depth exhausted: stick to the alternatives terminating the fastest
+		best := expr.subs[0]
+		bestDepth := g.minDepth(best)
+		for _, sub := range expr.subs[1:] {
+			if subDepth := g.minDepth(sub); subDepth < bestDepth {
+				best, bestDepth = sub, subDepth
+			}
+		}
+		return best
+	}
+	total := 0
+	for i, sub := range expr.subs {
+		if g.minDepth(sub) != math.MaxInt32 {
+			total += expr.weights[i]
+		}
+	}
+	choice := genParams.Rng.Intn(total)
+	for i, sub := range expr.subs {
+		if g.minDepth(sub) == math.MaxInt32 {
+			continue
+		}
+		if choice < expr.weights[i] {
+			return sub
+		}
+		choice -= expr.weights[i]
+	}
+	return expr.subs[len(expr.subs)-1]
+}
+
+type grammarToken struct {
+	kind  rune // 'i': identifier, 's': string literal, 'n': number, otherwise the symbol itself
+	value string
+}
+
+func tokenizeGrammar(grammarStr string) ([]grammarToken, error) {
+	tokens := make([]grammarToken, 0)
+	runes := []rune(grammarStr)
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '(' && i+1 < len(runes) && runes[i+1] == '*':
+			end := i + 2
+			for end+1 < len(runes) && (runes[end] != '*' || runes[end+1] != ')') {
+				end++
+			}
+			if end+1 >= len(runes) {
+				return nil, fmt.Errorf("Unterminated comment at %d", i)
+			}
+			i = end + 2
+		case ch == ':' && i+2 < len(runes) && runes[i+1] == ':' && runes[i+2] == '=':
+			tokens = append(tokens, grammarToken{kind: '='})
+			i += 3
+		case strings.ContainsRune("=|()[]{};.<>", ch):
+			tokens = append(tokens, grammarToken{kind: ch})
+			i++
+		case ch == '"' || ch == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != ch {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("Unterminated string literal at %d", i)
+			}
+			text := string(runes[i+1 : end])
+			if ch == '"' {
+				unquoted, err := strconv.Unquote(string(runes[i : end+1]))
+				if err != nil {
+					return nil, fmt.Errorf("Invalid string literal at %d: %v", i, err)
+				}
+				text = unquoted
+			}
+			tokens = append(tokens, grammarToken{kind: 's', value: text})
+			i = end + 1
+		case unicode.IsDigit(ch):
+			end := i
+			for end < len(runes) && unicode.IsDigit(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, grammarToken{kind: 'n', value: string(runes[i:end])})
+			i = end
+		case unicode.IsLetter(ch) || ch == '_':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_' || runes[end] == '-') {
+				end++
+			}
+			tokens = append(tokens, grammarToken{kind: 'i', value: string(runes[i:end])})
+			i = end
+		default:
+			return nil, fmt.Errorf("Unexpected character %q at %d", ch, i)
+		}
+	}
+	return tokens, nil
+}
+
+type grammarParser struct {
+	tokens []grammarToken
+	pos    int
+}
+
+func (p *grammarParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *grammarParser) peek(offset int) rune {
+	if p.pos+offset >= len(p.tokens) {
+		return 0
+	}
+	return p.tokens[p.pos+offset].kind
+}
+
+func (p *grammarParser) expect(kind rune) error {
+	if p.peek(0) != kind {
+		return fmt.Errorf("Expected %q at token %d", kind, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *grammarParser) parseRule() (string, *grammarExpr, error) {
+	if p.peek(0) != 'i' {
+		return "", nil, fmt.Errorf("Expected rule name at token %d", p.pos)
+	}
+	name := p.tokens[p.pos].value
+	p.pos++
+	if err := p.expect('='); err != nil {
+		return "", nil, err
+	}
+	expr, err := p.parseAlternatives()
+	if err != nil {
+		return "", nil, err
+	}
+	if p.peek(0) == ';' || p.peek(0) == '.' {
+		p.pos++
+	} else if !p.done() && !p.atRuleStart() {
+		return "", nil, fmt.Errorf("Unexpected token at %d in rule %s", p.pos, name)
+	}
+	return name, expr, nil
+}
+
+func (p *grammarParser) atRuleStart() bool {
+	return p.peek(0) == 'i' && p.peek(1) == '='
+}
+
+func (p *grammarParser) parseAlternatives() (*grammarExpr, error) {
+	alt := &grammarExpr{kind: grammarAlt}
+	for {
+		weight := 1
+		if p.peek(0) == '<' {
+			if p.peek(1) != 'n' || p.peek(2) != '>' {
+				return nil, fmt.Errorf("Invalid weight at token %d", p.pos)
+			}
+			weight, _ = strconv.Atoi(p.tokens[p.pos+1].value)
+			if weight <= 0 {
+				return nil, fmt.Errorf("Weight has to be positive at token %d", p.pos)
+			}
+			p.pos += 3
+		}
+		seq, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		alt.subs = append(alt.subs, seq)
+		alt.weights = append(alt.weights, weight)
+		if p.peek(0) != '|' {
+			break
+		}
+		p.pos++
+	}
+	if len(alt.subs) == 1 {
+		return alt.subs[0], nil
+	}
+	return alt, nil
+}
+
+func (p *grammarParser) parseSequence() (*grammarExpr, error) {
+	seq := &grammarExpr{kind: grammarSeq}
+	for !p.done() && !p.atRuleStart() {
+		var term *grammarExpr
+		switch p.peek(0) {
+		case 'i':
+			term = &grammarExpr{kind: grammarRef, text: p.tokens[p.pos].value}
+			p.pos++
+		case 's':
+			term = &grammarExpr{kind: grammarTerminal, text: p.tokens[p.pos].value}
+			p.pos++
+		case '(', '[', '{':
+			open := p.peek(0)
+			p.pos++
+			sub, err := p.parseAlternatives()
+			if err != nil {
+				return nil, err
+			}
+			switch open {
+			case '(':
+				term = sub
+				err = p.expect(')')
+			case '[':
+				term = &grammarExpr{kind: grammarOpt, subs: []*grammarExpr{sub}}
+				err = p.expect(']')
+			case '{':
+				term = &grammarExpr{kind: grammarRep, subs: []*grammarExpr{sub}}
+				err = p.expect('}')
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if term == nil {
+			break
+		}
+		seq.subs = append(seq.subs, term)
+	}
+	if len(seq.subs) == 1 {
+		return seq.subs[0], nil
+	}
+	return seq, nil
+}
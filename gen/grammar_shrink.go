@@ -0,0 +1,155 @@
+package gen
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/leanovate/gopter"
+)
+
+// grammarNode is a node of the derivation tree of a generated string
+type grammarNode struct {
+	expr     *grammarExpr
+	text     string
+	children []*grammarNode
+}
+
+func (n *grammarNode) String() string {
+	var builder strings.Builder
+	n.writeTo(&builder)
+	return builder.String()
+}
+
+func (n *grammarNode) writeTo(builder *strings.Builder) {
+	builder.WriteString(n.text)
+	for _, child := range n.children {
+		child.writeTo(builder)
+	}
+}
+
+func (n *grammarNode) withChildren(children []*grammarNode) *grammarNode {
+	return &grammarNode{
+		expr:     n.expr,
+		text:     n.text,
+		children: children,
+	}
+}
+
+// prunings returns all trees derived from n by a single pruning step:
+// replacing a rule expansion by a nested expansion of the same rule,
+// replacing a rule expansion by an expansion of the same rule from an element
+// of a following repetition (dropping that element), dropping an element of a
+// repetition or dropping an optional part.
+func (n *grammarNode) prunings() []*grammarNode {
+	result := make([]*grammarNode, 0)
+	if n.expr.kind == grammarRef {
+		for _, child := range n.children {
+			result = append(result, child.nestedRefs(n.expr.text)...)
+		}
+	}
+	switch {
+	case n.expr.kind == grammarRep:
+		for i := range n.children {
+			children := make([]*grammarNode, 0, len(n.children)-1)
+			children = append(children, n.children[:i]...)
+			children = append(children, n.children[i+1:]...)
+			result = append(result, n.withChildren(children))
+		}
+	case n.expr.kind == grammarOpt && len(n.children) > 0:
+		result = append(result, n.withChildren(nil))
+	case n.expr.kind == grammarSeq:
+		result = append(result, n.hoistedRefs()...)
+	}
+	for i, child := range n.children {
+		for _, pruned := range child.prunings() {
+			children := make([]*grammarNode, len(n.children))
+			copy(children, n.children)
+			children[i] = pruned
+			result = append(result, n.withChildren(children))
+		}
+	}
+	return result
+}
+
+// hoistedRefs returns the trees derived from a sequence like
+// `term { "+" term }` by replacing the leading term with a term of an element
+// of the repetition and dropping that element.
+// Otherwise a part of the leading term could only be kept by dropping all of
+// the following elements.
+func (n *grammarNode) hoistedRefs() []*grammarNode {
+	result := make([]*grammarNode, 0)
+	for i, child := range n.children {
+		if child.expr.kind != grammarRef {
+			continue
+		}
+		for j := i + 1; j < len(n.children); j++ {
+			rep := n.children[j]
+			if rep.expr.kind != grammarRep {
+				continue
+			}
+			for k, elem := range rep.children {
+				for _, ref := range elem.nestedRefs(child.expr.text) {
+					elems := make([]*grammarNode, 0, len(rep.children)-1)
+					elems = append(elems, rep.children[:k]...)
+					elems = append(elems, rep.children[k+1:]...)
+					children := make([]*grammarNode, len(n.children))
+					copy(children, n.children)
+					children[i] = ref
+					children[j] = rep.withChildren(elems)
+					result = append(result, n.withChildren(children))
+				}
+			}
+		}
+	}
+	return result
+}
+
+func (n *grammarNode) nestedRefs(rule string) []*grammarNode {
+	result := make([]*grammarNode, 0)
+	if n.expr.kind == grammarRef && n.expr.text == rule {
+		result = append(result, n)
+	}
+	for _, child := range n.children {
+		result = append(result, child.nestedRefs(rule)...)
+	}
+	return result
+}
+
+// grammarShrinker shrinks strings by their derivation tree.
+// Since a Shrinker only gets the string value, the trees of all candidates
+// of the last shrink are remembered.
+type grammarShrinker struct {
+	sync.Mutex
+	trees map[string]*grammarNode
+}
+
+func (s *grammarShrinker) Shrink(v interface{}) gopter.Shrink {
+	value := v.(string)
+	s.Lock()
+	tree, ok := s.trees[value]
+	if ok {
+		s.trees = map[string]*grammarNode{value: tree}
+	}
+	s.Unlock()
+	if !ok {
+		return gopter.NoShrink
+	}
+	candidates := tree.prunings()
+	seen := map[string]bool{value: true}
+	return func() (interface{}, bool) {
+		for len(candidates) > 0 {
+			candidate := candidates[0]
+			candidates = candidates[1:]
+			str := candidate.String()
+			if seen[str] {
+				continue
+			}
+			seen[str] = true
+			s.Lock()
+			s.trees[str] = candidate
+			s.Unlock()
+			return str, true
+		}
+		return nil, false
+	}
+}
@@ -0,0 +1,112 @@
+package gen_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+const arithmeticGrammar = `
+(* simple arithmetic expressions *)
+expr   = term { ( "+" | "-" ) term } ;
+term   = factor { "*" factor } ;
+factor = <3> number | "(" expr ")" ;
+number = digit { digit } ;
+digit  = "0" | "1" | "2" | "3" | "4" | "5" | "6" | "7" | "8" | "9" ;
+`
+
+func balancedArithmetic(str string) bool {
+	depth := 0
+	for _, ch := range str {
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0 && regexp.MustCompile(`^[0-9+\-*()]+$`).MatchString(str)
+}
+
+func TestGrammarMatch(t *testing.T) {
+	commonGeneratorTest(t, "arithmetic grammar", gen.GrammarMatch(arithmeticGrammar), func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && balancedArithmetic(str)
+	})
+
+	bnf := gen.GrammarMatch(`list ::= "[" [ item { "," item } ] "]" item ::= 'a' | list`)
+	commonGeneratorTest(t, "bnf list grammar", bnf, func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && strings.HasPrefix(str, "[") && strings.HasSuffix(str, "]") &&
+			strings.Count(str, "[") == strings.Count(str, "]")
+	})
+
+	invalids := []string{
+		"",
+		"a = b ;",
+		"a = \"unterminated ;",
+		"a = ( \"x\" ;",
+		"a = <0> \"x\" | \"y\" ;",
+		"a = \"x\" ; a = \"y\" ;",
+	}
+	for _, invalid := range invalids {
+		if value, ok := gen.GrammarMatch(invalid).Sample(); ok {
+			t.Errorf("Grammar %#v should fail: %#v", invalid, value)
+		}
+	}
+
+	grammar, err := gen.ParseGrammar("loop = \"x\" loop ;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := grammar.Gen("loop", 5).Sample(); ok {
+		t.Errorf("Non-terminating rule should fail: %#v", value)
+	}
+}
+
+func TestGrammarWeights(t *testing.T) {
+	grammar, err := gen.ParseGrammar(`choice = <1> "a" | <9> "b" ;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	choice := grammar.Gen("choice", 3)
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		value, ok := choice.Sample()
+		if !ok {
+			t.FailNow()
+		}
+		counts[value.(string)]++
+	}
+	if counts["a"] < 50 || counts["a"] > 150 || counts["b"] < 850 {
+		t.Errorf("Invalid distribution: %#v", counts)
+	}
+}
+
+func TestGrammarShrink(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(
+		func(expr string) bool {
+			return !strings.Contains(expr, "(")
+		},
+		gen.GrammarMatch(arithmeticGrammar),
+	).Check(parameters)
+
+	if result.Status != gopter.TestFailed || len(result.Args) != 1 {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	shrunk := result.Args[0].Arg.(string)
+	if !balancedArithmetic(shrunk) || !strings.Contains(shrunk, "(") {
+		t.Errorf("Shrunk value is not in the language: %#v", shrunk)
+	}
+	if len(shrunk) > 5 {
+		t.Errorf("Shrunk value is not minimal: %#v (from %#v)", shrunk, result.Args[0].OrigArg)
+	}
+}
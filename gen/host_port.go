@@ -0,0 +1,205 @@
+package gen
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/leanovate/gopter"
+)
+
+// Pathologies of a generated malformed HostPortAddress
+const (
+	HostPortMissingPort     = "missing port"
+	HostPortScheme          = "scheme prefix"
+	HostPortUnbracketedIPv6 = "unbracketed IPv6"
+	HostPortPortOutOfRange  = "port out of range"
+	HostPortNonNumericPort  = "non-numeric port"
+	HostPortUnclosedBracket = "unclosed bracket"
+)
+
+// HostPortAddress is a generated host:port endpoint
+type HostPortAddress struct {
+	// Address is the endpoint as string (e.g. "[::1]:8080")
+	Address string
+	// Host is the host without brackets (may be empty)
+	Host string
+	// Port is the port (-1 for a missing port)
+	Port int
+	// Pathology describes how the address was broken (empty for valid
+	// addresses)
+	Pathology string
+}
+
+// ListenerAddress is a generated network and address as accepted by net.Listen
+type ListenerAddress struct {
+	// Network is "tcp", "tcp4", "tcp6" or "unix"
+	Network string
+	// Address is a host:port endpoint or the path of a unix socket
+	Address string
+}
+
+var hostPortEdgePorts = []int{0, 1, 80, 443, 1023, 1024, 8080, 65534, 65535}
+
+var hostPortHostnames = []string{"localhost", "example.com", "a.b.example.org", "xn--bcher-kva.example", "host-1", "a"}
+
+var hostPortIPv6Hosts = []string{"::", "::1", "2001:db8::1", "fe80::1%eth0", "::ffff:192.0.2.1", "2001:db8:0:0:0:0:0:1"}
+
+var hostPortSchemes = []string{"http://", "https://", "tcp://", "unix://"}
+
+// HostPort generates valid host:port endpoints (HostPortAddress) as accepted
+// by net.SplitHostPort: IPv4 and (bracketed) IPv6 addresses including zones,
+// hostnames and empty hosts with edge ports like 0, 1 and 65535.
+// The kind of host and edge ports are added as labels.
+func HostPort() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		address, labels := genHostPort(genParams)
+		genResult := gopter.NewGenResult(address, gopter.NoShrinker)
+		genResult.Labels = labels
+		return genResult
+	}
+}
+
+// MalformedHostPort generates host:port endpoints (HostPortAddress) that are
+// rejected by net.SplitHostPort or a strict port parser: missing ports, scheme
+// prefixes, unbracketed IPv6 addresses, ports out of range or non-numeric
+// ports and unclosed brackets.
+// The pathology is added as label.
+func MalformedHostPort() gopter.Gen {
+	pathologies := []string{
+		HostPortMissingPort, HostPortScheme, HostPortUnbracketedIPv6, HostPortPortOutOfRange,
+		HostPortNonNumericPort, HostPortUnclosedBracket,
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		address, _ := genHostPort(genParams)
+		address.Pathology = pathologies[genParams.Rng.Intn(len(pathologies))]
+		host := address.Address[:len(address.Address)-len(strconv.Itoa(address.Port))-1]
+		switch address.Pathology {
+		case HostPortMissingPort:
+			address.Port = -1
+			address.Address = host
+			if genParams.NextBool() {
+				address.Address += ":"
+			}
+		case HostPortScheme:
+			address.Address = hostPortSchemes[genParams.Rng.Intn(len(hostPortSchemes))] + address.Address
+		case HostPortUnbracketedIPv6:
+			address.Host = hostPortIPv6Hosts[genParams.Rng.Intn(len(hostPortIPv6Hosts))]
+			address.Address = address.Host + ":" + strconv.Itoa(address.Port)
+		case HostPortPortOutOfRange:
+			address.Port = []int{-1, 65536, 99999}[genParams.Rng.Intn(3)]
+			address.Address = host + ":" + strconv.Itoa(address.Port)
+		case HostPortNonNumericPort:
+			address.Address = host + ":" + []string{"http", "8o", "80 ", "+80", "0x50"}[genParams.Rng.Intn(5)]
+		case HostPortUnclosedBracket:
+			address.Host = hostPortIPv6Hosts[genParams.Rng.Intn(len(hostPortIPv6Hosts))]
+			address.Address = "[" + address.Host + ":" + strconv.Itoa(address.Port)
+		}
+		genResult := gopter.NewGenResult(address, gopter.NoShrinker)
+		genResult.Labels = []string{address.Pathology}
+		return genResult
+	}
+}
+
+// ListenerConfig generates configurations (ListenerAddress) for net.Listen:
+// tcp, tcp4 and tcp6 networks with matching (or empty) hosts and ephemeral (0)
+// or edge ports, and unix sockets with relative, absolute and abstract paths up
+// to the maximum length of 107 bytes.
+// The network and edge cases are added as labels.
+func ListenerConfig() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var config ListenerAddress
+		var labels []string
+		switch genParams.Rng.Intn(4) {
+		case 0:
+			config.Network = "tcp4"
+			host := []string{"", "0.0.0.0", "127.0.0.1", "localhost"}[genParams.Rng.Intn(4)]
+			port := genListenerPort(genParams, &labels)
+			config.Address = net.JoinHostPort(host, strconv.Itoa(port))
+		case 1:
+			config.Network = "tcp6"
+			host := []string{"", "::", "::1", "localhost"}[genParams.Rng.Intn(4)]
+			port := genListenerPort(genParams, &labels)
+			config.Address = net.JoinHostPort(host, strconv.Itoa(port))
+		case 2:
+			config.Network = "tcp"
+			address, hostLabels := genHostPort(genParams)
+			port := genListenerPort(genParams, &labels)
+			config.Address = net.JoinHostPort(address.Host, strconv.Itoa(port))
+			labels = append(labels, hostLabels[0])
+		default:
+			config.Network = "unix"
+			config.Address = genUnixSocketPath(genParams, &labels)
+		}
+		genResult := gopter.NewGenResult(config, gopter.NoShrinker)
+		genResult.Labels = append([]string{config.Network}, labels...)
+		return genResult
+	}
+}
+
+func genHostPort(genParams *gopter.GenParameters) (HostPortAddress, []string) {
+	var address HostPortAddress
+	var labels []string
+	switch genParams.Rng.Intn(5) {
+	case 0:
+		labels = append(labels, "IPv4")
+		ip := net.IPv4(byte(genParams.Rng.Intn(256)), byte(genParams.Rng.Intn(256)),
+			byte(genParams.Rng.Intn(256)), byte(genParams.Rng.Intn(256)))
+		address.Host = []string{ip.String(), "0.0.0.0", "127.0.0.1", "255.255.255.255"}[genParams.Rng.Intn(4)]
+	case 1:
+		labels = append(labels, "IPv6")
+		address.Host = hostPortIPv6Hosts[genParams.Rng.Intn(len(hostPortIPv6Hosts))]
+	case 2:
+		labels = append(labels, "hostname")
+		address.Host = hostPortHostnames[genParams.Rng.Intn(len(hostPortHostnames))]
+	case 3:
+		labels = append(labels, "empty host")
+	default:
+		labels = append(labels, "random IPv6")
+		ip := make(net.IP, net.IPv6len)
+		for i := range ip {
+			ip[i] = byte(genParams.Rng.Intn(256))
+		}
+		address.Host = ip.String()
+	}
+	if genParams.NextBool() {
+		address.Port = hostPortEdgePorts[genParams.Rng.Intn(len(hostPortEdgePorts))]
+		labels = append(labels, fmt.Sprintf("port %d", address.Port))
+	} else {
+		address.Port = genParams.Rng.Intn(65536)
+	}
+	address.Address = net.JoinHostPort(address.Host, strconv.Itoa(address.Port))
+	return address, labels
+}
+
+func genListenerPort(genParams *gopter.GenParameters, labels *[]string) int {
+	if genParams.NextBool() {
+		*labels = append(*labels, "ephemeral port")
+		return 0
+	}
+	return hostPortEdgePorts[genParams.Rng.Intn(len(hostPortEdgePorts))]
+}
+
+// maxUnixSocketPath is the maximum length of a unix socket path on Linux
+// (without the terminating zero)
+const maxUnixSocketPath = 107
+
+func genUnixSocketPath(genParams *gopter.GenParameters, labels *[]string) string {
+	var prefix string
+	switch genParams.Rng.Intn(3) {
+	case 0:
+		*labels = append(*labels, "relative path")
+	case 1:
+		*labels = append(*labels, "absolute path")
+		prefix = "/tmp/"
+	default:
+		*labels = append(*labels, "abstract socket")
+		prefix = "@"
+	}
+	length := 1 + genParams.Rng.Intn(20)
+	if genParams.Rng.Intn(4) == 0 {
+		*labels = append(*labels, "longest path")
+		length = maxUnixSocketPath - len(prefix) - len(".sock")
+	}
+	return prefix + genFromChars(genParams, length, "abcxyz0123", "abcxyz0123-_.") + ".sock"
+}
@@ -0,0 +1,96 @@
+package gen_test
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestHostPort(t *testing.T) {
+	labels := map[string]bool{}
+	commonGeneratorTest(t, "host port", gen.HostPort(), func(value interface{}) bool {
+		address, ok := value.(gen.HostPortAddress)
+		if !ok || address.Pathology != "" {
+			return false
+		}
+		host, port, err := net.SplitHostPort(address.Address)
+		if err != nil {
+			t.Errorf("Invalid address %q: %v", address.Address, err)
+			return false
+		}
+		portNum, err := strconv.Atoi(port)
+		return err == nil && host == address.Host && portNum == address.Port && portNum >= 0 && portNum <= 65535
+	})
+	for i := 0; i < 200; i++ {
+		value, _ := gen.HostPort().Sample()
+		address := value.(gen.HostPortAddress)
+		if strings.Contains(address.Host, ":") {
+			labels["bracketed"] = strings.HasPrefix(address.Address, "[")
+		}
+		if address.Port == 65535 {
+			labels["max port"] = true
+		}
+	}
+	if !labels["bracketed"] || !labels["max port"] {
+		t.Errorf("Edge cases missing: %v", labels)
+	}
+}
+
+func TestMalformedHostPort(t *testing.T) {
+	pathologies := map[string]bool{}
+	commonGeneratorTest(t, "malformed host port", gen.MalformedHostPort(), func(value interface{}) bool {
+		address, ok := value.(gen.HostPortAddress)
+		if !ok || address.Pathology == "" {
+			return false
+		}
+		pathologies[address.Pathology] = true
+		_, port, err := net.SplitHostPort(address.Address)
+		if err != nil {
+			return true
+		}
+		portNum, err := strconv.Atoi(port)
+		if err != nil || portNum < 0 || portNum > 65535 || port != strconv.Itoa(portNum) {
+			return true
+		}
+		t.Errorf("Valid address (%s): %q", address.Pathology, address.Address)
+		return false
+	})
+	if len(pathologies) != 6 {
+		t.Errorf("Not all pathologies generated: %v", pathologies)
+	}
+}
+
+func TestListenerConfig(t *testing.T) {
+	networks := map[string]bool{}
+	commonGeneratorTest(t, "listener config", gen.ListenerConfig(), func(value interface{}) bool {
+		config, ok := value.(gen.ListenerAddress)
+		if !ok {
+			return false
+		}
+		networks[config.Network] = true
+		if config.Network == "unix" {
+			return len(config.Address) > 0 && len(config.Address) <= 107
+		}
+		host, port, err := net.SplitHostPort(config.Address)
+		if err != nil {
+			return false
+		}
+		if _, err := strconv.Atoi(port); err != nil {
+			return false
+		}
+		ip := net.ParseIP(host)
+		switch config.Network {
+		case "tcp4":
+			return ip == nil || ip.To4() != nil
+		case "tcp6":
+			return ip == nil || ip.To4() == nil
+		}
+		return config.Network == "tcp"
+	})
+	if len(networks) != 4 {
+		t.Errorf("Not all networks generated: %v", networks)
+	}
+}
@@ -9,44 +9,118 @@ import (
 
 // Int64Range generates int64 numbers within a given range
 func Int64Range(min, max int64) gopter.Gen {
+	sieve := func(v interface{}) bool {
+		return v.(int64) >= min && v.(int64) <= max
+	}
+	if max == math.MaxInt64 && min == math.MinInt64 {
+		sieve = nil
+	}
+	return int64RangeGen(min, max, reflect.TypeOf(int64(0)), boxInt64, Int64Shrinker, sieve)
+}
+
+// UInt64Range generates uint64 numbers within a given range
+func UInt64Range(min, max uint64) gopter.Gen {
+	sieve := func(v interface{}) bool {
+		return v.(uint64) >= min && v.(uint64) <= max
+	}
+	if max == math.MaxUint64 && min == 0 {
+		sieve = nil
+	}
+	return uint64RangeGen(min, max, reflect.TypeOf(uint64(0)), boxUint64, UInt64Shrinker, sieve)
+}
+
+// int64RangeGen is the common fast path of the signed integer generators.
+// The sieve, domain and shrinker are created once per generator (instead of
+// mapping an int64 generator), so generating a value only allocates the
+// GenResult and the boxed value. Batches (see gopter.Gen.Batch) are generated
+// without any of them.
+func int64RangeGen(min, max int64, resultType reflect.Type, box func(int64) interface{},
+	shrinker gopter.Shrinker, sieve func(interface{}) bool) gopter.Gen {
 	if max < min {
-		return Fail(reflect.TypeOf(int64(0)))
+		return Fail(resultType)
 	}
 	if max == math.MaxInt64 && min == math.MinInt64 { // Check for range overflow
-		return func(genParams *gopter.GenParameters) *gopter.GenResult {
-			return gopter.NewGenResult(genParams.NextInt64(), Int64Shrinker)
+		next := func(genParams *gopter.GenParameters) int64 {
+			return genParams.NextInt64()
 		}
+		return gopter.NewBatchGen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+			return &gopter.GenResult{
+				Shrinker:   shrinker,
+				ResultType: resultType,
+				Result:     box(next(genParams)),
+				Sieve:      sieve,
+			}
+		}, batchInt64s(resultType, next))
 	}
 
 	rangeSize := uint64(max - min + 1)
-	return func(genParams *gopter.GenParameters) *gopter.GenResult {
-		var nextResult = uint64(min) + (genParams.NextUint64() % rangeSize)
-		genResult := gopter.NewGenResult(int64(nextResult), Int64Shrinker)
-		genResult.Sieve = func(v interface{}) bool {
-			return v.(int64) >= min && v.(int64) <= max
+	var domain func() []interface{}
+	if rangeSize <= gopter.MaxEnumerableDomainSize {
+		domain = func() []interface{} {
+			values := make([]interface{}, 0, rangeSize)
+			for i := uint64(0); i < rangeSize; i++ {
+				values = append(values, box(min+int64(i)))
+			}
+			return values
 		}
-		return genResult
 	}
+	next := func(genParams *gopter.GenParameters) int64 {
+		return int64(uint64(min) + (genParams.NextUint64() % rangeSize))
+	}
+	return gopter.NewBatchGen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+		return &gopter.GenResult{
+			Shrinker:   shrinker,
+			ResultType: resultType,
+			Result:     box(next(genParams)),
+			Sieve:      sieve,
+			Domain:     domain,
+		}
+	}, batchInt64s(resultType, next))
 }
 
-// UInt64Range generates uint64 numbers within a given range
-func UInt64Range(min, max uint64) gopter.Gen {
+// uint64RangeGen is the common fast path of the unsigned integer generators
+// (see int64RangeGen)
+func uint64RangeGen(min, max uint64, resultType reflect.Type, box func(uint64) interface{},
+	shrinker gopter.Shrinker, sieve func(interface{}) bool) gopter.Gen {
 	if max < min {
-		return Fail(reflect.TypeOf(uint64(0)))
+		return Fail(resultType)
 	}
 	d := max - min + 1
-	if d == 0 { // Check overflow (i.e. max = MaxInt64, min = MinInt64)
-		return func(genParams *gopter.GenParameters) *gopter.GenResult {
-			return gopter.NewGenResult(genParams.NextUint64(), UInt64Shrinker)
+	if d == 0 { // Check overflow (i.e. max = MaxUint64, min = 0)
+		next := func(genParams *gopter.GenParameters) uint64 {
+			return genParams.NextUint64()
 		}
+		return gopter.NewBatchGen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+			return &gopter.GenResult{
+				Shrinker:   shrinker,
+				ResultType: resultType,
+				Result:     box(next(genParams)),
+				Sieve:      sieve,
+			}
+		}, batchUint64s(resultType, next))
 	}
-	return func(genParams *gopter.GenParameters) *gopter.GenResult {
-		genResult := gopter.NewGenResult(min+genParams.NextUint64()%d, UInt64Shrinker)
-		genResult.Sieve = func(v interface{}) bool {
-			return v.(uint64) >= min && v.(uint64) <= max
+	var domain func() []interface{}
+	if d <= gopter.MaxEnumerableDomainSize {
+		domain = func() []interface{} {
+			values := make([]interface{}, 0, d)
+			for i := uint64(0); i < d; i++ {
+				values = append(values, box(min+i))
+			}
+			return values
 		}
-		return genResult
 	}
+	next := func(genParams *gopter.GenParameters) uint64 {
+		return min + genParams.NextUint64()%d
+	}
+	return gopter.NewBatchGen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+		return &gopter.GenResult{
+			Shrinker:   shrinker,
+			ResultType: resultType,
+			Result:     box(next(genParams)),
+			Sieve:      sieve,
+			Domain:     domain,
+		}
+	}, batchUint64s(resultType, next))
 }
 
 // Int64 generates an arbitrary int64 number
@@ -61,22 +135,16 @@ func UInt64() gopter.Gen {
 
 // Int32Range generates int32 numbers within a given range
 func Int32Range(min, max int32) gopter.Gen {
-	return Int64Range(int64(min), int64(max)).
-		Map(int64To32).
-		WithShrinker(Int32Shrinker).
-		SuchThat(func(v int32) bool {
-			return v >= min && v <= max
-		})
+	return int64RangeGen(int64(min), int64(max), reflect.TypeOf(int32(0)), boxInt32, Int32Shrinker, func(v interface{}) bool {
+		return v.(int32) >= min && v.(int32) <= max
+	})
 }
 
 // UInt32Range generates uint32 numbers within a given range
 func UInt32Range(min, max uint32) gopter.Gen {
-	return UInt64Range(uint64(min), uint64(max)).
-		Map(uint64To32).
-		WithShrinker(UInt32Shrinker).
-		SuchThat(func(v uint32) bool {
-			return v >= min && v <= max
-		})
+	return uint64RangeGen(uint64(min), uint64(max), reflect.TypeOf(uint32(0)), boxUint32, UInt32Shrinker, func(v interface{}) bool {
+		return v.(uint32) >= min && v.(uint32) <= max
+	})
 }
 
 // Int32 generate arbitrary int32 numbers
@@ -91,22 +159,16 @@ func UInt32() gopter.Gen {
 
 // Int16Range generates int16 numbers within a given range
 func Int16Range(min, max int16) gopter.Gen {
-	return Int64Range(int64(min), int64(max)).
-		Map(int64To16).
-		WithShrinker(Int16Shrinker).
-		SuchThat(func(v int16) bool {
-			return v >= min && v <= max
-		})
+	return int64RangeGen(int64(min), int64(max), reflect.TypeOf(int16(0)), boxInt16, Int16Shrinker, func(v interface{}) bool {
+		return v.(int16) >= min && v.(int16) <= max
+	})
 }
 
 // UInt16Range generates uint16 numbers within a given range
 func UInt16Range(min, max uint16) gopter.Gen {
-	return UInt64Range(uint64(min), uint64(max)).
-		Map(uint64To16).
-		WithShrinker(UInt16Shrinker).
-		SuchThat(func(v uint16) bool {
-			return v >= min && v <= max
-		})
+	return uint64RangeGen(uint64(min), uint64(max), reflect.TypeOf(uint16(0)), boxUint16, UInt16Shrinker, func(v interface{}) bool {
+		return v.(uint16) >= min && v.(uint16) <= max
+	})
 }
 
 // Int16 generate arbitrary int16 numbers
@@ -121,22 +183,16 @@ func UInt16() gopter.Gen {
 
 // Int8Range generates int8 numbers within a given range
 func Int8Range(min, max int8) gopter.Gen {
-	return Int64Range(int64(min), int64(max)).
-		Map(int64To8).
-		WithShrinker(Int8Shrinker).
-		SuchThat(func(v int8) bool {
-			return v >= min && v <= max
-		})
+	return int64RangeGen(int64(min), int64(max), reflect.TypeOf(int8(0)), boxInt8, Int8Shrinker, func(v interface{}) bool {
+		return v.(int8) >= min && v.(int8) <= max
+	})
 }
 
 // UInt8Range generates uint8 numbers within a given range
 func UInt8Range(min, max uint8) gopter.Gen {
-	return UInt64Range(uint64(min), uint64(max)).
-		Map(uint64To8).
-		WithShrinker(UInt8Shrinker).
-		SuchThat(func(v uint8) bool {
-			return v >= min && v <= max
-		})
+	return uint64RangeGen(uint64(min), uint64(max), reflect.TypeOf(uint8(0)), boxUint8, UInt8Shrinker, func(v interface{}) bool {
+		return v.(uint8) >= min && v.(uint8) <= max
+	})
 }
 
 // Int8 generate arbitrary int8 numbers
@@ -151,36 +207,26 @@ func UInt8() gopter.Gen {
 
 // IntRange generates int numbers within a given range
 func IntRange(min, max int) gopter.Gen {
-	return Int64Range(int64(min), int64(max)).
-		Map(int64ToInt).
-		WithShrinker(IntShrinker).
-		SuchThat(func(v int) bool {
-			return v >= min && v <= max
-		})
+	return int64RangeGen(int64(min), int64(max), reflect.TypeOf(int(0)), boxInt, IntShrinker, func(v interface{}) bool {
+		return v.(int) >= min && v.(int) <= max
+	})
 }
 
 // Int generate arbitrary int numbers
 func Int() gopter.Gen {
-	return Int64Range(math.MinInt32, math.MaxInt32).
-		Map(int64ToInt).
-		WithShrinker(IntShrinker)
+	return int64RangeGen(math.MinInt32, math.MaxInt32, reflect.TypeOf(int(0)), boxInt, IntShrinker, nil)
 }
 
 // UIntRange generates uint numbers within a given range
 func UIntRange(min, max uint) gopter.Gen {
-	return UInt64Range(uint64(min), uint64(max)).
-		Map(uint64ToUint).
-		WithShrinker(UIntShrinker).
-		SuchThat(func(v uint) bool {
-			return v >= min && v <= max
-		})
+	return uint64RangeGen(uint64(min), uint64(max), reflect.TypeOf(uint(0)), boxUint, UIntShrinker, func(v interface{}) bool {
+		return v.(uint) >= min && v.(uint) <= max
+	})
 }
 
 // UInt generate arbitrary uint numbers
 func UInt() gopter.Gen {
-	return UInt64Range(0, math.MaxUint32).
-		Map(uint64ToUint).
-		WithShrinker(UIntShrinker)
+	return uint64RangeGen(0, math.MaxUint32, reflect.TypeOf(uint(0)), boxUint, UIntShrinker, nil)
 }
 
 // Size just extracts the MaxSize field of the GenParameters.
@@ -192,6 +238,46 @@ func Size() gopter.Gen {
 	}
 }
 
+func boxInt64(value int64) interface{} {
+	return value
+}
+
+func boxUint64(value uint64) interface{} {
+	return value
+}
+
+func boxInt32(value int64) interface{} {
+	return int32(value)
+}
+
+func boxUint32(value uint64) interface{} {
+	return uint32(value)
+}
+
+func boxInt16(value int64) interface{} {
+	return int16(value)
+}
+
+func boxUint16(value uint64) interface{} {
+	return uint16(value)
+}
+
+func boxInt8(value int64) interface{} {
+	return int8(value)
+}
+
+func boxUint8(value uint64) interface{} {
+	return uint8(value)
+}
+
+func boxInt(value int64) interface{} {
+	return int(value)
+}
+
+func boxUint(value uint64) interface{} {
+	return uint(value)
+}
+
 func int64To32(value int64) int32 {
 	return int32(value)
 }
@@ -223,3 +309,81 @@ func int64ToInt(value int64) int {
 func uint64ToUint(value uint64) uint {
 	return uint(value)
 }
+
+// batchInt64s creates the batch function of the signed integer generators,
+// next generates a single value like the generator does
+func batchInt64s(resultType reflect.Type, next func(*gopter.GenParameters) int64) gopter.BatchFunc {
+	sliceType := reflect.SliceOf(resultType)
+	return func(genParams *gopter.GenParameters, buf interface{}, n int) interface{} {
+		if buf == nil {
+			buf = reflect.MakeSlice(sliceType, 0, n).Interface()
+		}
+		switch values := buf.(type) {
+		case []int64:
+			for i := 0; i < n; i++ {
+				values = append(values, next(genParams))
+			}
+			return values
+		case []int32:
+			for i := 0; i < n; i++ {
+				values = append(values, int32(next(genParams)))
+			}
+			return values
+		case []int16:
+			for i := 0; i < n; i++ {
+				values = append(values, int16(next(genParams)))
+			}
+			return values
+		case []int8:
+			for i := 0; i < n; i++ {
+				values = append(values, int8(next(genParams)))
+			}
+			return values
+		case []int:
+			for i := 0; i < n; i++ {
+				values = append(values, int(next(genParams)))
+			}
+			return values
+		}
+		return nil
+	}
+}
+
+// batchUint64s creates the batch function of the unsigned integer generators
+// (see batchInt64s)
+func batchUint64s(resultType reflect.Type, next func(*gopter.GenParameters) uint64) gopter.BatchFunc {
+	sliceType := reflect.SliceOf(resultType)
+	return func(genParams *gopter.GenParameters, buf interface{}, n int) interface{} {
+		if buf == nil {
+			buf = reflect.MakeSlice(sliceType, 0, n).Interface()
+		}
+		switch values := buf.(type) {
+		case []uint64:
+			for i := 0; i < n; i++ {
+				values = append(values, next(genParams))
+			}
+			return values
+		case []uint32:
+			for i := 0; i < n; i++ {
+				values = append(values, uint32(next(genParams)))
+			}
+			return values
+		case []uint16:
+			for i := 0; i < n; i++ {
+				values = append(values, uint16(next(genParams)))
+			}
+			return values
+		case []uint8:
+			for i := 0; i < n; i++ {
+				values = append(values, uint8(next(genParams)))
+			}
+			return values
+		case []uint:
+			for i := 0; i < n; i++ {
+				values = append(values, uint(next(genParams)))
+			}
+			return values
+		}
+		return nil
+	}
+}
@@ -128,3 +128,32 @@ func TestGenSize(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkPrimitiveGens(b *testing.B) {
+	gens := []struct {
+		name string
+		gen  gopter.Gen
+	}{
+		{"Int", gen.Int()},
+		{"IntRange", gen.IntRange(-1000, 1000)},
+		{"Int64", gen.Int64()},
+		{"Int32", gen.Int32()},
+		{"UInt8", gen.UInt8()},
+		{"UInt", gen.UInt()},
+		{"Bool", gen.Bool()},
+		{"Float64", gen.Float64()},
+		{"Float64Range", gen.Float64Range(-1, 1)},
+		{"Float32", gen.Float32()},
+	}
+	for _, g := range gens {
+		b.Run(g.name, func(b *testing.B) {
+			genParams := gopter.DefaultGenParameters()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, ok := g.gen(genParams).Retrieve(); !ok {
+					b.Fatal("Invalid gen result")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/leanovate/gopter"
+)
+
+// Operations of an InventoryScript
+const (
+	InventoryReserve = "reserve"
+	InventoryRelease = "release"
+	InventoryCommit  = "commit"
+)
+
+// InventoryLevels are the stock levels of an inventory.
+// Stock is never created or destroyed, i.e. the total of the levels is always
+// the initial stock.
+type InventoryLevels struct {
+	// Available is the stock that can be reserved
+	Available int
+	// Reserved is the stock held by open reservations
+	Reserved int
+	// Committed is the stock of committed (i.e. sold) reservations
+	Committed int
+}
+
+// Total is the sum of all levels
+func (l InventoryLevels) Total() int {
+	return l.Available + l.Reserved + l.Committed
+}
+
+// InventoryOp is a single operation of an InventoryScript with its expected
+// outcome according to the reference model
+type InventoryOp struct {
+	Op string
+	// Reservation is the id of the reservation that is created, released or
+	// committed
+	Reservation int
+	// Quantity to reserve or the quantity of the released/committed
+	// reservation (0 if it is not open)
+	Quantity int
+	// ExpectedOk is false if the operation is expected to be rejected, i.e. a
+	// reservation exceeding the available stock or the release/commit of a
+	// reservation that is not open
+	ExpectedOk bool
+	// Expected levels after the operation
+	Expected InventoryLevels
+}
+
+func (o InventoryOp) String() string {
+	result := "ok"
+	if !o.ExpectedOk {
+		result = "rejected"
+	}
+	if o.Op == InventoryReserve {
+		return fmt.Sprintf("reserve(#%d, %d) -> %s", o.Reservation, o.Quantity, result)
+	}
+	return fmt.Sprintf("%s(#%d) -> %s", o.Op, o.Reservation, result)
+}
+
+// InventoryScript is a sequence of operations on the inventory of a single
+// item
+type InventoryScript struct {
+	// Stock is the initial (available) stock
+	Stock int
+	Ops   []InventoryOp
+}
+
+// Verify runs the script against an implementation.
+// "apply" has to execute an operation on the implementation and return if it
+// succeeded as well as the stock levels after the operation.
+// Overselling (negative levels), phantom stock (levels not adding up to the
+// initial stock) and the first other deviation from the reference model are
+// returned as error.
+func (s InventoryScript) Verify(apply func(op InventoryOp) (ok bool, levels InventoryLevels)) error {
+	for i, op := range s.Ops {
+		ok, levels := apply(op)
+		switch {
+		case levels.Available < 0 || levels.Reserved < 0 || levels.Committed < 0:
+			return fmt.Errorf("Step %d: %v oversold to %+v", i, op, levels)
+		case levels.Total() != s.Stock:
+			return fmt.Errorf("Step %d: %v resulted in a total of %d, expected %d", i, op, levels.Total(), s.Stock)
+		case ok != op.ExpectedOk:
+			return fmt.Errorf("Step %d: %v returned ok=%v", i, op, ok)
+		case levels != op.Expected:
+			return fmt.Errorf("Step %d: %v resulted in %+v, expected %+v", i, op, levels, op.Expected)
+		}
+	}
+	return nil
+}
+
+// InventoryScripts generates scripts of reserve/release/commit operations on
+// an initial stock of up to MaxSize. Reservations may exceed the available
+// stock and releases/commits may refer to reservations that are unknown or
+// already closed. The expected outcome of each operation is determined by
+// executing a reference inventory during generation, so that the total stock
+// is conserved by construction.
+// Rejected operations and sold out stock are added as labels.
+// The scripts shrink by removing operations (the expected outcomes are
+// recalculated).
+func InventoryScripts() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		length := 0
+		if genParams.MaxSize > genParams.MinSize {
+			length = genParams.Rng.Intn(genParams.MaxSize-genParams.MinSize) + genParams.MinSize
+		} else {
+			length = genParams.MaxSize
+		}
+		script := InventoryScript{
+			Stock: genParams.Rng.Intn(genParams.MaxSize + 1),
+			Ops:   make([]InventoryOp, 0, length),
+		}
+		reservations := 0
+		for i := 0; i < length; i++ {
+			var op InventoryOp
+			switch genParams.Rng.Intn(4) {
+			case 0, 1:
+				op.Op = InventoryReserve
+				op.Reservation = reservations
+				op.Quantity = 1 + genParams.Rng.Intn(script.Stock/2+2)
+				reservations++
+			case 2:
+				op.Op = InventoryRelease
+			case 3:
+				op.Op = InventoryCommit
+			}
+			if op.Op != InventoryReserve {
+				// may refer to a reservation that does not (yet) exist
+				op.Reservation = genParams.Rng.Intn(reservations + 1)
+			}
+			script.Ops = append(script.Ops, op)
+		}
+		script = script.withExpectations()
+
+		genResult := gopter.NewGenResult(script, InventoryScriptShrinker)
+		genResult.Labels = inventoryScriptFeatures(script)
+		return genResult
+	}
+}
+
+// withExpectations executes the reference model to set the expected outcome
+// of all operations
+func (s InventoryScript) withExpectations() InventoryScript {
+	result := InventoryScript{Stock: s.Stock, Ops: make([]InventoryOp, len(s.Ops))}
+	levels := InventoryLevels{Available: s.Stock}
+	open := map[int]int{}
+	for i, op := range s.Ops {
+		op.ExpectedOk = false
+		switch op.Op {
+		case InventoryReserve:
+			if op.Quantity <= levels.Available {
+				levels.Available -= op.Quantity
+				levels.Reserved += op.Quantity
+				open[op.Reservation] = op.Quantity
+				op.ExpectedOk = true
+			}
+		case InventoryRelease, InventoryCommit:
+			quantity, ok := open[op.Reservation]
+			op.Quantity = quantity
+			if !ok {
+				break
+			}
+			delete(open, op.Reservation)
+			levels.Reserved -= quantity
+			if op.Op == InventoryRelease {
+				levels.Available += quantity
+			} else {
+				levels.Committed += quantity
+			}
+			op.ExpectedOk = true
+		}
+		op.Expected = levels
+		result.Ops[i] = op
+	}
+	return result
+}
+
+func inventoryScriptFeatures(script InventoryScript) []string {
+	features := map[string]bool{}
+	for _, op := range script.Ops {
+		switch {
+		case op.Op == InventoryReserve && !op.ExpectedOk:
+			features["insufficient stock"] = true
+		case !op.ExpectedOk:
+			features["unknown reservation"] = true
+		case op.Op == InventoryCommit && op.Expected.Available == 0 && op.Expected.Reserved == 0:
+			features["sold out"] = true
+		}
+	}
+	return sortedFeatures(features)
+}
+
+// InventoryScriptShrinker shrinks an InventoryScript by removing operations
+func InventoryScriptShrinker(v interface{}) gopter.Shrink {
+	script := v.(InventoryScript)
+	return SliceShrinker(gopter.NoShrinker)(script.Ops).Map(func(ops []InventoryOp) InventoryScript {
+		return InventoryScript{Stock: script.Stock, Ops: ops}.withExpectations()
+	})
+}
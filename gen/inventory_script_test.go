@@ -0,0 +1,86 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+type inventory struct {
+	levels       gen.InventoryLevels
+	reservations map[int]int
+	// allowOversell accepts reservations of up to twice the available stock
+	allowOversell bool
+}
+
+func (i *inventory) apply(op gen.InventoryOp) (bool, gen.InventoryLevels) {
+	switch op.Op {
+	case gen.InventoryReserve:
+		limit := i.levels.Available
+		if i.allowOversell {
+			limit *= 2
+		}
+		if op.Quantity > limit {
+			return false, i.levels
+		}
+		i.levels.Available -= op.Quantity
+		i.levels.Reserved += op.Quantity
+		i.reservations[op.Reservation] = op.Quantity
+		return true, i.levels
+	}
+	quantity, ok := i.reservations[op.Reservation]
+	if !ok {
+		return false, i.levels
+	}
+	delete(i.reservations, op.Reservation)
+	i.levels.Reserved -= quantity
+	if op.Op == gen.InventoryRelease {
+		i.levels.Available += quantity
+	} else {
+		i.levels.Committed += quantity
+	}
+	return true, i.levels
+}
+
+func TestInventoryScripts(t *testing.T) {
+	commonGeneratorTest(t, "inventory script", gen.InventoryScripts(), func(value interface{}) bool {
+		script, ok := value.(gen.InventoryScript)
+		if !ok {
+			return false
+		}
+		for _, op := range script.Ops {
+			if op.Expected.Total() != script.Stock || op.Expected.Available < 0 {
+				return false
+			}
+		}
+		impl := &inventory{levels: gen.InventoryLevels{Available: script.Stock}, reservations: map[int]int{}}
+		return script.Verify(impl.apply) == nil
+	})
+}
+
+func TestInventoryScriptShrink(t *testing.T) {
+	result := prop.ForAll(
+		func(script gen.InventoryScript) bool {
+			impl := &inventory{
+				levels:        gen.InventoryLevels{Available: script.Stock},
+				reservations:  map[int]int{},
+				allowOversell: true,
+			}
+			return script.Verify(impl.apply) == nil
+		},
+		gen.InventoryScripts(),
+	).Check(gopter.DefaultTestParameters())
+
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	// only reservations remain, the last one being the oversold one
+	script := result.Args[0].Arg.(gen.InventoryScript)
+	for i, op := range script.Ops {
+		if op.Op != gen.InventoryReserve || op.ExpectedOk == (i == len(script.Ops)-1) {
+			t.Errorf("Script is not minimal: %v", script.Ops)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package gen
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// invisibleChar is an invisible control character and its common
+// abbreviation (used as label)
+type invisibleChar struct {
+	char rune
+	name string
+}
+
+// bidiControls are the Unicode bidirectional formatting characters
+var bidiControls = []invisibleChar{
+	{'\u202A', "LRE"},
+	{'\u202B', "RLE"},
+	{'\u202C', "PDF"},
+	{'\u202D', "LRO"},
+	{'\u202E', "RLO"},
+	{'\u2066', "LRI"},
+	{'\u2067', "RLI"},
+	{'\u2068', "FSI"},
+	{'\u2069', "PDI"},
+	{'\u200E', "LRM"},
+	{'\u200F', "RLM"},
+}
+
+// zeroWidthChars are the Unicode characters without width
+var zeroWidthChars = []invisibleChar{
+	{'\u200B', "ZWSP"},
+	{'\u200C', "ZWNJ"},
+	{'\u200D', "ZWJ"},
+	{'\u2060', "WJ"},
+	{'\uFEFF', "ZWNBSP"},
+}
+
+// WithBidiControls injects 1 to 3 Unicode bidirectional control characters
+// (e.g. RLO, LRO, LRI or RLM) at random positions of the strings generated by
+// "stringGen" (e.g. to test the display or comparison of file names and user
+// names spoofed by reordering).
+// The abbreviations of the injected characters are added as labels.
+// The strings shrink by removing characters, but always keep an injected
+// character.
+func WithBidiControls(stringGen gopter.Gen) gopter.Gen {
+	return withInvisibleChars(stringGen, bidiControls)
+}
+
+// WithZeroWidth injects 1 to 3 Unicode zero width characters (ZWSP, ZWNJ,
+// ZWJ, WJ or ZWNBSP) at random positions of the strings generated by
+// "stringGen" (e.g. to test trimming, length limits or the comparison of
+// security-sensitive identifiers).
+// The abbreviations of the injected characters are added as labels.
+// The strings shrink by removing characters, but always keep an injected
+// character.
+func WithZeroWidth(stringGen gopter.Gen) gopter.Gen {
+	return withInvisibleChars(stringGen, zeroWidthChars)
+}
+
+func withInvisibleChars(stringGen gopter.Gen, chars []invisibleChar) gopter.Gen {
+	isInjected := func(ch rune) bool {
+		for _, injected := range chars {
+			if injected.char == ch {
+				return true
+			}
+		}
+		return false
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		result := stringGen(genParams)
+		value, ok := result.Retrieve()
+		if !ok {
+			return gopter.NewEmptyResult(reflect.TypeOf(""))
+		}
+		runes := []rune(value.(string))
+		labels := map[string]bool{}
+		count := 1 + genParams.Rng.Intn(3)
+		for i := 0; i < count; i++ {
+			injected := chars[genParams.Rng.Intn(len(chars))]
+			pos := genParams.Rng.Intn(len(runes) + 1)
+			runes = append(runes[:pos], append([]rune{injected.char}, runes[pos:]...)...)
+			labels[injected.name] = true
+		}
+
+		genResult := gopter.NewGenResult(string(runes), invisibleCharsShrinker(isInjected))
+		genResult.Labels = append(append([]string{}, result.Labels...), sortedFeatures(labels)...)
+		genResult.Sieve = func(v interface{}) bool {
+			return strings.IndexFunc(v.(string), isInjected) >= 0
+		}
+		return genResult
+	}
+}
+
+// invisibleCharsShrinker removes single injected characters (as long as
+// another one remains) and then single other characters
+func invisibleCharsShrinker(isInjected func(rune) bool) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		runes := []rune(v.(string))
+		injected := 0
+		for _, ch := range runes {
+			if isInjected(ch) {
+				injected++
+			}
+		}
+		var candidates []int
+		for i, ch := range runes {
+			if isInjected(ch) && injected > 1 {
+				candidates = append(candidates, i)
+			}
+		}
+		for i, ch := range runes {
+			if !isInjected(ch) {
+				candidates = append(candidates, i)
+			}
+		}
+		return func() (interface{}, bool) {
+			if len(candidates) == 0 {
+				return nil, false
+			}
+			pos := candidates[0]
+			candidates = candidates[1:]
+			return string(runes[:pos]) + string(runes[pos+1:]), true
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gen_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestWithBidiControls(t *testing.T) {
+	commonGeneratorTest(t, "bidi controls", gen.WithBidiControls(gen.AlphaString()), func(value interface{}) bool {
+		v, ok := value.(string)
+		if !ok {
+			return false
+		}
+		injected := 0
+		for _, ch := range v {
+			switch {
+			case unicode.Is(unicode.Bidi_Control, ch):
+				injected++
+			case !unicode.IsLetter(ch):
+				return false
+			}
+		}
+		return injected >= 1 && injected <= 3
+	})
+}
+
+func TestWithZeroWidth(t *testing.T) {
+	commonGeneratorTest(t, "zero width", gen.WithZeroWidth(gen.AlphaString()), func(value interface{}) bool {
+		v, ok := value.(string)
+		return ok && strings.TrimFunc(v, unicode.IsLetter) != "" &&
+			strings.IndexFunc(v, func(ch rune) bool {
+				return !unicode.IsLetter(ch) && !strings.ContainsRune("\u200B\u200C\u200D\u2060\uFEFF", ch)
+			}) < 0
+	})
+
+	genResult := gen.WithZeroWidth(gen.Const("abc"))(gopter.DefaultGenParameters())
+	if len(genResult.Labels) == 0 {
+		t.Errorf("Injections not labeled: %#v", genResult)
+	}
+	for _, label := range genResult.Labels {
+		if label != "ZWSP" && label != "ZWNJ" && label != "ZWJ" && label != "WJ" && label != "ZWNBSP" {
+			t.Errorf("Invalid label: %s", label)
+		}
+	}
+}
+
+func TestWithZeroWidthShrink(t *testing.T) {
+	result := prop.ForAll(
+		func(v string) bool {
+			// a naive sanitizer that only keeps letters and spaces
+			return strings.Map(func(ch rune) rune {
+				if unicode.IsLetter(ch) || unicode.IsSpace(ch) {
+					return ch
+				}
+				return -1
+			}, v) == v
+		},
+		gen.WithZeroWidth(gen.AlphaString()),
+	).Check(gopter.DefaultTestParameters())
+
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if shrunk := result.Args[0].Arg.(string); len([]rune(shrunk)) != 1 {
+		t.Errorf("Invalid shrunk value: %q", shrunk)
+	}
+}
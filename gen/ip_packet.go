@@ -0,0 +1,337 @@
+package gen
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/leanovate/gopter"
+)
+
+// Transport protocols of a generated IPPacketLayout
+const (
+	IPProtoTCP = 6
+	IPProtoUDP = 17
+)
+
+// Pathologies of a generated IPPacketLayout
+const (
+	PacketTruncated           = "truncated"
+	PacketBadVersion          = "bad version"
+	PacketBadIHL              = "IHL below minimum"
+	PacketIHLBeyondLength     = "IHL beyond total length"
+	PacketTotalLengthMismatch = "total length mismatch"
+	PacketBadDataOffset       = "bad TCP data offset"
+	PacketUDPLengthMismatch   = "UDP length mismatch"
+	PacketTrailingBytes       = "trailing bytes"
+)
+
+// IPPacketLayout is a generated IPv4 or IPv6 packet containing a TCP or UDP
+// segment
+type IPPacketLayout struct {
+	// Version is the IP version (4 or 6)
+	Version int
+	// Protocol is the transport protocol (IPProtoTCP or IPProtoUDP)
+	Protocol int
+	// Payload is the payload of the transport protocol
+	Payload []byte
+	// Raw is the wire format of the packet (IP header, transport header and
+	// payload)
+	Raw []byte
+	// ChecksumsValid is true if the IPv4 header and transport checksums are
+	// correct
+	ChecksumsValid bool
+	// Pathology describes how the packet was broken (empty for valid packets)
+	Pathology string
+}
+
+// Label is the label of the packet, e.g. "IPv4/TCP"
+func (p *IPPacketLayout) Label() string {
+	protocol := "TCP"
+	if p.Protocol == IPProtoUDP {
+		protocol = "UDP"
+	}
+	return fmt.Sprintf("IPv%d/%s", p.Version, protocol)
+}
+
+// IPPacket generates valid IPv4/IPv6 packets with TCP/UDP segments, header
+// fields are biased towards their boundaries (e.g. port 0 or 65535, maximum
+// number of options). If validChecksums is false all checksums are wrong.
+// The protocols (e.g. "IPv6/UDP") are added as label.
+func IPPacket(validChecksums bool) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		packet := genIPPacket(genParams, validChecksums)
+		genResult := gopter.NewGenResult(packet, gopter.NoShrinker)
+		genResult.Labels = []string{packet.Label()}
+		return genResult
+	}
+}
+
+// PathologicalIPPacket generates packets like IPPacket with a broken layout:
+// truncated headers, invalid versions, header lengths or data offsets beyond
+// the available bytes and length fields not matching the packet. The checksums
+// of pathological packets may or may not be correct.
+// The protocols and the pathology are added as labels.
+func PathologicalIPPacket() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		packet := genIPPacket(genParams, genParams.NextBool())
+		breakIPPacket(genParams, packet)
+		genResult := gopter.NewGenResult(packet, gopter.NoShrinker)
+		genResult.Labels = []string{packet.Label(), packet.Pathology}
+		return genResult
+	}
+}
+
+// maxIPPayloadLen keeps the length fields of packets with maximum header
+// lengths (and some trailing bytes) within 16 bit
+const maxIPPayloadLen = 0xffff - 256
+
+// boundaryUint picks 0, max or a random value up to max
+func boundaryUint(genParams *gopter.GenParameters, max uint64) uint64 {
+	switch genParams.Rng.Intn(4) {
+	case 0:
+		return 0
+	case 1:
+		return max
+	}
+	return genParams.NextUint64() % (max + 1)
+}
+
+func randomBytes(genParams *gopter.GenParameters, n int) []byte {
+	result := make([]byte, n)
+	genParams.Rng.Read(result)
+	return result
+}
+
+func genIPPacket(genParams *gopter.GenParameters, validChecksums bool) *IPPacketLayout {
+	packet := &IPPacketLayout{
+		Version:        4,
+		Protocol:       IPProtoTCP,
+		ChecksumsValid: validChecksums,
+	}
+	if genParams.NextBool() {
+		packet.Version = 6
+	}
+	if genParams.NextBool() {
+		packet.Protocol = IPProtoUDP
+	}
+	payloadLen := 0
+	if genParams.MaxSize > 0 {
+		payloadLen = genParams.Rng.Intn(genParams.MaxSize + 1)
+	}
+	if payloadLen > maxIPPayloadLen {
+		payloadLen = maxIPPayloadLen
+	}
+	packet.Payload = randomBytes(genParams, payloadLen)
+
+	var src, dst []byte
+	if packet.Version == 4 {
+		src, dst = randomBytes(genParams, 4), randomBytes(genParams, 4)
+	} else {
+		src, dst = randomBytes(genParams, 16), randomBytes(genParams, 16)
+	}
+
+	var segment []byte
+	var checksumOffset int
+	if packet.Protocol == IPProtoTCP {
+		segment = genTCPHeader(genParams)
+		checksumOffset = 16
+	} else {
+		segment = genUDPHeader(genParams, len(packet.Payload))
+		checksumOffset = 6
+	}
+	segment = append(segment, packet.Payload...)
+	checksum := transportChecksum(packet.Version, packet.Protocol, src, dst, segment)
+	if !validChecksums {
+		checksum = wrongChecksum(genParams, checksum)
+	}
+	binary.BigEndian.PutUint16(segment[checksumOffset:], checksum)
+
+	var header []byte
+	if packet.Version == 4 {
+		header = genIPv4Header(genParams, packet.Protocol, src, dst, len(segment), validChecksums)
+	} else {
+		header = genIPv6Header(genParams, packet.Protocol, src, dst, len(segment))
+	}
+	packet.Raw = append(header, segment...)
+	return packet
+}
+
+func genIPv4Header(genParams *gopter.GenParameters, protocol int, src, dst []byte, segmentLen int, validChecksum bool) []byte {
+	ihl := 5 + int(boundaryUint(genParams, 10))
+	header := make([]byte, ihl*4)
+	header[0] = 4<<4 | byte(ihl)
+	header[1] = byte(boundaryUint(genParams, 0xff))
+	binary.BigEndian.PutUint16(header[2:], uint16(ihl*4+segmentLen))
+	binary.BigEndian.PutUint16(header[4:], uint16(boundaryUint(genParams, 0xffff)))
+	// don't fragment flag, fragment offset 0
+	if genParams.NextBool() {
+		header[6] = 0x40
+	}
+	header[8] = byte(boundaryUint(genParams, 0xff))
+	header[9] = byte(protocol)
+	copy(header[12:], src)
+	copy(header[16:], dst)
+	// options are padded with NOPs and terminated by an end of options list
+	for i := 20; i < len(header)-1; i++ {
+		header[i] = 1
+	}
+	checksum := onesComplementChecksum(header)
+	if !validChecksum {
+		checksum = wrongChecksum(genParams, checksum)
+	}
+	binary.BigEndian.PutUint16(header[10:], checksum)
+	return header
+}
+
+func genIPv6Header(genParams *gopter.GenParameters, protocol int, src, dst []byte, segmentLen int) []byte {
+	header := make([]byte, 40)
+	binary.BigEndian.PutUint32(header, 6<<28|uint32(boundaryUint(genParams, 0xff))<<20|uint32(boundaryUint(genParams, 0xfffff)))
+	binary.BigEndian.PutUint16(header[4:], uint16(segmentLen))
+	header[6] = byte(protocol)
+	header[7] = byte(boundaryUint(genParams, 0xff))
+	copy(header[8:], src)
+	copy(header[24:], dst)
+	return header
+}
+
+func genTCPHeader(genParams *gopter.GenParameters) []byte {
+	dataOffset := 5 + int(boundaryUint(genParams, 10))
+	header := make([]byte, dataOffset*4)
+	binary.BigEndian.PutUint16(header[0:], uint16(boundaryUint(genParams, 0xffff)))
+	binary.BigEndian.PutUint16(header[2:], uint16(boundaryUint(genParams, 0xffff)))
+	binary.BigEndian.PutUint32(header[4:], uint32(boundaryUint(genParams, 0xffffffff)))
+	binary.BigEndian.PutUint32(header[8:], uint32(boundaryUint(genParams, 0xffffffff)))
+	header[12] = byte(dataOffset << 4)
+	header[13] = byte(boundaryUint(genParams, 0xff))
+	binary.BigEndian.PutUint16(header[14:], uint16(boundaryUint(genParams, 0xffff)))
+	binary.BigEndian.PutUint16(header[18:], uint16(boundaryUint(genParams, 0xffff)))
+	for i := 20; i < len(header)-1; i++ {
+		header[i] = 1
+	}
+	return header
+}
+
+func genUDPHeader(genParams *gopter.GenParameters, payloadLen int) []byte {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint16(header[0:], uint16(boundaryUint(genParams, 0xffff)))
+	binary.BigEndian.PutUint16(header[2:], uint16(boundaryUint(genParams, 0xffff)))
+	binary.BigEndian.PutUint16(header[4:], uint16(8+payloadLen))
+	return header
+}
+
+// onesComplementChecksum calculates the internet checksum (RFC 1071) of a
+// number of byte slices (any existing checksum has to be zeroed)
+func onesComplementChecksum(data ...[]byte) uint16 {
+	var sum uint32
+	for _, d := range data {
+		for i := 0; i+1 < len(d); i += 2 {
+			sum += uint32(d[i])<<8 | uint32(d[i+1])
+		}
+		if len(d)%2 == 1 {
+			sum += uint32(d[len(d)-1]) << 8
+		}
+	}
+	for sum > 0xffff {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+func transportChecksum(version, protocol int, src, dst, segment []byte) uint16 {
+	var pseudoHeader []byte
+	if version == 4 {
+		pseudoHeader = make([]byte, 12)
+		copy(pseudoHeader, src)
+		copy(pseudoHeader[4:], dst)
+		pseudoHeader[9] = byte(protocol)
+		binary.BigEndian.PutUint16(pseudoHeader[10:], uint16(len(segment)))
+	} else {
+		pseudoHeader = make([]byte, 40)
+		copy(pseudoHeader, src)
+		copy(pseudoHeader[16:], dst)
+		binary.BigEndian.PutUint32(pseudoHeader[32:], uint32(len(segment)))
+		pseudoHeader[39] = byte(protocol)
+	}
+	checksum := onesComplementChecksum(pseudoHeader, segment)
+	if protocol == IPProtoUDP && checksum == 0 {
+		// a zero UDP checksum means "no checksum"
+		return 0xffff
+	}
+	return checksum
+}
+
+func wrongChecksum(genParams *gopter.GenParameters, checksum uint16) uint16 {
+	// a zero checksum is avoided as it means "no checksum" for UDP
+	for {
+		if wrong := uint16(1 + genParams.Rng.Intn(0xffff)); wrong != checksum {
+			return wrong
+		}
+	}
+}
+
+func breakIPPacket(genParams *gopter.GenParameters, packet *IPPacketLayout) {
+	ipHeaderLen := 40
+	if packet.Version == 4 {
+		ipHeaderLen = int(packet.Raw[0]&0x0f) * 4
+	}
+	pathologies := []string{PacketTruncated, PacketBadVersion, PacketTotalLengthMismatch, PacketTrailingBytes}
+	if packet.Version == 4 {
+		pathologies = append(pathologies, PacketBadIHL, PacketIHLBeyondLength)
+	}
+	if packet.Protocol == IPProtoTCP {
+		pathologies = append(pathologies, PacketBadDataOffset)
+	} else {
+		pathologies = append(pathologies, PacketUDPLengthMismatch)
+	}
+	packet.Pathology = pathologies[genParams.Rng.Intn(len(pathologies))]
+
+	switch packet.Pathology {
+	case PacketTruncated:
+		// cut within the IP or the transport header
+		transportHeaderLen := 8
+		if packet.Protocol == IPProtoTCP {
+			transportHeaderLen = int(packet.Raw[ipHeaderLen+12]>>4) * 4
+		}
+		packet.Raw = packet.Raw[:genParams.Rng.Intn(ipHeaderLen+transportHeaderLen)]
+	case PacketBadVersion:
+		version := byte(genParams.Rng.Intn(14))
+		if version >= 4 {
+			version++
+		}
+		if version >= 6 {
+			version++
+		}
+		packet.Raw[0] = version<<4 | packet.Raw[0]&0x0f
+	case PacketTotalLengthMismatch:
+		lengthOffset, length := 4, len(packet.Raw)-ipHeaderLen
+		if packet.Version == 4 {
+			lengthOffset, length = 2, len(packet.Raw)
+		}
+		wrong := length + 1 + genParams.Rng.Intn(0xffff-length)
+		if genParams.NextBool() && length > 0 {
+			wrong = genParams.Rng.Intn(length)
+		}
+		binary.BigEndian.PutUint16(packet.Raw[lengthOffset:], uint16(wrong))
+	case PacketTrailingBytes:
+		packet.Raw = append(packet.Raw, randomBytes(genParams, 1+genParams.Rng.Intn(16))...)
+	case PacketBadIHL:
+		packet.Raw[0] = 4<<4 | byte(genParams.Rng.Intn(5))
+	case PacketIHLBeyondLength:
+		// shrink the total length below the header length
+		binary.BigEndian.PutUint16(packet.Raw[2:], uint16(genParams.Rng.Intn(ipHeaderLen)))
+	case PacketBadDataOffset:
+		dataOffset := byte(genParams.Rng.Intn(5))
+		if segmentLen := len(packet.Raw) - ipHeaderLen; genParams.NextBool() && segmentLen < 60 {
+			// data offset beyond the end of the segment
+			dataOffset = 15
+		}
+		packet.Raw[ipHeaderLen+12] = dataOffset<<4 | packet.Raw[ipHeaderLen+12]&0x0f
+	case PacketUDPLengthMismatch:
+		length := len(packet.Raw) - ipHeaderLen
+		wrong := genParams.Rng.Intn(8)
+		if genParams.NextBool() {
+			wrong = length + 1 + genParams.Rng.Intn(0xffff-length)
+		}
+		binary.BigEndian.PutUint16(packet.Raw[ipHeaderLen+4:], uint16(wrong))
+	}
+}
@@ -0,0 +1,109 @@
+package gen_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func internetChecksum(data []byte) uint16 {
+	var sum uint32
+	for i := 0; i < len(data); i += 2 {
+		word := uint32(data[i]) << 8
+		if i+1 < len(data) {
+			word |= uint32(data[i+1])
+		}
+		sum += word
+	}
+	for sum > 0xffff {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+// parseIPPacket is a strict parser returning the transport segment and the
+// pseudo header for its checksum
+func parseIPPacket(raw []byte) (segment, pseudoHeader []byte, err error) {
+	if len(raw) < 1 {
+		return nil, nil, errors.New("empty")
+	}
+	var protocol byte
+	switch raw[0] >> 4 {
+	case 4:
+		ihl := int(raw[0]&0x0f) * 4
+		if len(raw) < 20 || ihl < 20 || ihl > len(raw) {
+			return nil, nil, errors.New("invalid IHL")
+		}
+		if int(binary.BigEndian.Uint16(raw[2:])) != len(raw) {
+			return nil, nil, errors.New("invalid total length")
+		}
+		protocol = raw[9]
+		segment = raw[ihl:]
+		pseudoHeader = append(append(append([]byte{}, raw[12:20]...), 0, protocol), byte(len(segment)>>8), byte(len(segment)))
+	case 6:
+		if len(raw) < 40 || int(binary.BigEndian.Uint16(raw[4:])) != len(raw)-40 {
+			return nil, nil, errors.New("invalid payload length")
+		}
+		protocol = raw[6]
+		segment = raw[40:]
+		pseudoHeader = append(append([]byte{}, raw[8:40]...), byte(len(segment)>>24), byte(len(segment)>>16), byte(len(segment)>>8), byte(len(segment)), 0, 0, 0, protocol)
+	default:
+		return nil, nil, errors.New("invalid version")
+	}
+	switch protocol {
+	case gen.IPProtoTCP:
+		if len(segment) < 20 {
+			return nil, nil, errors.New("truncated TCP header")
+		}
+		if dataOffset := int(segment[12]>>4) * 4; dataOffset < 20 || dataOffset > len(segment) {
+			return nil, nil, errors.New("invalid data offset")
+		}
+	case gen.IPProtoUDP:
+		if len(segment) < 8 || int(binary.BigEndian.Uint16(segment[4:])) != len(segment) {
+			return nil, nil, errors.New("invalid UDP length")
+		}
+	default:
+		return nil, nil, errors.New("invalid protocol")
+	}
+	return segment, pseudoHeader, nil
+}
+
+func TestIPPacket(t *testing.T) {
+	for _, validChecksums := range []bool{true, false} {
+		commonGeneratorTest(t, "ip packet", gen.IPPacket(validChecksums), func(value interface{}) bool {
+			packet, ok := value.(*gen.IPPacketLayout)
+			if !ok || packet.Pathology != "" || packet.ChecksumsValid != validChecksums {
+				return false
+			}
+			segment, pseudoHeader, err := parseIPPacket(packet.Raw)
+			if err != nil || int(packet.Raw[0]>>4) != packet.Version || !bytes.HasSuffix(segment, packet.Payload) {
+				t.Logf("Invalid packet %s: %v", packet.Label(), err)
+				return false
+			}
+			checksumsValid := internetChecksum(append(pseudoHeader, segment...)) == 0
+			if packet.Version == 4 {
+				checksumsValid = checksumsValid && internetChecksum(packet.Raw[:len(packet.Raw)-len(segment)]) == 0
+			}
+			return checksumsValid == validChecksums
+		})
+	}
+}
+
+func TestPathologicalIPPacket(t *testing.T) {
+	pathologies := map[string]bool{}
+	commonGeneratorTest(t, "pathological ip packet", gen.PathologicalIPPacket(), func(value interface{}) bool {
+		packet, ok := value.(*gen.IPPacketLayout)
+		if !ok || packet.Pathology == "" {
+			return false
+		}
+		pathologies[packet.Pathology] = true
+		_, _, err := parseIPPacket(packet.Raw)
+		return err != nil
+	})
+	if len(pathologies) != 8 {
+		t.Errorf("Not all pathologies generated: %v", pathologies)
+	}
+}
@@ -0,0 +1,156 @@
+package gen
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+// jsonStringChars are some of the interesting characters of JSON strings
+// (that are escaped when serialized)
+var jsonStringChars = []rune("abcxyzABC019 \"\\/\b\f\n\r\t\u0000\u001f<>&\u00e9\u00a0\u2028\U0001F600")
+
+var jsonValueType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// jsonEdgeNumbers are numbers that are hard to serialize or parse
+var jsonEdgeNumbers = []float64{
+	0, math.Copysign(0, -1), 1, -1, 0.1, 1e21, 1e-7, 1 << 53, -(1 << 53),
+	math.MaxFloat64, -math.MaxFloat64, math.SmallestNonzeroFloat64,
+}
+
+// JSONValue generates JSON documents as decoded by encoding/json into an
+// interface{}: nil, bool, float64, string, []interface{} and
+// map[string]interface{} nested up to maxDepth levels.
+// The strings contain characters that have to be escaped and the numbers
+// include edge cases like -0, 1e21 and math.MaxFloat64 (but no NaN or
+// infinities).
+// The used kinds of values ("null", "bool", "number", "string", "array",
+// "object") are added as labels.
+// The documents shrink structurally (see JSONValueShrinker).
+func JSONValue(maxDepth int) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		value := genJSONValue(genParams, maxDepth, features)
+		genResult := gopter.NewGenResult(value, JSONValueShrinker)
+		// null is a valid document
+		genResult.ResultType = jsonValueType
+		genResult.Sieve = func(interface{}) bool {
+			return true
+		}
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+// JSONString generates the serialized form of the documents generated by
+// JSONValue with a maximum depth of DefaultRecursionDepth.
+// The strings shrink with the underlying documents.
+func JSONString() gopter.Gen {
+	values := JSONValue(DefaultRecursionDepth)
+	// Map would pass the *GenResult to a func(interface{}), the result without
+	// shrinker is shrunk by serializing the shrinks of the document
+	return values.MapResult(func(valueResult *gopter.GenResult) *gopter.GenResult {
+		data, _ := json.Marshal(valueResult.Result)
+		return &gopter.GenResult{
+			Labels:     valueResult.Labels,
+			ResultType: reflect.TypeOf(""),
+			Result:     string(data),
+		}
+	})
+}
+
+func genJSONValue(genParams *gopter.GenParameters, depth int, features map[string]bool) interface{} {
+	kinds := 6
+	if depth <= 0 {
+		kinds = 4
+	}
+	switch genParams.Rng.Intn(kinds) {
+	case 0:
+		features["null"] = true
+		return nil
+	case 1:
+		features["bool"] = true
+		return genParams.NextBool()
+	case 2:
+		features["number"] = true
+		switch genParams.Rng.Intn(3) {
+		case 0:
+			return jsonEdgeNumbers[genParams.Rng.Intn(len(jsonEdgeNumbers))]
+		case 1:
+			return float64(genParams.Rng.Intn(2001) - 1000)
+		}
+		return genParams.Rng.NormFloat64() * math.Pow(10, float64(genParams.Rng.Intn(41)-20))
+	case 3:
+		features["string"] = true
+		return genJSONString(genParams)
+	case 4:
+		features["array"] = true
+		array := make([]interface{}, genJSONLength(genParams))
+		for i := range array {
+			array[i] = genJSONValue(genParams, depth-1, features)
+		}
+		return array
+	}
+	features["object"] = true
+	object := map[string]interface{}{}
+	length := genJSONLength(genParams)
+	for i := 0; i < length; i++ {
+		object[genJSONString(genParams)] = genJSONValue(genParams, depth-1, features)
+	}
+	return object
+}
+
+func genJSONLength(genParams *gopter.GenParameters) int {
+	maxLength := genParams.MaxSize
+	if maxLength > 5 {
+		maxLength = 5
+	}
+	return genParams.Rng.Intn(maxLength + 1)
+}
+
+func genJSONString(genParams *gopter.GenParameters) string {
+	str := make([]rune, genParams.Rng.Intn(10))
+	for i := range str {
+		str[i] = jsonStringChars[genParams.Rng.Intn(len(jsonStringChars))]
+	}
+	return string(str)
+}
+
+// JSONValueShrinker shrinks a JSON document (see JSONValue): Arrays and
+// objects are replaced by their elements, shortened and their elements
+// shrunk, strings and numbers shrink like strings and float64 and true
+// shrinks to false.
+func JSONValueShrinker(v interface{}) gopter.Shrink {
+	switch value := v.(type) {
+	case []interface{}:
+		return valuesShrink(append([]interface{}{}, value...)).
+			Interleave(SliceShrinker(JSONValueShrinker)(value))
+	case map[string]interface{}:
+		elements := make([]interface{}, 0, len(value))
+		for _, key := range sortedJSONKeys(value) {
+			elements = append(elements, value[key])
+		}
+		return valuesShrink(elements).Interleave(MapShrinker(StringShrinker, JSONValueShrinker)(value))
+	case string:
+		return StringShrinker(value)
+	case float64:
+		return Float64Shrinker(value)
+	case bool:
+		if value {
+			return valuesShrink([]interface{}{false})
+		}
+	}
+	return gopter.NoShrink
+}
+
+func sortedJSONKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
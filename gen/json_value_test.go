@@ -0,0 +1,87 @@
+package gen_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// jsonDepth is the nesting depth of a JSON document
+func jsonDepth(value interface{}) int {
+	depth := 0
+	switch value := value.(type) {
+	case []interface{}:
+		for _, element := range value {
+			if d := jsonDepth(element); d > depth {
+				depth = d
+			}
+		}
+		return depth + 1
+	case map[string]interface{}:
+		for _, element := range value {
+			if d := jsonDepth(element); d > depth {
+				depth = d
+			}
+		}
+		return depth + 1
+	}
+	return 0
+}
+
+func TestJSONValue(t *testing.T) {
+	parameters := gopter.DefaultGenParameters()
+	labels := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		genResult := gen.JSONValue(3)(parameters)
+		value, _ := genResult.Retrieve()
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("Invalid value: %#v (%v)", value, err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded, value) {
+			t.Errorf("Value does not round trip: %#v != %#v", decoded, value)
+		}
+		if depth := jsonDepth(value); depth > 3 {
+			t.Errorf("Invalid depth %d: %#v", depth, value)
+		}
+		for _, label := range genResult.Labels {
+			labels[label] = true
+		}
+	}
+	for _, label := range []string{"null", "bool", "number", "string", "array", "object"} {
+		if !labels[label] {
+			t.Errorf("Label %#v never generated", label)
+		}
+	}
+
+	commonGeneratorTest(t, "JSON string", gen.JSONString(), func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && json.Valid([]byte(str))
+	})
+}
+
+func TestJSONValueShrinker(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(value interface{}) bool {
+		return jsonDepth(value) < 2
+	}, gen.JSONValue(4)).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	data, _ := json.Marshal(result.Args[0].Arg)
+	if str := string(data); str != "[[]]" && str != `{"":[]}` && str != `[{}]` && str != `{"":{}}` {
+		t.Errorf("Invalid shrunk value: %s", str)
+	}
+
+	if shrink := gen.JSONValueShrinker(true); !reflect.DeepEqual(shrink.All(), []interface{}{false}) {
+		t.Errorf("Invalid shrink: %#v", shrink.All())
+	}
+	if shrink := gen.JSONValueShrinker(nil); len(shrink.All()) != 0 {
+		t.Errorf("Invalid shrink: %#v", shrink.All())
+	}
+}
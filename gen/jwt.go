@@ -0,0 +1,156 @@
+package gen
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/leanovate/gopter"
+)
+
+// Validity windows of a generated JWTToken
+const (
+	JWTValid       = "valid"
+	JWTExpired     = "expired"
+	JWTNotYetValid = "not yet valid"
+)
+
+// JWTToken is a generated JSON web token
+type JWTToken struct {
+	Token  string
+	Header map[string]interface{}
+	Claims map[string]interface{}
+	// Window is one of JWTValid, JWTExpired or JWTNotYetValid (relative to
+	// the reference time of the generator)
+	Window string
+	// Defect describes how a malformed token was broken (empty for
+	// structurally valid tokens)
+	Defect string
+}
+
+var jwtAlgs = []string{"HS256", "HS384", "HS512", "RS256", "RS512", "ES256", "ES256K", "PS256", "EdDSA", "none", "HS1", "hs256"}
+
+var jwtClaimValues = []interface{}{
+	"", "admin", "user@example.com", "ä😀\u0000", 0, -1, 1.5, true, false, nil,
+	[]interface{}{"a", "b"}, map[string]interface{}{"nested": true},
+}
+
+// JWT generates structurally valid JSON web tokens with random claims and
+// (partly exotic) algorithms that are valid, expired or not yet valid
+// relative to "now". Tokens with alg HS256 are signed with hmacKey, all others
+// have a random signature ("none" has an empty one).
+// The validity window is added as label.
+func JWT(now time.Time, hmacKey []byte) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		token := genJWT(genParams, now, hmacKey)
+		genResult := gopter.NewGenResult(token, gopter.NoShrinker)
+		genResult.Labels = []string{token.Window}
+		return genResult
+	}
+}
+
+// MalformedJWT generates tokens like JWT that are broken afterwards: invalid
+// base64, truncated or missing segments, additional segments or payloads that
+// are not JSON. The defect is added as label.
+func MalformedJWT(now time.Time, hmacKey []byte) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		token := genJWT(genParams, now, hmacKey)
+		segments := strings.Split(token.Token, ".")
+		switch genParams.Rng.Intn(6) {
+		case 0:
+			idx := genParams.Rng.Intn(2)
+			pos := genParams.Rng.Intn(len(segments[idx]) + 1)
+			badChars := "*=+/ %!"
+			bad := badChars[genParams.Rng.Intn(len(badChars))]
+			segments[idx] = segments[idx][:pos] + string(bad) + segments[idx][pos:]
+			token.Defect = "invalid base64"
+		case 1:
+			idx := genParams.Rng.Intn(2)
+			segments[idx] = segments[idx][:genParams.Rng.Intn(len(segments[idx]))]
+			if segments[idx] == "" {
+				segments[idx] = "e"
+			}
+			token.Defect = "truncated segment"
+		case 2:
+			segments = segments[:1+genParams.Rng.Intn(2)]
+			token.Defect = "missing segment"
+		case 3:
+			segments = append(segments, segments[1])
+			token.Defect = "additional segment"
+		case 4:
+			payloads := []string{"null", "[]", "{", "not json", "{\"exp\":\"tomorrow\"}"}
+			segments[1] = base64.RawURLEncoding.EncodeToString([]byte(payloads[genParams.Rng.Intn(len(payloads))]))
+			token.Defect = "invalid payload"
+		default:
+			segments[0] = base64.RawURLEncoding.EncodeToString([]byte("{\"typ\":\"JWT\"}"))
+			token.Defect = "missing alg"
+		}
+		token.Token = strings.Join(segments, ".")
+		genResult := gopter.NewGenResult(token, gopter.NoShrinker)
+		genResult.Labels = []string{"malformed: " + token.Defect}
+		return genResult
+	}
+}
+
+func genJWT(genParams *gopter.GenParameters, now time.Time, hmacKey []byte) JWTToken {
+	alg := jwtAlgs[genParams.Rng.Intn(len(jwtAlgs))]
+	header := map[string]interface{}{"alg": alg, "typ": "JWT"}
+	if genParams.NextBool() {
+		header["kid"] = Identifier()(genParams).Result
+	}
+
+	issuedAt := now.Add(-time.Duration(genParams.Rng.Int63n(int64(24*time.Hour))) - time.Second)
+	lifetime := time.Second + time.Duration(genParams.Rng.Int63n(int64(48*time.Hour)))
+	var notBefore time.Time
+	window := []string{JWTValid, JWTExpired, JWTNotYetValid}[genParams.Rng.Intn(3)]
+	switch window {
+	case JWTValid:
+		notBefore = issuedAt
+		if expiry := notBefore.Add(lifetime); !expiry.After(now) {
+			lifetime = now.Sub(notBefore) + time.Second
+		}
+	case JWTExpired:
+		notBefore = now.Add(-lifetime - time.Second - time.Duration(genParams.Rng.Int63n(int64(time.Hour))))
+	default:
+		notBefore = now.Add(time.Second + time.Duration(genParams.Rng.Int63n(int64(time.Hour))))
+	}
+	claims := map[string]interface{}{
+		"iat": issuedAt.Unix(),
+		"nbf": notBefore.Unix(),
+		"exp": notBefore.Add(lifetime).Unix(),
+		"sub": Identifier()(genParams).Result,
+	}
+	for i := genParams.Rng.Intn(5); i > 0; i-- {
+		claim := Identifier()(genParams).Result.(string)
+		if claim == "" {
+			continue
+		}
+		if _, ok := claims[claim]; !ok {
+			claims[claim] = jwtClaimValues[genParams.Rng.Intn(len(jwtClaimValues))]
+		}
+	}
+
+	headerJSON, _ := json.Marshal(header)
+	claimsJSON, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	var signature []byte
+	switch alg {
+	case "none":
+	case "HS256":
+		mac := hmac.New(sha256.New, hmacKey)
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	default:
+		signature = make([]byte, 32+genParams.Rng.Intn(64))
+		genParams.Rng.Read(signature)
+	}
+	return JWTToken{
+		Token:  signingInput + "." + base64.RawURLEncoding.EncodeToString(signature),
+		Header: header,
+		Claims: claims,
+		Window: window,
+	}
+}
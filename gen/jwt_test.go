@@ -0,0 +1,88 @@
+package gen_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+// parseJWT is a simple (non-validating) JWT parser
+func parseJWT(token string) (header, claims map[string]interface{}, signature []byte, ok bool) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, nil, nil, false
+	}
+	headerJSON, err1 := base64.RawURLEncoding.DecodeString(segments[0])
+	claimsJSON, err2 := base64.RawURLEncoding.DecodeString(segments[1])
+	signature, err3 := base64.RawURLEncoding.DecodeString(segments[2])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil, nil, nil, false
+	}
+	if json.Unmarshal(headerJSON, &header) != nil || json.Unmarshal(claimsJSON, &claims) != nil || claims == nil {
+		return nil, nil, nil, false
+	}
+	if _, ok := header["alg"].(string); !ok {
+		return nil, nil, nil, false
+	}
+	if _, ok := claims["exp"].(float64); !ok {
+		return nil, nil, nil, false
+	}
+	return header, claims, signature, true
+}
+
+func TestJWT(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	key := []byte("secret")
+	commonGeneratorTest(t, "jwt", gen.JWT(now, key), func(value interface{}) bool {
+		token, ok := value.(gen.JWTToken)
+		if !ok || token.Defect != "" {
+			return false
+		}
+		header, claims, signature, ok := parseJWT(token.Token)
+		if !ok || header["alg"] != token.Header["alg"] {
+			return false
+		}
+		nbf, exp := int64(claims["nbf"].(float64)), int64(claims["exp"].(float64))
+		switch token.Window {
+		case gen.JWTValid:
+			ok = nbf <= now.Unix() && exp > now.Unix()
+		case gen.JWTExpired:
+			ok = exp < now.Unix()
+		case gen.JWTNotYetValid:
+			ok = nbf > now.Unix()
+		default:
+			ok = false
+		}
+		switch header["alg"] {
+		case "none":
+			ok = ok && len(signature) == 0
+		case "HS256":
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte(token.Token[:strings.LastIndex(token.Token, ".")]))
+			ok = ok && hmac.Equal(signature, mac.Sum(nil))
+		}
+		return ok
+	})
+}
+
+func TestMalformedJWT(t *testing.T) {
+	now := time.Now()
+	commonGeneratorTest(t, "malformed jwt", gen.MalformedJWT(now, nil), func(value interface{}) bool {
+		token, ok := value.(gen.JWTToken)
+		if !ok || token.Defect == "" {
+			return false
+		}
+		if token.Defect == "truncated segment" {
+			// truncated segments might still be decodable
+			return true
+		}
+		_, _, _, ok = parseJWT(token.Token)
+		return !ok
+	})
+}
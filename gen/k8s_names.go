@@ -0,0 +1,257 @@
+package gen
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// Maximum lengths of Kubernetes style names
+const (
+	DNS1123LabelMaxLength     = 63
+	DNS1123SubdomainMaxLength = 253
+	LabelValueMaxLength       = 63
+)
+
+var (
+	dns1123LabelRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	labelNameRegexp    = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+)
+
+func validDNS1123Label(str string) bool {
+	return len(str) <= DNS1123LabelMaxLength && dns1123LabelRegexp.MatchString(str)
+}
+
+func validDNS1123Subdomain(str string) bool {
+	if str == "" || len(str) > DNS1123SubdomainMaxLength {
+		return false
+	}
+	for _, label := range strings.Split(str, ".") {
+		if !dns1123LabelRegexp.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func validLabelKey(key string) bool {
+	name := key
+	if idx := strings.LastIndex(key, "/"); idx >= 0 {
+		if !validDNS1123Subdomain(key[:idx]) {
+			return false
+		}
+		name = key[idx+1:]
+	}
+	return len(name) <= LabelValueMaxLength && labelNameRegexp.MatchString(name)
+}
+
+func validLabelValue(value string) bool {
+	return value == "" || (len(value) <= LabelValueMaxLength && labelNameRegexp.MatchString(value))
+}
+
+func validLabelMap(labels map[string]string) bool {
+	for key, value := range labels {
+		if !validLabelKey(key) || !validLabelValue(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// genFromChars creates a string of a given length with first/last characters
+// from "edge" and all others from "inner"
+func genFromChars(genParams *gopter.GenParameters, length int, edge, inner string) string {
+	result := make([]byte, length)
+	for i := range result {
+		chars := inner
+		if i == 0 || i == length-1 {
+			chars = edge
+		}
+		result[i] = chars[genParams.Rng.Intn(len(chars))]
+	}
+	return string(result)
+}
+
+const (
+	dns1123EdgeChars  = "abcdefghijklmnopqrstuvwxyz0123456789"
+	dns1123InnerChars = dns1123EdgeChars + "-"
+	labelEdgeChars    = dns1123EdgeChars + "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	labelInnerChars   = labelEdgeChars + "-_."
+)
+
+// nameLength chooses a length in [1, maxLength] preferring short names, but
+// using the maximum length in 10% of the cases
+func nameLength(genParams *gopter.GenParameters, maxLength int) int {
+	if genParams.Rng.Intn(10) == 0 {
+		return maxLength
+	}
+	if maxLength > 20 {
+		maxLength = 20
+	}
+	return 1 + genParams.Rng.Intn(maxLength)
+}
+
+func genDNS1123Label(genParams *gopter.GenParameters, maxLength int) string {
+	return genFromChars(genParams, nameLength(genParams, maxLength), dns1123EdgeChars, dns1123InnerChars)
+}
+
+func genDNS1123Subdomain(genParams *gopter.GenParameters) string {
+	if genParams.Rng.Intn(10) == 0 {
+		// boundary length: 3 labels of 63 + 1 label of 61 characters
+		labels := []string{
+			genFromChars(genParams, 63, dns1123EdgeChars, dns1123InnerChars),
+			genFromChars(genParams, 63, dns1123EdgeChars, dns1123InnerChars),
+			genFromChars(genParams, 63, dns1123EdgeChars, dns1123InnerChars),
+			genFromChars(genParams, 61, dns1123EdgeChars, dns1123InnerChars),
+		}
+		return strings.Join(labels, ".")
+	}
+	labels := make([]string, 1+genParams.Rng.Intn(4))
+	for i := range labels {
+		labels[i] = genDNS1123Label(genParams, DNS1123LabelMaxLength)
+	}
+	if subdomain := strings.Join(labels, "."); len(subdomain) <= DNS1123SubdomainMaxLength {
+		return subdomain
+	}
+	// 4 labels of the maximum length are too long
+	return strings.Join(labels[:len(labels)-1], ".")
+}
+
+func validStringGen(generate func(*gopter.GenParameters) string, valid func(string) bool) gopter.Gen {
+	sieve := func(v interface{}) bool {
+		return valid(v.(string))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		genResult := gopter.NewGenResult(generate(genParams), filteredShrinker(StringShrinker, sieve))
+		genResult.Sieve = sieve
+		return genResult
+	}
+}
+
+// invalidStringGen breaks valid strings by a typical mistake
+func invalidStringGen(generate func(*gopter.GenParameters) string, valid func(string) bool, maxLength int) gopter.Gen {
+	return validStringGen(func(genParams *gopter.GenParameters) string {
+		str := breakString(genParams, generate(genParams), maxLength)
+		if valid(str) {
+			// e.g. empty label values are valid
+			str += "-"
+		}
+		return str
+	}, func(str string) bool {
+		return !valid(str)
+	})
+}
+
+func breakString(genParams *gopter.GenParameters, str string, maxLength int) string {
+	switch genParams.Rng.Intn(6) {
+	case 0:
+		return ""
+	case 1:
+		return str + strings.Repeat("a", maxLength+1-len(str))
+	case 2:
+		return "-" + str
+	case 3:
+		return str + "-"
+	case 4:
+		if str == "" {
+			return "Ä"
+		}
+		return strings.ToUpper(str[:1]) + "Ä" + str[1:]
+	default:
+		bad := " _/@:*?äÖ.\t"
+		pos := genParams.Rng.Intn(len(str) + 1)
+		return str[:pos] + string(bad[genParams.Rng.Intn(len(bad))]) + str[pos:]
+	}
+}
+
+// DNS1123Label generates valid RFC 1123 labels as used for most Kubernetes
+// resource names (lower case alphanumeric characters or "-", starting and
+// ending with an alphanumeric character, at most 63 characters).
+// Names with the maximum length are generated regularly.
+func DNS1123Label() gopter.Gen {
+	return validStringGen(func(genParams *gopter.GenParameters) string {
+		return genDNS1123Label(genParams, DNS1123LabelMaxLength)
+	}, validDNS1123Label)
+}
+
+// InvalidDNS1123Label generates strings that are no valid RFC 1123 labels
+// (e.g. too long, upper case, leading or trailing "-", invalid characters)
+func InvalidDNS1123Label() gopter.Gen {
+	return invalidStringGen(func(genParams *gopter.GenParameters) string {
+		return genDNS1123Label(genParams, DNS1123LabelMaxLength)
+	}, validDNS1123Label, DNS1123LabelMaxLength)
+}
+
+// K8sName generates valid Kubernetes object names, i.e. RFC 1123 subdomains
+// (dot separated RFC 1123 labels, at most 253 characters).
+// Names with the maximum length are generated regularly.
+func K8sName() gopter.Gen {
+	return validStringGen(genDNS1123Subdomain, validDNS1123Subdomain)
+}
+
+// InvalidK8sName generates strings that are no valid Kubernetes object names
+func InvalidK8sName() gopter.Gen {
+	return invalidStringGen(genDNS1123Subdomain, validDNS1123Subdomain, DNS1123SubdomainMaxLength)
+}
+
+func genLabelKey(genParams *gopter.GenParameters) string {
+	name := genFromChars(genParams, nameLength(genParams, LabelValueMaxLength), labelEdgeChars, labelInnerChars)
+	if genParams.NextBool() {
+		return genDNS1123Subdomain(genParams) + "/" + name
+	}
+	return name
+}
+
+func genLabelValue(genParams *gopter.GenParameters) string {
+	if genParams.Rng.Intn(10) == 0 {
+		return ""
+	}
+	return genFromChars(genParams, nameLength(genParams, LabelValueMaxLength), labelEdgeChars, labelInnerChars)
+}
+
+func genLabelMap(genParams *gopter.GenParameters) map[string]string {
+	labels := map[string]string{}
+	for i := genParams.Rng.Intn(8); i > 0; i-- {
+		labels[genLabelKey(genParams)] = genLabelValue(genParams)
+	}
+	return labels
+}
+
+// LabelSelectorMap generates valid Kubernetes label maps (as used by label
+// selectors): Keys with an optional DNS subdomain prefix and a name of at most
+// 63 characters, values of at most 63 characters (possibly empty).
+func LabelSelectorMap() gopter.Gen {
+	sieve := func(v interface{}) bool {
+		return validLabelMap(v.(map[string]string))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		genResult := gopter.NewGenResult(genLabelMap(genParams),
+			filteredShrinker(MapShrinker(StringShrinker, StringShrinker), sieve))
+		genResult.Sieve = sieve
+		return genResult
+	}
+}
+
+// InvalidLabelSelectorMap generates label maps with at least one invalid key
+// or value
+func InvalidLabelSelectorMap() gopter.Gen {
+	invalidKey := InvalidDNS1123Label()
+	invalidValue := invalidStringGen(genLabelValue, validLabelValue, LabelValueMaxLength)
+	sieve := func(v interface{}) bool {
+		return !validLabelMap(v.(map[string]string))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		labels := genLabelMap(genParams)
+		if genParams.NextBool() {
+			key, _ := invalidKey(genParams).Retrieve()
+			labels[key.(string)+"/"] = genLabelValue(genParams)
+		} else {
+			value, _ := invalidValue(genParams).Retrieve()
+			labels[genLabelKey(genParams)] = value.(string)
+		}
+		genResult := gopter.NewGenResult(labels, filteredShrinker(MapShrinker(StringShrinker, StringShrinker), sieve))
+		genResult.Sieve = sieve
+		return genResult
+	}
+}
@@ -0,0 +1,87 @@
+package gen_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+var (
+	testDNS1123Label = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	testLabelName    = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+)
+
+func isDNS1123Label(str string) bool {
+	return len(str) <= 63 && testDNS1123Label.MatchString(str)
+}
+
+func isK8sName(str string) bool {
+	if len(str) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(str, ".") {
+		if !testDNS1123Label.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLabelMap(labels map[string]string) bool {
+	for key, value := range labels {
+		parts := strings.Split(key, "/")
+		if len(parts) > 2 || (len(parts) == 2 && !isK8sName(parts[0])) {
+			return false
+		}
+		name := parts[len(parts)-1]
+		if len(name) > 63 || !testLabelName.MatchString(name) ||
+			len(value) > 63 || (value != "" && !testLabelName.MatchString(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDNS1123Label(t *testing.T) {
+	commonGeneratorTest(t, "dns1123 label", gen.DNS1123Label(), func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && isDNS1123Label(str)
+	})
+	commonGeneratorTest(t, "invalid dns1123 label", gen.InvalidDNS1123Label(), func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && !isDNS1123Label(str)
+	})
+
+	boundary := false
+	for i := 0; i < 100; i++ {
+		value, _ := gen.DNS1123Label().Sample()
+		boundary = boundary || len(value.(string)) == 63
+	}
+	if !boundary {
+		t.Error("No label of maximum length generated")
+	}
+}
+
+func TestK8sName(t *testing.T) {
+	commonGeneratorTest(t, "k8s name", gen.K8sName(), func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && isK8sName(str)
+	})
+	commonGeneratorTest(t, "invalid k8s name", gen.InvalidK8sName(), func(value interface{}) bool {
+		str, ok := value.(string)
+		return ok && !isK8sName(str)
+	})
+}
+
+func TestLabelSelectorMap(t *testing.T) {
+	commonGeneratorTest(t, "label map", gen.LabelSelectorMap(), func(value interface{}) bool {
+		labels, ok := value.(map[string]string)
+		return ok && isLabelMap(labels)
+	})
+	commonGeneratorTest(t, "invalid label map", gen.InvalidLabelSelectorMap(), func(value interface{}) bool {
+		labels, ok := value.(map[string]string)
+		return ok && !isLabelMap(labels)
+	})
+}
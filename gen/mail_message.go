@@ -0,0 +1,332 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// MailHeaderField is a generated header field of a mail message
+type MailHeaderField struct {
+	// Name of the header field (e.g. "Subject")
+	Name string
+	// Raw is the field as it appears in the message (possibly folded,
+	// including the terminating CRLF)
+	Raw string
+	// Value is the unfolded value of the field
+	Value string
+	// Decoded is the unfolded value with all encoded-words decoded
+	Decoded string
+	// Address is the expected parsed address of address fields (From, To),
+	// nil for all other fields
+	Address *mail.Address
+}
+
+// MIMEPart is a node of a generated MIME tree
+type MIMEPart struct {
+	// ContentType is the media type without parameters (e.g. "text/plain")
+	ContentType string
+	// Boundary of a multipart
+	Boundary string
+	// TransferEncoding is the Content-Transfer-Encoding of a leaf ("7bit",
+	// "base64" or "quoted-printable")
+	TransferEncoding string
+	// Body is the decoded content of a leaf
+	Body []byte
+	// Parts are the children of a multipart
+	Parts []*MIMEPart
+	// Header is the encoded header of the part (including the terminating
+	// empty line)
+	Header string
+	// Content is the encoded content of the part (without its header)
+	Content []byte
+}
+
+// MailMessageLayout is a generated mail message
+type MailMessageLayout struct {
+	// Headers are the header fields of the message (without the MIME headers
+	// of the root part)
+	Headers []MailHeaderField
+	// Root is the root of the MIME tree of the message body
+	Root *MIMEPart
+	// Raw is the encoded message
+	Raw []byte
+}
+
+var mailWords = []string{
+	"hello", "world", "meeting", "re:", "fwd:", "report", "invoice", "status", "update", "urgent",
+	"Grüße", "naïve", "café", "日本語", "привет", "€100", "a", "",
+}
+
+var mailDomains = []string{"example.com", "example.org", "mail.example.net", "xn--bcher-kva.example"}
+
+// MailHeaders generates the header fields of mail messages (RFC 5322): From
+// and To addresses with display names (quoted, encoded or with comments),
+// Subjects with encoded-words (Q and B encoding), Message-IDs and custom
+// fields. Long fields are folded.
+// The used features are added as labels.
+func MailHeaders() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		fields := genMailHeaders(genParams, features)
+		genResult := gopter.NewGenResult(fields, gopter.NoShrinker)
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+// MIMETree generates nested MIME trees (RFC 2045/2046) of multipart/mixed,
+// alternative and related parts with text and binary leaves encoded as 7bit,
+// base64 or quoted-printable. Multiparts may be empty and are nested up to a
+// depth of 3.
+// The used features are added as labels.
+func MIMETree() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		root := genMIMEPart(genParams, 0, features)
+		genResult := gopter.NewGenResult(root, gopter.NoShrinker)
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+// MailMessage generates mail messages (MailMessageLayout) consisting of
+// generated headers (see MailHeaders) and a MIME tree (see MIMETree).
+// The used features are added as labels.
+func MailMessage() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		message := &MailMessageLayout{
+			Headers: genMailHeaders(genParams, features),
+			Root:    genMIMEPart(genParams, 0, features),
+		}
+		var raw bytes.Buffer
+		for _, field := range message.Headers {
+			raw.WriteString(field.Raw)
+		}
+		raw.WriteString("MIME-Version: 1.0\r\n")
+		raw.WriteString(message.Root.Header)
+		raw.Write(message.Root.Content)
+		message.Raw = raw.Bytes()
+
+		genResult := gopter.NewGenResult(message, gopter.NoShrinker)
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+func sortedFeatures(features map[string]bool) []string {
+	labels := make([]string, 0, len(features))
+	for feature := range features {
+		labels = append(labels, feature)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+func genMailText(genParams *gopter.GenParameters, minWords, maxWords int) string {
+	count := minWords + genParams.Rng.Intn(maxWords-minWords+1)
+	words := make([]string, 0, count)
+	for len(words) < count {
+		if word := mailWords[genParams.Rng.Intn(len(mailWords))]; word != "" {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeMailText encodes non-ASCII text (and some or if forced all ASCII
+// texts) as encoded-words. Forced encodings always use the B encoding, since
+// the Q encoding might leave specials like ':' unencoded.
+func encodeMailText(genParams *gopter.GenParameters, text string, force bool, features map[string]bool) string {
+	if text == "" || isASCII(text) && !force && genParams.Rng.Intn(4) != 0 {
+		return text
+	}
+	if isASCII(text) {
+		// mime.WordEncoder does not encode ASCII text
+		features["encoded-word (B)"] = true
+		var words []string
+		for len(text) > 45 {
+			words = append(words, "=?utf-8?b?"+base64.StdEncoding.EncodeToString([]byte(text[:45]))+"?=")
+			text = text[45:]
+		}
+		words = append(words, "=?utf-8?b?"+base64.StdEncoding.EncodeToString([]byte(text))+"?=")
+		return strings.Join(words, " ")
+	}
+	if !force && genParams.NextBool() {
+		features["encoded-word (Q)"] = true
+		return mime.QEncoding.Encode("utf-8", text)
+	}
+	features["encoded-word (B)"] = true
+	return mime.BEncoding.Encode("utf-8", text)
+}
+
+// foldMailField creates the raw header field, folding it at (single) spaces
+// if it is long or randomly
+func foldMailField(genParams *gopter.GenParameters, name, value string, features map[string]bool) string {
+	if len(name)+len(value) > 76 || genParams.Rng.Intn(4) == 0 {
+		words := strings.Split(value, " ")
+		var folded strings.Builder
+		lineLength := len(name) + 2
+		for i, word := range words {
+			if i > 0 {
+				if lineLength+len(word) > 76 || genParams.Rng.Intn(3) == 0 {
+					folded.WriteString("\r\n ")
+					lineLength = 1
+					features["folding"] = true
+				} else {
+					folded.WriteString(" ")
+					lineLength++
+				}
+			}
+			folded.WriteString(word)
+			lineLength += len(word)
+		}
+		value = folded.String()
+	}
+	return name + ": " + value + "\r\n"
+}
+
+func genMailAddress(genParams *gopter.GenParameters, features map[string]bool) (string, *mail.Address) {
+	address := &mail.Address{
+		Address: fmt.Sprintf("%s.%d@%s", []string{"john", "jane", "info", "no-reply"}[genParams.Rng.Intn(4)],
+			genParams.Rng.Intn(1000), mailDomains[genParams.Rng.Intn(len(mailDomains))]),
+	}
+	switch genParams.Rng.Intn(4) {
+	case 0:
+		return address.Address, address
+	case 1:
+		features["quoted display name"] = true
+		address.Name = "Doe, " + genMailText(genParams, 1, 2)
+		if !isASCII(address.Name) {
+			return mime.BEncoding.Encode("utf-8", address.Name) + " <" + address.Address + ">", address
+		}
+		return `"` + address.Name + `" <` + address.Address + ">", address
+	}
+	address.Name = genMailText(genParams, 1, 3)
+	// non-atom characters require encoding
+	value := encodeMailText(genParams, address.Name, strings.Contains(address.Name, ":"), features)
+	value += " <" + address.Address + ">"
+	if genParams.NextBool() {
+		features["comment"] = true
+		value += " (" + strings.Replace(genMailText(genParams, 1, 3), ":", "", -1) + ")"
+	}
+	return value, address
+}
+
+func genMailHeaders(genParams *gopter.GenParameters, features map[string]bool) []MailHeaderField {
+	var fields []MailHeaderField
+	addField := func(name, value, decoded string, address *mail.Address) {
+		fields = append(fields, MailHeaderField{
+			Name:    name,
+			Raw:     foldMailField(genParams, name, value, features),
+			Value:   value,
+			Decoded: decoded,
+			Address: address,
+		})
+	}
+
+	from, fromAddress := genMailAddress(genParams, features)
+	addField("From", from, "", fromAddress)
+	to, toAddress := genMailAddress(genParams, features)
+	addField("To", to, "", toAddress)
+	subject := genMailText(genParams, 0, 20)
+	addField("Subject", encodeMailText(genParams, subject, false, features), subject, nil)
+	messageID := fmt.Sprintf("<%x.%x@%s>", genParams.NextUint64(), genParams.NextUint64(), mailDomains[genParams.Rng.Intn(len(mailDomains))])
+	addField("Message-Id", messageID, messageID, nil)
+	for i := genParams.Rng.Intn(3); i > 0; i-- {
+		text := genMailText(genParams, 1, 30)
+		addField(fmt.Sprintf("X-Custom-%d", i), encodeMailText(genParams, text, false, features), text, nil)
+	}
+	return fields
+}
+
+var mimeMultipartTypes = []string{"multipart/mixed", "multipart/alternative", "multipart/related"}
+
+var mimeLeafTypes = []string{"text/plain", "text/html", "application/octet-stream", "image/png"}
+
+func genMIMEPart(genParams *gopter.GenParameters, depth int, features map[string]bool) *MIMEPart {
+	if depth < 3 && genParams.Rng.Intn(depth+2) == 0 {
+		return genMIMEMultipart(genParams, depth, features)
+	}
+	part := &MIMEPart{ContentType: mimeLeafTypes[genParams.Rng.Intn(len(mimeLeafTypes))]}
+	contentType := part.ContentType
+	if strings.HasPrefix(part.ContentType, "text/") {
+		part.Body = []byte(genMailText(genParams, 0, 50))
+		contentType = mime.FormatMediaType(part.ContentType, map[string]string{"charset": "utf-8"})
+	} else {
+		part.Body = randomBytes(genParams, genParams.Rng.Intn(genParams.MaxSize+1))
+	}
+
+	var content bytes.Buffer
+	switch {
+	case isASCII(string(part.Body)) && genParams.NextBool():
+		part.TransferEncoding = "7bit"
+		content.Write(part.Body)
+	case genParams.NextBool():
+		part.TransferEncoding = "quoted-printable"
+		writer := quotedprintable.NewWriter(&content)
+		// otherwise line breaks are normalized to CRLF
+		writer.Binary = !strings.HasPrefix(part.ContentType, "text/")
+		writer.Write(part.Body)
+		writer.Close()
+	default:
+		part.TransferEncoding = "base64"
+		encoded := base64.StdEncoding.EncodeToString(part.Body)
+		for len(encoded) > 76 {
+			content.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		content.WriteString(encoded)
+	}
+	features[part.TransferEncoding] = true
+	part.Header = "Content-Type: " + contentType + "\r\nContent-Transfer-Encoding: " + part.TransferEncoding + "\r\n\r\n"
+	part.Content = content.Bytes()
+	return part
+}
+
+func genMIMEMultipart(genParams *gopter.GenParameters, depth int, features map[string]bool) *MIMEPart {
+	part := &MIMEPart{
+		ContentType: mimeMultipartTypes[genParams.Rng.Intn(len(mimeMultipartTypes))],
+		// "=_" can neither occur in base64 nor quoted-printable content
+		Boundary: fmt.Sprintf("=_%d_%x", depth, genParams.NextUint64()),
+	}
+	features[part.ContentType] = true
+	if depth > 0 {
+		features["nested multipart"] = true
+	}
+	count := genParams.Rng.Intn(4)
+	if count == 0 {
+		features["empty multipart"] = true
+	}
+
+	var content bytes.Buffer
+	content.WriteString("This is a multi-part message in MIME format.\r\n")
+	for i := 0; i < count; i++ {
+		child := genMIMEPart(genParams, depth+1, features)
+		part.Parts = append(part.Parts, child)
+		content.WriteString("\r\n--" + part.Boundary + "\r\n")
+		content.WriteString(child.Header)
+		content.Write(child.Content)
+	}
+	content.WriteString("\r\n--" + part.Boundary + "--\r\n")
+	part.Header = "Content-Type: " + mime.FormatMediaType(part.ContentType, map[string]string{"boundary": part.Boundary}) + "\r\n\r\n"
+	part.Content = content.Bytes()
+	return part
+}
@@ -0,0 +1,149 @@
+package gen_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func checkMailHeaders(t *testing.T, header mail.Header, fields []gen.MailHeaderField) bool {
+	decoder := &mime.WordDecoder{}
+	for _, field := range fields {
+		if value := header.Get(field.Name); value != field.Value {
+			t.Logf("Invalid value of %s: %q != %q", field.Name, value, field.Value)
+			return false
+		}
+		if field.Address != nil {
+			address, err := mail.ParseAddress(field.Value)
+			if err != nil || address.Name != field.Address.Name || address.Address != field.Address.Address {
+				t.Logf("Invalid address %q: %v %v", field.Value, address, err)
+				return false
+			}
+			continue
+		}
+		if decoded, err := decoder.DecodeHeader(field.Value); err != nil || decoded != field.Decoded {
+			t.Logf("Invalid decoded value of %s: %q != %q (%v)", field.Name, decoded, field.Decoded, err)
+			return false
+		}
+	}
+	return true
+}
+
+// checkMIMEPart compares a MIME part with the parsed header and body
+func checkMIMEPart(t *testing.T, header textproto.MIMEHeader, body io.Reader, part *gen.MIMEPart) bool {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || mediaType != part.ContentType {
+		t.Logf("Invalid content type %q: %v", header.Get("Content-Type"), err)
+		return false
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for _, child := range part.Parts {
+			childPart, err := reader.NextPart()
+			if err != nil || !checkMIMEPart(t, childPart.Header, childPart, child) {
+				t.Logf("Invalid child part: %v", err)
+				return false
+			}
+		}
+		if _, err := reader.NextPart(); err != io.EOF {
+			t.Logf("Unexpected part: %v", err)
+			return false
+		}
+		return true
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return false
+	}
+	switch header.Get("Content-Transfer-Encoding") {
+	case "base64":
+		content, err = base64.StdEncoding.DecodeString(strings.Replace(string(content), "\r\n", "", -1))
+	case "quoted-printable":
+		// only decoded automatically by multipart.Reader
+		content, err = ioutil.ReadAll(quotedprintable.NewReader(bytes.NewReader(content)))
+	}
+	if err != nil {
+		return false
+	}
+	if !bytes.Equal(content, part.Body) {
+		t.Logf("Invalid body: %q != %q", content, part.Body)
+		return false
+	}
+	return true
+}
+
+func TestMailHeaders(t *testing.T) {
+	labels := map[string]bool{}
+	commonGeneratorTest(t, "mail headers", gen.MailHeaders(), func(value interface{}) bool {
+		fields, ok := value.([]gen.MailHeaderField)
+		if !ok {
+			return false
+		}
+		var raw strings.Builder
+		for _, field := range fields {
+			raw.WriteString(field.Raw)
+			if strings.Contains(field.Raw, "\r\n ") {
+				labels["folding"] = true
+			}
+		}
+		raw.WriteString("\r\n")
+		message, err := mail.ReadMessage(strings.NewReader(raw.String()))
+		if err != nil {
+			t.Logf("Invalid headers %q: %v", raw.String(), err)
+			return false
+		}
+		return checkMailHeaders(t, message.Header, fields)
+	})
+	if !labels["folding"] {
+		t.Error("No folded headers generated")
+	}
+}
+
+func TestMIMETree(t *testing.T) {
+	nested := false
+	commonGeneratorTest(t, "mime tree", gen.MIMETree(), func(value interface{}) bool {
+		root, ok := value.(*gen.MIMEPart)
+		if !ok {
+			return false
+		}
+		for _, part := range root.Parts {
+			nested = nested || len(part.Parts) > 0
+		}
+		message, err := mail.ReadMessage(bytes.NewReader(append([]byte(root.Header), root.Content...)))
+		if err != nil {
+			t.Logf("Invalid part: %v", err)
+			return false
+		}
+		return checkMIMEPart(t, textproto.MIMEHeader(message.Header), message.Body, root)
+	})
+	if !nested {
+		t.Error("No nested multiparts generated")
+	}
+}
+
+func TestMailMessage(t *testing.T) {
+	commonGeneratorTest(t, "mail message", gen.MailMessage(), func(value interface{}) bool {
+		layout, ok := value.(*gen.MailMessageLayout)
+		if !ok {
+			return false
+		}
+		message, err := mail.ReadMessage(bytes.NewReader(layout.Raw))
+		if err != nil {
+			t.Logf("Invalid message %q: %v", layout.Raw, err)
+			return false
+		}
+		return message.Header.Get("MIME-Version") == "1.0" &&
+			checkMailHeaders(t, message.Header, layout.Headers) &&
+			checkMIMEPart(t, textproto.MIMEHeader(message.Header), message.Body, layout.Root)
+	})
+}
@@ -3,6 +3,7 @@ package gen
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/leanovate/gopter"
 )
@@ -67,7 +68,7 @@ func MapShrinkerOne(keyShrinker, elementShrinker gopter.Shrinker) gopter.Shrinke
 			panic(fmt.Sprintf("%#v is not a map", v))
 		}
 
-		keys := rv.MapKeys()
+		keys := sortedMapKeys(rv)
 		shrinks := make([]gopter.Shrink, 0, len(keys))
 		for _, key := range keys {
 			mapShrinkOne := &mapShrinkOne{
@@ -122,7 +123,7 @@ func MapShrinker(keyShrinker, elementShrinker gopter.Shrinker) gopter.Shrinker {
 		if rv.Kind() != reflect.Map {
 			panic(fmt.Sprintf("%#v is not a Map", v))
 		}
-		keys := rv.MapKeys()
+		keys := sortedMapKeys(rv)
 		mapShrink := &mapShrink{
 			original:     rv,
 			originalKeys: keys,
@@ -147,3 +148,39 @@ func MapShrinker(keyShrinker, elementShrinker gopter.Shrinker) gopter.Shrinker {
 		return gopter.ConcatShrinks(shrinks...)
 	}
 }
+
+// sortedMapKeys gets the keys of a map in a deterministic order (independent
+// of the map iteration), so that shrinkers always create the same candidates:
+// Numbers, strings and bools are ordered by their value, all other keys by
+// their (go syntax) representation
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+	return keys
+}
+
+func lessKey(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		}
+	}
+	return fmt.Sprintf("%#v", a.Interface()) < fmt.Sprintf("%#v", b.Interface())
+}
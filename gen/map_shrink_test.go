@@ -1,10 +1,13 @@
 package gen_test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
 )
 
 func TestMapShrinkerOne(t *testing.T) {
@@ -55,3 +58,39 @@ func TestMapShrinker(t *testing.T) {
 		}
 	}
 }
+
+func TestMapShrinkerDeterministic(t *testing.T) {
+	original := map[int]string{}
+	for i := 0; i < 20; i++ {
+		original[i*7%20] = fmt.Sprintf("v%d", i)
+	}
+	// the keys are shrunk in their order (independent of the map iteration)
+	expected := gen.MapShrinker(gen.IntShrinker, gen.StringShrinker)(original).All()
+	expectedOne := gen.MapShrinkerOne(gen.IntShrinker, gen.StringShrinker)(original).All()
+	for i := 0; i < 10; i++ {
+		if shrinks := gen.MapShrinker(gen.IntShrinker, gen.StringShrinker)(original).All(); !reflect.DeepEqual(shrinks, expected) {
+			t.Fatalf("Invalid shrinks: %#v != %#v", shrinks, expected)
+		}
+		if shrinks := gen.MapShrinkerOne(gen.IntShrinker, gen.StringShrinker)(original).All(); !reflect.DeepEqual(shrinks, expectedOne) {
+			t.Fatalf("Invalid shrinks: %#v != %#v", shrinks, expectedOne)
+		}
+	}
+
+	// the same seed yields the same minimized counterexample
+	check := func() interface{} {
+		result := prop.ForAll(func(m map[int]int) bool {
+			sum := 0
+			for key, value := range m {
+				sum += key + value
+			}
+			return sum < 500
+		}, gen.MapOf(gen.IntRange(0, 100), gen.IntRange(0, 100))).Check(gopter.DefaultTestParametersWithSeed(1234))
+		return result.Args[0].Arg
+	}
+	minimized := check()
+	for i := 0; i < 5; i++ {
+		if other := check(); !reflect.DeepEqual(other, minimized) {
+			t.Fatalf("Invalid minimized map: %#v != %#v", other, minimized)
+		}
+	}
+}
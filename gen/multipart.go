@@ -0,0 +1,180 @@
+package gen
+
+import (
+	"bytes"
+	"mime"
+
+	"github.com/leanovate/gopter"
+)
+
+// MultipartPart is a part of a generated MultipartForm
+type MultipartPart struct {
+	// Name is the form field name
+	Name string
+	// FileName is the file name of a file part (empty for field parts)
+	FileName string
+	// ContentType is the content type of a file part (empty for field parts)
+	ContentType string
+	// Content is the content of the part
+	Content []byte
+}
+
+// MultipartForm is a generated multipart/form-data body
+type MultipartForm struct {
+	// Boundary separating the parts
+	Boundary string
+	// ContentType is the value of the Content-Type header of the request
+	ContentType string
+	// Body is the encoded body
+	Body []byte
+	// Parts are the parts a parser is expected to find in the body
+	Parts []MultipartPart
+}
+
+const multipartBoundaryChars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ'()+_,-./:=? "
+
+var multipartContentTypes = []string{
+	"application/octet-stream", "text/plain", "text/plain; charset=utf-8", "image/png", "application/json",
+}
+
+// MultipartFormData generates multipart/form-data bodies with random field and
+// file parts (including duplicate names, empty contents and non-ASCII names).
+// Boundaries are biased towards their edge cases, i.e. the minimum and maximum
+// length of 1 and 70 characters and special characters (that have to be
+// quoted in the Content-Type), parts may contain the boundary without a
+// leading line break and bodies may have a preamble and epilogue.
+// If oversizedPartSize is greater than 0, one out of four bodies contains a
+// part with that many bytes (to test size limits of a handler).
+// The used edge cases are added as labels.
+func MultipartFormData(oversizedPartSize int) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		form := &MultipartForm{Boundary: genMultipartBoundary(genParams)}
+		form.ContentType = mime.FormatMediaType("multipart/form-data", map[string]string{"boundary": form.Boundary})
+		var labels []string
+		switch len(form.Boundary) {
+		case 1:
+			labels = append(labels, "shortest boundary")
+		case 70:
+			labels = append(labels, "longest boundary")
+		}
+
+		count := 0
+		if genParams.MaxSize > 0 {
+			count = genParams.Rng.Intn(genParams.MaxSize/10 + 2)
+		}
+		oversized := -1
+		if oversizedPartSize > 0 && count > 0 && genParams.Rng.Intn(4) == 0 {
+			oversized = genParams.Rng.Intn(count)
+			labels = append(labels, "oversized")
+		}
+		for i := 0; i < count; i++ {
+			part := genMultipartPart(genParams, form)
+			if i == oversized {
+				part.Content = multipartContent(form.Boundary, randomBytes(genParams, oversizedPartSize))
+			}
+			form.Parts = append(form.Parts, part)
+		}
+		if count == 0 {
+			labels = append(labels, "no parts")
+		}
+
+		var body bytes.Buffer
+		if genParams.Rng.Intn(4) == 0 {
+			labels = append(labels, "preamble")
+			body.WriteString("This is a multipart message.\r\n")
+		}
+		for _, part := range form.Parts {
+			params := map[string]string{"name": part.Name}
+			if part.FileName != "" {
+				params["filename"] = part.FileName
+			}
+			body.WriteString("--" + form.Boundary + "\r\n")
+			body.WriteString("Content-Disposition: " + mime.FormatMediaType("form-data", params) + "\r\n")
+			if part.ContentType != "" {
+				body.WriteString("Content-Type: " + part.ContentType + "\r\n")
+			}
+			body.WriteString("\r\n")
+			body.Write(part.Content)
+			body.WriteString("\r\n")
+		}
+		body.WriteString("--" + form.Boundary + "--\r\n")
+		if genParams.Rng.Intn(4) == 0 {
+			labels = append(labels, "epilogue")
+			body.WriteString("Ignored epilogue\r\n")
+		}
+		form.Body = body.Bytes()
+
+		genResult := gopter.NewGenResult(form, gopter.NoShrinker)
+		genResult.Labels = labels
+		return genResult
+	}
+}
+
+func genMultipartBoundary(genParams *gopter.GenParameters) string {
+	length := 1 + genParams.Rng.Intn(70)
+	switch genParams.Rng.Intn(4) {
+	case 0:
+		length = 1
+	case 1:
+		length = 70
+	}
+	boundary := make([]byte, length)
+	for i := range boundary {
+		boundary[i] = multipartBoundaryChars[genParams.Rng.Intn(len(multipartBoundaryChars))]
+	}
+	if boundary[length-1] == ' ' {
+		// a boundary must not end with a space
+		boundary[length-1] = '_'
+	}
+	return string(boundary)
+}
+
+func genMultipartPart(genParams *gopter.GenParameters, form *MultipartForm) MultipartPart {
+	part := MultipartPart{}
+	if len(form.Parts) > 0 && genParams.Rng.Intn(5) == 0 {
+		// duplicate field name
+		part.Name = form.Parts[genParams.Rng.Intn(len(form.Parts))].Name
+	} else {
+		part.Name = multipartName(genParams)
+	}
+
+	var content []byte
+	if genParams.NextBool() {
+		part.FileName = multipartName(genParams) + []string{"", ".txt", ".png", ".tar.gz"}[genParams.Rng.Intn(4)]
+		part.ContentType = multipartContentTypes[genParams.Rng.Intn(len(multipartContentTypes))]
+		content = randomBytes(genParams, genParams.Rng.Intn(genParams.MaxSize+1))
+	} else {
+		content = []byte(formatLiteral(genParams))
+	}
+	if genParams.Rng.Intn(4) == 0 {
+		// the boundary is only a delimiter if it follows a line break
+		content = append(content, []byte(" --"+form.Boundary)...)
+	}
+	part.Content = multipartContent(form.Boundary, content)
+	return part
+}
+
+func multipartName(genParams *gopter.GenParameters) string {
+	const alphabet = "abcdefxyzABC0123_-. äöü"
+	runes := []rune(alphabet)
+	name := make([]rune, 1+genParams.Rng.Intn(12))
+	for i := range name {
+		name[i] = runes[genParams.Rng.Intn(len(runes))]
+	}
+	if name[0] == ' ' || name[0] == '.' {
+		name[0] = 'f'
+	}
+	return string(name)
+}
+
+// multipartContent removes accidental delimiters from random content
+func multipartContent(boundary string, content []byte) []byte {
+	delimiter := []byte("\r\n--" + boundary)
+	for idx := bytes.Index(content, delimiter); idx >= 0; idx = bytes.Index(content, delimiter) {
+		content[idx] = ' '
+	}
+	if bytes.HasPrefix(content, delimiter[2:]) {
+		content[0] = ' '
+	}
+	return content
+}
@@ -0,0 +1,69 @@
+package gen_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func parseMultipartForm(form *gen.MultipartForm) ([]gen.MultipartPart, error) {
+	_, params, err := mime.ParseMediaType(form.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	reader := multipart.NewReader(bytes.NewReader(form.Body), params["boundary"])
+	var parts []gen.MultipartPart
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		} else if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, gen.MultipartPart{
+			Name:        part.FormName(),
+			FileName:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Content:     content,
+		})
+	}
+}
+
+func TestMultipartFormData(t *testing.T) {
+	labels := map[string]bool{}
+	commonGeneratorTest(t, "multipart form data", gen.MultipartFormData(1<<16), func(value interface{}) bool {
+		form, ok := value.(*gen.MultipartForm)
+		if !ok || len(form.Boundary) < 1 || len(form.Boundary) > 70 {
+			return false
+		}
+		parts, err := parseMultipartForm(form)
+		if err != nil || len(parts) != len(form.Parts) {
+			t.Logf("Invalid body %q: %v", form.Body, err)
+			return false
+		}
+		for i, part := range parts {
+			expected := form.Parts[i]
+			if part.Name != expected.Name || part.FileName != expected.FileName ||
+				part.ContentType != expected.ContentType || !bytes.Equal(part.Content, expected.Content) {
+				t.Logf("Invalid part %#v, expected %#v", part, expected)
+				return false
+			}
+			if len(part.Content) == 1<<16 {
+				labels["oversized"] = true
+			}
+		}
+		return true
+	})
+	if !labels["oversized"] {
+		t.Error("No oversized parts generated")
+	}
+}
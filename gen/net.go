@@ -0,0 +1,272 @@
+package gen
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// ipCategory is a special IP address range with its label
+type ipCategory struct {
+	label  string
+	prefix []byte
+}
+
+// ipv4Categories are the special IPv4 address ranges generated by IPv4 (the
+// prefix is completed by random bytes)
+var ipv4Categories = []ipCategory{
+	{"unspecified", []byte{0, 0, 0, 0}},
+	{"loopback", []byte{127}},
+	{"broadcast", []byte{255, 255, 255, 255}},
+	{"multicast", []byte{224}},
+	{"link-local", []byte{169, 254}},
+	{"private", []byte{10}},
+	{"private", []byte{172, 16}},
+	{"private", []byte{192, 168}},
+}
+
+// ipv6Categories are the special IPv6 address ranges generated by IPv6 (the
+// prefix is completed by random bytes)
+var ipv6Categories = []ipCategory{
+	{"unspecified", make([]byte, net.IPv6len)},
+	{"loopback", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+	{"multicast", []byte{0xff, 0x02}},
+	{"link-local", []byte{0xfe, 0x80, 0, 0, 0, 0, 0, 0}},
+	{"unique local", []byte{0xfd}},
+	{"IPv4-mapped", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff}},
+}
+
+// IPv4 generates IPv4 addresses (as 4 byte net.IP) including the edge cases
+// unspecified (0.0.0.0), loopback, broadcast (255.255.255.255), multicast,
+// link-local and private addresses.
+// The kind of address is added as label ("random" for all others).
+// The addresses shrink towards 0.0.0.0 by clearing bytes from the end.
+func IPv4() gopter.Gen {
+	return genIP(true, false)
+}
+
+// IPv6 generates IPv6 addresses (as 16 byte net.IP) including the edge cases
+// unspecified (::), loopback (::1), multicast, link-local, unique local and
+// IPv4-mapped addresses.
+// The kind of address is added as label ("random" for all others).
+// The addresses shrink towards :: by clearing bytes from the end.
+func IPv6() gopter.Gen {
+	return genIP(false, true)
+}
+
+// IP generates IPv4 and IPv6 addresses (see IPv4 and IPv6), additionally
+// labeled with "IPv4" or "IPv6"
+func IP() gopter.Gen {
+	return genIP(true, true)
+}
+
+func genIP(v4, v6 bool) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var labels []string
+		useV4 := v4 && (!v6 || genParams.NextBool())
+		if v4 && v6 {
+			if useV4 {
+				labels = append(labels, "IPv4")
+			} else {
+				labels = append(labels, "IPv6")
+			}
+		}
+		var ip net.IP
+		var label string
+		if useV4 {
+			ip, label = randomIP(genParams, net.IPv4len, ipv4Categories)
+		} else {
+			ip, label = randomIP(genParams, net.IPv6len, ipv6Categories)
+		}
+		genResult := gopter.NewGenResult(ip, IPShrinker)
+		genResult.Labels = append(labels, label)
+		return genResult
+	}
+}
+
+func randomIP(genParams *gopter.GenParameters, length int, categories []ipCategory) (net.IP, string) {
+	ip := make(net.IP, length)
+	for i := range ip {
+		ip[i] = byte(genParams.Rng.Intn(256))
+	}
+	if genParams.NextBool() {
+		return ip, "random"
+	}
+	category := categories[genParams.Rng.Intn(len(categories))]
+	copy(ip, category.prefix)
+	if category.label == "private" && len(category.prefix) == 2 && category.prefix[0] == 172 {
+		// 172.16.0.0/12
+		ip[1] = 16 + ip[1]%16
+	}
+	return ip, category.label
+}
+
+// IPShrinker shrinks a net.IP by clearing its bytes from the end
+func IPShrinker(v interface{}) gopter.Shrink {
+	return bytesShrink(v.(net.IP), func(shrunk []byte) interface{} {
+		return net.IP(shrunk)
+	})
+}
+
+// bytesShrink clears the non-zero bytes one by one starting with the last
+func bytesShrink(value []byte, convert func([]byte) interface{}) gopter.Shrink {
+	var candidates []interface{}
+	shrunk := append([]byte{}, value...)
+	for i := len(shrunk) - 1; i >= 0; i-- {
+		if shrunk[i] != 0 {
+			shrunk[i] = 0
+			candidates = append(candidates, convert(append([]byte{}, shrunk...)))
+		}
+	}
+	return valuesShrink(candidates)
+}
+
+// CIDR generates IPv4 and IPv6 networks (as net.IPNet with the host bits of
+// the address cleared) including the edge cases of prefix length 0 (all
+// addresses), the full prefix length (single address) and point-to-point
+// prefixes (/31 and /127).
+// The IP version and edge cases are added as labels.
+// The networks shrink by shortening the prefix.
+func CIDR() gopter.Gen {
+	ips := IP()
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		ipResult := ips(genParams)
+		ip := ipResult.Result.(net.IP)
+		bits := len(ip) * 8
+		labels := ipResult.Labels[:1]
+		var ones int
+		switch genParams.Rng.Intn(6) {
+		case 0:
+			labels = append(labels, "prefix 0")
+		case 1:
+			labels = append(labels, "single address")
+			ones = bits
+		case 2:
+			labels = append(labels, "point-to-point")
+			ones = bits - 1
+		default:
+			ones = 1 + genParams.Rng.Intn(bits-1)
+		}
+		genResult := gopter.NewGenResult(newIPNet(ip, ones), IPNetShrinker)
+		genResult.Labels = labels
+		return genResult
+	}
+}
+
+func newIPNet(ip net.IP, ones int) net.IPNet {
+	mask := net.CIDRMask(ones, len(ip)*8)
+	return net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+// IPNetShrinker shrinks a net.IPNet by shortening its prefix (towards 0)
+func IPNetShrinker(v interface{}) gopter.Shrink {
+	network := v.(net.IPNet)
+	ones, _ := network.Mask.Size()
+	var candidates []interface{}
+	seen := map[int]bool{ones: true}
+	for _, shorter := range []int{0, ones / 2, ones - 1} {
+		if shorter >= 0 && !seen[shorter] {
+			seen[shorter] = true
+			candidates = append(candidates, newIPNet(network.IP, shorter))
+		}
+	}
+	return valuesShrink(candidates)
+}
+
+// MACAddress generates 48 bit MAC addresses (as net.HardwareAddr) including
+// the edge cases of the broadcast (ff:ff:ff:ff:ff:ff) and zero address,
+// multicast and locally administered addresses.
+// The kind of address is added as label ("universal" for all others).
+// The addresses shrink towards the zero address by clearing bytes from the
+// end.
+func MACAddress() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		mac := make(net.HardwareAddr, 6)
+		for i := range mac {
+			mac[i] = byte(genParams.Rng.Intn(256))
+		}
+		var label string
+		switch genParams.Rng.Intn(6) {
+		case 0:
+			label = "broadcast"
+			copy(mac, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+		case 1:
+			label = "zero"
+			copy(mac, make([]byte, 6))
+		case 2:
+			label = "multicast"
+			mac[0] |= 0x01
+		case 3:
+			label = "locally administered"
+			mac[0] = mac[0]&^0x01 | 0x02
+		default:
+			label = "universal"
+			mac[0] &^= 0x03
+		}
+		genResult := gopter.NewGenResult(mac, MACAddressShrinker)
+		genResult.Labels = []string{label}
+		return genResult
+	}
+}
+
+// MACAddressShrinker shrinks a net.HardwareAddr by clearing its bytes from
+// the end
+func MACAddressShrinker(v interface{}) gopter.Shrink {
+	return bytesShrink(v.(net.HardwareAddr), func(shrunk []byte) interface{} {
+		return net.HardwareAddr(shrunk)
+	})
+}
+
+func validHostname(str string) bool {
+	for _, label := range strings.Split(str, ".") {
+		if len(label) > DNS1123LabelMaxLength {
+			return false
+		}
+	}
+	return validDNS1123Subdomain(strings.ToLower(str))
+}
+
+// Hostname generates valid hostnames according to RFC 1123 (dot separated
+// labels of letters, digits and "-", at most 253 characters) including
+// "localhost", capitalized names, single labels and names with the maximum
+// length.
+// The hostnames shrink like strings (as long as they stay valid).
+func Hostname() gopter.Gen {
+	return validStringGen(func(genParams *gopter.GenParameters) string {
+		if genParams.Rng.Intn(10) == 0 {
+			return "localhost"
+		}
+		hostname := genDNS1123Subdomain(genParams)
+		if genParams.Rng.Intn(4) == 0 {
+			hostname = strings.ToUpper(hostname[:1]) + hostname[1:]
+		}
+		return hostname
+	}, validHostname)
+}
+
+func validPort(v interface{}) bool {
+	return v.(int) >= 0 && v.(int) <= 65535
+}
+
+// Port generates port numbers (as int) in the range [0, 65535], favoring edge
+// cases like 0, 1, 1023, 1024 and 65535.
+// The edge ports are added as labels (e.g. "port 0").
+// The ports shrink towards 0.
+func Port() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var port int
+		var labels []string
+		if genParams.NextBool() {
+			port = hostPortEdgePorts[genParams.Rng.Intn(len(hostPortEdgePorts))]
+			labels = append(labels, "port "+strconv.Itoa(port))
+		} else {
+			port = genParams.Rng.Intn(65536)
+		}
+		genResult := gopter.NewGenResult(port, filteredShrinker(IntShrinker, validPort))
+		genResult.Sieve = validPort
+		genResult.Labels = labels
+		return genResult
+	}
+}
@@ -0,0 +1,127 @@
+package gen_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestIP(t *testing.T) {
+	commonGeneratorTest(t, "IPv4", gen.IPv4(), func(value interface{}) bool {
+		ip, ok := value.(net.IP)
+		return ok && len(ip) == net.IPv4len && net.ParseIP(ip.String()).Equal(ip)
+	})
+	commonGeneratorTest(t, "IPv6", gen.IPv6(), func(value interface{}) bool {
+		ip, ok := value.(net.IP)
+		return ok && len(ip) == net.IPv6len && net.ParseIP(ip.String()).Equal(ip)
+	})
+	commonGeneratorTest(t, "IP", gen.IP(), func(value interface{}) bool {
+		ip, ok := value.(net.IP)
+		return ok && (len(ip) == net.IPv4len || len(ip) == net.IPv6len)
+	})
+
+	parameters := gopter.DefaultGenParameters()
+	for i := 0; i < 200; i++ {
+		result := gen.IP()(parameters)
+		ip := result.Result.(net.IP)
+		var expected bool
+		switch result.Labels[1] {
+		case "unspecified":
+			expected = ip.IsUnspecified()
+		case "loopback":
+			expected = ip.IsLoopback()
+		case "multicast":
+			expected = ip.IsMulticast()
+		case "link-local":
+			expected = ip.IsLinkLocalUnicast()
+		case "private", "unique local":
+			expected = ip.IsPrivate()
+		case "broadcast":
+			expected = ip.Equal(net.IPv4bcast)
+		case "IPv4-mapped":
+			expected = len(ip) == net.IPv6len && ip.To4() != nil
+		default:
+			expected = result.Labels[1] == "random"
+		}
+		if !expected {
+			t.Errorf("Invalid %v address: %v", result.Labels, ip)
+		}
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	commonGeneratorTest(t, "CIDR", gen.CIDR(), func(value interface{}) bool {
+		network, ok := value.(net.IPNet)
+		if !ok {
+			return false
+		}
+		_, parsed, err := net.ParseCIDR(network.String())
+		return err == nil && parsed.IP.Equal(network.IP) && parsed.String() == network.String()
+	})
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(network net.IPNet) bool {
+		ones, _ := network.Mask.Size()
+		return ones < 8
+	}, gen.CIDR()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if ones, _ := result.Args[0].Arg.(net.IPNet).Mask.Size(); ones != 8 {
+		t.Errorf("Invalid shrunk network: %v", result.Args[0].Arg)
+	}
+}
+
+func TestMACAddress(t *testing.T) {
+	commonGeneratorTest(t, "MAC address", gen.MACAddress(), func(value interface{}) bool {
+		mac, ok := value.(net.HardwareAddr)
+		if !ok {
+			return false
+		}
+		parsed, err := net.ParseMAC(mac.String())
+		return err == nil && parsed.String() == mac.String()
+	})
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(mac net.HardwareAddr) bool {
+		return mac[0]&0x01 == 0
+	}, gen.MACAddress()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if mac := result.Args[0].Arg.(net.HardwareAddr); !strings.HasSuffix(mac.String(), ":00:00:00:00:00") {
+		t.Errorf("Invalid shrunk MAC address: %v", mac)
+	}
+}
+
+func TestHostname(t *testing.T) {
+	commonGeneratorTest(t, "hostname", gen.Hostname(), func(value interface{}) bool {
+		hostname, ok := value.(string)
+		if !ok || hostname == "" || len(hostname) > 253 {
+			return false
+		}
+		for _, label := range strings.Split(hostname, ".") {
+			if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") ||
+				strings.Trim(strings.ToLower(label), "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+				return false
+			}
+		}
+		return true
+	})
+
+	// the seed generates 4 labels of the maximum length
+	if value, ok := gen.Hostname()(gopter.DefaultGenParameters().CloneWithSeed(3222)).Retrieve(); !ok || len(value.(string)) > 253 {
+		t.Errorf("Invalid hostname: %#v", value)
+	}
+}
+
+func TestPort(t *testing.T) {
+	commonGeneratorTest(t, "port", gen.Port(), func(value interface{}) bool {
+		port, ok := value.(int)
+		return ok && port >= 0 && port <= 65535
+	})
+}
@@ -0,0 +1,225 @@
+package gen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// FormattedNumber is a number as it might be written by a user
+type FormattedNumber struct {
+	// Text is the formatted number
+	Text string
+	// Value is the number Text denotes when the separators of its Locale are
+	// respected
+	Value float64
+	// Locale is the convention of the separators (see NumericString)
+	Locale string
+	// Valid is true if strconv.ParseFloat accepts Text
+	Valid bool
+
+	spec numericSpec
+}
+
+// numberLocale are the separators of a locale
+type numberLocale struct {
+	name    string
+	group   string
+	decimal string
+	// lakh groups all but the last 3 digits in pairs (e.g. 12,34,567)
+	lakh bool
+}
+
+var numberLocales = []numberLocale{
+	{"en", ",", ".", false},
+	{"de", ".", ",", false},
+	{"fr", "\u202F", ",", false},
+	{"ch", "'", ".", false},
+	{"in", ",", ".", true},
+}
+
+// numericSpec are the parts of a FormattedNumber
+type numericSpec struct {
+	locale       int
+	sign         string
+	leadingZeros int
+	integer      string
+	fraction     string
+	exponent     string
+	grouped      bool
+	leading      string
+	trailing     string
+}
+
+// NumericString generates numbers as strings with leading zeros, signs,
+// fractions, exponents, group separators and surrounding whitespace.
+// The separators follow one of the locales "en" (1,234.5), "de" (1.234,5),
+// "fr" (1 234,5 with a narrow no-break space), "ch" (1'234.5) and "in"
+// (12,34,567.8).
+// The locale, "valid" or "invalid" (according to strconv.ParseFloat) and the
+// used features ("sign", "leading zeros", "exponent", "grouped",
+// "whitespace") are added as labels.
+// The numbers shrink by removing features and digits.
+func NumericString() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		spec := numericSpec{
+			locale:  genParams.Rng.Intn(len(numberLocales)),
+			integer: randomDigits(genParams, 1+genParams.Rng.Intn(12)),
+		}
+		if spec.integer[0] == '0' && len(spec.integer) > 1 {
+			spec.integer = "1" + spec.integer[1:]
+		}
+		switch genParams.Rng.Intn(4) {
+		case 0:
+			spec.sign = "-"
+		case 1:
+			spec.sign = "+"
+		}
+		if genParams.Rng.Intn(4) == 0 {
+			spec.leadingZeros = 1 + genParams.Rng.Intn(3)
+		}
+		if genParams.NextBool() {
+			spec.fraction = randomDigits(genParams, 1+genParams.Rng.Intn(6))
+		}
+		if genParams.Rng.Intn(5) == 0 {
+			spec.exponent = []string{"e", "E", "e+", "e-", "E-"}[genParams.Rng.Intn(5)] +
+				strconv.Itoa(genParams.Rng.Intn(100))
+		}
+		spec.grouped = len(spec.integer) > 3 && genParams.NextBool()
+		if genParams.Rng.Intn(5) == 0 {
+			whitespace := []string{" ", "\t", "  ", "\u00A0", "\n"}
+			spec.leading = whitespace[genParams.Rng.Intn(len(whitespace))]
+			if genParams.NextBool() {
+				spec.trailing = whitespace[genParams.Rng.Intn(len(whitespace))]
+			}
+		}
+		number := spec.number()
+
+		genResult := gopter.NewGenResult(number, FormattedNumberShrinker)
+		genResult.Labels = append([]string{number.Locale}, number.features()...)
+		return genResult
+	}
+}
+
+func randomDigits(genParams *gopter.GenParameters, count int) string {
+	digits := make([]byte, count)
+	for i := range digits {
+		digits[i] = byte('0' + genParams.Rng.Intn(10))
+	}
+	return string(digits)
+}
+
+func (s numericSpec) number() FormattedNumber {
+	locale := numberLocales[s.locale]
+	integer := strings.Repeat("0", s.leadingZeros) + s.integer
+	if s.grouped {
+		integer = groupDigits(integer, locale)
+	}
+	text := s.leading + s.sign + integer
+	plain := s.sign + s.integer
+	if s.fraction != "" {
+		text += locale.decimal + s.fraction
+		plain += "." + s.fraction
+	}
+	text += s.exponent + s.trailing
+	plain += s.exponent
+	value, _ := strconv.ParseFloat(plain, 64)
+	_, err := strconv.ParseFloat(text, 64)
+	return FormattedNumber{
+		Text:   text,
+		Value:  value,
+		Locale: locale.name,
+		Valid:  err == nil,
+		spec:   s,
+	}
+}
+
+// groupDigits inserts the group separators of a locale
+func groupDigits(digits string, locale numberLocale) string {
+	var groups []string
+	size := 3
+	for len(digits) > size {
+		groups = append([]string{digits[len(digits)-size:]}, groups...)
+		digits = digits[:len(digits)-size]
+		if locale.lakh {
+			size = 2
+		}
+	}
+	return strings.Join(append([]string{digits}, groups...), locale.group)
+}
+
+func (n FormattedNumber) features() []string {
+	s := n.spec
+	features := map[string]bool{"valid": n.Valid, "invalid": !n.Valid}
+	features["sign"] = s.sign != ""
+	features["leading zeros"] = s.leadingZeros > 0
+	features["exponent"] = s.exponent != ""
+	features["grouped"] = s.grouped
+	features["whitespace"] = s.leading != "" || s.trailing != ""
+	var labels []string
+	for _, feature := range sortedFeatures(features) {
+		if features[feature] {
+			labels = append(labels, feature)
+		}
+	}
+	return labels
+}
+
+// FormattedNumberShrinker shrinks a FormattedNumber by removing whitespace,
+// separators, exponent, sign, leading zeros, fraction and digits (keeping the
+// locale)
+func FormattedNumberShrinker(v interface{}) gopter.Shrink {
+	spec := v.(FormattedNumber).spec
+	var candidates []interface{}
+	add := func(shrunk numericSpec) {
+		shrunk.grouped = shrunk.grouped && len(shrunk.integer) > 3
+		candidates = append(candidates, shrunk.number())
+	}
+	if spec.leading != "" || spec.trailing != "" {
+		shrunk := spec
+		shrunk.leading, shrunk.trailing = "", ""
+		add(shrunk)
+	}
+	if spec.grouped {
+		shrunk := spec
+		shrunk.grouped = false
+		add(shrunk)
+	}
+	if spec.exponent != "" {
+		shrunk := spec
+		shrunk.exponent = ""
+		add(shrunk)
+	}
+	if spec.sign != "" {
+		shrunk := spec
+		shrunk.sign = ""
+		add(shrunk)
+	}
+	if spec.leadingZeros > 0 {
+		shrunk := spec
+		shrunk.leadingZeros = 0
+		add(shrunk)
+	}
+	if spec.fraction != "" {
+		shrunk := spec
+		shrunk.fraction = ""
+		add(shrunk)
+		if len(spec.fraction) > 1 {
+			shrunk.fraction = spec.fraction[:len(spec.fraction)-1]
+			add(shrunk)
+		}
+	}
+	if len(spec.integer) > 1 {
+		shrunk := spec
+		shrunk.integer = spec.integer[:len(spec.integer)/2]
+		add(shrunk)
+		shrunk.integer = spec.integer[:len(spec.integer)-1]
+		add(shrunk)
+	} else if spec.integer != "0" {
+		shrunk := spec
+		shrunk.integer = "0"
+		add(shrunk)
+	}
+	return valuesShrink(candidates)
+}
@@ -0,0 +1,64 @@
+package gen_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+var numberSeparators = map[string]*strings.Replacer{
+	"en": strings.NewReplacer(",", ""),
+	"de": strings.NewReplacer(".", "", ",", "."),
+	"fr": strings.NewReplacer("\u202F", "", ",", "."),
+	"ch": strings.NewReplacer("'", ""),
+	"in": strings.NewReplacer(",", ""),
+}
+
+// parseFormattedNumber is a simple tolerant parser of the generated numbers
+func parseFormattedNumber(number gen.FormattedNumber) (float64, error) {
+	text := strings.TrimSpace(number.Text)
+	return strconv.ParseFloat(numberSeparators[number.Locale].Replace(text), 64)
+}
+
+func TestNumericString(t *testing.T) {
+	commonGeneratorTest(t, "numeric string", gen.NumericString(), func(value interface{}) bool {
+		number, ok := value.(gen.FormattedNumber)
+		if !ok || numberSeparators[number.Locale] == nil {
+			return false
+		}
+		_, err := strconv.ParseFloat(number.Text, 64)
+		parsed, parseErr := parseFormattedNumber(number)
+		return number.Valid == (err == nil) && parseErr == nil && parsed == number.Value
+	})
+
+	labels := map[string]bool{}
+	parameters := gopter.DefaultGenParameters()
+	for i := 0; i < 1000; i++ {
+		for _, label := range gen.NumericString()(parameters).Labels {
+			labels[label] = true
+		}
+	}
+	for _, label := range []string{"en", "de", "fr", "ch", "in", "valid", "invalid", "sign", "leading zeros", "exponent", "grouped", "whitespace"} {
+		if !labels[label] {
+			t.Errorf("Label %#v never generated", label)
+		}
+	}
+}
+
+func TestFormattedNumberShrinker(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(number gen.FormattedNumber) bool {
+		return number.Valid
+	}, gen.NumericString()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	number := result.Args[0].Arg.(gen.FormattedNumber)
+	if len(number.Text) > 5 {
+		t.Errorf("Invalid shrunk number: %#v", number.Text)
+	}
+}
@@ -0,0 +1,273 @@
+package gen
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/leanovate/gopter"
+)
+
+// ObjectStoreLimits contains the provider specific constraints of an object
+// storage
+type ObjectStoreLimits struct {
+	// MaxKeyBytes is the maximum length of an object key in bytes (UTF-8)
+	MaxKeyBytes int
+	// ForbiddenKeys are keys that are not allowed as a whole
+	ForbiddenKeys []string
+	// ForbiddenKeyChars are characters not allowed in an object key
+	ForbiddenKeyChars string
+	// MaxTags is the maximum number of tags of an object (0 if tags are not
+	// supported)
+	MaxTags int
+	// MaxTagKeyLen and MaxTagValueLen are the maximum lengths (in runes) of
+	// the keys and values of tags
+	MaxTagKeyLen, MaxTagValueLen int
+	// MaxMetadataBytes is the maximum size of all keys and values of the user
+	// metadata of an object
+	MaxMetadataBytes int
+}
+
+var (
+	// S3Limits are the constraints of AWS S3
+	S3Limits = ObjectStoreLimits{
+		MaxKeyBytes:      1024,
+		MaxTags:          10,
+		MaxTagKeyLen:     128,
+		MaxTagValueLen:   256,
+		MaxMetadataBytes: 2048,
+	}
+	// GCSLimits are the constraints of Google Cloud Storage
+	GCSLimits = ObjectStoreLimits{
+		MaxKeyBytes:       1024,
+		ForbiddenKeys:     []string{".", ".."},
+		ForbiddenKeyChars: "\r\n",
+		MaxMetadataBytes:  8192,
+	}
+	// AzureBlobLimits are the constraints of Azure Blob Storage
+	AzureBlobLimits = ObjectStoreLimits{
+		MaxKeyBytes:      1024,
+		ForbiddenKeys:    []string{".", ".."},
+		MaxTags:          10,
+		MaxTagKeyLen:     128,
+		MaxTagValueLen:   256,
+		MaxMetadataBytes: 8192,
+	}
+)
+
+// objectKeySegmentChars are some of the interesting characters of object keys
+var objectKeySegmentChars = []rune("abcxyzABCXYZ0189-_.!*'() &$@=;:+,?\\{}^%`[]\"<>~#|äöüßéñ日本語中文한국어🙂 \u200b")
+
+// ObjectKey generates object keys that are valid for the given limits: Deep
+// prefixes, unicode and special characters, trailing slashes and keys close
+// to the size limit.
+// The keys shrink to shorter ones (keeping them valid).
+func ObjectKey(limits ObjectStoreLimits) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var builder strings.Builder
+		depth := genParams.Rng.Intn(8)
+		if genParams.Rng.Intn(10) == 0 {
+			depth = 50 + genParams.Rng.Intn(100)
+		}
+		for i := 0; i <= depth; i++ {
+			if i > 0 {
+				builder.WriteRune('/')
+			}
+			segmentLen := 1 + genParams.Rng.Intn(16)
+			for j := 0; j < segmentLen; j++ {
+				builder.WriteRune(objectKeySegmentChars[genParams.Rng.Intn(len(objectKeySegmentChars))])
+			}
+		}
+		if genParams.Rng.Intn(5) == 0 {
+			builder.WriteRune('/')
+		}
+		key := builder.String()
+		if genParams.Rng.Intn(10) == 0 {
+			// pad to the size limit
+			key += strings.Repeat("x", limits.MaxKeyBytes)
+		}
+		key = truncateUTF8(key, limits.MaxKeyBytes)
+		key = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(limits.ForbiddenKeyChars, r) {
+				return '_'
+			}
+			return r
+		}, key)
+		for _, forbidden := range limits.ForbiddenKeys {
+			if key == forbidden {
+				key += "_"
+			}
+		}
+
+		sieve := func(v interface{}) bool {
+			return limits.validKey(v.(string))
+		}
+		genResult := gopter.NewGenResult(key, filteredShrinker(StringShrinker, sieve))
+		genResult.Sieve = sieve
+		return genResult
+	}
+}
+
+// filteredShrinker only keeps the shrunk values passing the sieve
+func filteredShrinker(shrinker gopter.Shrinker, sieve func(interface{}) bool) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		return shrinker(v).Filter(sieve)
+	}
+}
+
+func (l ObjectStoreLimits) validKey(key string) bool {
+	if key == "" || len(key) > l.MaxKeyBytes || !utf8.ValidString(key) ||
+		strings.ContainsAny(key, l.ForbiddenKeyChars) {
+		return false
+	}
+	for _, forbidden := range l.ForbiddenKeys {
+		if key == forbidden {
+			return false
+		}
+	}
+	return true
+}
+
+func truncateUTF8(str string, maxBytes int) string {
+	if len(str) <= maxBytes {
+		return str
+	}
+	str = str[:maxBytes]
+	for !utf8.ValidString(str) {
+		str = str[:len(str)-1]
+	}
+	return str
+}
+
+// objectTagChars are the characters allowed in tags (besides letters and
+// digits)
+const objectTagChars = " +-=._:/@"
+
+// ObjectTags generates tag sets (map[string]string) that are valid for the
+// given limits, i.e. have at most limits.MaxTags tags with keys and values
+// of letters, digits and " +-=._:/@".
+// Keys never start with the reserved prefix "aws:".
+// If the provider does not support tags only empty tag sets are generated.
+func ObjectTags(limits ObjectStoreLimits) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		tags := map[string]string{}
+		if limits.MaxTags > 0 {
+			count := genParams.Rng.Intn(limits.MaxTags + 1)
+			for i := 0; i < count; i++ {
+				key := objectTagString(genParams, 1, limits.MaxTagKeyLen)
+				if strings.HasPrefix(key, "aws:") {
+					key = "x" + key[1:]
+				}
+				tags[key] = objectTagString(genParams, 0, limits.MaxTagValueLen)
+			}
+		}
+		sieve := func(v interface{}) bool {
+			return limits.validTags(v.(map[string]string))
+		}
+		genResult := gopter.NewGenResult(tags, filteredShrinker(MapShrinker(StringShrinker, StringShrinker), sieve))
+		genResult.Sieve = sieve
+		return genResult
+	}
+}
+
+func objectTagString(genParams *gopter.GenParameters, minLen, maxLen int) string {
+	length := minLen + genParams.Rng.Intn(maxLen-minLen+1)
+	if genParams.NextBool() && length > 16 {
+		length = minLen + genParams.Rng.Intn(16-minLen+1)
+	}
+	runes := make([]rune, length)
+	for i := range runes {
+		switch genParams.Rng.Intn(4) {
+		case 0:
+			runes[i] = rune(objectTagChars[genParams.Rng.Intn(len(objectTagChars))])
+		case 1:
+			runes[i] = []rune("äöüéñ日本")[genParams.Rng.Intn(7)]
+		default:
+			runes[i] = rune('a' + genParams.Rng.Intn(26))
+		}
+	}
+	return string(runes)
+}
+
+func (l ObjectStoreLimits) validTags(tags map[string]string) bool {
+	if len(tags) > l.MaxTags {
+		return false
+	}
+	validChars := func(str string) bool {
+		for _, r := range str {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+				r > 127 || strings.ContainsRune(objectTagChars, r)) {
+				return false
+			}
+		}
+		return true
+	}
+	for key, value := range tags {
+		if key == "" || strings.HasPrefix(key, "aws:") ||
+			utf8.RuneCountInString(key) > l.MaxTagKeyLen || utf8.RuneCountInString(value) > l.MaxTagValueLen ||
+			!validChars(key) || !validChars(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ObjectMetadata generates user metadata (map[string]string) valid for the
+// given limits: keys are lower case ASCII (letters, digits and dashes) values
+// printable ASCII and the total size stays below limits.MaxMetadataBytes.
+func ObjectMetadata(limits ObjectStoreLimits) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		metadata := map[string]string{}
+		total := 0
+		count := genParams.Rng.Intn(10)
+		for i := 0; i < count; i++ {
+			key := objectMetadataString(genParams, "abcdefghijklmnopqrstuvwxyz0123456789-", 1+genParams.Rng.Intn(32))
+			value := objectMetadataString(genParams, "", genParams.Rng.Intn(256))
+			if _, ok := metadata[key]; ok || total+len(key)+len(value) > limits.MaxMetadataBytes {
+				continue
+			}
+			metadata[key] = value
+			total += len(key) + len(value)
+		}
+		sieve := func(v interface{}) bool {
+			return limits.validMetadata(v.(map[string]string))
+		}
+		genResult := gopter.NewGenResult(metadata, filteredShrinker(MapShrinker(StringShrinker, StringShrinker), sieve))
+		genResult.Sieve = sieve
+		return genResult
+	}
+}
+
+// objectMetadataString creates a string of chars (or printable ASCII if chars
+// is empty)
+func objectMetadataString(genParams *gopter.GenParameters, chars string, length int) string {
+	result := make([]byte, length)
+	for i := range result {
+		if chars == "" {
+			result[i] = byte(' ' + genParams.Rng.Intn('~'-' '+1))
+		} else {
+			result[i] = chars[genParams.Rng.Intn(len(chars))]
+		}
+	}
+	return string(result)
+}
+
+func (l ObjectStoreLimits) validMetadata(metadata map[string]string) bool {
+	total := 0
+	for key, value := range metadata {
+		if key == "" {
+			return false
+		}
+		for _, r := range key {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+		for _, r := range value {
+			if r < ' ' || r > '~' {
+				return false
+			}
+		}
+		total += len(key) + len(value)
+	}
+	return total <= l.MaxMetadataBytes
+}
@@ -0,0 +1,89 @@
+package gen_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestObjectKey(t *testing.T) {
+	for _, limits := range []gen.ObjectStoreLimits{gen.S3Limits, gen.GCSLimits, gen.AzureBlobLimits} {
+		commonGeneratorTest(t, "object key", gen.ObjectKey(limits), func(value interface{}) bool {
+			key, ok := value.(string)
+			if !ok || key == "" || len(key) > limits.MaxKeyBytes || !utf8.ValidString(key) ||
+				strings.ContainsAny(key, limits.ForbiddenKeyChars) {
+				return false
+			}
+			for _, forbidden := range limits.ForbiddenKeys {
+				if key == forbidden {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	var deep, trailing, long bool
+	for i := 0; i < 1000; i++ {
+		value, _ := gen.ObjectKey(gen.S3Limits).Sample()
+		key := value.(string)
+		deep = deep || strings.Count(key, "/") > 20
+		trailing = trailing || strings.HasSuffix(key, "/")
+		long = long || len(key) > 1000
+	}
+	if !deep || !trailing || !long {
+		t.Errorf("Missing interesting keys: deep=%v trailing=%v long=%v", deep, trailing, long)
+	}
+}
+
+func TestObjectKeyShrink(t *testing.T) {
+	result := prop.ForAll(
+		func(key string) bool {
+			return !strings.Contains(key, "/")
+		},
+		gen.ObjectKey(gen.GCSLimits),
+	).Check(gopter.DefaultTestParameters())
+
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != "/" {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
+
+func TestObjectTags(t *testing.T) {
+	commonGeneratorTest(t, "s3 tags", gen.ObjectTags(gen.S3Limits), func(value interface{}) bool {
+		tags, ok := value.(map[string]string)
+		if !ok || len(tags) > 10 {
+			return false
+		}
+		for key, value := range tags {
+			if key == "" || strings.HasPrefix(key, "aws:") || utf8.RuneCountInString(key) > 128 || utf8.RuneCountInString(value) > 256 {
+				return false
+			}
+		}
+		return true
+	})
+	commonGeneratorTest(t, "gcs tags", gen.ObjectTags(gen.GCSLimits), func(value interface{}) bool {
+		tags, ok := value.(map[string]string)
+		return ok && len(tags) == 0
+	})
+}
+
+func TestObjectMetadata(t *testing.T) {
+	limits := gen.S3Limits
+	limits.MaxMetadataBytes = 300
+	commonGeneratorTest(t, "metadata", gen.ObjectMetadata(limits), func(value interface{}) bool {
+		metadata, ok := value.(map[string]string)
+		total := 0
+		for key, value := range metadata {
+			if key == "" || strings.ToLower(key) != key {
+				return false
+			}
+			total += len(key) + len(value)
+		}
+		return ok && total <= 300
+	})
+}
@@ -11,9 +11,14 @@ func OneConstOf(consts ...interface{}) gopter.Gen {
 	if len(consts) == 0 {
 		return Fail(reflect.TypeOf(nil))
 	}
+	domain := func() []interface{} {
+		return consts
+	}
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
 		idx := genParams.Rng.Intn(len(consts))
-		return gopter.NewGenResult(consts[idx], gopter.NoShrinker)
+		genResult := gopter.NewGenResult(consts[idx], gopter.NoShrinker)
+		genResult.Domain = domain
+		return genResult
 	}
 }
 
@@ -24,6 +29,9 @@ func OneGenOf(gens ...gopter.Gen) gopter.Gen {
 	}
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
 		idx := genParams.Rng.Intn(len(gens))
-		return gens[idx](genParams)
+		result := gens[idx](genParams)
+		// the domain is the one of the chosen generator only
+		result.Domain = nil
+		return result
 	}
 }
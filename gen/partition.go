@@ -0,0 +1,113 @@
+package gen
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+// PartitionOf generates []int64 slices of length parts with non-negative
+// elements that sum up exactly to total (i.e. random compositions of total).
+// This is useful to test allocation, billing or rounding code.
+// The shrinker preserves the sum by moving amounts into the first part.
+func PartitionOf(total int64, parts int) gopter.Gen {
+	if total < 0 || parts <= 0 {
+		return Fail(reflect.TypeOf([]int64{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		cuts := make([]int64, parts-1)
+		for i := range cuts {
+			cuts[i] = int64(genParams.NextUint64() % (uint64(total) + 1))
+		}
+		sort.Slice(cuts, func(i, j int) bool {
+			return cuts[i] < cuts[j]
+		})
+		partition := make([]int64, parts)
+		last := int64(0)
+		for i, cut := range cuts {
+			partition[i] = cut - last
+			last = cut
+		}
+		partition[parts-1] = total - last
+
+		return newPartitionResult(partition, total)
+	}
+}
+
+// PartitionOfGen is like PartitionOf, but the total is generated by totalGen,
+// which has to generate non-negative int64 numbers.
+func PartitionOfGen(totalGen gopter.Gen, parts int) gopter.Gen {
+	return totalGen.FlatMap(func(v interface{}) gopter.Gen {
+		return PartitionOf(v.(int64), parts)
+	}, reflect.TypeOf([]int64{}))
+}
+
+// WeightedPartitionOf generates []int64 slices with one non-negative element
+// per weight summing up exactly to total. The expected share of each element is
+// proportional to its weight, i.e. an element with weight 0 is always 0.
+// This is useful to test code splitting amounts by weights (e.g. taxes or
+// allocations) against randomized, but plausible splits.
+func WeightedPartitionOf(total int64, weights []float64) gopter.Gen {
+	weightSum := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return Fail(reflect.TypeOf([]int64{}))
+		}
+		weightSum += weight
+	}
+	if total < 0 || len(weights) == 0 || weightSum <= 0 {
+		return Fail(reflect.TypeOf([]int64{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		shares := make([]float64, len(weights))
+		shareSum := 0.0
+		for i, weight := range weights {
+			shares[i] = weight * 2 * genParams.Rng.Float64()
+			shareSum += shares[i]
+		}
+		if shareSum == 0 {
+			copy(shares, weights)
+			shareSum = weightSum
+		}
+		partition := make([]int64, len(weights))
+		remaining := total
+		for i, share := range shares {
+			partition[i] = int64(float64(total) * share / shareSum)
+			if partition[i] > remaining {
+				partition[i] = remaining
+			}
+			remaining -= partition[i]
+		}
+		// Distribute the rounding remainder among the parts with a positive weight
+		for remaining > 0 {
+			idx := genParams.Rng.Intn(len(weights))
+			if weights[idx] == 0 {
+				continue
+			}
+			amount := remaining
+			if amount > 1 {
+				amount = 1 + genParams.Rng.Int63n(amount)
+			}
+			partition[idx] += amount
+			remaining -= amount
+		}
+
+		return newPartitionResult(partition, total)
+	}
+}
+
+func newPartitionResult(partition []int64, total int64) *gopter.GenResult {
+	genResult := gopter.NewGenResult(partition, PartitionShrinker)
+	genResult.Sieve = func(v interface{}) bool {
+		sum := int64(0)
+		for _, part := range v.([]int64) {
+			if part < 0 {
+				return false
+			}
+			sum += part
+		}
+		return sum == total
+	}
+	return genResult
+}
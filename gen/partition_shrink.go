@@ -0,0 +1,49 @@
+package gen
+
+import "github.com/leanovate/gopter"
+
+type partitionShrink struct {
+	original []int64
+	index    int
+	shrink   int64Shrink
+}
+
+func (s *partitionShrink) Next() (interface{}, bool) {
+	for s.index < len(s.original) {
+		value, ok := s.shrink.Next()
+		if ok {
+			shrunk := make([]int64, len(s.original))
+			copy(shrunk, s.original)
+			shrunk[s.index] = value.(int64)
+			shrunk[0] += s.original[s.index] - value.(int64)
+			return shrunk, true
+		}
+		s.index++
+		if s.index < len(s.original) {
+			s.shrink = int64Shrink{
+				original: s.original[s.index],
+				half:     s.original[s.index],
+			}
+		}
+	}
+	return nil, false
+}
+
+// PartitionShrinker is a shrinker for []int64 partitions (see PartitionOf).
+// Every part but the first is shrunk towards 0 and the difference is moved
+// to the first part, i.e. the sum of a partition is preserved.
+func PartitionShrinker(v interface{}) gopter.Shrink {
+	partition := v.([]int64)
+	if len(partition) < 2 {
+		return gopter.NoShrink
+	}
+	shrink := &partitionShrink{
+		original: partition,
+		index:    1,
+		shrink: int64Shrink{
+			original: partition[1],
+			half:     partition[1],
+		},
+	}
+	return shrink.Next
+}
@@ -0,0 +1,104 @@
+package gen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+func sumsTo(total int64, parts int) func(interface{}) bool {
+	return func(v interface{}) bool {
+		partition, ok := v.([]int64)
+		if !ok || len(partition) != parts {
+			return false
+		}
+		sum := int64(0)
+		for _, part := range partition {
+			if part < 0 {
+				return false
+			}
+			sum += part
+		}
+		return sum == total
+	}
+}
+
+func TestPartitionOf(t *testing.T) {
+	commonGeneratorTest(t, "partition of 100", gen.PartitionOf(100, 4), sumsTo(100, 4))
+	commonGeneratorTest(t, "partition of 0", gen.PartitionOf(0, 3), sumsTo(0, 3))
+	commonGeneratorTest(t, "partition in one", gen.PartitionOf(12345, 1), sumsTo(12345, 1))
+	commonGeneratorTest(t, "partition of max", gen.PartitionOf(1<<62, 5), sumsTo(1<<62, 5))
+
+	if value, ok := gen.PartitionOf(-1, 3).Sample(); ok {
+		t.Errorf("Negative total should fail: %#v", value)
+	}
+	if value, ok := gen.PartitionOf(10, 0).Sample(); ok {
+		t.Errorf("No parts should fail: %#v", value)
+	}
+}
+
+func TestPartitionOfGen(t *testing.T) {
+	partitionGen := gen.PartitionOfGen(gen.Int64Range(0, 1000), 3)
+	for i := 0; i < 100; i++ {
+		value, ok := partitionGen.Sample()
+		if !ok {
+			t.FailNow()
+		}
+		partition := value.([]int64)
+		sum := partition[0] + partition[1] + partition[2]
+		if !sumsTo(sum, 3)(partition) || sum > 1000 {
+			t.Errorf("Invalid partition: %#v", partition)
+		}
+	}
+}
+
+func TestWeightedPartitionOf(t *testing.T) {
+	weights := []float64{1, 0, 3}
+	commonGeneratorTest(t, "weighted partition", gen.WeightedPartitionOf(999, weights), func(v interface{}) bool {
+		return sumsTo(999, 3)(v)
+	})
+
+	totals := make([]int64, 3)
+	for i := 0; i < 1000; i++ {
+		value, ok := gen.WeightedPartitionOf(1000, weights).Sample()
+		if !ok {
+			t.FailNow()
+		}
+		partition := value.([]int64)
+		if partition[1] != 0 {
+			t.Errorf("Part with weight 0 is not empty: %#v", partition)
+		}
+		for i, part := range partition {
+			totals[i] += part
+		}
+	}
+	if totals[2] < 2*totals[0] {
+		t.Errorf("Weights are not respected: %#v", totals)
+	}
+
+	if value, ok := gen.WeightedPartitionOf(10, []float64{0, 0}).Sample(); ok {
+		t.Errorf("Zero weights should fail: %#v", value)
+	}
+	if value, ok := gen.WeightedPartitionOf(10, []float64{1, -1}).Sample(); ok {
+		t.Errorf("Negative weights should fail: %#v", value)
+	}
+}
+
+func TestPartitionShrinker(t *testing.T) {
+	shrinks := gen.PartitionShrinker([]int64{1, 4, 2}).All()
+	expected := []interface{}{
+		[]int64{5, 0, 2},
+		[]int64{3, 2, 2},
+		[]int64{2, 3, 2},
+		[]int64{3, 4, 0},
+		[]int64{2, 4, 1},
+	}
+	if !reflect.DeepEqual(shrinks, expected) {
+		t.Errorf("Invalid shrinks: %#v", shrinks)
+	}
+
+	if shrinks := gen.PartitionShrinker([]int64{7}).All(); len(shrinks) != 0 {
+		t.Errorf("Invalid shrinks: %#v", shrinks)
+	}
+}
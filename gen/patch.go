@@ -0,0 +1,229 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// Hunk is a change of a document: The lines Old starting at Line (0-based)
+// of the original document are replaced by the lines New
+type Hunk struct {
+	Line int
+	Old  []string
+	New  []string
+}
+
+// Patch is a line based patch of a document, i.e. a list of non-overlapping
+// hunks ordered by their line
+type Patch []Hunk
+
+// Apply applies the patch to the lines of a document.
+// This is supposed to be used as oracle for the tested patch implementation.
+// Fails if a hunk does not match the document.
+func (p Patch) Apply(document []string) ([]string, error) {
+	result := make([]string, 0, len(document))
+	line := 0
+	for i, hunk := range p {
+		if hunk.Line < line || hunk.Line+len(hunk.Old) > len(document) {
+			return nil, fmt.Errorf("Hunk %d out of range: line %d", i, hunk.Line)
+		}
+		for j, old := range hunk.Old {
+			if document[hunk.Line+j] != old {
+				return nil, fmt.Errorf("Hunk %d does not match line %d: %q", i, hunk.Line+j, document[hunk.Line+j])
+			}
+		}
+		result = append(result, document[line:hunk.Line]...)
+		result = append(result, hunk.New...)
+		line = hunk.Line + len(hunk.Old)
+	}
+	return append(result, document[line:]...), nil
+}
+
+// String formats the patch as unified diff without context lines
+func (p Patch) String() string {
+	var result strings.Builder
+	offset := 0
+	for _, hunk := range p {
+		fmt.Fprintf(&result, "@@ -%s +%s @@\n", unifiedRange(hunk.Line, len(hunk.Old)), unifiedRange(hunk.Line+offset, len(hunk.New)))
+		for _, old := range hunk.Old {
+			fmt.Fprintf(&result, "-%s\n", old)
+		}
+		for _, new := range hunk.New {
+			fmt.Fprintf(&result, "+%s\n", new)
+		}
+		offset += len(hunk.New) - len(hunk.Old)
+	}
+	return result.String()
+}
+
+// unifiedRange formats a range of lines as in a unified diff, i.e. 1-based
+// and referring to the line before the range if it is empty
+func unifiedRange(line, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", line)
+	}
+	return fmt.Sprintf("%d,%d", line+1, count)
+}
+
+// DocumentPatch is a document and a patch as generated by ValidPatch or
+// InvalidPatch.
+// If Invalid is true, the patch does not apply to the document (a hunk does
+// not match or is out of range) and Patched is nil, otherwise Patched is the
+// result of applying the patch.
+type DocumentPatch struct {
+	Document []string
+	Patch    Patch
+	Patched  []string
+	Invalid  bool
+}
+
+// patchLines are the contents of the generated lines, there are only a few of
+// them to get documents with repeated lines
+var patchLines = []string{"", "{", "}", "foo", "bar", "baz", "return nil", "\t// comment", "  indented  "}
+
+// ValidPatch generates documents (i.e. lines of text with many repetitions)
+// with a patch that is guaranteed to apply, the result of applying the patch
+// is part of the generated value (see DocumentPatch).
+// genParams.MaxSize limits the number of lines of the document.
+// The shrinker removes hunks and unchanged lines of the document.
+func ValidPatch() gopter.Gen {
+	return genDocumentPatch(false)
+}
+
+// InvalidPatch generates documents with a patch that does not apply, since
+// one of its hunks does not match the document or is out of range (see
+// DocumentPatch).
+// genParams.MaxSize limits the number of lines of the document.
+// The shrinker removes the other hunks and unchanged lines of the document.
+func InvalidPatch() gopter.Gen {
+	return genDocumentPatch(true)
+}
+
+func genDocumentPatch(invalid bool) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		document := make([]string, genParams.Rng.Intn(genParams.MaxSize+1))
+		for i := range document {
+			document[i] = genPatchLine(genParams)
+		}
+		patch := Patch{}
+		// the line after each hunk is skipped, i.e. there is at least one
+		// unchanged line between hunks
+		for line := 0; line <= len(document); line++ {
+			if genParams.Rng.Intn(4) != 0 {
+				continue
+			}
+			hunk := Hunk{Line: line}
+			for removed := genParams.Rng.Intn(3); removed > 0 && line < len(document); removed-- {
+				hunk.Old = append(hunk.Old, document[line])
+				line++
+			}
+			for added := genParams.Rng.Intn(3); added > 0 || len(hunk.Old) == 0 && len(hunk.New) == 0; added-- {
+				hunk.New = append(hunk.New, genPatchLine(genParams))
+			}
+			patch = append(patch, hunk)
+		}
+		if invalid {
+			patch = corruptPatch(genParams, document, patch)
+		}
+
+		genResult := gopter.NewGenResult(newDocumentPatch(document, patch), DocumentPatchShrinker)
+		genResult.Sieve = func(v interface{}) bool {
+			_, err := v.(DocumentPatch).Patch.Apply(v.(DocumentPatch).Document)
+			return (err != nil) == invalid
+		}
+		if invalid {
+			genResult.Labels = []string{"invalid patch"}
+		} else {
+			genResult.Labels = []string{"valid patch"}
+		}
+		return genResult
+	}
+}
+
+func genPatchLine(genParams *gopter.GenParameters) string {
+	if genParams.Rng.Intn(10) == 0 {
+		return fmt.Sprintf("line %d", genParams.Rng.Intn(100))
+	}
+	return patchLines[genParams.Rng.Intn(len(patchLines))]
+}
+
+// corruptPatch makes a hunk of a patch invalid: An old line that does not
+// match the document or a line beyond the end of the document
+func corruptPatch(genParams *gopter.GenParameters, document []string, patch Patch) Patch {
+	if len(patch) == 0 {
+		patch = Patch{{Line: len(document), New: []string{genPatchLine(genParams)}}}
+	}
+	corrupted := make(Patch, len(patch))
+	copy(corrupted, patch)
+	i := genParams.Rng.Intn(len(corrupted))
+	hunk := corrupted[i]
+	if len(hunk.Old) > 0 && genParams.NextBool() {
+		j := genParams.Rng.Intn(len(hunk.Old))
+		hunk.Old = append([]string{}, hunk.Old...)
+		hunk.Old[j] += "~"
+	} else {
+		// beyond the end, the following hunks are dropped to keep the order
+		hunk.Line = len(document) + 1
+		corrupted = corrupted[:i+1]
+	}
+	corrupted[i] = hunk
+	return corrupted
+}
+
+func newDocumentPatch(document []string, patch Patch) DocumentPatch {
+	patched, err := patch.Apply(document)
+	return DocumentPatch{
+		Document: document,
+		Patch:    patch,
+		Patched:  patched,
+		Invalid:  err != nil,
+	}
+}
+
+// DocumentPatchShrinker is a shrinker for DocumentPatch values, hunks are
+// removed first and then the lines of the document that are not changed by the
+// patch. The validity of the patch is preserved.
+func DocumentPatchShrinker(v interface{}) gopter.Shrink {
+	value := v.(DocumentPatch)
+	var candidates []DocumentPatch
+	for i := range value.Patch {
+		patch := make(Patch, 0, len(value.Patch)-1)
+		patch = append(patch, value.Patch[:i]...)
+		patch = append(patch, value.Patch[i+1:]...)
+		candidates = append(candidates, newDocumentPatch(value.Document, patch))
+	}
+	changed := map[int]bool{}
+	for _, hunk := range value.Patch {
+		for j := range hunk.Old {
+			changed[hunk.Line+j] = true
+		}
+	}
+	for line := len(value.Document) - 1; line >= 0; line-- {
+		if changed[line] {
+			continue
+		}
+		document := make([]string, 0, len(value.Document)-1)
+		document = append(document, value.Document[:line]...)
+		document = append(document, value.Document[line+1:]...)
+		patch := make(Patch, len(value.Patch))
+		for i, hunk := range value.Patch {
+			if hunk.Line > line {
+				hunk.Line--
+			}
+			patch[i] = hunk
+		}
+		candidates = append(candidates, newDocumentPatch(document, patch))
+	}
+	return func() (interface{}, bool) {
+		for len(candidates) > 0 {
+			candidate := candidates[0]
+			candidates = candidates[1:]
+			if candidate.Invalid == value.Invalid {
+				return candidate, true
+			}
+		}
+		return nil, false
+	}
+}
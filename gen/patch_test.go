@@ -0,0 +1,86 @@
+package gen_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestPatchApply(t *testing.T) {
+	patch := gen.Patch{
+		{Line: 0, New: []string{"first"}},
+		{Line: 1, Old: []string{"b", "c"}, New: []string{"x"}},
+		{Line: 4, Old: []string{"e"}},
+	}
+	patched, err := patch.Apply([]string{"a", "b", "c", "d", "e"})
+	if err != nil || !reflect.DeepEqual(patched, []string{"first", "a", "x", "d"}) {
+		t.Errorf("Invalid patched: %#v (%v)", patched, err)
+	}
+	if str := patch.String(); str != "@@ -0,0 +1,1 @@\n+first\n@@ -2,2 +3,1 @@\n-b\n-c\n+x\n@@ -5,1 +4,0 @@\n-e\n" {
+		t.Errorf("Invalid unified diff: %s", str)
+	}
+
+	if _, err := patch.Apply([]string{"a", "b", "x", "d", "e"}); err == nil {
+		t.Error("Patch applied to mismatching document")
+	}
+	if _, err := patch.Apply([]string{"a", "b", "c"}); err == nil {
+		t.Error("Patch applied to short document")
+	}
+}
+
+func TestValidPatch(t *testing.T) {
+	hunks := map[int]bool{}
+	commonGeneratorTest(t, "valid patch", gen.ValidPatch(), func(v interface{}) bool {
+		value := v.(gen.DocumentPatch)
+		hunks[len(value.Patch)] = true
+		patched, err := value.Patch.Apply(value.Document)
+		return err == nil && !value.Invalid && reflect.DeepEqual(patched, value.Patched)
+	})
+	if len(hunks) < 3 {
+		t.Errorf("Patches should have various numbers of hunks: %v", hunks)
+	}
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(value gen.DocumentPatch) bool {
+		return len(value.Patch) < 2
+	}, gen.ValidPatch()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	shrunk := result.Args[0].Arg.(gen.DocumentPatch)
+	if len(shrunk.Patch) != 2 || shrunk.Invalid {
+		t.Errorf("Invalid shrunk value: %#v", shrunk)
+	}
+	for i := range shrunk.Document {
+		changed := false
+		for _, hunk := range shrunk.Patch {
+			changed = changed || i >= hunk.Line && i < hunk.Line+len(hunk.Old)
+		}
+		if !changed {
+			t.Errorf("Unchanged line %d not shrunk: %#v", i, shrunk)
+		}
+	}
+}
+
+func TestInvalidPatch(t *testing.T) {
+	commonGeneratorTest(t, "invalid patch", gen.InvalidPatch(), func(v interface{}) bool {
+		value := v.(gen.DocumentPatch)
+		_, err := value.Patch.Apply(value.Document)
+		return err != nil && value.Invalid && value.Patched == nil
+	})
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(value gen.DocumentPatch) bool {
+		return !strings.Contains(value.Patch.String(), "@@")
+	}, gen.InvalidPatch()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if shrunk := result.Args[0].Arg.(gen.DocumentPatch); len(shrunk.Patch) != 1 || !shrunk.Invalid {
+		t.Errorf("Invalid shrunk value: %#v", shrunk)
+	}
+}
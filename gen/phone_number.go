@@ -0,0 +1,145 @@
+package gen
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// FormattedPhoneNumber is a generated phone number of a region.
+// Formatted is the number as a user might enter it, E164 the normalized form
+// it represents.
+type FormattedPhoneNumber struct {
+	Region    string
+	E164      string
+	Formatted string
+	// Extension is the extension contained in Formatted (only set by
+	// AdversarialPhoneNumber)
+	Extension string
+}
+
+type phoneRegion struct {
+	countryCode string
+	// templates of the national significant number: X is any digit, N is
+	// 2-9, M is 1-9 all other characters are literals
+	templates []string
+	// nationalFormat of the number, each # is replaced by a digit of the
+	// national significant number
+	nationalFormat string
+}
+
+var phoneRegions = map[string]phoneRegion{
+	"US": {"1", []string{"NXXNXXXXXX"}, "(###) ###-####"},
+	"DE": {"49", []string{"15XXXXXXXXX", "16XXXXXXXX", "17XXXXXXXXX"}, "0### ########"},
+	"GB": {"44", []string{"7MXXXXXXXX"}, "0#### ######"},
+	"FR": {"33", []string{"MXXXXXXXX"}, "0# ## ## ## ##"},
+	"IN": {"91", []string{"6XXXXXXXXX", "7XXXXXXXXX", "8XXXXXXXXX", "9XXXXXXXXX"}, "0##### #####"},
+	"JP": {"81", []string{"70XXXXXXXX", "80XXXXXXXX", "90XXXXXXXX"}, "0##-####-####"},
+	"AU": {"61", []string{"4XXXXXXXX"}, "0### ### ###"},
+	"BR": {"55", []string{"MM9XXXXXXXX"}, "(##) #####-####"},
+}
+
+// keypadLetters maps digits to the letters of a phone keypad
+var keypadLetters = map[byte]string{
+	'2': "ABC", '3': "DEF", '4': "GHI", '5': "JKL",
+	'6': "MNO", '7': "PQRS", '8': "TUV", '9': "WXYZ",
+}
+
+// PhoneNumber generates plausibly valid phone numbers of a region (ISO 3166
+// country code, e.g. "US", "DE", "GB", "FR", "IN", "JP", "AU", "BR") either
+// in E.164 or in the national format. The region is added as label.
+// The generator fails for unsupported regions.
+func PhoneNumber(region string) gopter.Gen {
+	return phoneNumber(region, false)
+}
+
+// AdversarialPhoneNumber is like PhoneNumber, but also generates numbers in the
+// international format with 00 prefix, with extensions, superfluous
+// separators (spaces, dashes, dots) and vanity letters instead of digits.
+func AdversarialPhoneNumber(region string) gopter.Gen {
+	return phoneNumber(region, true)
+}
+
+func phoneNumber(region string, adversarial bool) gopter.Gen {
+	spec, ok := phoneRegions[region]
+	if !ok {
+		return Fail(reflect.TypeOf(FormattedPhoneNumber{}))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		template := spec.templates[genParams.Rng.Intn(len(spec.templates))]
+		nsn := make([]byte, len(template))
+		for i := range template {
+			switch template[i] {
+			case 'X':
+				nsn[i] = byte('0' + genParams.Rng.Intn(10))
+			case 'N':
+				nsn[i] = byte('2' + genParams.Rng.Intn(8))
+			case 'M':
+				nsn[i] = byte('1' + genParams.Rng.Intn(9))
+			default:
+				nsn[i] = template[i]
+			}
+		}
+		number := FormattedPhoneNumber{
+			Region: region,
+			E164:   "+" + spec.countryCode + string(nsn),
+		}
+		formats := 2
+		if adversarial {
+			formats = 3
+		}
+		switch genParams.Rng.Intn(formats) {
+		case 0:
+			number.Formatted = number.E164
+		case 1:
+			number.Formatted = formatPhoneNumber(spec.nationalFormat, nsn)
+		default:
+			number.Formatted = "00" + spec.countryCode + " " + string(nsn)
+		}
+		if adversarial {
+			number.Formatted = garblePhoneNumber(genParams, number.Formatted)
+			if genParams.NextBool() {
+				number.Extension = strconv.Itoa(genParams.Rng.Intn(10000) + 1)
+				suffixes := []string{" ext. ", " x", ";ext=", " #"}
+				number.Formatted += suffixes[genParams.Rng.Intn(len(suffixes))] + number.Extension
+			}
+		}
+		genResult := gopter.NewGenResult(number, gopter.NoShrinker)
+		genResult.Labels = []string{region}
+		return genResult
+	}
+}
+
+func formatPhoneNumber(format string, nsn []byte) string {
+	var builder strings.Builder
+	idx := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] == '#' && idx < len(nsn) {
+			builder.WriteByte(nsn[idx])
+			idx++
+		} else {
+			builder.WriteByte(format[i])
+		}
+	}
+	builder.Write(nsn[idx:])
+	return builder.String()
+}
+
+// garblePhoneNumber inserts separators and replaces digits by vanity letters
+func garblePhoneNumber(genParams *gopter.GenParameters, formatted string) string {
+	separators := []string{" ", "-", ".", "  "}
+	var builder strings.Builder
+	for i := 0; i < len(formatted); i++ {
+		ch := formatted[i]
+		if letters, ok := keypadLetters[ch]; ok && i > 3 && genParams.Rng.Intn(8) == 0 {
+			ch = letters[genParams.Rng.Intn(len(letters))]
+		}
+		builder.WriteByte(ch)
+		if i > 0 && genParams.Rng.Intn(10) == 0 {
+			builder.WriteString(separators[genParams.Rng.Intn(len(separators))])
+		}
+	}
+	return builder.String()
+}
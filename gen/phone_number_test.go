@@ -0,0 +1,60 @@
+package gen_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter/gen"
+)
+
+var keypadDigits = strings.NewReplacer(
+	"A", "2", "B", "2", "C", "2", "D", "3", "E", "3", "F", "3",
+	"G", "4", "H", "4", "I", "4", "J", "5", "K", "5", "L", "5",
+	"M", "6", "N", "6", "O", "6", "P", "7", "Q", "7", "R", "7", "S", "7",
+	"T", "8", "U", "8", "V", "8", "W", "9", "X", "9", "Y", "9", "Z", "9",
+)
+
+var nonDigits = regexp.MustCompile(`[^0-9]`)
+
+var extensionSuffix = regexp.MustCompile(`( ext\. | x|;ext=| #)[0-9]+$`)
+
+var countryCodes = map[string]string{
+	"US": "1", "DE": "49", "GB": "44", "FR": "33", "IN": "91", "JP": "81", "AU": "61", "BR": "55",
+}
+
+// normalizePhoneNumber is a simple normalization of the generated phone numbers
+func normalizePhoneNumber(number gen.FormattedPhoneNumber) string {
+	formatted := extensionSuffix.ReplaceAllString(number.Formatted, "")
+	digits := nonDigits.ReplaceAllString(keypadDigits.Replace(formatted), "")
+	countryCode := countryCodes[number.Region]
+	switch {
+	case strings.HasPrefix(formatted, "+"):
+		return "+" + digits
+	case strings.HasPrefix(formatted, "00"):
+		return "+" + digits[2:]
+	case strings.HasPrefix(digits, "0"):
+		return "+" + countryCode + digits[1:]
+	}
+	return "+" + countryCode + digits
+}
+
+func TestPhoneNumber(t *testing.T) {
+	e164 := regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	for region := range countryCodes {
+		commonGeneratorTest(t, "phone number "+region, gen.PhoneNumber(region), func(value interface{}) bool {
+			number, ok := value.(gen.FormattedPhoneNumber)
+			return ok && number.Region == region && e164.MatchString(number.E164) &&
+				number.Extension == "" && normalizePhoneNumber(number) == number.E164
+		})
+		commonGeneratorTest(t, "adversarial phone number "+region, gen.AdversarialPhoneNumber(region), func(value interface{}) bool {
+			number, ok := value.(gen.FormattedPhoneNumber)
+			return ok && e164.MatchString(number.E164) && normalizePhoneNumber(number) == number.E164 &&
+				(number.Extension == "" || strings.HasSuffix(number.Formatted, number.Extension))
+		})
+	}
+
+	if value, ok := gen.PhoneNumber("XX").Sample(); ok {
+		t.Errorf("Unknown region should fail: %#v", value)
+	}
+}
@@ -0,0 +1,140 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/leanovate/gopter"
+)
+
+type namedBoundary struct {
+	value int64
+	name  string
+}
+
+var byteSizeBoundaries = []namedBoundary{
+	{0, "zero"},
+	{512, "sector size (512B)"},
+	{1500, "MTU (1500B)"},
+	{4096, "page size (4KiB)"},
+	{9000, "jumbo frame (9000B)"},
+	{1 << 16, "2^16 (64KiB)"},
+	{1 << 20, "1MiB"},
+	{2 << 20, "huge page size (2MiB)"},
+	{1 << 30, "1GiB"},
+	{1 << 31, "2^31 (2GiB)"},
+	{1 << 32, "2^32 (4GiB)"},
+	{1 << 53, "2^53 (max exact float64)"},
+	{math.MaxInt64, "max int64"},
+}
+
+var rateEventBoundaries = []namedBoundary{
+	{0, "zero"},
+	{1, "one"},
+	{10, "10"},
+	{60, "60"},
+	{100, "100"},
+	{1000, "1000"},
+	{3600, "3600"},
+	{math.MaxInt32, "max int32"},
+	{math.MaxInt64, "max int64"},
+}
+
+var rateIntervals = []time.Duration{
+	time.Nanosecond, time.Millisecond, time.Second, time.Minute, time.Hour, 24 * time.Hour,
+}
+
+// RateLimit is a rate limit of at most Events per Interval
+type RateLimit struct {
+	Events   int64
+	Interval time.Duration
+}
+
+// PerSecond is the number of events per second
+func (r RateLimit) PerSecond() float64 {
+	return float64(r.Events) / r.Interval.Seconds()
+}
+
+func (r RateLimit) String() string {
+	return fmt.Sprintf("%d/%v", r.Events, r.Interval)
+}
+
+// ByteSize generates memory and buffer sizes (as int64 number of bytes)
+// around meaningful boundaries like the sector and page size, the MTU, 2^31,
+// 2^32 or the maximum int64, i.e. the boundary itself or off by one. One out
+// of four sizes is a random value.
+// The boundaries are added as human-readable label (e.g. "page size (4KiB)-1").
+// Sizes shrink to the smaller boundaries.
+func ByteSize() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		size, label := genAroundBoundary(genParams, byteSizeBoundaries)
+		genResult := gopter.NewGenResult(size, boundaryShrinker(byteSizeBoundaries))
+		genResult.Labels = []string{label}
+		return genResult
+	}
+}
+
+// Rate generates rate limits (RateLimit) with event counts around meaningful
+// boundaries (0, 1, 60, 1000, max int32 ...) and intervals from a nanosecond
+// to a day.
+// The event count boundary and interval are added as human-readable labels.
+// Rates shrink their event counts to the smaller boundaries.
+func Rate() gopter.Gen {
+	eventsShrinker := boundaryShrinker(rateEventBoundaries)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		events, label := genAroundBoundary(genParams, rateEventBoundaries)
+		rate := RateLimit{
+			Events:   events,
+			Interval: rateIntervals[genParams.Rng.Intn(len(rateIntervals))],
+		}
+		genResult := gopter.NewGenResult(rate, func(v interface{}) gopter.Shrink {
+			rate := v.(RateLimit)
+			return eventsShrinker(rate.Events).Map(func(events int64) RateLimit {
+				return RateLimit{Events: events, Interval: rate.Interval}
+			})
+		})
+		genResult.Labels = []string{"events: " + label, "interval: " + rate.Interval.String()}
+		return genResult
+	}
+}
+
+// genAroundBoundary picks a non-negative value at or next to one of the
+// boundaries (or a random value)
+func genAroundBoundary(genParams *gopter.GenParameters, boundaries []namedBoundary) (int64, string) {
+	if genParams.Rng.Intn(4) == 0 {
+		return genParams.Rng.Int63(), "random"
+	}
+	boundary := boundaries[genParams.Rng.Intn(len(boundaries))]
+	switch offset := genParams.Rng.Intn(3) - 1; {
+	case offset < 0 && boundary.value > 0:
+		return boundary.value - 1, boundary.name + "-1"
+	case offset > 0 && boundary.value < math.MaxInt64:
+		return boundary.value + 1, boundary.name + "+1"
+	}
+	return boundary.value, boundary.name
+}
+
+// boundaryShrinker shrinks a value to all smaller boundaries (and the values
+// next to them)
+func boundaryShrinker(boundaries []namedBoundary) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		value := v.(int64)
+		var candidates []int64
+		for _, boundary := range boundaries {
+			for _, candidate := range []int64{boundary.value - 1, boundary.value, boundary.value + 1} {
+				if candidate >= 0 && candidate < value && (len(candidates) == 0 || candidate > candidates[len(candidates)-1]) {
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+		return func() (interface{}, bool) {
+			if len(candidates) == 0 {
+				return nil, false
+			}
+			candidate := candidates[0]
+			candidates = candidates[1:]
+			return candidate, true
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package gen_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestByteSize(t *testing.T) {
+	sizes := map[int64]bool{}
+	commonGeneratorTest(t, "byte size", gen.ByteSize(), func(value interface{}) bool {
+		size, ok := value.(int64)
+		sizes[size] = true
+		return ok && size >= 0
+	})
+	if len(sizes) < 10 {
+		t.Errorf("Not enough different sizes generated: %v", sizes)
+	}
+
+	genResult := gen.ByteSize()(gopter.DefaultGenParameters())
+	if len(genResult.Labels) != 1 {
+		t.Errorf("Invalid labels: %v", genResult.Labels)
+	}
+	shrinks := genResult.Shrinker(int64(4096)).All()
+	if len(shrinks) != 9 || shrinks[0] != int64(0) || shrinks[8] != int64(4095) {
+		t.Errorf("Invalid shrinks: %v", shrinks)
+	}
+}
+
+func TestRate(t *testing.T) {
+	commonGeneratorTest(t, "rate", gen.Rate(), func(value interface{}) bool {
+		rate, ok := value.(gen.RateLimit)
+		return ok && rate.Events >= 0 && rate.Interval >= time.Nanosecond && rate.PerSecond() >= 0
+	})
+
+	rate := gen.RateLimit{Events: 100, Interval: time.Minute}
+	if rate.String() != "100/1m0s" || math.Abs(rate.PerSecond()-100.0/60) > 1e-9 {
+		t.Errorf("Invalid rate: %v %v", rate, rate.PerSecond())
+	}
+	shrinks := gen.Rate()(gopter.DefaultGenParameters()).Shrinker(rate).All()
+	if len(shrinks) == 0 || shrinks[0] != (gen.RateLimit{Events: 0, Interval: time.Minute}) {
+		t.Errorf("Invalid shrinks: %v", shrinks)
+	}
+}
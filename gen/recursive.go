@@ -0,0 +1,41 @@
+package gen
+
+import "github.com/leanovate/gopter"
+
+// DefaultRecursionDepth is the maximum depth used by Recursive
+const DefaultRecursionDepth = 5
+
+// Recursive creates a generator of recursive structures (like trees or JSON
+// documents) with a maximum depth of DefaultRecursionDepth (see
+// RecursiveWithDepth).
+func Recursive(base gopter.Gen, rec func(gopter.Gen) gopter.Gen) gopter.Gen {
+	return RecursiveWithDepth(base, rec, DefaultRecursionDepth)
+}
+
+// RecursiveWithDepth creates a generator of recursive structures.
+// "rec" gets the recursive generator itself and has to create the generator of
+// a composite value from it, e.g.
+//
+//	gen.RecursiveWithDepth(leafGen, func(tree gopter.Gen) gopter.Gen {
+//		return gen.OneGenOf(leafGen, gen.SliceOf(tree).Map(newNode))
+//	}, 3)
+//
+// The nesting depth is tracked in GenParameters.Depth, once "maxDepth" is
+// reached the "base" generator is used instead (i.e. "base" and the results
+// of "rec" have to be of the same type).
+// Note: Nested recursive generators share the depth.
+func RecursiveWithDepth(base gopter.Gen, rec func(gopter.Gen) gopter.Gen, maxDepth int) gopter.Gen {
+	var composite gopter.Gen
+	var recursive gopter.Gen
+	recursive = func(genParams *gopter.GenParameters) *gopter.GenResult {
+		if composite == nil || genParams.Depth >= maxDepth {
+			// composite is nil while rec inspects the generator
+			return base(genParams)
+		}
+		nested := *genParams
+		nested.Depth++
+		return composite(&nested)
+	}
+	composite = rec(recursive)
+	return recursive
+}
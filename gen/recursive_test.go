@@ -0,0 +1,70 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+type recursiveTree struct {
+	Value    int
+	Children []*recursiveTree
+}
+
+func (t *recursiveTree) depth() int {
+	depth := 0
+	for _, child := range t.Children {
+		if childDepth := child.depth(); childDepth > depth {
+			depth = childDepth
+		}
+	}
+	return depth + 1
+}
+
+func recursiveTreeGen(maxDepth int, withLeaves bool) gopter.Gen {
+	leaf := gen.IntRange(0, 10).Map(func(v int) *recursiveTree {
+		return &recursiveTree{Value: v}
+	})
+	return gen.RecursiveWithDepth(leaf, func(tree gopter.Gen) gopter.Gen {
+		node := gen.SliceOfN(2, tree).Map(func(children []*recursiveTree) *recursiveTree {
+			return &recursiveTree{Children: children}
+		})
+		if withLeaves {
+			return gen.OneGenOf(leaf, node)
+		}
+		return node
+	}, maxDepth)
+}
+
+func TestRecursive(t *testing.T) {
+	commonGeneratorTest(t, "recursive tree", recursiveTreeGen(4, true), func(value interface{}) bool {
+		tree, ok := value.(*recursiveTree)
+		return ok && tree.depth() <= 5
+	})
+	// without leaves in rec all branches are expanded up to the maximum depth
+	commonGeneratorTest(t, "full tree", recursiveTreeGen(3, false), func(value interface{}) bool {
+		tree, ok := value.(*recursiveTree)
+		return ok && tree.depth() == 4 && len(tree.Children[1].Children[0].Children) == 2
+	})
+
+	genParams := gopter.DefaultGenParameters()
+	genParams.Depth = 3
+	if value, ok := recursiveTreeGen(3, false)(genParams).Retrieve(); !ok || value.(*recursiveTree).depth() != 1 {
+		t.Errorf("Invalid value at max depth: %#v", value)
+	}
+	if genParams.Depth != 3 {
+		t.Errorf("Depth modified: %d", genParams.Depth)
+	}
+}
+
+func TestRecursiveDefaultDepth(t *testing.T) {
+	chain := gen.Recursive(gen.Const(&recursiveTree{}), func(chain gopter.Gen) gopter.Gen {
+		return gen.SliceOfN(1, chain).Map(func(children []*recursiveTree) *recursiveTree {
+			return &recursiveTree{Children: children}
+		})
+	})
+	if value, ok := chain.Sample(); !ok || value.(*recursiveTree).depth() != gen.DefaultRecursionDepth+1 {
+		t.Errorf("Invalid recursive value: %#v", value)
+	}
+}
@@ -0,0 +1,193 @@
+//go:build go1.23
+// +build go1.23
+
+package gen
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/leanovate/gopter"
+)
+
+// maxSeqElementAttempts is the number of attempts to generate an element of an
+// infinite sequence before giving up
+const maxSeqElementAttempts = 100
+
+// SeqOf generates finite sequences (iter.Seq) of up to n elements.
+// The sequences yield the same elements every time they are iterated and
+// shrink to fewer and smaller elements.
+func SeqOf[T any](elemGen gopter.GenT[T], n int) gopter.GenT[iter.Seq[T]] {
+	if n < 0 {
+		return gopter.GenT[iter.Seq[T]](Fail(reflect.TypeOf((*iter.Seq[T])(nil)).Elem()))
+	}
+	elementsGen := seqElements(elemGen, n)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		result := elementsGen(genParams)
+		value, ok := result.Retrieve()
+		if !ok {
+			return gopter.NewEmptyResult(reflect.TypeOf((*iter.Seq[T])(nil)).Elem())
+		}
+		// sequences are functions, i.e. the elements of a sequence have to be
+		// collected to shrink (or sieve) it
+		genResult := gopter.NewGenResult(slices.Values(value.([]T)), func(v interface{}) gopter.Shrink {
+			return result.Shrinker(slices.Collect(v.(iter.Seq[T]))).Map(func(elements []T) iter.Seq[T] {
+				return slices.Values(elements)
+			})
+		})
+		if result.Sieve != nil {
+			genResult.Sieve = func(v interface{}) bool {
+				return result.Sieve(slices.Collect(v.(iter.Seq[T])))
+			}
+		}
+		return genResult
+	}
+}
+
+// InfiniteSeqOf generates infinite sequences (iter.Seq), i.e. sequences that
+// only end if the consumer stops.
+// The elements are generated lazily and yield the same elements every time
+// they are iterated. To detect consumers that do not stop the sequences panic
+// if more than maxConsumed elements are consumed by an iteration (so that the
+// property fails with an error).
+// The sequences are not shrunk.
+func InfiniteSeqOf[T any](elemGen gopter.GenT[T], maxConsumed int) gopter.GenT[iter.Seq[T]] {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		elemParams := genParams.CloneWithSeed(genParams.Rng.Int63())
+		var lock sync.Mutex
+		var elements []T
+		element := func(i int) T {
+			lock.Lock()
+			defer lock.Unlock()
+			for attempts := 0; len(elements) <= i; attempts++ {
+				if attempts >= maxSeqElementAttempts {
+					panic(fmt.Sprintf("Element %d of infinite sequence could not be generated", i))
+				}
+				if value, ok := elemGen(elemParams).Retrieve(); ok {
+					elements = append(elements, value.(T))
+				}
+			}
+			return elements[i]
+		}
+		var seq iter.Seq[T] = func(yield func(T) bool) {
+			for i := 0; ; i++ {
+				if i >= maxConsumed {
+					panic(fmt.Sprintf("Infinite sequence consumed beyond %d elements", maxConsumed))
+				}
+				if !yield(element(i)) {
+					return
+				}
+			}
+		}
+		return gopter.NewGenResult(seq, gopter.NoShrinker)
+	}
+}
+
+// seqWithError are the elements of a sequence that fails with an error after
+// the first failAt elements (-1 if it does not fail)
+type seqWithError[T any] struct {
+	elements []T
+	failAt   int
+}
+
+// seq2 creates the sequence that yields the elements and err at failAt
+func (s seqWithError[T]) seq2(err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for i, element := range s.elements {
+			if i == s.failAt {
+				break
+			}
+			if !yield(element, nil) {
+				return
+			}
+		}
+		if s.failAt >= 0 {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// collectSeqWithError collects the elements and the position of the error of
+// a sequence
+func collectSeqWithError[T any](seq iter.Seq2[T, error]) seqWithError[T] {
+	collected := seqWithError[T]{failAt: -1}
+	for element, err := range seq {
+		if err != nil {
+			collected.failAt = len(collected.elements)
+			break
+		}
+		collected.elements = append(collected.elements, element)
+	}
+	return collected
+}
+
+// SeqWithErrorOf generates sequences (iter.Seq2) of up to n elements (with a
+// nil error) that fail midway in half of the cases: At a random position (or
+// after all elements) err is yielded with the zero value and the sequence
+// ends.
+// Fails if err is nil.
+// The sequences shrink to earlier errors and fewer and smaller elements.
+func SeqWithErrorOf[T any](elemGen gopter.GenT[T], n int, err error) gopter.GenT[iter.Seq2[T, error]] {
+	resultType := reflect.TypeOf((*iter.Seq2[T, error])(nil)).Elem()
+	if n < 0 || err == nil {
+		return gopter.GenT[iter.Seq2[T, error]](Fail(resultType))
+	}
+	elementsGen := seqElements(elemGen, n)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		result := elementsGen(genParams)
+		value, ok := result.Retrieve()
+		if !ok {
+			return gopter.NewEmptyResult(resultType)
+		}
+		seq := seqWithError[T]{elements: value.([]T), failAt: -1}
+		if genParams.NextBool() {
+			seq.failAt = genParams.Rng.Intn(len(seq.elements) + 1)
+		}
+		genResult := gopter.NewGenResult(seq.seq2(err), func(v interface{}) gopter.Shrink {
+			return seqWithErrorShrink(collectSeqWithError(v.(iter.Seq2[T, error])), result.Shrinker).Map(func(shrunk seqWithError[T]) iter.Seq2[T, error] {
+				return shrunk.seq2(err)
+			})
+		})
+		if result.Sieve != nil {
+			genResult.Sieve = func(v interface{}) bool {
+				return result.Sieve(collectSeqWithError(v.(iter.Seq2[T, error])).elements)
+			}
+		}
+		return genResult
+	}
+}
+
+// seqWithErrorShrink shrinks the position of the error and the elements (the
+// error stays within the elements)
+func seqWithErrorShrink[T any](seq seqWithError[T], elementsShrinker gopter.Shrinker) gopter.Shrink {
+	var failAtShrink gopter.Shrink = gopter.NoShrink
+	if seq.failAt > 0 {
+		failAtShrink = UIntShrinker(uint(seq.failAt)).Map(func(failAt uint) seqWithError[T] {
+			return seqWithError[T]{elements: seq.elements, failAt: int(failAt)}
+		})
+	}
+	elementsShrink := elementsShrinker(seq.elements).Map(func(elements []T) seqWithError[T] {
+		return seqWithError[T]{elements: elements, failAt: min(seq.failAt, len(elements))}
+	})
+	return failAtShrink.Interleave(elementsShrink)
+}
+
+// seqElements generates the up to n elements of a sequence
+func seqElements[T any](elemGen gopter.GenT[T], n int) gopter.GenT[[]T] {
+	elemType := reflect.TypeOf((*T)(nil)).Elem()
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		elements, elementSieve, elementShrinker := genSlice(elemGen.Untyped(), genParams, genParams.Rng.Intn(n+1), elemType)
+		if elementShrinker == nil {
+			elementShrinker = gopter.NoShrinker
+		}
+		genResult := gopter.NewGenResult(elements.Interface(), SliceShrinker(elementShrinker))
+		if elementSieve != nil {
+			genResult.Sieve = forAllSieve(elementSieve)
+		}
+		return genResult
+	}
+}
@@ -0,0 +1,131 @@
+//go:build go1.23
+// +build go1.23
+
+package gen_test
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// sumSeq is the code under test, it sums up the elements of a sequence
+func sumSeq(seq iter.Seq[int]) int {
+	sum := 0
+	for v := range seq {
+		sum += v
+	}
+	return sum
+}
+
+func TestSeqOf(t *testing.T) {
+	elements := gopter.Typed[int](gen.IntRange(0, 100))
+	commonGeneratorTest(t, "seq", gen.SeqOf(elements, 10).Untyped(), func(value interface{}) bool {
+		seq, ok := value.(iter.Seq[int])
+		collected := slices.Collect(seq)
+		// sequences can be iterated multiple times
+		return ok && len(collected) <= 10 && slices.Equal(collected, slices.Collect(seq))
+	})
+
+	result := prop.ForAllT(func(seq iter.Seq[int]) bool {
+		return sumSeq(seq) < 100
+	}, gen.SeqOf(elements, 10)).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	// every shrink of a sequence with a sum of 100 passes
+	if shrunk := slices.Collect(result.Args[0].Arg.(iter.Seq[int])); sumSeq(slices.Values(shrunk)) != 100 {
+		t.Errorf("Invalid shrunk sequence: %v", shrunk)
+	}
+
+	if _, ok := gen.SeqOf(elements, -1).Sample(); ok {
+		t.Error("Negative length should fail")
+	}
+}
+
+func TestInfiniteSeqOf(t *testing.T) {
+	seq, ok := gen.InfiniteSeqOf(gopter.Typed[int](gen.Int()), 1000).Sample()
+	if !ok {
+		t.Fatal("Sample failed")
+	}
+	var first []int
+	for v := range seq {
+		first = append(first, v)
+		if len(first) == 100 {
+			break
+		}
+	}
+	var again []int
+	for v := range seq {
+		again = append(again, v)
+		if len(again) == 50 {
+			break
+		}
+	}
+	if len(first) != 100 || !slices.Equal(first[:50], again) {
+		t.Errorf("Invalid iterations: %v %v", first, again)
+	}
+
+	// consumers that do not stop fail with an error
+	result := prop.ForAllT(func(seq iter.Seq[int]) bool {
+		return sumSeq(seq) != 0
+	}, gen.InfiniteSeqOf(gopter.Typed[int](gen.Const(1).Map(func(v int) int { return v })), 1000)).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestError || !strings.Contains(result.Error.Error(), "consumed beyond 1000 elements") {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
+
+var errSeq = errors.New("seq failed")
+
+// collectSeq is the code under test, it collects the elements of a sequence
+// until the first error
+func collectSeq(seq iter.Seq2[int, error]) ([]int, error) {
+	var collected []int
+	for v, err := range seq {
+		if err != nil {
+			return collected, err
+		}
+		collected = append(collected, v)
+	}
+	return collected, nil
+}
+
+func TestSeqWithErrorOf(t *testing.T) {
+	var failed, succeeded int
+	elements := gopter.Typed[int](gen.IntRange(0, 100))
+	commonGeneratorTest(t, "seq with error", gen.SeqWithErrorOf(elements, 10, errSeq).Untyped(), func(value interface{}) bool {
+		seq, ok := value.(iter.Seq2[int, error])
+		collected, err := collectSeq(seq)
+		if err != nil {
+			failed++
+		} else {
+			succeeded++
+		}
+		return ok && len(collected) <= 10 && (err == nil || err == errSeq)
+	})
+	if failed == 0 || succeeded == 0 {
+		t.Errorf("Invalid distribution: %d failed, %d succeeded", failed, succeeded)
+	}
+
+	// collecting discards the elements before the error
+	result := prop.ForAllT(func(seq iter.Seq2[int, error]) bool {
+		collected, err := collectSeq(seq)
+		return err == nil || len(collected) == 0
+	}, gen.SeqWithErrorOf(elements, 10, errSeq)).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if collected, err := collectSeq(result.Args[0].Arg.(iter.Seq2[int, error])); len(collected) != 1 || collected[0] != 0 || err != errSeq {
+		t.Errorf("Invalid shrunk sequence: %v %v", collected, err)
+	}
+
+	if _, ok := gen.SeqWithErrorOf(elements, 10, nil).Sample(); ok {
+		t.Error("Missing error should fail")
+	}
+}
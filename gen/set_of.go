@@ -0,0 +1,134 @@
+package gen
+
+import (
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+var emptyStruct = reflect.ValueOf(struct{}{})
+
+// SetOf generates an arbitrary set (i.e. a map[T]struct{}) of generated
+// elements.
+// genParams.MaxSize sets an (exclusive) upper limit on the size of the set
+// genParams.MinSize sets an (inclusive) lower limit on the size of the set
+// (which might not be reached if the element generator does not generate
+// enough distinct elements)
+func SetOf(elementGen gopter.Gen) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		len := 0
+		if genParams.MaxSize > 0 || genParams.MinSize > 0 {
+			if genParams.MinSize > genParams.MaxSize {
+				panic("GenParameters.MinSize must be <= GenParameters.MaxSize")
+			}
+
+			if genParams.MaxSize == genParams.MinSize {
+				len = genParams.MaxSize
+			} else {
+				len = genParams.Rng.Intn(genParams.MaxSize-genParams.MinSize) + genParams.MinSize
+			}
+		}
+
+		element := elementGen(genParams)
+		elementSieve := element.Sieve
+		elementShrinker := element.Shrinker
+		result := reflect.MakeMapWithSize(reflect.MapOf(element.ResultType, emptyStruct.Type()), len)
+		for attempts := 0; result.Len() < len && attempts < 10*len; attempts++ {
+			if value, ok := element.Retrieve(); ok {
+				if value == nil {
+					result.SetMapIndex(reflect.Zero(element.ResultType), emptyStruct)
+				} else {
+					result.SetMapIndex(reflect.ValueOf(value), emptyStruct)
+				}
+			}
+			element = elementGen(genParams)
+		}
+
+		genResult := gopter.NewGenResult(result.Interface(), SetShrinker(elementShrinker))
+		if elementSieve != nil {
+			genResult.Sieve = forAllKeyValueSieve(elementSieve, nil)
+		}
+		return genResult
+	}
+}
+
+// SubsetOfSet generates arbitrary subsets of a set (i.e. a map[T]struct{}),
+// every element of the set is a member of a subset with a probability of 1/2.
+// The subsets are shrunk by removing members.
+func SubsetOfSet(set interface{}) gopter.Gen {
+	rv := reflect.ValueOf(set)
+	if rv.Kind() != reflect.Map || rv.Type().Elem() != emptyStruct.Type() {
+		return Fail(reflect.TypeOf(set))
+	}
+	// the elements in a stable order, so that a seed always generates the
+	// same subset
+	elements := sortedMapKeys(rv)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		result := reflect.MakeMap(rv.Type())
+		for _, element := range elements {
+			if genParams.NextBool() {
+				result.SetMapIndex(element, emptyStruct)
+			}
+		}
+
+		genResult := gopter.NewGenResult(result.Interface(), SetShrinker(gopter.NoShrinker))
+		genResult.Sieve = func(v interface{}) bool {
+			for _, element := range reflect.ValueOf(v).MapKeys() {
+				if !rv.MapIndex(element).IsValid() {
+					return false
+				}
+			}
+			return true
+		}
+		return genResult
+	}
+}
+
+// DisjointSets generates slices of n pairwise disjoint sets (i.e.
+// map[T]struct{}) of generated elements, which is useful to test set
+// algebra (e.g. that the intersection of the sets is empty).
+// The size of the union of all sets is limited like the size of SetOf.
+// The sets are shrunk by removing members.
+func DisjointSets(n int, elementGen gopter.Gen) gopter.Gen {
+	setType := reflect.MapOf(elementGen(gopter.MinGenParams).ResultType, emptyStruct.Type())
+	if n <= 0 {
+		return Fail(reflect.SliceOf(setType))
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		union := SetOf(elementGen)(genParams)
+		value, ok := union.Retrieve()
+		if !ok {
+			return gopter.NewEmptyResult(reflect.SliceOf(setType))
+		}
+		unionValue := reflect.ValueOf(value)
+		result := reflect.MakeSlice(reflect.SliceOf(unionValue.Type()), n, n)
+		for i := 0; i < n; i++ {
+			result.Index(i).Set(reflect.MakeMap(unionValue.Type()))
+		}
+		for _, element := range sortedMapKeys(unionValue) {
+			result.Index(genParams.Rng.Intn(n)).SetMapIndex(element, emptyStruct)
+		}
+
+		genResult := gopter.NewGenResult(result.Interface(), SliceShrinkerOne(SetShrinker(gopter.NoShrinker)))
+		genResult.Sieve = func(v interface{}) bool {
+			sets := reflect.ValueOf(v)
+			if sets.Len() != n {
+				return false
+			}
+			seen := map[interface{}]bool{}
+			for i := 0; i < sets.Len(); i++ {
+				if union.Sieve != nil && !union.Sieve(sets.Index(i).Interface()) {
+					return false
+				}
+				for _, element := range sets.Index(i).MapKeys() {
+					if seen[element.Interface()] {
+						return false
+					}
+					seen[element.Interface()] = true
+				}
+			}
+			return true
+		}
+		return genResult
+	}
+}
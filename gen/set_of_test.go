@@ -0,0 +1,102 @@
+package gen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestSetOf(t *testing.T) {
+	genParams := gopter.DefaultGenParameters()
+	genParams.MaxSize = 50
+	setGen := gen.SetOf(gen.IntRange(0, 1000))
+	for i := 0; i < 100; i++ {
+		sample, ok := setGen(genParams).Retrieve()
+		set, isSet := sample.(map[int]struct{})
+		if !ok || !isSet || len(set) >= 50 {
+			t.Fatalf("Invalid set: %#v", sample)
+		}
+		for element := range set {
+			if element < 0 || element > 1000 {
+				t.Errorf("Invalid element: %#v", set)
+			}
+		}
+	}
+
+	genParams.MinSize = 10
+	genParams.MaxSize = 10
+	sample, ok := setGen(genParams).Retrieve()
+	if set := sample.(map[int]struct{}); !ok || len(set) != 10 {
+		t.Errorf("Invalid set: %#v", sample)
+	}
+	// there are not enough distinct elements
+	sample, ok = gen.SetOf(gen.IntRange(1, 3))(genParams).Retrieve()
+	if set := sample.(map[int]struct{}); !ok || len(set) != 3 {
+		t.Errorf("Invalid set: %#v", sample)
+	}
+
+	commonGeneratorTest(t, "set of even", gen.SetOf(gen.Int().SuchThat(func(v int) bool {
+		return v%2 == 0
+	})), func(v interface{}) bool {
+		for element := range v.(map[int]struct{}) {
+			if element%2 != 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestSubsetOfSet(t *testing.T) {
+	set := map[string]struct{}{"a": {}, "b": {}, "c": {}, "d": {}}
+	subsets := map[int]bool{}
+	commonGeneratorTest(t, "subset", gen.SubsetOfSet(set), func(v interface{}) bool {
+		subset := v.(map[string]struct{})
+		subsets[len(subset)] = true
+		for element := range subset {
+			if _, ok := set[element]; !ok {
+				return false
+			}
+		}
+		return true
+	})
+	if len(subsets) < 3 {
+		t.Errorf("Subsets should have various sizes: %v", subsets)
+	}
+
+	parameters := gopter.DefaultGenParameters()
+	first, _ := gen.SubsetOfSet(set)(parameters.CloneWithSeed(1234)).Retrieve()
+	second, _ := gen.SubsetOfSet(set)(parameters.CloneWithSeed(1234)).Retrieve()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Subsets of the same seed differ: %#v != %#v", first, second)
+	}
+
+	if _, ok := gen.SubsetOfSet(map[string]bool{"a": true}).Sample(); ok {
+		t.Error("Subset of a map that is not a set")
+	}
+}
+
+func TestDisjointSets(t *testing.T) {
+	commonGeneratorTest(t, "disjoint sets", gen.DisjointSets(3, gen.IntRange(0, 20)), func(v interface{}) bool {
+		sets := v.([]map[int]struct{})
+		if len(sets) != 3 {
+			return false
+		}
+		seen := map[int]bool{}
+		for _, set := range sets {
+			for element := range set {
+				if seen[element] || element < 0 || element > 20 {
+					return false
+				}
+				seen[element] = true
+			}
+		}
+		return true
+	})
+
+	if _, ok := gen.DisjointSets(0, gen.Int()).Sample(); ok {
+		t.Error("Disjoint sets for n = 0")
+	}
+}
@@ -0,0 +1,75 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+type setShrinkOne struct {
+	original reflect.Value
+	element  reflect.Value
+	shrink   gopter.Shrink
+}
+
+func (s *setShrinkOne) Next() (interface{}, bool) {
+	for {
+		value, ok := s.shrink()
+		if !ok {
+			return nil, false
+		}
+		shrunk := reflect.Zero(s.element.Type())
+		if value != nil {
+			shrunk = reflect.ValueOf(value)
+		}
+		if s.original.MapIndex(shrunk).IsValid() {
+			// the shrunk element is already a member
+			continue
+		}
+		result := reflect.MakeMapWithSize(s.original.Type(), s.original.Len())
+		for _, element := range s.original.MapKeys() {
+			if element.Interface() != s.element.Interface() {
+				result.SetMapIndex(element, emptyStruct)
+			}
+		}
+		result.SetMapIndex(shrunk, emptyStruct)
+		return result.Interface(), true
+	}
+}
+
+// SetShrinker creates a shrinker for sets (i.e. map[T]struct{}) from a
+// shrinker of the elements. Members are removed first, then each member is
+// shrunk after the other (skipping elements that are already members).
+func SetShrinker(elementShrinker gopter.Shrinker) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			panic(fmt.Sprintf("%#v is not a set", v))
+		}
+		elements := sortedMapKeys(rv)
+		removeShrink := &mapShrink{
+			original:     rv,
+			originalKeys: elements,
+			offset:       0,
+			length:       rv.Len(),
+			chunkLength:  rv.Len() >> 1,
+		}
+		if rv.Len() == 1 {
+			// the chunks of a single member would be empty
+			removeShrink.chunkLength = 1
+		}
+
+		shrinks := make([]gopter.Shrink, 0, rv.Len()+1)
+		shrinks = append(shrinks, removeShrink.Next)
+		for _, element := range elements {
+			setShrinkOne := &setShrinkOne{
+				original: rv,
+				element:  element,
+				shrink:   elementShrinker(element.Interface()),
+			}
+			shrinks = append(shrinks, setShrinkOne.Next)
+		}
+		return gopter.ConcatShrinks(shrinks...)
+	}
+}
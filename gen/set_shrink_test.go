@@ -0,0 +1,41 @@
+package gen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestSetShrinker(t *testing.T) {
+	setShrink := gen.SetShrinker(gen.Int64Shrinker)(map[int64]struct{}{
+		2: {},
+	}).All()
+	if !reflect.DeepEqual(setShrink, []interface{}{
+		map[int64]struct{}{},
+		map[int64]struct{}{0: {}},
+		map[int64]struct{}{1: {}},
+		map[int64]struct{}{-1: {}},
+	}) {
+		t.Errorf("Invalid setShrink: %#v", setShrink)
+	}
+
+	setShrink = gen.SetShrinker(gen.Int64Shrinker)(map[int64]struct{}{
+		0: {},
+		2: {},
+	}).All()
+	if !reflect.DeepEqual(setShrink, []interface{}{
+		map[int64]struct{}{2: {}},
+		map[int64]struct{}{0: {}},
+		map[int64]struct{}{0: {}, 1: {}},
+		map[int64]struct{}{0: {}, -1: {}},
+	}) {
+		t.Errorf("Invalid setShrink: %#v", setShrink)
+	}
+
+	setShrink = gen.SetShrinker(gopter.NoShrinker)(map[string]struct{}{}).All()
+	if len(setShrink) != 0 {
+		t.Errorf("Invalid setShrink: %#v", setShrink)
+	}
+}
@@ -134,12 +134,31 @@ func Identifier() gopter.Gen {
 	}).WithShrinker(StringShrinker)
 }
 
-// UnicodeString generates an arbitrary string from a given
-// unicode table.
-func UnicodeString(table *unicode.RangeTable) gopter.Gen {
-	return genString(UnicodeChar(table), func(ch rune) bool {
-		return unicode.Is(table, ch)
-	})
+// UnicodeString generates an arbitrary string from the given
+// unicode tables (e.g. unicode.Greek or unicode.Mn).
+// The characters are equally distributed over the tables, so that small
+// tables are not dominated by large ones.
+func UnicodeString(tables ...*unicode.RangeTable) gopter.Gen {
+	if len(tables) == 0 {
+		return Fail(reflect.TypeOf(""))
+	}
+	charGens := make([]gopter.Gen, len(tables))
+	for i, table := range tables {
+		if table == nil || len(table.R16)+len(table.R32) == 0 {
+			return Fail(reflect.TypeOf(""))
+		}
+		charGens[i] = UnicodeChar(table)
+	}
+	sieve := func(ch rune) bool {
+		return unicode.IsOneOf(tables, ch)
+	}
+	return genString(func(genParams *gopter.GenParameters) *gopter.GenResult {
+		result := charGens[genParams.Rng.Intn(len(charGens))](genParams)
+		result.Sieve = func(v interface{}) bool {
+			return sieve(v.(rune))
+		}
+		return result
+	}, sieve)
 }
 
 func genString(runeGen gopter.Gen, runeSieve func(ch rune) bool) gopter.Gen {
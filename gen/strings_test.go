@@ -142,6 +142,25 @@ func TestUnicodeString(t *testing.T) {
 		t.Fail()
 	}
 
+	if value, ok := gen.UnicodeString().Sample(); ok {
+		t.Errorf("Invalid value without tables: %#v", value)
+	}
+	if value, ok := gen.UnicodeString(unicode.Latin, nil).Sample(); ok {
+		t.Errorf("Invalid value with nil table: %#v", value)
+	}
+	commonGeneratorTest(t, "unicodeString multiple tables", gen.UnicodeString(unicode.Greek, unicode.Mn), func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		for _, ch := range str {
+			if !unicode.In(ch, unicode.Greek, unicode.Mn) {
+				return false
+			}
+		}
+		return true
+	})
+
 	for _, table := range unicode.Scripts {
 		unicodeString := gen.UnicodeString(table)
 		commonGeneratorTest(t, "unicodeString", unicodeString, func(value interface{}) bool {
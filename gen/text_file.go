@@ -0,0 +1,175 @@
+package gen
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/leanovate/gopter"
+)
+
+// Pathologies of a generated TextFile (used as labels)
+const (
+	TextMixedLineEndings = "mixed line endings"
+	TextCRLF             = "crlf"
+	TextCROnly           = "cr only"
+	TextBOM              = "bom"
+	TextNoFinalNewline   = "no final newline"
+	TextLongLine         = "long line"
+	TextInvalidUTF8      = "invalid utf-8"
+	TextEmpty            = "empty"
+)
+
+// TextFileLongLineLength is the length of the very long lines of a TextFile,
+// it exceeds the default buffer size of a bufio.Scanner (64 KiB)
+const TextFileLongLineLength = 70000
+
+// UTF8BOM is the byte order mark of UTF-8
+const UTF8BOM = "\xef\xbb\xbf"
+
+// TextFile is generated line based text content
+type TextFile struct {
+	// BOM is either empty or UTF8BOM
+	BOM string
+	// Lines are the lines of the file without line endings
+	Lines []string
+	// LineEndings contains the ending of each line ("\n", "\r\n" or "\r"),
+	// the last line may have an empty ending (i.e. no final newline)
+	LineEndings []string
+}
+
+// Content returns the raw content of the file
+func (f TextFile) Content() string {
+	var builder strings.Builder
+	builder.WriteString(f.BOM)
+	for i, line := range f.Lines {
+		builder.WriteString(line)
+		builder.WriteString(f.LineEndings[i])
+	}
+	return builder.String()
+}
+
+// Pathologies lists the pathologies of the file
+func (f TextFile) Pathologies() []string {
+	result := make([]string, 0)
+	endings := map[string]bool{}
+	longLine, invalidUTF8 := false, false
+	for i, line := range f.Lines {
+		if f.LineEndings[i] != "" {
+			endings[f.LineEndings[i]] = true
+		}
+		longLine = longLine || len(line) >= TextFileLongLineLength
+		invalidUTF8 = invalidUTF8 || !utf8.ValidString(line)
+	}
+	switch {
+	case len(endings) > 1:
+		result = append(result, TextMixedLineEndings)
+	case endings["\r\n"]:
+		result = append(result, TextCRLF)
+	case endings["\r"]:
+		result = append(result, TextCROnly)
+	}
+	if f.BOM != "" {
+		result = append(result, TextBOM)
+	}
+	if len(f.Lines) == 0 {
+		result = append(result, TextEmpty)
+	} else if f.LineEndings[len(f.LineEndings)-1] == "" {
+		result = append(result, TextNoFinalNewline)
+	}
+	if longLine {
+		result = append(result, TextLongLine)
+	}
+	if invalidUTF8 {
+		result = append(result, TextInvalidUTF8)
+	}
+	return result
+}
+
+var textFileLineEndings = []string{"\n", "\r\n", "\r"}
+
+var textFileLinePieces = []string{
+	"", " ", "\t", "hello world", "äöü€", "日本語", "🙂", "\x00", "  trailing  ",
+	"\xff\xfe", "\xc3", "a\u200bb", "\u2028", "\x1b[31mred\x1b[0m",
+}
+
+// TextFileContent generates multi-line text content with mixed line endings, byte
+// order marks, missing final newlines, very long lines and invalid UTF-8.
+// The pathologies of the file are added as labels (see TextFile.Pathologies).
+// Files are shrunk by removing and shortening lines.
+func TextFileContent() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		file := TextFile{}
+		if genParams.Rng.Intn(5) == 0 {
+			file.BOM = UTF8BOM
+		}
+		// all lines use the same ending unless the file has mixed endings
+		ending := textFileLineEndings[genParams.Rng.Intn(len(textFileLineEndings))]
+		mixed := genParams.Rng.Intn(3) == 0
+		count := genParams.Rng.Intn(genParams.MaxSize/4 + 1)
+		for i := 0; i < count; i++ {
+			file.Lines = append(file.Lines, genTextFileLine(genParams))
+			if mixed {
+				ending = textFileLineEndings[genParams.Rng.Intn(len(textFileLineEndings))]
+			}
+			file.LineEndings = append(file.LineEndings, ending)
+		}
+		if count > 0 && genParams.NextBool() {
+			file.LineEndings[count-1] = ""
+		}
+		genResult := gopter.NewGenResult(file, TextFileShrinker)
+		genResult.Labels = file.Pathologies()
+		return genResult
+	}
+}
+
+func genTextFileLine(genParams *gopter.GenParameters) string {
+	if genParams.Rng.Intn(50) == 0 {
+		return strings.Repeat("x", TextFileLongLineLength+genParams.Rng.Intn(100))
+	}
+	var builder strings.Builder
+	for i := genParams.Rng.Intn(4); i > 0; i-- {
+		piece := textFileLinePieces[genParams.Rng.Intn(len(textFileLinePieces))]
+		if (piece == "\xff\xfe" || piece == "\xc3") && genParams.Rng.Intn(3) != 0 {
+			piece = "plain"
+		}
+		builder.WriteString(piece)
+	}
+	return builder.String()
+}
+
+// TextFileShrinker shrinks a TextFile by removing lines, removing the BOM and
+// halving long lines
+func TextFileShrinker(v interface{}) gopter.Shrink {
+	file := v.(TextFile)
+	candidates := make([]TextFile, 0, len(file.Lines)+1)
+	if file.BOM != "" {
+		candidates = append(candidates, TextFile{Lines: file.Lines, LineEndings: file.LineEndings})
+	}
+	for i := range file.Lines {
+		candidate := TextFile{
+			BOM:         file.BOM,
+			Lines:       append(append([]string{}, file.Lines[:i]...), file.Lines[i+1:]...),
+			LineEndings: append(append([]string{}, file.LineEndings[:i]...), file.LineEndings[i+1:]...),
+		}
+		if i == len(file.Lines)-1 && i > 0 {
+			// keep the (missing) final newline
+			candidate.LineEndings[i-1] = file.LineEndings[i]
+		}
+		candidates = append(candidates, candidate)
+	}
+	for i, line := range file.Lines {
+		if len(line) > 1 {
+			lines := append([]string{}, file.Lines...)
+			lines[i] = line[:len(line)/2]
+			candidates = append(candidates, TextFile{BOM: file.BOM, Lines: lines, LineEndings: file.LineEndings})
+		}
+	}
+	idx := 0
+	return func() (interface{}, bool) {
+		if idx >= len(candidates) {
+			return nil, false
+		}
+		idx++
+		return candidates[idx-1], true
+	}
+}
@@ -0,0 +1,63 @@
+package gen_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestTextFileContent(t *testing.T) {
+	commonGeneratorTest(t, "text file", gen.TextFileContent(), func(value interface{}) bool {
+		file, ok := value.(gen.TextFile)
+		if !ok || len(file.Lines) != len(file.LineEndings) {
+			return false
+		}
+		content := strings.TrimPrefix(file.Content(), file.BOM)
+		for i, line := range file.Lines {
+			if !strings.HasPrefix(content, line+file.LineEndings[i]) {
+				return false
+			}
+			content = content[len(line)+len(file.LineEndings[i]):]
+		}
+		return content == ""
+	})
+
+	pathologies := map[string]bool{}
+	for i := 0; i < 500; i++ {
+		result := gen.TextFileContent()(gopter.DefaultGenParameters())
+		for _, label := range result.Labels {
+			pathologies[label] = true
+		}
+	}
+	for _, pathology := range []string{gen.TextMixedLineEndings, gen.TextCRLF, gen.TextCROnly, gen.TextBOM,
+		gen.TextNoFinalNewline, gen.TextLongLine, gen.TextInvalidUTF8, gen.TextEmpty} {
+		if !pathologies[pathology] {
+			t.Errorf("Pathology %s not generated", pathology)
+		}
+	}
+}
+
+func TestTextFileShrink(t *testing.T) {
+	// bufio.Scanner fails on long lines
+	result := prop.ForAll(
+		func(file gen.TextFile) bool {
+			scanner := bufio.NewScanner(strings.NewReader(file.Content()))
+			for scanner.Scan() {
+			}
+			return scanner.Err() == nil
+		},
+		gen.TextFileContent(),
+	).Check(gopter.DefaultTestParameters())
+
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	file := result.Args[0].Arg.(gen.TextFile)
+	if len(file.Lines) != 1 || len(file.Lines[0]) < 64*1024 || file.BOM != "" {
+		t.Errorf("Shrunk file is not minimal: %d lines", len(file.Lines))
+	}
+}
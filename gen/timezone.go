@@ -0,0 +1,105 @@
+package gen
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/leanovate/gopter"
+)
+
+// timeZoneNames are IANA zones with unusual offsets, DST rules or historical
+// transitions
+var timeZoneNames = []string{
+	"UTC",
+	"Europe/London",
+	"Europe/Berlin",
+	"Europe/Dublin", // negative DST in the database
+	"Europe/Moscow", // several changes of standard offset
+	"America/New_York",
+	"America/Los_Angeles",
+	"America/Phoenix",  // no DST
+	"America/St_Johns", // -03:30
+	"America/Havana",   // DST transitions at midnight
+	"America/Caracas",  // changed offset in 2007 and 2016
+	"America/Santiago",
+	"America/Sao_Paulo", // DST abolished in 2019
+	"Africa/Casablanca", // DST suspended during Ramadan
+	"Asia/Tehran",       // +03:30
+	"Asia/Kolkata",      // +05:30
+	"Asia/Kathmandu",    // +05:45
+	"Asia/Shanghai",
+	"Asia/Tokyo",
+	"Asia/Pyongyang", // changed offset in 2015 and 2018
+	"Asia/Gaza",
+	"Australia/Adelaide",  // +09:30 with DST
+	"Australia/Lord_Howe", // 30 minute DST
+	"Pacific/Marquesas",   // -09:30
+	"Pacific/Chatham",     // +12:45 with DST
+	"Pacific/Apia",        // skipped 2011-12-30
+	"Pacific/Kiritimati",  // +14:00
+	"Pacific/Pago_Pago",   // -11:00
+	"Antarctica/Troll",    // 2 hour DST
+}
+
+var (
+	timeZonesOnce sync.Once
+	timeZones     []*time.Location
+)
+
+// etcGMTZone creates the Etc/GMT zone for an offset in hours.
+// Mind the POSIX style inverted sign, e.g. Etc/GMT+5 is UTC-05:00.
+func etcGMTZone(hours int) *time.Location {
+	name := "Etc/GMT"
+	if hours < 0 {
+		name = fmt.Sprintf("Etc/GMT+%d", -hours)
+	} else if hours > 0 {
+		name = fmt.Sprintf("Etc/GMT-%d", hours)
+	}
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	return time.FixedZone(name, hours*3600)
+}
+
+func loadTimeZones() {
+	for _, name := range timeZoneNames {
+		if loc, err := time.LoadLocation(name); err == nil {
+			timeZones = append(timeZones, loc)
+		}
+	}
+	for hours := -12; hours <= 14; hours++ {
+		timeZones = append(timeZones, etcGMTZone(hours))
+	}
+}
+
+// TimeZone generates *time.Location values across the IANA database (zones
+// with unusual offsets and historical transitions as well as all Etc/GMT
+// offsets). The name of the zone is added as label.
+// Zones that are not available in the local timezone database are skipped,
+// the Etc/GMT offsets fall back to fixed zones.
+// The generated values shrink to UTC.
+func TimeZone() gopter.Gen {
+	timeZonesOnce.Do(loadTimeZones)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		loc := timeZones[genParams.Rng.Intn(len(timeZones))]
+		genResult := gopter.NewGenResult(loc, TimeZoneShrinker)
+		genResult.Labels = []string{loc.String()}
+		return genResult
+	}
+}
+
+// TimeZoneShrinker shrinks a *time.Location to UTC
+func TimeZoneShrinker(v interface{}) gopter.Shrink {
+	if v.(*time.Location).String() == "UTC" {
+		return gopter.NoShrink
+	}
+	done := false
+	return func() (interface{}, bool) {
+		if done {
+			return nil, false
+		}
+		done = true
+		return time.UTC, true
+	}
+}
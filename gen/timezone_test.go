@@ -0,0 +1,58 @@
+package gen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestTimeZone(t *testing.T) {
+	commonGeneratorTest(t, "timezone", gen.TimeZone(), func(value interface{}) bool {
+		loc, ok := value.(*time.Location)
+		return ok && loc != nil && loc.String() != ""
+	})
+
+	names := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		value, ok := gen.TimeZone().Sample()
+		if !ok {
+			t.FailNow()
+		}
+		names[value.(*time.Location).String()] = true
+	}
+	if !names["Etc/GMT+5"] || !names["Etc/GMT-14"] || len(names) < 20 {
+		t.Errorf("Too few zones generated: %#v", names)
+	}
+
+	_, offset := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).In(etcZone(t, "Etc/GMT+5")).Zone()
+	if offset != -5*3600 {
+		t.Errorf("Invalid offset of Etc/GMT+5: %d", offset)
+	}
+}
+
+func etcZone(t *testing.T, name string) *time.Location {
+	for i := 0; i < 10000; i++ {
+		value, _ := gen.TimeZone().Sample()
+		if loc := value.(*time.Location); loc.String() == name {
+			return loc
+		}
+	}
+	t.Fatalf("%s not generated", name)
+	return nil
+}
+
+func TestTimeZoneShrink(t *testing.T) {
+	result := prop.ForAll(
+		func(loc *time.Location) bool {
+			return false
+		},
+		gen.TimeZone(),
+	).Check(gopter.DefaultTestParameters())
+
+	if result.Status != gopter.TestFailed || result.Args[0].Arg.(*time.Location).String() != "UTC" {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
@@ -0,0 +1,342 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+// Operations of a TransactionScript
+const (
+	TxRead   = "read"
+	TxWrite  = "write"
+	TxCommit = "commit"
+	TxAbort  = "abort"
+)
+
+// Anomalies detected by TransactionScript.Anomalies
+const (
+	// AnomalyDirtyRead is a read of a value written by a transaction that
+	// has not been committed (yet)
+	AnomalyDirtyRead = "dirty read"
+	// AnomalyLostUpdate is a committed write of a transaction that read the
+	// key without observing the write of another transaction committed
+	// before
+	AnomalyLostUpdate = "lost update"
+	// AnomalyWriteSkew are two committed concurrent transactions that each
+	// read a key the other one wrote without observing the write
+	AnomalyWriteSkew = "write skew"
+)
+
+// IsolationLevel is a transaction isolation level that forbids a set of
+// anomalies
+type IsolationLevel int
+
+const (
+	// ReadUncommitted allows all anomalies
+	ReadUncommitted IsolationLevel = iota
+	// ReadCommitted forbids dirty reads
+	ReadCommitted
+	// RepeatableRead (or snapshot isolation) forbids dirty reads and lost
+	// updates
+	RepeatableRead
+	// Serializable forbids all anomalies
+	Serializable
+)
+
+func (l IsolationLevel) String() string {
+	switch l {
+	case ReadUncommitted:
+		return "READ UNCOMMITTED"
+	case ReadCommitted:
+		return "READ COMMITTED"
+	case RepeatableRead:
+		return "REPEATABLE READ"
+	case Serializable:
+		return "SERIALIZABLE"
+	}
+	return ""
+}
+
+// Forbids checks if an anomaly must not occur at the isolation level
+func (l IsolationLevel) Forbids(anomaly string) bool {
+	switch anomaly {
+	case AnomalyDirtyRead:
+		return l >= ReadCommitted
+	case AnomalyLostUpdate:
+		return l >= RepeatableRead
+	case AnomalyWriteSkew:
+		return l >= Serializable
+	}
+	return false
+}
+
+// TxOp is a single operation of a transaction in a TransactionScript
+type TxOp struct {
+	// Tx is the number of the transaction (starting with 0)
+	Tx  int
+	Op  string
+	Key string
+	// Value to write, all written values of a script are unique (and not 0),
+	// so that every read value can be attributed to its write
+	Value int
+}
+
+func (o TxOp) String() string {
+	switch o.Op {
+	case TxRead:
+		return fmt.Sprintf("T%d: read(%s)", o.Tx, o.Key)
+	case TxWrite:
+		return fmt.Sprintf("T%d: write(%s, %d)", o.Tx, o.Key, o.Value)
+	}
+	return fmt.Sprintf("T%d: %s", o.Tx, o.Op)
+}
+
+// TxOpResult is the outcome of a TxOp executed by a storage engine
+type TxOpResult struct {
+	// Ok is false if the engine rejected the operation, which aborts the
+	// transaction (e.g. due to a conflict), the remaining operations of the
+	// transaction are ignored
+	Ok bool
+	// Value read by a read operation (0 for the initial value)
+	Value int
+}
+
+// TransactionScript is an interleaving of the operations of concurrent
+// transactions on a few keys (all of them initially 0). Each transaction ends
+// with a commit or an abort.
+type TransactionScript struct {
+	Keys []string
+	Ops  []TxOp
+}
+
+// Run executes all operations with "execute" (in the order of the script)
+// and returns their results. The operations of a transaction following a
+// rejected operation are skipped (with a result that is not Ok).
+func (s TransactionScript) Run(execute func(op TxOp) TxOpResult) []TxOpResult {
+	results := make([]TxOpResult, len(s.Ops))
+	rejected := map[int]bool{}
+	for i, op := range s.Ops {
+		if rejected[op.Tx] {
+			continue
+		}
+		results[i] = execute(op)
+		if !results[i].Ok {
+			rejected[op.Tx] = true
+		}
+	}
+	return results
+}
+
+// Check checks the results of the operations (see Run) for anomalies
+// forbidden by the isolation level and reads of values that have never been
+// written. The first violation is returned as error.
+func (s TransactionScript) Check(level IsolationLevel, results []TxOpResult) error {
+	if len(results) != len(s.Ops) {
+		return fmt.Errorf("Expected %d results, got %d", len(s.Ops), len(results))
+	}
+	writers := s.writers()
+	for i, op := range s.Ops {
+		if op.Op == TxRead && results[i].Ok && results[i].Value != 0 {
+			if writer, ok := writers[results[i].Value]; !ok || s.Ops[writer].Key != op.Key {
+				return fmt.Errorf("Step %d: %v returned %d, which has not been written", i, op, results[i].Value)
+			}
+		}
+	}
+	for _, anomaly := range s.Anomalies(results) {
+		if level.Forbids(anomaly) {
+			return fmt.Errorf("%s is forbidden by %v", anomaly, level)
+		}
+	}
+	return nil
+}
+
+// Anomalies detects the anomalies (AnomalyDirtyRead, AnomalyLostUpdate,
+// AnomalyWriteSkew) in the results of the operations
+func (s TransactionScript) Anomalies(results []TxOpResult) []string {
+	anomalies := map[string]bool{}
+	writers := s.writers()
+	commits := map[int]int{}
+	for i, op := range s.Ops {
+		if op.Op == TxCommit && i < len(results) && results[i].Ok {
+			commits[op.Tx] = i
+		}
+	}
+	committedBefore := func(tx, step int) bool {
+		commit, ok := commits[tx]
+		return ok && commit < step
+	}
+
+	for i, op := range s.Ops {
+		if op.Op != TxRead || i >= len(results) || !results[i].Ok {
+			continue
+		}
+		if writer, ok := writers[results[i].Value]; ok && s.Ops[writer].Tx != op.Tx && !committedBefore(s.Ops[writer].Tx, i) {
+			anomalies[AnomalyDirtyRead] = true
+		}
+	}
+
+	// effective reads and writes of the committed transactions
+	reads := map[int]map[string]int{}
+	writes := map[int]map[string]int{}
+	for i, op := range s.Ops {
+		if _, committed := commits[op.Tx]; !committed || i >= len(results) || !results[i].Ok {
+			continue
+		}
+		switch op.Op {
+		case TxRead:
+			if reads[op.Tx] == nil {
+				reads[op.Tx] = map[string]int{}
+			}
+			if _, ok := reads[op.Tx][op.Key]; !ok {
+				reads[op.Tx][op.Key] = i
+			}
+		case TxWrite:
+			if writes[op.Tx] == nil {
+				writes[op.Tx] = map[string]int{}
+			}
+			if _, ok := writes[op.Tx][op.Key]; !ok {
+				writes[op.Tx][op.Key] = i
+			}
+		}
+	}
+	// readStale checks if t1 has read a version of a key that precedes the
+	// write of t2 (i.e. the initial value or a value committed before t2)
+	readStale := func(t1, t2 int, key string) bool {
+		read, ok := reads[t1][key]
+		if _, written := writes[t2][key]; !ok || !written {
+			return false
+		}
+		writer, ok := writers[results[read].Value]
+		if !ok {
+			return true
+		}
+		commit, committed := commits[s.Ops[writer].Tx]
+		return s.Ops[writer].Tx != t2 && committed && commit < commits[t2]
+	}
+	for t1 := range commits {
+		for t2 := range commits {
+			if t1 == t2 {
+				continue
+			}
+			for key, write := range writes[t1] {
+				// t1 has overwritten the write of t2 based on a stale read
+				if readStale(t1, t2, key) && reads[t1][key] < write && commits[t2] < commits[t1] {
+					anomalies[AnomalyLostUpdate] = true
+				}
+			}
+			for x := range writes[t1] {
+				for y := range writes[t2] {
+					_, t1WritesY := writes[t1][y]
+					_, t2WritesX := writes[t2][x]
+					if x != y && !t1WritesY && !t2WritesX && readStale(t1, t2, y) && readStale(t2, t1, x) {
+						anomalies[AnomalyWriteSkew] = true
+					}
+				}
+			}
+		}
+	}
+	return sortedFeatures(anomalies)
+}
+
+// writers maps the written values to the index of their write
+func (s TransactionScript) writers() map[int]int {
+	writers := map[int]int{}
+	for i, op := range s.Ops {
+		if op.Op == TxWrite {
+			writers[op.Value] = i
+		}
+	}
+	return writers
+}
+
+// TransactionScripts generates interleavings of 2 or 3 concurrent
+// transactions with 1 to 4 reads and writes on up to 3 keys, each
+// transaction ends with a commit (or occasionally an abort).
+// The scripts shrink by removing transactions or single reads and writes.
+func TransactionScripts() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		keys := []string{"x", "y", "z"}[:2+genParams.Rng.Intn(2)]
+		transactions := make([][]TxOp, 2+genParams.Rng.Intn(2))
+		value := 0
+		for tx := range transactions {
+			count := 1 + genParams.Rng.Intn(4)
+			for i := 0; i < count; i++ {
+				op := TxOp{Tx: tx, Op: TxRead, Key: keys[genParams.Rng.Intn(len(keys))]}
+				if genParams.NextBool() {
+					value++
+					op.Op, op.Value = TxWrite, value
+				}
+				transactions[tx] = append(transactions[tx], op)
+			}
+			end := TxOp{Tx: tx, Op: TxCommit}
+			if genParams.Rng.Intn(5) == 0 {
+				end.Op = TxAbort
+			}
+			transactions[tx] = append(transactions[tx], end)
+		}
+
+		script := TransactionScript{Keys: keys}
+		for remaining := len(transactions); remaining > 0; {
+			tx := genParams.Rng.Intn(len(transactions))
+			if len(transactions[tx]) == 0 {
+				continue
+			}
+			script.Ops = append(script.Ops, transactions[tx][0])
+			transactions[tx] = transactions[tx][1:]
+			if len(transactions[tx]) == 0 {
+				remaining--
+			}
+		}
+		return gopter.NewGenResult(script, TransactionScriptShrinker)
+	}
+}
+
+// TransactionScriptShrinker shrinks a TransactionScript by removing
+// transactions and then single reads and writes
+func TransactionScriptShrinker(v interface{}) gopter.Shrink {
+	script := v.(TransactionScript)
+	var txs []int
+	seen := map[int]bool{}
+	for _, op := range script.Ops {
+		if !seen[op.Tx] {
+			seen[op.Tx] = true
+			txs = append(txs, op.Tx)
+		}
+	}
+	sort.Ints(txs)
+	var candidates []TransactionScript
+	without := func(keep func(int, TxOp) bool) TransactionScript {
+		shrunk := TransactionScript{Keys: script.Keys}
+		for i, op := range script.Ops {
+			if keep(i, op) {
+				shrunk.Ops = append(shrunk.Ops, op)
+			}
+		}
+		return shrunk
+	}
+	if len(txs) > 1 {
+		for _, tx := range txs {
+			candidates = append(candidates, without(func(_ int, op TxOp) bool {
+				return op.Tx != tx
+			}))
+		}
+	}
+	for i, op := range script.Ops {
+		if op.Op == TxRead || op.Op == TxWrite {
+			idx := i
+			candidates = append(candidates, without(func(j int, _ TxOp) bool {
+				return j != idx
+			}))
+		}
+	}
+	return func() (interface{}, bool) {
+		if len(candidates) == 0 {
+			return nil, false
+		}
+		candidate := candidates[0]
+		candidates = candidates[1:]
+		return candidate, true
+	}
+}
@@ -0,0 +1,181 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// dirtyEngine applies writes immediately without any isolation
+type dirtyEngine struct {
+	store map[string]int
+}
+
+func (e *dirtyEngine) execute(op gen.TxOp) gen.TxOpResult {
+	switch op.Op {
+	case gen.TxRead:
+		return gen.TxOpResult{Ok: true, Value: e.store[op.Key]}
+	case gen.TxWrite:
+		e.store[op.Key] = op.Value
+	}
+	return gen.TxOpResult{Ok: true}
+}
+
+// snapshotEngine implements snapshot isolation with first-committer-wins
+type snapshotEngine struct {
+	store     map[string]int
+	versions  map[string]int
+	version   int
+	snapshots map[int]int
+	local     map[int]map[string]int
+	reads     map[int]map[string]int
+}
+
+func newSnapshotEngine() *snapshotEngine {
+	return &snapshotEngine{
+		store:     map[string]int{},
+		versions:  map[string]int{},
+		snapshots: map[int]int{},
+		local:     map[int]map[string]int{},
+		reads:     map[int]map[string]int{},
+	}
+}
+
+func (e *snapshotEngine) execute(op gen.TxOp) gen.TxOpResult {
+	if _, ok := e.snapshots[op.Tx]; !ok {
+		e.snapshots[op.Tx] = e.version
+		e.local[op.Tx] = map[string]int{}
+		e.reads[op.Tx] = map[string]int{}
+		for key, value := range e.store {
+			e.reads[op.Tx][key] = value
+		}
+	}
+	switch op.Op {
+	case gen.TxRead:
+		if value, ok := e.local[op.Tx][op.Key]; ok {
+			return gen.TxOpResult{Ok: true, Value: value}
+		}
+		return gen.TxOpResult{Ok: true, Value: e.reads[op.Tx][op.Key]}
+	case gen.TxWrite:
+		e.local[op.Tx][op.Key] = op.Value
+	case gen.TxCommit:
+		for key := range e.local[op.Tx] {
+			if e.versions[key] > e.snapshots[op.Tx] {
+				return gen.TxOpResult{Ok: false}
+			}
+		}
+		e.version++
+		for key, value := range e.local[op.Tx] {
+			e.store[key] = value
+			e.versions[key] = e.version
+		}
+	}
+	return gen.TxOpResult{Ok: true}
+}
+
+func TestTransactionScripts(t *testing.T) {
+	commonGeneratorTest(t, "transaction script", gen.TransactionScripts(), func(value interface{}) bool {
+		script, ok := value.(gen.TransactionScript)
+		if !ok || len(script.Ops) == 0 {
+			return false
+		}
+		ended := map[int]bool{}
+		for _, op := range script.Ops {
+			if ended[op.Tx] {
+				return false
+			}
+			ended[op.Tx] = op.Op == gen.TxCommit || op.Op == gen.TxAbort
+		}
+		for _, end := range ended {
+			if !end {
+				return false
+			}
+		}
+		results := script.Run(newSnapshotEngine().execute)
+		return script.Check(gen.RepeatableRead, results) == nil &&
+			script.Check(gen.ReadUncommitted, script.Run((&dirtyEngine{store: map[string]int{}}).execute)) == nil
+	})
+}
+
+func TestTransactionScriptAnomalies(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	parameters.MinSuccessfulTests = 1000
+
+	for _, check := range []struct {
+		name    string
+		engine  func() func(gen.TxOp) gen.TxOpResult
+		level   gen.IsolationLevel
+		anomaly string
+	}{
+		{"dirty engine", func() func(gen.TxOp) gen.TxOpResult {
+			return (&dirtyEngine{store: map[string]int{}}).execute
+		}, gen.ReadCommitted, gen.AnomalyDirtyRead},
+		{"snapshot engine", func() func(gen.TxOp) gen.TxOpResult {
+			return newSnapshotEngine().execute
+		}, gen.Serializable, gen.AnomalyWriteSkew},
+	} {
+		result := prop.ForAll(
+			func(script gen.TransactionScript) error {
+				return script.Check(check.level, script.Run(check.engine()))
+			},
+			gen.TransactionScripts(),
+		).Check(parameters)
+		if result.Status != gopter.TestFailed {
+			t.Errorf("%s: %v not detected: %#v", check.name, check.anomaly, result)
+			continue
+		}
+		script := result.Args[0].Arg.(gen.TransactionScript)
+		anomalies := script.Anomalies(script.Run(check.engine()))
+		if len(anomalies) != 1 || anomalies[0] != check.anomaly {
+			t.Errorf("%s: invalid anomalies %v of %v", check.name, anomalies, script.Ops)
+		}
+	}
+}
+
+func TestTransactionScriptLostUpdate(t *testing.T) {
+	script := gen.TransactionScript{
+		Keys: []string{"x"},
+		Ops: []gen.TxOp{
+			{Tx: 0, Op: gen.TxRead, Key: "x"},
+			{Tx: 1, Op: gen.TxRead, Key: "x"},
+			{Tx: 1, Op: gen.TxWrite, Key: "x", Value: 1},
+			{Tx: 1, Op: gen.TxCommit},
+			{Tx: 0, Op: gen.TxWrite, Key: "x", Value: 2},
+			{Tx: 0, Op: gen.TxCommit},
+		},
+	}
+	// read committed without conflict detection
+	store := map[string]int{}
+	local := map[int]map[string]int{0: {}, 1: {}}
+	results := script.Run(func(op gen.TxOp) gen.TxOpResult {
+		switch op.Op {
+		case gen.TxRead:
+			return gen.TxOpResult{Ok: true, Value: store[op.Key]}
+		case gen.TxWrite:
+			local[op.Tx][op.Key] = op.Value
+		case gen.TxCommit:
+			for key, value := range local[op.Tx] {
+				store[key] = value
+			}
+		}
+		return gen.TxOpResult{Ok: true}
+	})
+	if anomalies := script.Anomalies(results); len(anomalies) != 1 || anomalies[0] != gen.AnomalyLostUpdate {
+		t.Errorf("Invalid anomalies: %v", anomalies)
+	}
+	if err := script.Check(gen.ReadCommitted, results); err != nil {
+		t.Errorf("Invalid check: %v", err)
+	}
+	if err := script.Check(gen.RepeatableRead, results); err == nil || err.Error() != "lost update is forbidden by REPEATABLE READ" {
+		t.Errorf("Invalid check: %v", err)
+	}
+	// the snapshot engine rejects the commit of the second writer
+	if results := script.Run(newSnapshotEngine().execute); results[5].Ok || script.Check(gen.Serializable, results) != nil {
+		t.Errorf("Invalid snapshot results: %v", results)
+	}
+	if err := script.Check(gen.ReadUncommitted, []gen.TxOpResult{{Ok: true, Value: 3}, {}, {}, {}, {}, {}}); err == nil {
+		t.Error("Read of unknown value not detected")
+	}
+}
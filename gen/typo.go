@@ -0,0 +1,118 @@
+package gen
+
+import (
+	"reflect"
+	"unicode"
+
+	"github.com/leanovate/gopter"
+)
+
+// Kinds of edits of TypoOf
+const (
+	TypoTransposition = "transposition"
+	TypoSubstitution  = "substitution"
+	TypoDeletion      = "deletion"
+	TypoDuplication   = "duplication"
+)
+
+var typoKinds = []string{TypoTransposition, TypoSubstitution, TypoDeletion, TypoDuplication}
+
+// qwertyRows is the layout used to find adjacent keys
+var qwertyRows = []string{"1234567890-=", "qwertyuiop[]", "asdfghjkl;'", "zxcvbnm,./"}
+
+// adjacentKeys maps each key of a QWERTY keyboard to its neighbours
+var adjacentKeys = func() map[rune][]rune {
+	result := map[rune][]rune{}
+	for row, keys := range qwertyRows {
+		for col, key := range keys {
+			for _, neighbourRow := range []int{row - 1, row, row + 1} {
+				if neighbourRow < 0 || neighbourRow >= len(qwertyRows) {
+					continue
+				}
+				neighbours := []rune(qwertyRows[neighbourRow])
+				for _, neighbourCol := range []int{col - 1, col, col + 1} {
+					if neighbourCol < 0 || neighbourCol >= len(neighbours) ||
+						(neighbourRow == row && neighbourCol == col) {
+						continue
+					}
+					result[key] = append(result[key], neighbours[neighbourCol])
+				}
+			}
+		}
+	}
+	return result
+}()
+
+// TypoOf generates realistic typos of a given string: One to three edits
+// (transposition of adjacent characters, substitution by an adjacent key of a
+// QWERTY keyboard, deletion or duplication of a character).
+// The kinds of edits are added as labels, the generated string is always
+// different from the original (i.e. the generator fails for empty strings).
+func TypoOf(str string) gopter.Gen {
+	original := []rune(str)
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		// edits might cancel each other out (e.g. a transposition of "aa")
+		typo, labels := genTypo(genParams, original)
+		for attempt := 0; attempt < 10 && typo == str && str != ""; attempt++ {
+			typo, labels = genTypo(genParams, original)
+		}
+		genResult := gopter.NewGenResult(typo, gopter.NoShrinker)
+		genResult.Labels = labels
+		genResult.Sieve = func(v interface{}) bool {
+			return v.(string) != str
+		}
+		return genResult
+	}
+}
+
+func genTypo(genParams *gopter.GenParameters, original []rune) (string, []string) {
+	runes := append([]rune{}, original...)
+	edits := 1 + genParams.Rng.Intn(3)
+	if genParams.Rng.Intn(2) == 0 {
+		edits = 1
+	}
+	labels := make([]string, 0, edits)
+	for i := 0; i < edits && len(runes) > 0; i++ {
+		kind := typoKinds[genParams.Rng.Intn(len(typoKinds))]
+		if kind == TypoTransposition && len(runes) < 2 {
+			kind = TypoDuplication
+		}
+		pos := genParams.Rng.Intn(len(runes))
+		switch kind {
+		case TypoTransposition:
+			if pos == len(runes)-1 {
+				pos--
+			}
+			runes[pos], runes[pos+1] = runes[pos+1], runes[pos]
+		case TypoSubstitution:
+			runes[pos] = adjacentKey(genParams, runes[pos])
+		case TypoDeletion:
+			runes = append(runes[:pos:pos], runes[pos+1:]...)
+		case TypoDuplication:
+			runes = append(runes[:pos+1:pos+1], runes[pos:]...)
+		}
+		labels = append(labels, kind)
+	}
+	return string(runes), labels
+}
+
+// TypoOfGen generates typos (see TypoOf) of strings generated by strGen
+func TypoOfGen(strGen gopter.Gen) gopter.Gen {
+	return strGen.FlatMap(func(v interface{}) gopter.Gen {
+		return TypoOf(v.(string))
+	}, reflect.TypeOf(""))
+}
+
+// adjacentKey picks a neighbour of a key (keeping upper case), characters
+// without neighbours are replaced by a random letter
+func adjacentKey(genParams *gopter.GenParameters, r rune) rune {
+	neighbours := adjacentKeys[unicode.ToLower(r)]
+	if len(neighbours) == 0 {
+		return rune('a' + genParams.Rng.Intn(26))
+	}
+	neighbour := neighbours[genParams.Rng.Intn(len(neighbours))]
+	if unicode.IsUpper(r) {
+		return unicode.ToUpper(neighbour)
+	}
+	return neighbour
+}
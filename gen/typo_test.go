@@ -0,0 +1,74 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// editDistance is the Damerau-Levenshtein distance (optimal string alignment)
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func TestTypoOf(t *testing.T) {
+	for _, str := range []string{"hello", "Gopher", "a", "property based testing"} {
+		commonGeneratorTest(t, "typo of "+str, gen.TypoOf(str), func(value interface{}) bool {
+			typo, ok := value.(string)
+			distance := editDistance(str, typo)
+			return ok && typo != str && distance >= 1 && distance <= 6
+		})
+	}
+
+	commonGeneratorTest(t, "typo of generated", gen.TypoOfGen(gen.Identifier()), func(value interface{}) bool {
+		_, ok := value.(string)
+		return ok
+	})
+
+	if value, ok := gen.TypoOf("").Sample(); ok {
+		t.Errorf("Typo of empty string should fail: %#v", value)
+	}
+
+	labels := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		result := gen.TypoOf("keyboard")(gopter.DefaultGenParameters())
+		for _, label := range result.Labels {
+			labels[label] = true
+		}
+	}
+	if len(labels) != 4 {
+		t.Errorf("Not all kinds of typos generated: %#v", labels)
+	}
+}
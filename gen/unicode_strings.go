@@ -0,0 +1,183 @@
+package gen
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/leanovate/gopter"
+)
+
+const (
+	zeroWidthJoiner   = '\u200D'
+	variationSelector = '\uFE0F'
+	combiningKeycap   = '\u20E3'
+)
+
+// emojiRanges are the blocks of the common emoji (only the assigned symbols
+// of the blocks are used)
+var emojiRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F300, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6FF, Stride: 1},
+		{Lo: 0x1F90C, Hi: 0x1F9FF, Stride: 1},
+	},
+}
+
+// skinToneBases are emoji of people and hands that support skin tone
+// modifiers
+var skinToneBases = []rune{0x1F44B, 0x1F44C, 0x1F44D, 0x1F44E, 0x1F44F, 0x1F466, 0x1F467, 0x1F468, 0x1F469, 0x1F64B, 0x1F9D1}
+
+// AnyPrintableString generates strings of printable characters of all
+// scripts, i.e. letters, marks, numbers, punctuation and symbols (see
+// unicode.PrintRanges).
+func AnyPrintableString() gopter.Gen {
+	return UnicodeString(unicode.PrintRanges...)
+}
+
+// RTLString generates strings of letters of right-to-left scripts (Arabic,
+// Hebrew, Syriac, Thaana and N'Ko).
+func RTLString() gopter.Gen {
+	return UnicodeString(unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko)
+}
+
+// CombiningMarkString generates strings of latin letters and digits, each
+// followed by up to 3 combining marks (e.g. "a\u0301\u0327"), so that the
+// number of runes differs from the number of characters as perceived by a
+// user.
+// The strings shrink by removing characters together with their marks.
+func CombiningMarkString() gopter.Gen {
+	bases := AlphaNumChar()
+	marks := UnicodeChar(unicode.Mn)
+	return clusterString(func(genParams *gopter.GenParameters, features map[string]bool) string {
+		base, _ := bases(genParams).Retrieve()
+		cluster := []rune{base.(rune)}
+		count := genParams.Rng.Intn(4)
+		for i := 0; i < count; i++ {
+			mark, _ := marks(genParams).Retrieve()
+			cluster = append(cluster, mark.(rune))
+		}
+		return string(cluster)
+	}, combiningMarkClusters)
+}
+
+// EmojiString generates strings of emoji including sequences of multiple
+// runes: variation selectors, skin tone modifiers, zero width joiner
+// sequences (e.g. family emoji), flags and keycaps.
+// The used sequences ("variation selector", "skin tone", "ZWJ sequence",
+// "flag", "keycap") are added as labels.
+// The strings shrink by removing whole emoji (sequences).
+func EmojiString() gopter.Gen {
+	emoji := UnicodeChar(emojiRanges)
+	drawEmoji := func(genParams *gopter.GenParameters) rune {
+		for {
+			if value, _ := emoji(genParams).Retrieve(); unicode.Is(unicode.So, value.(rune)) {
+				return value.(rune)
+			}
+		}
+	}
+	return clusterString(func(genParams *gopter.GenParameters, features map[string]bool) string {
+		switch genParams.Rng.Intn(6) {
+		case 1:
+			features["variation selector"] = true
+			return string([]rune{drawEmoji(genParams), variationSelector})
+		case 2:
+			features["skin tone"] = true
+			base := skinToneBases[genParams.Rng.Intn(len(skinToneBases))]
+			return string([]rune{base, rune(0x1F3FB + genParams.Rng.Intn(5))})
+		case 3:
+			features["ZWJ sequence"] = true
+			sequence := []rune{drawEmoji(genParams)}
+			count := 1 + genParams.Rng.Intn(3)
+			for i := 0; i < count; i++ {
+				sequence = append(sequence, zeroWidthJoiner, drawEmoji(genParams))
+			}
+			return string(sequence)
+		case 4:
+			features["flag"] = true
+			return string([]rune{rune(0x1F1E6 + genParams.Rng.Intn(26)), rune(0x1F1E6 + genParams.Rng.Intn(26))})
+		case 5:
+			features["keycap"] = true
+			keys := "0123456789#*"
+			return string([]rune{rune(keys[genParams.Rng.Intn(len(keys))]), variationSelector, combiningKeycap})
+		}
+		return string(drawEmoji(genParams))
+	}, emojiClusters)
+}
+
+// clusterString generates strings of up to MaxSize clusters of runes (i.e.
+// characters as perceived by a user) created by "cluster".
+// The strings shrink by removing whole clusters (as determined by "split").
+func clusterString(cluster func(genParams *gopter.GenParameters, features map[string]bool) string,
+	split func(string) []string) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		length := genParams.Rng.Intn(genParams.MaxSize + 1)
+		features := map[string]bool{}
+		var builder strings.Builder
+		for i := 0; i < length; i++ {
+			builder.WriteString(cluster(genParams, features))
+		}
+		genResult := gopter.NewGenResult(builder.String(), clusterShrinker(split))
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+// clusterShrinker shrinks strings by removing clusters of runes
+func clusterShrinker(split func(string) []string) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		return SliceShrinker(gopter.NoShrinker)(split(v.(string))).Map(func(clusters []string) string {
+			return strings.Join(clusters, "")
+		})
+	}
+}
+
+// combiningMarkClusters splits a string before every rune that is not a
+// combining mark
+func combiningMarkClusters(s string) []string {
+	var clusters []string
+	start := 0
+	for i, ch := range s {
+		if i > start && !unicode.Is(unicode.Mn, ch) {
+			clusters = append(clusters, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// emojiClusters splits a string into emoji (sequences)
+func emojiClusters(s string) []string {
+	var clusters [][]rune
+	for _, ch := range s {
+		last := len(clusters) - 1
+		switch {
+		case last < 0:
+		case ch == zeroWidthJoiner || ch == variationSelector || ch == combiningKeycap || (ch >= 0x1F3FB && ch <= 0x1F3FF):
+			clusters[last] = append(clusters[last], ch)
+			continue
+		case clusters[last][len(clusters[last])-1] == zeroWidthJoiner:
+			clusters[last] = append(clusters[last], ch)
+			continue
+		case isRegionalIndicator(ch) && len(clusters[last]) == 1 && isRegionalIndicator(clusters[last][0]):
+			clusters[last] = append(clusters[last], ch)
+			continue
+		}
+		clusters = append(clusters, []rune{ch})
+	}
+	result := make([]string, len(clusters))
+	for i, cluster := range clusters {
+		result[i] = string(cluster)
+	}
+	return result
+}
+
+func isRegionalIndicator(ch rune) bool {
+	return ch >= 0x1F1E6 && ch <= 0x1F1FF
+}
@@ -0,0 +1,121 @@
+package gen_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestAnyPrintableString(t *testing.T) {
+	commonGeneratorTest(t, "printable string", gen.AnyPrintableString(), func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok || !utf8.ValidString(str) {
+			return false
+		}
+		for _, ch := range str {
+			if !unicode.IsPrint(ch) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestRTLString(t *testing.T) {
+	commonGeneratorTest(t, "rtl string", gen.RTLString(), func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		for _, ch := range str {
+			if !unicode.In(ch, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestCombiningMarkString(t *testing.T) {
+	commonGeneratorTest(t, "combining mark string", gen.CombiningMarkString(), func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok || !utf8.ValidString(str) {
+			return false
+		}
+		for i, ch := range str {
+			if i == 0 && unicode.Is(unicode.Mn, ch) {
+				return false
+			}
+			if !unicode.Is(unicode.Mn, ch) && !unicode.IsLetter(ch) && !unicode.IsDigit(ch) {
+				return false
+			}
+		}
+		return true
+	})
+
+	// a rune based truncation breaks the marks of a character
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(s string) bool {
+		runes := []rune(s)
+		if len(runes) > 3 {
+			runes = runes[:3]
+		}
+		truncated := string(runes)
+		return truncated == s || !unicode.Is(unicode.Mn, []rune(s)[len(runes)])
+	}, gen.CombiningMarkString()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	shrunk := []rune(result.Args[0].Arg.(string))
+	if len(shrunk) != 4 || unicode.Is(unicode.Mn, shrunk[0]) || !unicode.Is(unicode.Mn, shrunk[3]) {
+		t.Errorf("Invalid shrunk value: %q", string(shrunk))
+	}
+}
+
+func TestEmojiString(t *testing.T) {
+	commonGeneratorTest(t, "emoji string", gen.EmojiString(), func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok || !utf8.ValidString(str) {
+			return false
+		}
+		for _, ch := range str {
+			if ch < 0x2600 && !strings.ContainsRune("0123456789#*\u200D\uFE0F\u20E3", ch) {
+				return false
+			}
+		}
+		return true
+	})
+
+	labels := map[string]bool{}
+	genParams := gopter.DefaultGenParameters()
+	for i := 0; i < 20; i++ {
+		for _, label := range gen.EmojiString()(genParams).Labels {
+			labels[label] = true
+		}
+	}
+	for _, label := range []string{"variation selector", "skin tone", "ZWJ sequence", "flag", "keycap"} {
+		if !labels[label] {
+			t.Errorf("Label %s not generated", label)
+		}
+	}
+
+	// shrinks to a single ZWJ sequence
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(s string) bool {
+		return !strings.ContainsRune(s, '\u200D')
+	}, gen.EmojiString()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	shrunk := []rune(result.Args[0].Arg.(string))
+	for i, ch := range shrunk {
+		if (i%2 == 1) != (ch == '\u200D') || (i%2 == 0 && ch < 0x2600) {
+			t.Errorf("Invalid shrunk value: %q", string(shrunk))
+		}
+	}
+}
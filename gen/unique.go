@@ -0,0 +1,57 @@
+package gen
+
+import "github.com/leanovate/gopter"
+
+// uniqueAttempts is the number of attempts to generate a value that has not
+// been taken from a pool, before the pool is considered to be exhausted
+const uniqueAttempts = 100
+
+// Unique generates values of a generator that are unique within a property
+// check, i.e. no value is repeated in the named pool (which might be shared by
+// multiple generators, e.g. Username and EmailAddress for "users").
+// If the pool is exhausted (no new value has been generated in 100 attempts)
+// it starts over.
+// Outside of a property check (e.g. Gen.Sample) there are no pools and values
+// might be repeated.
+// The values shrink like the values of the generator, but only to values that
+// have not been taken from the pool.
+func Unique(pool string, gen gopter.Gen) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		result := gen(genParams)
+		uniquePools := genParams.UniquePools
+		if uniquePools == nil {
+			return result
+		}
+		for attempts := 1; ; attempts++ {
+			value, ok := result.Retrieve()
+			if !ok {
+				return result
+			}
+			if uniquePools.Take(pool, value) {
+				break
+			}
+			if attempts == uniqueAttempts {
+				uniquePools.Reset(pool)
+				uniquePools.Take(pool, value)
+				break
+			}
+			result = gen(genParams)
+		}
+
+		// shrunk values are taken as well, so that the values of multiple
+		// arguments stay distinct
+		take := func(v interface{}) bool {
+			return uniquePools.Take(pool, v)
+		}
+		shrinker := result.Shrinker
+		result.Shrinker = func(v interface{}) gopter.Shrink {
+			return shrinker(v).Filter(take)
+		}
+		if tree := result.Tree; tree != nil {
+			result.Tree = func() *gopter.ShrinkTree {
+				return tree().Filter(take)
+			}
+		}
+		return result
+	}
+}
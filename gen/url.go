@@ -0,0 +1,183 @@
+package gen
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// URLOptions restricts the components of the URLs generated by
+// URLWithOptions
+type URLOptions struct {
+	// Schemes are the possible schemes (if empty "http" and "https")
+	Schemes []string
+	// Hosts are the possible hosts (if empty hostnames, IPv4 and IPv6
+	// addresses are generated)
+	Hosts []string
+	// NoUserInfo, NoPort, NoPath, NoQuery and NoFragment disable the
+	// respective components
+	NoUserInfo, NoPort, NoPath, NoQuery, NoFragment bool
+}
+
+// urlSegmentChars are some of the interesting characters of path segments,
+// query parameters and fragments (that are escaped if necessary)
+var urlSegmentChars = []rune("abcxyzABCXYZ0189-._~!$&'()*+,;=:@ %/?#[]äßé日本")
+
+// URL generates absolute URLs (as string) with all components: http and https
+// schemes, user info, hostnames, IPv4 and IPv6 hosts, ports, paths with
+// escaped, unicode, empty and dot segments, queries with repeated and empty
+// parameters and fragments.
+// The used components are added as labels.
+// The URLs shrink by removing single components.
+func URL() gopter.Gen {
+	return URLWithOptions(URLOptions{})
+}
+
+// URLWithOptions generates URLs like URL restricted by the options
+func URLWithOptions(options URLOptions) gopter.Gen {
+	schemes := options.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"http", "https"}
+	}
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		features := map[string]bool{}
+		u := url.URL{Scheme: schemes[genParams.Rng.Intn(len(schemes))]}
+		if !options.NoUserInfo && genParams.Rng.Intn(5) == 0 {
+			features["user info"] = true
+			user := genURLSegment(genParams, 8)
+			if genParams.NextBool() {
+				u.User = url.UserPassword(user, genURLSegment(genParams, 8))
+			} else {
+				u.User = url.User(user)
+			}
+		}
+		u.Host = genURLHost(genParams, options.Hosts, features)
+		if !options.NoPort && genParams.Rng.Intn(3) == 0 {
+			features["port"] = true
+			port := hostPortEdgePorts[genParams.Rng.Intn(len(hostPortEdgePorts))]
+			u.Host = net.JoinHostPort(strings.Trim(u.Host, "[]"), strconv.Itoa(port))
+		}
+		if !options.NoPath {
+			segments := make([]string, genParams.Rng.Intn(5))
+			for i := range segments {
+				switch genParams.Rng.Intn(8) {
+				case 0:
+					features["dot segment"] = true
+					segments[i] = []string{".", ".."}[genParams.Rng.Intn(2)]
+				case 1:
+					features["empty segment"] = true
+				default:
+					segments[i] = genURLSegment(genParams, 12)
+				}
+			}
+			if len(segments) > 0 || genParams.NextBool() {
+				u.Path = "/" + strings.Join(segments, "/")
+			}
+		}
+		if !options.NoQuery && genParams.NextBool() {
+			features["query"] = true
+			params := make([]string, 1+genParams.Rng.Intn(4))
+			for i := range params {
+				if i > 0 && genParams.Rng.Intn(4) == 0 {
+					features["repeated parameter"] = true
+					params[i] = params[i-1]
+					continue
+				}
+				params[i] = url.QueryEscape(genURLSegment(genParams, 8)) + "=" + url.QueryEscape(genURLSegment(genParams, 12))
+			}
+			u.RawQuery = strings.Join(params, "&")
+		}
+		if !options.NoFragment && genParams.Rng.Intn(4) == 0 {
+			features["fragment"] = true
+			u.Fragment = genURLSegment(genParams, 12)
+		}
+
+		genResult := gopter.NewGenResult(u.String(), URLShrinker)
+		genResult.Labels = sortedFeatures(features)
+		return genResult
+	}
+}
+
+func genURLHost(genParams *gopter.GenParameters, hosts []string, features map[string]bool) string {
+	if len(hosts) > 0 {
+		return hosts[genParams.Rng.Intn(len(hosts))]
+	}
+	switch genParams.Rng.Intn(6) {
+	case 0:
+		features["IPv4 host"] = true
+		ip, _ := randomIP(genParams, net.IPv4len, ipv4Categories)
+		return ip.String()
+	case 1:
+		features["IPv6 host"] = true
+		ip, _ := randomIP(genParams, net.IPv6len, ipv6Categories)
+		if ip.To4() != nil {
+			// net.IP formats IPv4-mapped addresses as IPv4
+			return "[::ffff:" + ip.To4().String() + "]"
+		}
+		return "[" + ip.String() + "]"
+	case 2:
+		return "localhost"
+	}
+	features["hostname"] = true
+	return genDNS1123Subdomain(genParams)
+}
+
+func genURLSegment(genParams *gopter.GenParameters, maxLength int) string {
+	segment := make([]rune, 1+genParams.Rng.Intn(maxLength))
+	for i := range segment {
+		segment[i] = urlSegmentChars[genParams.Rng.Intn(len(urlSegmentChars))]
+	}
+	return string(segment)
+}
+
+// URLShrinker shrinks a URL by removing its fragment, query (parameters),
+// user info, port and path segments
+func URLShrinker(v interface{}) gopter.Shrink {
+	u, err := url.Parse(v.(string))
+	if err != nil {
+		return gopter.NoShrink
+	}
+	var candidates []interface{}
+	shrunk := func(modify func(*url.URL)) {
+		copied := *u
+		modify(&copied)
+		candidates = append(candidates, copied.String())
+	}
+	if u.Fragment != "" {
+		shrunk(func(c *url.URL) {
+			c.Fragment, c.RawFragment = "", ""
+		})
+	}
+	if u.RawQuery != "" {
+		shrunk(func(c *url.URL) {
+			c.RawQuery = ""
+		})
+		if params := strings.Split(u.RawQuery, "&"); len(params) > 1 {
+			for i := range params {
+				remaining := append(append([]string{}, params[:i]...), params[i+1:]...)
+				shrunk(func(c *url.URL) {
+					c.RawQuery = strings.Join(remaining, "&")
+				})
+			}
+		}
+	}
+	if u.User != nil {
+		shrunk(func(c *url.URL) {
+			c.User = nil
+		})
+	}
+	if u.Port() != "" {
+		shrunk(func(c *url.URL) {
+			c.Host = strings.TrimSuffix(c.Host, ":"+c.Port())
+		})
+	}
+	if u.Path != "" {
+		shrunk(func(c *url.URL) {
+			c.Path, c.RawPath = u.Path[:strings.LastIndex(u.Path, "/")], ""
+		})
+	}
+	return valuesShrink(candidates)
+}
@@ -0,0 +1,43 @@
+package gen_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestURL(t *testing.T) {
+	commonGeneratorTest(t, "URL", gen.URL(), func(value interface{}) bool {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		u, err := url.Parse(str)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != "" && u.String() == str
+	})
+
+	options := gen.URLOptions{Schemes: []string{"ftp"}, Hosts: []string{"example.org"}, NoPath: true, NoQuery: true}
+	commonGeneratorTest(t, "URL with options", gen.URLWithOptions(options), func(value interface{}) bool {
+		u, err := url.Parse(value.(string))
+		return err == nil && u.Scheme == "ftp" && u.Hostname() == "example.org" && u.Path == "" && u.RawQuery == ""
+	})
+}
+
+func TestURLShrinker(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(str string) bool {
+		u, _ := url.Parse(str)
+		return !strings.Contains(u.RawQuery, "&")
+	}, gen.URL()).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	u, _ := url.Parse(result.Args[0].Arg.(string))
+	if strings.Count(u.RawQuery, "&") != 1 || u.Fragment != "" || u.User != nil || u.Port() != "" || u.Path != "" {
+		t.Errorf("Invalid shrunk URL: %v", u)
+	}
+}
@@ -0,0 +1,89 @@
+package gen
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/leanovate/gopter"
+)
+
+// Maximum lengths of usernames (like the limit of useradd) and slugs
+const (
+	UsernameMaxLength = 32
+	SlugMaxLength     = 64
+)
+
+var (
+	usernameRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*([._-][a-z0-9]+)*$`)
+	slugRegexp     = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+var (
+	usernameFirstNames = []string{"alice", "bob", "carol", "dave", "eve", "frank", "grace", "heidi", "ivan", "judy", "li", "mallory", "oscar", "peggy", "trent", "walter"}
+	usernameLastNames  = []string{"smith", "jones", "garcia", "nguyen", "schmidt", "rossi", "tanaka", "kowalski", "oconnor", "ng", "silva", "ivanova"}
+	slugWords          = []string{"a", "the", "how", "to", "go", "getting", "started", "with", "release", "notes", "faq", "hello", "world", "api", "v2", "2024", "new", "blog", "post", "guide"}
+)
+
+const (
+	usernameChars      = "abcdefghijklmnopqrstuvwxyz0123456789"
+	usernameSeparators = "._-"
+)
+
+func validUsername(str string) bool {
+	return len(str) <= UsernameMaxLength && usernameRegexp.MatchString(str)
+}
+
+func validSlug(str string) bool {
+	return len(str) <= SlugMaxLength && slugRegexp.MatchString(str)
+}
+
+// Username generates realistic usernames (as string): Lower case names like
+// "alice", "bob.smith", "csilva" or "eve_42" and random ones up to the maximum
+// length of 32 characters. A username starts with a letter and consists of
+// letters and digits separated by single ".", "_" or "-".
+// Use Unique to generate distinct usernames.
+// The usernames shrink like strings (as long as they stay valid).
+func Username() gopter.Gen {
+	return validStringGen(func(genParams *gopter.GenParameters) string {
+		first := usernameFirstNames[genParams.Rng.Intn(len(usernameFirstNames))]
+		last := usernameLastNames[genParams.Rng.Intn(len(usernameLastNames))]
+		separator := string(usernameSeparators[genParams.Rng.Intn(len(usernameSeparators))])
+		switch genParams.Rng.Intn(6) {
+		case 0:
+			return first
+		case 1:
+			return first + separator + last
+		case 2:
+			return first[:1] + last
+		case 3:
+			return first + strconv.Itoa(genParams.Rng.Intn(10000))
+		case 4:
+			return first + separator + last + strconv.Itoa(genParams.Rng.Intn(100))
+		}
+		length := nameLength(genParams, UsernameMaxLength)
+		return genFromChars(genParams, 1, "abcdefghijklmnopqrstuvwxyz", "") + genFromChars(genParams, length-1, usernameChars, usernameChars)
+	}, validUsername)
+}
+
+// Slug generates URL slugs (as string), i.e. lower case words and numbers
+// separated by "-" like "getting-started-with-go" up to the maximum length of
+// 64 characters.
+// Use Unique to generate distinct slugs.
+// The slugs shrink like strings (as long as they stay valid).
+func Slug() gopter.Gen {
+	return validStringGen(func(genParams *gopter.GenParameters) string {
+		if genParams.Rng.Intn(10) == 0 {
+			// boundary length
+			return genFromChars(genParams, SlugMaxLength, dns1123EdgeChars, dns1123EdgeChars)
+		}
+		words := make([]string, 1+genParams.Rng.Intn(5))
+		for i := range words {
+			words[i] = slugWords[genParams.Rng.Intn(len(slugWords))]
+		}
+		if genParams.Rng.Intn(4) == 0 {
+			words = append(words, strconv.Itoa(1+genParams.Rng.Intn(999)))
+		}
+		return strings.Join(words, "-")
+	}, validSlug)
+}
@@ -0,0 +1,81 @@
+package gen_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+var (
+	testUsername = regexp.MustCompile(`^[a-z][a-z0-9]*([._-][a-z0-9]+)*$`)
+	testSlug     = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+func TestUsername(t *testing.T) {
+	commonGeneratorTest(t, "username", gen.Username(), func(value interface{}) bool {
+		username, ok := value.(string)
+		return ok && len(username) <= 32 && testUsername.MatchString(username)
+	})
+}
+
+func TestSlug(t *testing.T) {
+	commonGeneratorTest(t, "slug", gen.Slug(), func(value interface{}) bool {
+		slug, ok := value.(string)
+		return ok && len(slug) <= 64 && testSlug.MatchString(slug)
+	})
+}
+
+func TestUnique(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	seen := map[string]bool{}
+	result := prop.ForAll(func(users []string) bool {
+		for _, user := range users {
+			if seen[user] {
+				return false
+			}
+			seen[user] = true
+		}
+		return true
+	}, gen.SliceOfN(5, gen.Unique("users", gen.Username()))).Check(parameters)
+	if !result.Passed() || len(seen) != 5*parameters.MinSuccessfulTests {
+		t.Errorf("Invalid result: %d %#v", len(seen), result)
+	}
+
+	// the pool of three values starts over once it is exhausted
+	result = prop.ForAll(func(values []int) bool {
+		return values[0] != values[1] && values[1] != values[2] && values[0] != values[2]
+	}, gen.SliceOfN(3, gen.Unique("small", gen.IntRange(0, 2)))).Check(parameters)
+	if result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	var values []int
+	result = prop.ForAll(func(value int) bool {
+		values = append(values, value)
+		return true
+	}, gen.Unique("small", gen.IntRange(0, 2))).Check(parameters)
+	if !result.Passed() || len(values) < 3 || values[0] == values[1] || values[1] == values[2] || values[0] == values[2] {
+		t.Errorf("Invalid result: %#v %#v", values, result)
+	}
+
+	// shrinking keeps the usernames distinct
+	result = prop.ForAll(func(users []string) bool {
+		return false
+	}, gen.SliceOfN(3, gen.Unique("users", gen.Username()))).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	users := result.Args[0].Arg.([]string)
+	if users[0] == users[1] || users[1] == users[2] || users[0] == users[2] {
+		t.Errorf("Invalid shrunk usernames: %#v", users)
+	}
+
+	// there are no pools outside of property checks
+	for i := 0; i < 10; i++ {
+		if value, ok := gen.Unique("small", gen.Const(1)).Sample(); !ok || value != 1 {
+			t.Errorf("Invalid value: %#v", value)
+		}
+	}
+}
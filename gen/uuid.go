@@ -0,0 +1,130 @@
+package gen
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/leanovate/gopter"
+)
+
+// crockfordBase32 is the alphabet of ULIDs
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// uuidLayout marks the variable bits of an identifier, all other bits have
+// the value of fixed
+type uuidLayout struct {
+	variable [16]byte
+	fixed    [16]byte
+}
+
+var (
+	uuidV4Layout = uuidLayout{
+		variable: [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x0f, 0xff, 0x3f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		fixed:    [16]byte{6: 0x40, 8: 0x80},
+	}
+	uuidV7Layout = uuidLayout{
+		variable: uuidV4Layout.variable,
+		fixed:    [16]byte{6: 0x70, 8: 0x80},
+	}
+	ulidLayout = uuidLayout{
+		variable: [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+)
+
+// UUIDv4 generates random (version 4) UUIDs in their canonical string form
+// (e.g. "f47ac10b-58cc-4372-a567-0e02b2c3d479").
+// The UUIDs shrink towards the nil UUID (keeping the version and variant).
+func UUIDv4() gopter.Gen {
+	return UUIDv4Bytes().Map(formatUUID)
+}
+
+// UUIDv4Bytes generates random (version 4) UUIDs as [16]byte
+func UUIDv4Bytes() gopter.Gen {
+	return genUUID(uuidV4Layout)
+}
+
+// UUIDv7 generates time-ordered (version 7) UUIDs in their canonical string
+// form with arbitrary 48 bit unix timestamps in milliseconds.
+// The UUIDs shrink towards the nil UUID (keeping the version and variant).
+func UUIDv7() gopter.Gen {
+	return UUIDv7Bytes().Map(formatUUID)
+}
+
+// UUIDv7Bytes generates time-ordered (version 7) UUIDs as [16]byte
+func UUIDv7Bytes() gopter.Gen {
+	return genUUID(uuidV7Layout)
+}
+
+// ULID generates ULIDs in their canonical form of 26 characters (Crockford's
+// base32, e.g. "01ARZ3NDEKTSV4RRFFQ69G5FAV") with arbitrary 48 bit unix
+// timestamps in milliseconds.
+// The ULIDs shrink towards "00000000000000000000000000".
+func ULID() gopter.Gen {
+	return ULIDBytes().Map(formatULID)
+}
+
+// ULIDBytes generates ULIDs as [16]byte
+func ULIDBytes() gopter.Gen {
+	return genUUID(ulidLayout)
+}
+
+func genUUID(layout uuidLayout) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var id [16]byte
+		binary.BigEndian.PutUint64(id[:8], genParams.NextUint64())
+		binary.BigEndian.PutUint64(id[8:], genParams.NextUint64())
+		genResult := gopter.NewGenResult(layout.apply(id), uuidShrinker(layout))
+		genResult.Sieve = func(v interface{}) bool {
+			id := v.([16]byte)
+			return layout.apply(id) == id
+		}
+		return genResult
+	}
+}
+
+// apply sets the fixed bits of the layout
+func (l uuidLayout) apply(id [16]byte) [16]byte {
+	for i := range id {
+		id[i] = id[i]&l.variable[i] | l.fixed[i]
+	}
+	return id
+}
+
+// uuidShrinker shrinks an identifier by clearing all variable bits and then
+// the variable bits of single bytes
+func uuidShrinker(layout uuidLayout) gopter.Shrinker {
+	return func(v interface{}) gopter.Shrink {
+		id := v.([16]byte)
+		minimal := layout.apply([16]byte{})
+		if id == minimal {
+			return gopter.NoShrink
+		}
+		candidates := []interface{}{minimal}
+		for i := range id {
+			if id[i]&layout.variable[i] != 0 {
+				shrunk := id
+				shrunk[i] = layout.fixed[i]
+				if shrunk != minimal {
+					candidates = append(candidates, shrunk)
+				}
+			}
+		}
+		return valuesShrink(candidates)
+	}
+}
+
+func formatUUID(id [16]byte) string {
+	encoded := hex.EncodeToString(id[:])
+	return encoded[:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:]
+}
+
+func formatULID(id [16]byte) string {
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	encoded := make([]byte, 26)
+	for i := len(encoded) - 1; i >= 0; i-- {
+		encoded[i] = crockfordBase32[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(encoded)
+}
@@ -0,0 +1,87 @@
+package gen_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestUUIDv4(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	commonGeneratorTest(t, "uuid v4", gen.UUIDv4(), func(value interface{}) bool {
+		v, ok := value.(string)
+		return ok && pattern.MatchString(v)
+	})
+	commonGeneratorTest(t, "uuid v4 bytes", gen.UUIDv4Bytes(), func(value interface{}) bool {
+		v, ok := value.([16]byte)
+		return ok && v[6]>>4 == 4 && v[8]>>6 == 2
+	})
+}
+
+func TestUUIDv7(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	commonGeneratorTest(t, "uuid v7", gen.UUIDv7(), func(value interface{}) bool {
+		v, ok := value.(string)
+		return ok && pattern.MatchString(v)
+	})
+	commonGeneratorTest(t, "uuid v7 bytes", gen.UUIDv7Bytes(), func(value interface{}) bool {
+		v, ok := value.([16]byte)
+		return ok && v[6]>>4 == 7 && v[8]>>6 == 2
+	})
+}
+
+func TestULID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+	commonGeneratorTest(t, "ulid", gen.ULID(), func(value interface{}) bool {
+		v, ok := value.(string)
+		return ok && pattern.MatchString(v)
+	})
+	commonGeneratorTest(t, "ulid bytes", gen.ULIDBytes(), func(value interface{}) bool {
+		_, ok := value.([16]byte)
+		return ok
+	})
+
+	// the string is the encoding of the bytes
+	for seed := int64(0); seed < 20; seed++ {
+		str, _ := gen.ULID()(gopter.DefaultGenParameters().CloneWithSeed(seed)).Retrieve()
+		id, _ := gen.ULIDBytes()(gopter.DefaultGenParameters().CloneWithSeed(seed)).Retrieve()
+		if decodeULID(str.(string)) != id.([16]byte) {
+			t.Errorf("Invalid encoding of %x: %s", id, str)
+		}
+	}
+}
+
+func decodeULID(s string) [16]byte {
+	var id [16]byte
+	for _, ch := range s {
+		carry := byte(strings.IndexRune("0123456789ABCDEFGHJKMNPQRSTVWXYZ", ch))
+		for i := 15; i >= 0; i-- {
+			shifted := uint16(id[i])<<5 | uint16(carry)
+			id[i] = byte(shifted)
+			carry = byte(shifted >> 8)
+		}
+	}
+	return id
+}
+
+func TestUUIDShrink(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	result := prop.ForAll(func(id string) bool {
+		return !strings.HasPrefix(id, "0")
+	}, gen.UUIDv4()).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != "00000000-0000-4000-8000-000000000000" {
+		t.Errorf("Invalid result: %#v", result.Args)
+	}
+
+	result = prop.ForAll(func(id string) bool {
+		return id < "4"
+	}, gen.ULID()).Check(parameters)
+	if result.Status != gopter.TestFailed || len(result.Args[0].Arg.(string)) != 26 ||
+		!strings.HasSuffix(result.Args[0].Arg.(string), "00000000000000000000000") {
+		t.Errorf("Invalid result: %#v", result.Args)
+	}
+}
@@ -16,7 +16,13 @@ type WeightedGen struct {
 
 // Weighted combines multiple generators, where each generator has a weight.
 // The weight of a generator is proportional to the probability that the
-// generator gets selected.
+// generator gets selected, e.g. to pick valid inputs 90% and edge cases 10%
+// of the time:
+//
+//	gen.Weighted([]gen.WeightedGen{
+//		{Weight: 9, Gen: validInput},
+//		{Weight: 1, Gen: edgeCases},
+//	})
 func Weighted(weightedGens []WeightedGen) gopter.Gen {
 	if len(weightedGens) == 0 {
 		panic("weightedGens must be non-empty")
@@ -39,6 +45,8 @@ func Weighted(weightedGens []WeightedGen) gopter.Gen {
 		gen := weightedGens[idx].Gen
 		result := gen(genParams)
 		result.Sieve = nil
+		// the domain is the one of the chosen generator only
+		result.Domain = nil
 		return result
 	}
 }
@@ -0,0 +1,105 @@
+package gopter
+
+import "reflect"
+
+// batchMaxAttemptsFactor limits the attempts of Batch to generate values
+// passing the sieve
+const batchMaxAttemptsFactor = 10
+
+// BatchFunc appends n generated values to buf, which is a slice of the result
+// type of the generator (nil if a new slice has to be created), and returns
+// the extended slice. It returns nil (without generating anything) if buf is a
+// slice of another type.
+type BatchFunc func(genParams *GenParameters, buf interface{}, n int) interface{}
+
+// batchGenPointer identifies the generators created by NewBatchGen
+var batchGenPointer = reflect.ValueOf(NewBatchGen(nil, nil)).Pointer()
+
+// NewBatchGen creates a generator that generates single values by gen and
+// batches of values by batch (see Gen.Batch).
+// batch has to create the same values as n invocations of gen (i.e. consume the
+// Rng the same way) and all of them have to pass the sieve of gen. It is only
+// used if the generator itself is batched, i.e. derived generators (e.g. by Map
+// or WithLabel) fall back to generating value by value.
+func NewBatchGen(gen Gen, batch BatchFunc) Gen {
+	return func(genParams *GenParameters) *GenResult {
+		if genParams.batch != nil {
+			*genParams.batch = batch
+			return nil
+		}
+		return gen(genParams)
+	}
+}
+
+// Batch generates n values in one call and returns them as a slice of the
+// result type of the generator (e.g. []int for gen.Int()).
+// Generators created by NewBatchGen (like the integer, float and bool
+// generators) create the values directly, without a GenResult or boxing per
+// value. All others are invoked value by value, where values not passing the
+// sieve are skipped and replaced by new attempts. If too many values are
+// rejected (more than 10 attempts per value) the result might contain less
+// than n values.
+func (g Gen) Batch(n int, genParams *GenParameters) interface{} {
+	return g.BatchInto(nil, n, genParams)
+}
+
+// BatchInto is like Batch but appends the values to buf (a slice of the
+// result type of the generator or nil), i.e. the buffer can be reused (e.g.
+// buf[:0]) for several batches to avoid allocations.
+func (g Gen) BatchInto(buf interface{}, n int, genParams *GenParameters) interface{} {
+	if batch := g.batchFunc(genParams); batch != nil {
+		if values := batch(genParams, buf, n); values != nil {
+			return values
+		}
+	}
+
+	var slice reflect.Value
+	if buf != nil {
+		slice = reflect.ValueOf(buf)
+	}
+	target := n
+	if slice.IsValid() {
+		target += slice.Len()
+	}
+	for attempts := 0; attempts < n*batchMaxAttemptsFactor; attempts++ {
+		if slice.IsValid() && slice.Len() >= target {
+			break
+		}
+		result := g(genParams)
+		if !slice.IsValid() {
+			if result.ResultType == nil {
+				return nil
+			}
+			slice = reflect.MakeSlice(reflect.SliceOf(result.ResultType), 0, n)
+		}
+		if result.Sieve == nil {
+			if result.Result == nil {
+				continue
+			}
+		} else if !result.Sieve(result.Result) {
+			continue
+		}
+		value := reflect.ValueOf(result.Result)
+		if !value.IsValid() {
+			value = reflect.Zero(slice.Type().Elem())
+		}
+		slice = reflect.Append(slice, value)
+	}
+	if !slice.IsValid() {
+		return nil
+	}
+	return slice.Interface()
+}
+
+// batchFunc returns the BatchFunc of a generator created by NewBatchGen, nil
+// if the generator does not generate batches
+func (g Gen) batchFunc(genParams *GenParameters) BatchFunc {
+	if g == nil || reflect.ValueOf(g).Pointer() != batchGenPointer {
+		return nil
+	}
+	var batch BatchFunc
+	probe := *genParams
+	probe.batch = &batch
+	g(&probe)
+	return batch
+}
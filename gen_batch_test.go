@@ -0,0 +1,74 @@
+package gopter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestGenBatch(t *testing.T) {
+	counter := 0
+	gen := gopter.Gen(func(*gopter.GenParameters) *gopter.GenResult {
+		counter++
+		return gopter.NewGenResult(counter, gopter.NoShrinker)
+	})
+
+	values := gen.Batch(10, gopter.DefaultGenParameters())
+	if !reflect.DeepEqual(values, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+		t.Errorf("Invalid batch: %#v", values)
+	}
+
+	even := gen.SuchThat(func(v int) bool { return v%2 == 0 })
+	buf := make([]int, 0, 5)
+	buf = even.BatchInto(buf, 5, gopter.DefaultGenParameters()).([]int)
+	if len(buf) != 5 {
+		t.Fatalf("Invalid batch: %#v", buf)
+	}
+	for _, value := range buf {
+		if value%2 != 0 {
+			t.Errorf("Value does not pass the sieve: %#v", buf)
+		}
+	}
+	if buf = even.BatchInto(buf[:0], 3, gopter.DefaultGenParameters()).([]int); len(buf) != 3 || cap(buf) != 5 {
+		t.Errorf("Buffer not reused: %#v", buf)
+	}
+
+	never := gen.SuchThat(func(v int) bool { return false })
+	if values := never.Batch(5, gopter.DefaultGenParameters()).([]int); len(values) != 0 {
+		t.Errorf("Invalid batch: %#v", values)
+	}
+}
+
+func TestNewBatchGen(t *testing.T) {
+	single := gopter.Gen(func(*gopter.GenParameters) *gopter.GenResult {
+		return gopter.NewGenResult("single", gopter.NoShrinker)
+	})
+	batched := gopter.NewBatchGen(single, func(genParams *gopter.GenParameters, buf interface{}, n int) interface{} {
+		if buf == nil {
+			buf = []string(nil)
+		}
+		values, ok := buf.([]string)
+		if !ok {
+			return nil
+		}
+		for i := 0; i < n; i++ {
+			values = append(values, "batch")
+		}
+		return values
+	})
+
+	if value, ok := batched.Sample(); !ok || value != "single" {
+		t.Errorf("Invalid sample: %#v", value)
+	}
+	if values := batched.Batch(2, gopter.DefaultGenParameters()); !reflect.DeepEqual(values, []string{"batch", "batch"}) {
+		t.Errorf("Invalid batch: %#v", values)
+	}
+	// Derived generators and mismatching buffers fall back to single values
+	if values := batched.WithLabel("label").Batch(2, gopter.DefaultGenParameters()); !reflect.DeepEqual(values, []string{"single", "single"}) {
+		t.Errorf("Invalid derived batch: %#v", values)
+	}
+	if values := batched.BatchInto([]interface{}{}, 2, gopter.DefaultGenParameters()); !reflect.DeepEqual(values, []interface{}{"single", "single"}) {
+		t.Errorf("Invalid interface batch: %#v", values)
+	}
+}
@@ -0,0 +1,119 @@
+package gopter
+
+import "reflect"
+
+// MaxEnumerableDomainSize is the maximum size of a generator domain that is
+// enumerated (see GenResult.Domain)
+const MaxEnumerableDomainSize = 1024
+
+// WithDomain creates a derived generator with a finite domain, i.e. all
+// values the generator can produce. This enables the exhaustive check of
+// properties (see TestParameters.ExhaustiveLimit).
+func (g Gen) WithDomain(values ...interface{}) Gen {
+	domain := func() []interface{} {
+		return values
+	}
+	return func(genParams *GenParameters) *GenResult {
+		result := g(genParams)
+		result.Domain = domain
+		return result
+	}
+}
+
+// DomainValues returns the values of the domain of a generator result that pass
+// its sieve, nil if the domain is unknown
+func (r *GenResult) DomainValues() []interface{} {
+	if r.Domain == nil {
+		return nil
+	}
+	domain := r.Domain()
+	if domain == nil {
+		return nil
+	}
+	values := make([]interface{}, 0, len(domain))
+	for _, value := range domain {
+		if (r.Sieve == nil && value != nil) || (r.Sieve != nil && r.Sieve(value)) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func mapDomain(result *GenResult, mapperVal reflect.Value) func() []interface{} {
+	return func() []interface{} {
+		values := result.DomainValues()
+		if values == nil {
+			return nil
+		}
+		mapped := make([]interface{}, 0, len(values))
+		seen := map[interface{}]bool{}
+		for _, value := range values {
+			v := mapperVal.Call([]reflect.Value{reflect.ValueOf(value)})[0].Interface()
+			if reflect.TypeOf(v).Comparable() {
+				if seen[v] {
+					continue
+				}
+				seen[v] = true
+			}
+			mapped = append(mapped, v)
+		}
+		return mapped
+	}
+}
+
+func combineDomains(domains []func() []interface{}) func() []interface{} {
+	for _, domain := range domains {
+		if domain == nil {
+			return nil
+		}
+	}
+	return func() []interface{} {
+		valuesOf := make([][]interface{}, len(domains))
+		size := 1
+		for i, domain := range domains {
+			valuesOf[i] = domain()
+			size *= len(valuesOf[i])
+			if valuesOf[i] == nil || size > MaxEnumerableDomainSize {
+				return nil
+			}
+		}
+		result := make([]interface{}, 0, size)
+		EnumerateProduct(valuesOf, func(combination []interface{}) bool {
+			result = append(result, append([]interface{}{}, combination...))
+			return true
+		})
+		return result
+	}
+}
+
+// EnumerateProduct calls f for all combinations of the cartesian product of
+// the given domains (until f returns false). The combination slice is reused
+// between the calls.
+func EnumerateProduct(domains [][]interface{}, f func(combination []interface{}) bool) {
+	for _, domain := range domains {
+		if len(domain) == 0 {
+			return
+		}
+	}
+	indices := make([]int, len(domains))
+	combination := make([]interface{}, len(domains))
+	for {
+		for i, idx := range indices {
+			combination[i] = domains[i][idx]
+		}
+		if !f(combination) {
+			return
+		}
+		i := len(indices) - 1
+		for ; i >= 0; i-- {
+			indices[i]++
+			if indices[i] < len(domains[i]) {
+				break
+			}
+			indices[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
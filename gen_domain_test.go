@@ -0,0 +1,53 @@
+package gopter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestEnumerateProduct(t *testing.T) {
+	var combinations [][]interface{}
+	gopter.EnumerateProduct([][]interface{}{{1, 2}, {"a", "b", "c"}}, func(combination []interface{}) bool {
+		combinations = append(combinations, append([]interface{}{}, combination...))
+		return true
+	})
+	if len(combinations) != 6 || !reflect.DeepEqual(combinations[0], []interface{}{1, "a"}) ||
+		!reflect.DeepEqual(combinations[5], []interface{}{2, "c"}) {
+		t.Errorf("Invalid combinations: %#v", combinations)
+	}
+
+	count := 0
+	gopter.EnumerateProduct([][]interface{}{{1, 2}, {3, 4}}, func([]interface{}) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("Enumeration should stop: %d", count)
+	}
+}
+
+func TestGenWithDomain(t *testing.T) {
+	gen := constGen(1).WithDomain(1, 2, 3)
+	result := gen(gopter.DefaultGenParameters())
+	if !reflect.DeepEqual(result.DomainValues(), []interface{}{1, 2, 3}) {
+		t.Errorf("Invalid domain: %#v", result.DomainValues())
+	}
+
+	mapped := gen.Map(func(v int) int { return v / 2 }).SuchThat(func(v int) bool { return v > 0 })
+	result = mapped(gopter.DefaultGenParameters())
+	if !reflect.DeepEqual(result.DomainValues(), []interface{}{1}) {
+		t.Errorf("Invalid mapped domain: %#v", result.DomainValues())
+	}
+
+	combined := gopter.CombineGens(gen, constGen("a").WithDomain("a", "b"))
+	result = combined(gopter.DefaultGenParameters())
+	if domain := result.DomainValues(); len(domain) != 6 || !reflect.DeepEqual(domain[1], []interface{}{1, "b"}) {
+		t.Errorf("Invalid combined domain: %#v", domain)
+	}
+
+	if result := gopter.CombineGens(gen, constGen("a"))(gopter.DefaultGenParameters()); result.Domain != nil {
+		t.Error("Combined domain should be unknown")
+	}
+}
@@ -7,10 +7,57 @@ import (
 
 // GenParameters encapsulates the parameters for all generators.
 type GenParameters struct {
-	MinSize        int
-	MaxSize        int
-	MaxShrinkCount int
-	Rng            *rand.Rand
+	MinSize           int
+	MaxSize           int
+	MaxShrinkCount    int
+	ArgShrinkStrategy ArgShrinkStrategy
+	ShrinkStrategy    ShrinkStrategy
+	Rng               *rand.Rand
+	// TraceGenerators enables the generation trace of properties (see
+	// Gen.Traced)
+	TraceGenerators bool
+	// ExhaustiveLimit is the maximum number of cases that are checked
+	// exhaustively (0 disables exhaustive checks)
+	ExhaustiveLimit int
+	// Trace collects the invocations of traced generators, nil if tracing is
+	// disabled
+	Trace *GenTrace
+	// InputDedup records the checked inputs of a property, nil if the
+	// deduplication is disabled (see TestParameters.DedupInputs)
+	InputDedup *InputDedup
+	// SieveStats collects the rejections of the sieves, nil if no statistics
+	// are collected
+	SieveStats *SieveStats
+	// RateLimiter limits the evaluations of a property (including shrinking),
+	// nil if unlimited (see TestParameters.MaxRate)
+	RateLimiter *RateLimiter
+	// DryRun requests properties to generate their arguments without
+	// evaluating their condition (see Properties.Manifest)
+	DryRun bool
+	// Depth is the current nesting depth of recursive generators (see
+	// gen.Recursive)
+	Depth int
+	// UniquePools are the pools of unique values of a property check (see
+	// gen.Unique), nil outside of a property check.
+	// They are not part of CloneWithSeed, since rerunning a generator with the
+	// same seed has to create the same values.
+	UniquePools *UniquePools
+	// CorpusExample is the serialized example of a Corpus a property should
+	// check instead of generated arguments (empty if the arguments have to be
+	// generated)
+	CorpusExample string
+	// ShardIndex and ShardTotal define the shard of a sharded property check
+	// (see TestParameters.ShardTotal), ShardTotal is 0 if the check is not
+	// sharded
+	ShardIndex int
+	ShardTotal int
+	// OptionsApplied is set if the check has been restarted with the Options
+	// of a PropResult
+	OptionsApplied bool
+
+	// batch receives the BatchFunc of a generator created by NewBatchGen (see
+	// Gen.BatchInto)
+	batch *BatchFunc
 }
 
 // WithSize modifies the size parameter. The size parameter defines an upper bound for the size of
@@ -21,6 +68,24 @@ func (p *GenParameters) WithSize(size int) *GenParameters {
 	return &newParameters
 }
 
+// WithTrace creates a copy of the parameters with a fresh GenTrace if
+// TraceGenerators is enabled.
+func (p *GenParameters) WithTrace() *GenParameters {
+	if !p.TraceGenerators {
+		return p
+	}
+	newParameters := *p
+	newParameters.Trace = NewGenTrace()
+	return &newParameters
+}
+
+// InShard checks if the case with the given index (e.g. of an exhaustive
+// check) belongs to the shard of the property check (always true if the check
+// is not sharded)
+func (p *GenParameters) InShard(index int) bool {
+	return p.ShardTotal <= 1 || index%p.ShardTotal == p.ShardIndex
+}
+
 // NextBool create a random boolean using the underlying Rng.
 func (p *GenParameters) NextBool() bool {
 	return p.Rng.Int63()&1 == 0
@@ -48,10 +113,17 @@ func (p *GenParameters) NextUint64() uint64 {
 // seed)
 func (p *GenParameters) CloneWithSeed(seed int64) *GenParameters {
 	return &GenParameters{
-		MinSize:        p.MinSize,
-		MaxSize:        p.MaxSize,
-		MaxShrinkCount: p.MaxShrinkCount,
-		Rng:            rand.New(NewLockedSource(seed)),
+		MinSize:           p.MinSize,
+		MaxSize:           p.MaxSize,
+		MaxShrinkCount:    p.MaxShrinkCount,
+		ArgShrinkStrategy: p.ArgShrinkStrategy,
+		ShrinkStrategy:    p.ShrinkStrategy,
+		Rng:               rand.New(NewLockedSource(seed)),
+		TraceGenerators:   p.TraceGenerators,
+		ExhaustiveLimit:   p.ExhaustiveLimit,
+		SieveStats:        p.SieveStats,
+		RateLimiter:       p.RateLimiter,
+		Depth:             p.Depth,
 	}
 }
 
@@ -9,6 +9,16 @@ type GenResult struct {
 	ResultType reflect.Type
 	Result     interface{}
 	Sieve      func(interface{}) bool
+	// Domain enumerates all possible values of a generator with a small finite
+	// domain (nil if the domain is infinite or too large)
+	Domain func() []interface{}
+	// Tree creates the shrink tree of a value that has been derived from other
+	// generated values (e.g. by Gen.Map), so that it is shrunk by shrinking
+	// them. If nil the value is shrunk by the Shrinker (see ShrinkTree).
+	Tree func() *ShrinkTree
+	// Trace contains the generator invocations that lead to the result (only
+	// if tracing is enabled, see Gen.Traced)
+	Trace []TraceEntry
 }
 
 // NewGenResult creates a new generator result from for a concrete value and
@@ -0,0 +1,131 @@
+//go:build go1.18
+// +build go1.18
+
+package gopter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GenT is a type-safe generator of values of type T.
+// It is a Gen with the guarantee that all generated values are of type T, so
+// mapping functions and sieves are checked at compile time instead of by
+// reflection (see MapT, FlatMapT and GenT.SuchThat). GenT and Gen can be
+// converted into each other (see Typed and GenT.Untyped).
+type GenT[T any] func(*GenParameters) *GenResult
+
+// Typed converts a generator to a GenT[T].
+// Panics if the generator produces values that are not assignable to T (like
+// Gen.Map and Gen.SuchThat do for mismatching functions).
+func Typed[T any](g Gen) GenT[T] {
+	resultType := reflect.TypeOf((*T)(nil)).Elem()
+	if genResultType := g(MinGenParams).ResultType; genResultType != nil && !genResultType.AssignableTo(resultType) {
+		panic(fmt.Sprintf("Generator of %v is not assignable to %v", genResultType, resultType))
+	}
+	return GenT[T](g)
+}
+
+// Untyped converts the generator to a Gen (e.g. to be used with prop.ForAll)
+func (g GenT[T]) Untyped() Gen {
+	return Gen(g)
+}
+
+// Sample generates a sample value
+func (g GenT[T]) Sample() (T, bool) {
+	value, ok := Gen(g).Sample()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return value.(T), true
+}
+
+// Batch generates n values in one call (see Gen.Batch)
+func (g GenT[T]) Batch(n int, genParams *GenParameters) []T {
+	return g.BatchInto(make([]T, 0, n), n, genParams)
+}
+
+// BatchInto appends n generated values to buf (see Gen.BatchInto)
+func (g GenT[T]) BatchInto(buf []T, n int, genParams *GenParameters) []T {
+	return Gen(g).BatchInto(buf, n, genParams).([]T)
+}
+
+// WithLabel adds a label to a generated value (see Gen.WithLabel)
+func (g GenT[T]) WithLabel(label string) GenT[T] {
+	return GenT[T](Gen(g).WithLabel(label))
+}
+
+// SuchThat creates a derived generator by adding a sieve (see Gen.SuchThat)
+func (g GenT[T]) SuchThat(f func(T) bool) GenT[T] {
+	return g.SuchThatNamed(funcName(reflect.ValueOf(f)), f)
+}
+
+// SuchThatNamed creates a derived generator by adding a named sieve (see
+// Gen.SuchThatNamed)
+func (g GenT[T]) SuchThatNamed(name string, f func(T) bool) GenT[T] {
+	return GenT[T](Gen(g).withSieve(name, func(v interface{}) bool {
+		value, ok := v.(T)
+		return ok && f(value)
+	}))
+}
+
+// MapT creates a derived generator by mapping all generated values with f
+// (see Gen.Map).
+// Note: The derived generator will not have a sieve, its values are shrunk by
+// mapping the shrinks of the generated value (see GenResult.Tree)
+func MapT[T, U any](g GenT[T], f func(T) U) GenT[U] {
+	inputType := reflect.TypeOf((*T)(nil)).Elem()
+	resultType := reflect.TypeOf((*U)(nil)).Elem()
+	mapValue := func(v interface{}) interface{} {
+		var value T
+		if v != nil {
+			value = v.(T)
+		}
+		return f(value)
+	}
+	return func(genParams *GenParameters) *GenResult {
+		result := g(genParams)
+		value, ok := result.Retrieve()
+		if !ok {
+			return &GenResult{
+				Shrinker:   NoShrinker,
+				Result:     nil,
+				Labels:     result.Labels,
+				ResultType: resultType,
+			}
+		}
+		shrinker := result.Shrinker
+		var tree func() *ShrinkTree
+		if inputType != resultType || result.Tree != nil {
+			tree = func() *ShrinkTree {
+				source, _ := result.ShrinkTree()
+				return source.Map(mapValue)
+			}
+			shrinker = treeShrinker(tree)
+		} else if shrinker == nil {
+			shrinker = NoShrinker
+		}
+		var domain func() []interface{}
+		if result.Domain != nil {
+			domain = mapDomain(result, reflect.ValueOf(f))
+		}
+		return &GenResult{
+			Shrinker:   shrinker,
+			Result:     mapValue(value),
+			Labels:     result.Labels,
+			ResultType: resultType,
+			Domain:     domain,
+			Tree:       tree,
+		}
+	}
+}
+
+// FlatMapT creates a derived generator by passing a generated value to f which
+// creates the generator of the derived value (see Gen.FlatMap)
+func FlatMapT[T, U any](g GenT[T], f func(T) GenT[U]) GenT[U] {
+	resultType := reflect.TypeOf((*U)(nil)).Elem()
+	return GenT[U](Gen(g).FlatMap(func(v interface{}) Gen {
+		return Gen(f(v.(T)))
+	}, resultType))
+}
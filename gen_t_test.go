@@ -0,0 +1,64 @@
+//go:build go1.18
+// +build go1.18
+
+package gopter_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestGenT(t *testing.T) {
+	ints := gopter.Typed[int](gen.IntRange(0, 100))
+	even := ints.SuchThat(func(v int) bool { return v%2 == 0 })
+	for i := 0; i < 100; i++ {
+		if v, ok := even.Sample(); ok && v%2 != 0 {
+			t.Errorf("Invalid sample: %d", v)
+		}
+	}
+
+	strs := gopter.MapT(ints, strconv.Itoa).WithLabel("number")
+	genResult := strs(gopter.DefaultGenParameters())
+	value, ok := genResult.Retrieve()
+	if !ok || genResult.ResultType.Kind().String() != "string" || len(genResult.Labels) != 1 || genResult.Labels[0] != "number" {
+		t.Errorf("Invalid mapped result: %#v", genResult)
+	}
+	if _, err := strconv.Atoi(value.(string)); err != nil {
+		t.Errorf("Invalid mapped value: %#v", value)
+	}
+
+	doubled := gopter.MapT(ints, func(v int) int { return 2 * v })
+	if shrinks := doubled(gopter.DefaultGenParameters()).Shrinker(10).All(); len(shrinks) == 0 {
+		t.Error("Shrinker of same type mapping missing")
+	}
+	bools := gopter.MapT(gopter.Typed[bool](gen.Bool()), func(b bool) string { return strconv.FormatBool(b) })
+	if domain := bools(gopter.DefaultGenParameters()).DomainValues(); len(domain) != 2 {
+		t.Errorf("Invalid mapped domain: %v", domain)
+	}
+
+	slices := gopter.FlatMapT(gopter.Typed[int](gen.IntRange(1, 5)), func(n int) gopter.GenT[[]int] {
+		return gopter.Typed[[]int](gen.SliceOfN(n, gen.Int()))
+	})
+	for i := 0; i < 20; i++ {
+		if v, ok := slices.Sample(); !ok || len(v) < 1 || len(v) > 5 {
+			t.Errorf("Invalid flat mapped sample: %v", v)
+		}
+	}
+
+	if values := gopter.Typed[int](gen.IntRange(1, 5)).Batch(10, gopter.DefaultGenParameters()); len(values) != 10 {
+		t.Errorf("Invalid typed batch: %v", values)
+	}
+	if values := gopter.Typed[interface{}](gen.Bool()).Batch(3, gopter.DefaultGenParameters()); len(values) != 3 {
+		t.Errorf("Invalid interface batch: %v", values)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Typed did not panic for a mismatching generator")
+		}
+	}()
+	gopter.Typed[string](gen.Int())
+}
@@ -2,6 +2,7 @@ package gopter_test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/leanovate/gopter"
@@ -134,6 +135,105 @@ func TestGenMapToManyReturns(t *testing.T) {
 	})
 }
 
+func TestGenMapShrink(t *testing.T) {
+	type celsius struct {
+		Degrees int64
+	}
+	gen := gopter.Gen(func(*gopter.GenParameters) *gopter.GenResult {
+		result := gopter.NewGenResult(int64(8), func(v interface{}) gopter.Shrink {
+			return gopter.Shrink(func() (interface{}, bool) { return nil, false })
+		})
+		result.Shrinker = func(v interface{}) gopter.Shrink {
+			shrunk := []interface{}{v.(int64) / 2, v.(int64) - 1}
+			return func() (interface{}, bool) {
+				if len(shrunk) == 0 {
+					return nil, false
+				}
+				value := shrunk[0]
+				shrunk = shrunk[1:]
+				return value, true
+			}
+		}
+		result.Sieve = func(v interface{}) bool { return v.(int64) != 4 }
+		return result
+	}).MapShrink(func(v int64) celsius {
+		return celsius{Degrees: v}
+	}, func(c celsius) int64 {
+		return c.Degrees
+	})
+
+	genResult := gen(gopter.DefaultGenParameters())
+	value, ok := genResult.Retrieve()
+	if !ok || value != (celsius{Degrees: 8}) || genResult.ResultType != reflect.TypeOf(celsius{}) {
+		t.Errorf("Invalid gen result: %#v", genResult)
+	}
+	if shrinks := genResult.Shrinker(celsius{Degrees: 20}).All(); !reflect.DeepEqual(shrinks, []interface{}{celsius{10}, celsius{19}}) {
+		t.Errorf("Invalid shrinks: %#v", shrinks)
+	}
+	if genResult.Sieve(celsius{Degrees: 4}) || !genResult.Sieve(celsius{Degrees: 5}) {
+		t.Error("Sieve not converted")
+	}
+}
+
+func TestGenMapShrinkToManyReturns(t *testing.T) {
+	defer expectPanic(t, "Param of MapShrink has to be a func with one param and one return value, but is func(string) (string, bool)")
+	constGen("sample").MapShrink(func(a string) (string, bool) {
+		return "", false
+	}, func(a string, b bool) string {
+		return a
+	})
+}
+
+func TestGenFilterMap(t *testing.T) {
+	counter := 0
+	gen := gopter.Gen(func(*gopter.GenParameters) *gopter.GenResult {
+		counter++
+		result := gopter.NewGenResult(counter, func(v interface{}) gopter.Shrink {
+			shrunk := []interface{}{v.(int) / 2, v.(int) - 2}
+			return func() (interface{}, bool) {
+				if len(shrunk) == 0 || v.(int) == 0 {
+					return nil, false
+				}
+				value := shrunk[0]
+				shrunk = shrunk[1:]
+				return value, true
+			}
+		})
+		result.Labels = []string{"counter"}
+		return result
+	}).FilterMap(func(v int) (string, bool) {
+		return strings.Repeat("x", v), v%2 == 0
+	})
+	counter = 0
+	genParams := gopter.DefaultGenParameters()
+	genParams.SieveStats = gopter.NewSieveStats()
+
+	if value, ok := gen(genParams).Retrieve(); ok {
+		t.Errorf("Odd value not discarded: %#v", value)
+	}
+	genResult := gen(genParams)
+	value, ok := genResult.Retrieve()
+	if !ok || value != "xx" || genResult.ResultType != reflect.TypeOf("") || !reflect.DeepEqual(genResult.Labels, []string{"counter"}) {
+		t.Errorf("Invalid gen result: %#v", genResult)
+	}
+	// the odd shrinks are left out
+	if shrinks := genResult.Shrinker("xx").All(); !reflect.DeepEqual(shrinks, []interface{}{""}) {
+		t.Errorf("Invalid shrinks: %#v", shrinks)
+	}
+	// each value is only recorded once
+	if stats := genParams.SieveStats.Stats(); len(stats) != 1 || stats[0].Name != "gopter_test.TestGenFilterMap.func2" ||
+		stats[0].Evaluated != 2 || stats[0].Rejected != 1 {
+		t.Errorf("Invalid stats: %#v", stats)
+	}
+}
+
+func TestGenFilterMapToInvalidReturns(t *testing.T) {
+	defer expectPanic(t, "Param of FilterMap has to be a func with two return values, the second of bool, but is func(string) string")
+	constGen("sample").FilterMap(func(a string) string {
+		return a
+	})
+}
+
 func TestGenMapResultIn(t *testing.T) {
 	gen := constGen("sample")
 	var mappedWith *gopter.GenResult
@@ -326,6 +426,60 @@ func TestSuchThat(t *testing.T) {
 	}
 }
 
+func TestGenSuchThatNamed(t *testing.T) {
+	counter := 0
+	gen := gopter.Gen(func(*gopter.GenParameters) *gopter.GenResult {
+		counter++
+		return gopter.NewGenResult(counter, gopter.NoShrinker)
+	}).SuchThatNamed("even", func(v int) bool {
+		return v%2 == 0
+	}).SuchThat(func(v int) bool {
+		return v%3 == 0
+	})
+	genParams := gopter.DefaultGenParameters()
+	genParams.SieveStats = gopter.NewSieveStats()
+	for i := 0; i < 12; i++ {
+		gen(genParams)
+	}
+
+	stats := genParams.SieveStats.Stats()
+	if len(stats) != 2 {
+		t.Fatalf("Invalid stats: %#v", stats)
+	}
+	if stats[0] != (gopter.SieveStat{Name: "even", Evaluated: 12, Rejected: 6}) || stats[0].RejectionRate() != 0.5 {
+		t.Errorf("Invalid stat: %#v", stats[0])
+	}
+	// the second sieve only evaluates values accepted by the first one
+	if stats[1].Name != "gopter_test.TestGenSuchThatNamed.func3" || stats[1].Evaluated != 6 || stats[1].Rejected != 4 {
+		t.Errorf("Invalid stat: %#v", stats[1])
+	}
+	if stats[0].String() != "50% of generated values rejected by sieve 'even'" {
+		t.Errorf("Invalid stat string: %s", stats[0].String())
+	}
+}
+
+func TestGenRetrySieve(t *testing.T) {
+	counter := 0
+	gen := gopter.Gen(func(*gopter.GenParameters) *gopter.GenResult {
+		counter++
+		return gopter.NewGenResult(counter, gopter.NoShrinker)
+	}).SuchThatNamed("multiple of 4", func(v int) bool {
+		return v%4 == 0
+	})
+	genParams := gopter.DefaultGenParameters()
+	genParams.SieveStats = gopter.NewSieveStats()
+
+	if value, ok := gen.RetrySieve(3).Sample(); !ok || value != 4 {
+		t.Errorf("Invalid value: %#v", value)
+	}
+	if value, ok := gen.RetrySieve(2)(genParams).Retrieve(); ok {
+		t.Errorf("Value found without enough retries: %#v", value)
+	}
+	if stats := genParams.SieveStats.Stats(); len(stats) != 1 || stats[0].Evaluated != 3 || stats[0].Rejected != 3 {
+		t.Errorf("Invalid stats: %#v", stats)
+	}
+}
+
 func TestGenSuchThatNoFunc(t *testing.T) {
 	defer expectPanic(t, "Param of SuchThat has to be a func, but is string")
 	constGen("sample").SuchThat("not a function")
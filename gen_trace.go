@@ -0,0 +1,97 @@
+package gopter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TraceEntry is the record of a single generator invocation
+type TraceEntry struct {
+	// Depth is the nesting level of the invocation
+	Depth int
+	Label string
+	// Size is the MaxSize of the GenParameters of the invocation
+	Size  int
+	Value interface{}
+	// Ok is false if the generator did not produce a valid value
+	Ok bool
+}
+
+func (e TraceEntry) String() string {
+	value := fmt.Sprintf("%+v", e.Value)
+	if !e.Ok {
+		value = "<invalid>"
+	}
+	return fmt.Sprintf("%s%s (size %d): %s", strings.Repeat("  ", e.Depth), e.Label, e.Size, value)
+}
+
+// GenTrace collects the trace of the generator invocations (see Gen.Traced)
+type GenTrace struct {
+	lk      sync.Mutex
+	depth   int
+	entries []TraceEntry
+}
+
+// NewGenTrace creates an empty GenTrace
+func NewGenTrace() *GenTrace {
+	return &GenTrace{}
+}
+
+// Entries returns a copy of all entries recorded so far
+func (t *GenTrace) Entries() []TraceEntry {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	return append([]TraceEntry{}, t.entries...)
+}
+
+// Len returns the number of entries recorded so far
+func (t *GenTrace) Len() int {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	return len(t.entries)
+}
+
+// Since returns a copy of all entries recorded after the first n entries
+func (t *GenTrace) Since(n int) []TraceEntry {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	if n >= len(t.entries) {
+		return nil
+	}
+	return append([]TraceEntry{}, t.entries[n:]...)
+}
+
+func (t *GenTrace) enter(label string, size int) int {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	t.entries = append(t.entries, TraceEntry{Depth: t.depth, Label: label, Size: size})
+	t.depth++
+	return len(t.entries) - 1
+}
+
+func (t *GenTrace) leave(idx int, result *GenResult) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	t.depth--
+	t.entries[idx].Value, t.entries[idx].Ok = result.Retrieve()
+}
+
+// Traced creates a derived generator that records each invocation (label,
+// size and produced value) in the GenTrace of the GenParameters.
+// The entries of the invocation (including all nested traced generators) are
+// attached to the GenResult.
+// If tracing is not enabled (GenParameters.Trace is nil) this is a no-op.
+func (g Gen) Traced(label string) Gen {
+	return func(genParams *GenParameters) *GenResult {
+		trace := genParams.Trace
+		if trace == nil {
+			return g(genParams)
+		}
+		idx := trace.enter(label, genParams.MaxSize)
+		result := g(genParams)
+		trace.leave(idx, result)
+		result.Trace = trace.Since(idx)
+		return result
+	}
+}
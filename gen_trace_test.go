@@ -0,0 +1,56 @@
+package gopter_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestGenTraced(t *testing.T) {
+	inner := constGen(21).Traced("inner")
+	outer := inner.Map(func(v int) int { return v * 2 }).Traced("outer")
+
+	result := outer(gopter.DefaultGenParameters())
+	if len(result.Trace) != 0 {
+		t.Errorf("Trace should be disabled by default: %#v", result.Trace)
+	}
+
+	genParams := gopter.DefaultGenParameters()
+	genParams.TraceGenerators = true
+	genParams = genParams.WithTrace()
+	result = outer(genParams)
+	if len(result.Trace) != 2 {
+		t.Fatalf("Invalid trace: %#v", result.Trace)
+	}
+	if result.Trace[0].Label != "outer" || result.Trace[0].Depth != 0 || result.Trace[0].Value != 42 ||
+		result.Trace[1].Label != "inner" || result.Trace[1].Depth != 1 || result.Trace[1].Value != 21 {
+		t.Errorf("Invalid trace: %#v", result.Trace)
+	}
+	if str := result.Trace[1].String(); str != "  inner (size 100): 21" {
+		t.Errorf("Invalid trace entry: %#v", str)
+	}
+}
+
+func TestGenTraceReport(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.TraceGenerators = true
+	properties := gopter.NewProperties(parameters)
+	properties.Property("traced", prop.ForAll(
+		func(v int) bool {
+			return v < 0
+		},
+		gen.IntRange(10, 20).WithLabel("number"),
+	))
+
+	var buffer bytes.Buffer
+	properties.Run(gopter.NewFormatedReporter(false, 75, &buffer))
+	output := buffer.String()
+	if !strings.Contains(output, "number_TRACE:") || !strings.Contains(output, "ARG_0 (size 0): ") ||
+		!strings.Contains(output, "  number (size 0): ") {
+		t.Errorf("Invalid output: %s", output)
+	}
+}
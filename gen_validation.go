@@ -0,0 +1,231 @@
+package gopter
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// GenValidationParameters configure the validation of generators (see
+// ValidateGensWithParameters)
+type GenValidationParameters struct {
+	// Samples is the number of values sampled from each generator
+	Samples int
+	// MinSize and MaxSize are the range of sizes the values are sampled with
+	MinSize int
+	MaxSize int
+	Seed    int64
+	// MaxSieveRejectionRate is the maximum ratio of samples that may be
+	// rejected by the sieve of a generator (e.g. by SuchThat)
+	MaxSieveRejectionRate float64
+	// MaxPanicRate is the maximum ratio of samples that may panic
+	MaxPanicRate float64
+	// MaxDuplicateRate is the maximum ratio of samples that may be duplicates
+	// of previous samples. Generators with a (finite) domain are exempt.
+	MaxDuplicateRate float64
+}
+
+// DefaultGenValidationParameters creates reasonable default parameters for
+// the validation of generators
+func DefaultGenValidationParameters() *GenValidationParameters {
+	return &GenValidationParameters{
+		Samples:               100,
+		MinSize:               0,
+		MaxSize:               100,
+		Seed:                  time.Now().UnixNano(),
+		MaxSieveRejectionRate: 0.5,
+		MaxPanicRate:          0,
+		MaxDuplicateRate:      0.9,
+	}
+}
+
+// GenValidation is the validation result of a generator
+type GenValidation struct {
+	// Index of the generator in the validated generators
+	Index int
+	// Labels of the first generated value (to identify the generator)
+	Labels []string
+	// Samples is the number of sampled values
+	Samples int
+	// Rejected is the number of samples rejected by the sieve
+	Rejected int
+	// Panics is the number of samples that panicked
+	Panics int
+	// Duplicates is the number of samples equal to a previous sample
+	Duplicates int
+	// Problems are the exceeded thresholds (empty if the generator is valid)
+	Problems []string
+}
+
+// Valid checks if the generator has not exceeded any threshold
+func (v *GenValidation) Valid() bool {
+	return len(v.Problems) == 0
+}
+
+func (v *GenValidation) String() string {
+	generator := fmt.Sprintf("generator %d", v.Index)
+	if len(v.Labels) > 0 {
+		generator = fmt.Sprintf("generator %d (%s)", v.Index, strings.Join(v.Labels, ", "))
+	}
+	if v.Valid() {
+		return generator + ": OK"
+	}
+	return generator + ": " + strings.Join(v.Problems, ", ")
+}
+
+// ValidateGens samples each generator with default parameters (see
+// DefaultGenValidationParameters) and reports generators whose sieve
+// rejection, panic or duplication rate exceeds its threshold as error.
+// This is intended to be called in TestMain before any property runs:
+//
+//	func TestMain(m *testing.M) {
+//		if err := gopter.ValidateGens(genOrder, genCustomer); err != nil {
+//			fmt.Println(err)
+//			os.Exit(1)
+//		}
+//		os.Exit(m.Run())
+//	}
+func ValidateGens(gens ...Gen) error {
+	var problems []string
+	for _, validation := range ValidateGensWithParameters(DefaultGenValidationParameters(), gens...) {
+		if !validation.Valid() {
+			problems = append(problems, validation.String())
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Invalid generators:\n" + strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// ValidateGensWithParameters samples each generator and returns the
+// validation results in the order of the generators
+func ValidateGensWithParameters(parameters *GenValidationParameters, gens ...Gen) []*GenValidation {
+	rng := rand.New(NewLockedSource(parameters.Seed))
+	validations := make([]*GenValidation, len(gens))
+	for i, gen := range gens {
+		validations[i] = validateGen(parameters, i, gen, rng.Int63())
+	}
+	return validations
+}
+
+func validateGen(parameters *GenValidationParameters, index int, gen Gen, seed int64) *GenValidation {
+	validation := &GenValidation{Index: index}
+	genParams := DefaultGenParameters().CloneWithSeed(seed)
+	genParams.MinSize = parameters.MinSize
+	seen := map[string]bool{}
+	hasDomain := false
+	for i := 0; i < parameters.Samples; i++ {
+		size := parameters.MinSize
+		if parameters.Samples > 1 {
+			size += (parameters.MaxSize - parameters.MinSize) * i / (parameters.Samples - 1)
+		}
+		validation.Samples++
+		genResult, panicked := sampleGen(gen, genParams.WithSize(size))
+		if panicked {
+			validation.Panics++
+			continue
+		}
+		if validation.Labels == nil {
+			validation.Labels = genResult.Labels
+		}
+		hasDomain = hasDomain || genResult.Domain != nil
+		value, ok := genResult.Retrieve()
+		if !ok {
+			validation.Rejected++
+			continue
+		}
+		key := fmt.Sprintf("%#v", value)
+		if seen[key] {
+			validation.Duplicates++
+		}
+		seen[key] = true
+	}
+
+	checkRate := func(count int, maxRate float64, description string) {
+		if validation.Samples > 0 && float64(count) > maxRate*float64(validation.Samples) {
+			validation.Problems = append(validation.Problems, fmt.Sprintf("%s rate %.2f exceeds %.2f",
+				description, float64(count)/float64(validation.Samples), maxRate))
+		}
+	}
+	checkRate(validation.Rejected, parameters.MaxSieveRejectionRate, "sieve rejection")
+	checkRate(validation.Panics, parameters.MaxPanicRate, "panic")
+	if !hasDomain {
+		checkRate(validation.Duplicates, parameters.MaxDuplicateRate, "duplicate")
+	}
+	return validation
+}
+
+func sampleGen(gen Gen, genParams *GenParameters) (genResult *GenResult, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			genResult, panicked = nil, true
+		}
+	}()
+	return gen(genParams), false
+}
+
+// MustSatisfy checks the generator eagerly by sampling it (with sizes from 0
+// to 100) and panics with the violating sample if any sample does not satisfy
+// f, so that a broken generator is detected when it is defined (e.g. at the
+// start of a test suite) instead of by mysterious property failures:
+//
+//	var genOrder = genRawOrder().MustSatisfy(validOrder, 100)
+//
+// f: has to be a function with one parameter (matching the generated value)
+// returning a bool (like SuchThat).
+// Samples rejected by the sieve of the generator are skipped. The samples are
+// generated with a random seed (unless the seed is defined by GOPTER_SEED, see
+// SeedEnv) that is part of the diagnostics.
+// The result is the generator itself.
+func (g Gen) MustSatisfy(f interface{}, samples int) Gen {
+	checkVal := reflect.ValueOf(f)
+	if checkVal.Kind() != reflect.Func {
+		panic(fmt.Sprintf("Param of MustSatisfy has to be a func, but is %v", checkVal.Kind()))
+	}
+	checkType := checkVal.Type()
+	if checkType.NumIn() != 1 {
+		panic(fmt.Sprintf("Param of MustSatisfy has to be a func with one param, but is %v", checkType.NumIn()))
+	} else if genResultType := g(MinGenParams).ResultType; !genResultType.AssignableTo(checkType.In(0)) {
+		panic(fmt.Sprintf("Param of MustSatisfy has to be a func with one param assignable to %v, but is %v", genResultType, checkType.In(0)))
+	}
+	if checkType.NumOut() != 1 || checkType.Out(0).Kind() != reflect.Bool {
+		panic(fmt.Sprintf("Param of MustSatisfy has to be a func with one return value of bool, but is %v", checkType))
+	}
+
+	seed := time.Now().UnixNano()
+	envSeed, ok, err := envSeed()
+	if err != nil {
+		panic(err.Error())
+	} else if ok {
+		seed = envSeed
+	}
+	genParams := DefaultGenParameters().CloneWithSeed(seed)
+	for i := 0; i < samples; i++ {
+		size := 0
+		if samples > 1 {
+			size = 100 * i / (samples - 1)
+		}
+		genResult := g(genParams.WithSize(size))
+		value, ok := genResult.Retrieve()
+		if !ok {
+			continue
+		}
+		arg := reflect.ValueOf(value)
+		if !arg.IsValid() {
+			arg = reflect.Zero(checkType.In(0))
+		}
+		if !checkVal.Call([]reflect.Value{arg})[0].Bool() {
+			labels := ""
+			if len(genResult.Labels) > 0 {
+				labels = fmt.Sprintf(", labels: %s", strings.Join(genResult.Labels, ", "))
+			}
+			panic(fmt.Sprintf("Generator violates %s in sample %d of %d (size: %d, seed: %d%s): %#v",
+				funcName(checkVal), i+1, samples, size, seed, labels, arg.Interface()))
+		}
+	}
+	return g
+}
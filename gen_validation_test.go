@@ -0,0 +1,99 @@
+package gopter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestValidateGens(t *testing.T) {
+	if err := gopter.ValidateGens(gen.Int(), gen.AlphaString(), gen.Bool()); err != nil {
+		t.Errorf("Valid generators reported as invalid: %v", err)
+	}
+
+	panicking := gen.IntRange(0, 9).Map(func(v int) int {
+		if v == 0 {
+			panic("Booom")
+		}
+		return v
+	})
+	err := gopter.ValidateGens(gen.Int(), panicking, gen.Int().Map(func(int) int { return 1 }).WithLabel("constant"))
+	if err == nil || !strings.Contains(err.Error(), "generator 1: panic rate") ||
+		!strings.Contains(err.Error(), "generator 2 (constant): duplicate rate 0.99 exceeds 0.90") ||
+		strings.Contains(err.Error(), "generator 0") {
+		t.Errorf("Invalid error: %v", err)
+	}
+}
+
+func TestValidateGensWithParameters(t *testing.T) {
+	parameters := gopter.DefaultGenValidationParameters()
+	parameters.Seed = 1234
+	parameters.MaxDuplicateRate = 1
+
+	rarelyValid := gen.IntRange(0, 99).SuchThat(func(v int) bool { return v < 10 })
+	validations := gopter.ValidateGensWithParameters(parameters, gen.SliceOf(gen.Int()), rarelyValid)
+	if len(validations) != 2 {
+		t.Fatalf("Invalid validations: %v", validations)
+	}
+	if !validations[0].Valid() || validations[0].Samples != 100 || validations[0].String() != "generator 0: OK" {
+		t.Errorf("Invalid first validation: %v", validations[0])
+	}
+	if validations[1].Valid() || validations[1].Rejected < 70 || validations[1].Panics != 0 ||
+		!strings.HasPrefix(validations[1].Problems[0], "sieve rejection rate") {
+		t.Errorf("Invalid second validation: %#v", validations[1])
+	}
+
+	sizes := map[int]bool{}
+	parameters.MinSize, parameters.MaxSize = 5, 10
+	gopter.ValidateGensWithParameters(parameters, gen.SliceOf(gen.Int()).Map(func(v []int) []int {
+		sizes[len(v)] = true
+		return v
+	}))
+	if len(sizes) < 2 || sizes[11] {
+		t.Errorf("Invalid sizes: %v", sizes)
+	}
+}
+
+func TestMustSatisfy(t *testing.T) {
+	positive := func(v int) bool { return v > 0 }
+	ints := gen.IntRange(1, 100)
+	if g := ints.MustSatisfy(positive, 100); g == nil {
+		t.Error("Generator missing")
+	}
+	// samples rejected by the sieve are not checked
+	gen.IntRange(-100, 100).SuchThat(positive).MustSatisfy(positive, 100)
+
+	mustPanic := func(name string, f func(), expected ...string) {
+		defer func() {
+			r := recover()
+			message, ok := r.(string)
+			if !ok {
+				t.Errorf("%s did not panic: %#v", name, r)
+				return
+			}
+			for _, part := range expected {
+				if !strings.Contains(message, part) {
+					t.Errorf("%s panicked without %q: %s", name, part, message)
+				}
+			}
+		}()
+		f()
+	}
+	mustPanic("violation", func() {
+		gen.IntRange(-100, 0).WithLabel("negative").MustSatisfy(positive, 10)
+	}, "Generator violates gopter_test.TestMustSatisfy.func1 in sample 1 of 10", "labels: negative", "seed: ")
+	mustPanic("nil value", func() {
+		gen.PtrOf(gen.Int()).MustSatisfy(func(v *int) bool { return v != nil }, 100)
+	}, "(*int)(nil)")
+	mustPanic("no func", func() {
+		ints.MustSatisfy(true, 10)
+	}, "has to be a func")
+	mustPanic("wrong param", func() {
+		ints.MustSatisfy(func(v string) bool { return true }, 10)
+	}, "assignable to int")
+	mustPanic("wrong result", func() {
+		ints.MustSatisfy(func(v int) int { return v }, 10)
+	}, "return value of bool")
+}
@@ -0,0 +1,36 @@
+package gopter
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// InputDedup records the inputs of a property (by a hash of their %#v
+// representation), it is safe for concurrent use
+type InputDedup struct {
+	lock sync.Mutex
+	seen map[uint64]bool
+}
+
+// NewInputDedup creates an empty InputDedup
+func NewInputDedup() *InputDedup {
+	return &InputDedup{seen: map[uint64]bool{}}
+}
+
+// Seen records the inputs and reports if they have been recorded before
+func (d *InputDedup) Seen(inputs ...interface{}) bool {
+	hash := fnv.New64a()
+	for _, input := range inputs {
+		fmt.Fprintf(hash, "%#v\x00", input)
+	}
+	key := hash.Sum64()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.seen[key] {
+		return true
+	}
+	d.seen[key] = true
+	return false
+}
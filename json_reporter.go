@@ -0,0 +1,93 @@
+package gopter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// JSONArg is an argument of a property result reported by a JSONReporter
+type JSONArg struct {
+	Label string `json:"label,omitempty"`
+	// Value is the (shrunk) argument as formatted by fmt ("%+v"), since not
+	// every argument can be represented as JSON
+	Value string `json:"value"`
+	// Original is the argument before shrinking (empty if it has not been
+	// shrunk)
+	Original string `json:"original,omitempty"`
+	Shrinks  int    `json:"shrinks"`
+}
+
+// JSONResult is a property result reported by a JSONReporter
+type JSONResult struct {
+	Property  string    `json:"property"`
+	Status    string    `json:"status"`
+	Seed      int64     `json:"seed,omitempty"`
+	Succeeded int       `json:"succeeded"`
+	Discarded int       `json:"discarded"`
+	Labels    []string  `json:"labels,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Args      []JSONArg `json:"args,omitempty"`
+	// Time and its breakdown (see TimeBreakdown) in nanoseconds
+	Time       time.Duration `json:"timeNs"`
+	Generation time.Duration `json:"generationNs,omitempty"`
+	Evaluation time.Duration `json:"evaluationNs,omitempty"`
+	Shrinking  time.Duration `json:"shrinkingNs,omitempty"`
+}
+
+// JSONReporter reports property results as JSON lines (one JSONResult per
+// line), e.g. to aggregate flaky properties, shrink quality and runtimes of a
+// test suite:
+//
+//	properties.TestingRun(t, gopter.NewJSONReporter(output))
+type JSONReporter struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONReporter creates a new JSON reporter writing to output
+func NewJSONReporter(output io.Writer) Reporter {
+	return &JSONReporter{encoder: json.NewEncoder(output)}
+}
+
+// ReportTestResult reports a single property result as JSON line
+func (r *JSONReporter) ReportTestResult(propName string, result *TestResult) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// the reporter interface does not support errors, a failed write is
+	// noticed by the consumer of the output
+	r.encoder.Encode(NewJSONResult(propName, result))
+}
+
+// NewJSONResult converts a property result to its JSON representation
+func NewJSONResult(propName string, result *TestResult) *JSONResult {
+	jsonResult := &JSONResult{
+		Property:   propName,
+		Status:     result.Status.String(),
+		Seed:       result.Seed,
+		Succeeded:  result.Succeeded,
+		Discarded:  result.Discarded,
+		Labels:     result.Labels,
+		Time:       result.Time,
+		Generation: result.Timing.Generation,
+		Evaluation: result.Timing.Evaluation,
+		Shrinking:  result.Timing.Shrinking,
+	}
+	if result.Error != nil {
+		jsonResult.Error = result.Error.Error()
+	}
+	for _, arg := range result.Args {
+		jsonArg := JSONArg{
+			Label:   arg.Label,
+			Value:   fmt.Sprintf("%+v", arg.Arg),
+			Shrinks: arg.Shrinks,
+		}
+		if arg.Shrinks > 0 {
+			jsonArg.Original = fmt.Sprintf("%+v", arg.OrigArg)
+		}
+		jsonResult.Args = append(jsonResult.Args, jsonArg)
+	}
+	return jsonResult
+}
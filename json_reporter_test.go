@@ -0,0 +1,61 @@
+package gopter_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestJSONReporter(t *testing.T) {
+	var buffer bytes.Buffer
+	properties := gopter.NewProperties(gopter.DefaultTestParametersWithSeed(1234))
+	properties.Property("always pass", prop.ForAll(
+		func(v int32) bool {
+			return true
+		},
+		gen.Int32(),
+	))
+	properties.Property("small", prop.ForAll(
+		func(v int) bool {
+			return v < 100
+		},
+		gen.IntRange(0, 1000).WithLabel("value"),
+	))
+	if properties.Run(gopter.NewJSONReporter(&buffer)) {
+		t.Error("Properties should fail")
+	}
+
+	var results []gopter.JSONResult
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+		var result gopter.JSONResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatalf("Invalid line %q: %v", scanner.Text(), err)
+		}
+		results = append(results, result)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Invalid results: %#v", results)
+	}
+	if passed := results[0]; passed.Property != "always pass" || passed.Status != "PASSED" || passed.Succeeded != 100 || passed.Seed != 1234 || passed.Time <= 0 || len(passed.Args) != 0 {
+		t.Errorf("Invalid passed result: %#v", passed)
+	}
+	failed := results[1]
+	if failed.Property != "small" || failed.Status != "FAILED" || failed.Seed != 1234 || len(failed.Args) != 1 {
+		t.Fatalf("Invalid failed result: %#v", failed)
+	}
+	if arg := failed.Args[0]; arg.Label != "value" || arg.Value != "100" || arg.Shrinks == 0 || arg.Original == "" {
+		t.Errorf("Invalid arg: %#v", arg)
+	}
+
+	result := gopter.NewJSONResult("error", &gopter.TestResult{Status: gopter.TestError, Error: errors.New("Boom"), Labels: []string{"label"}})
+	if result.Status != "ERROR" || result.Error != "Boom" || len(result.Labels) != 1 {
+		t.Errorf("Invalid error result: %#v", result)
+	}
+}
@@ -11,15 +11,17 @@ import (
 )
 
 type lockedSource struct {
-	lk  sync.Mutex
-	src rand.Source64
+	lk   sync.Mutex
+	src  rand.Source64
+	seed int64
 }
 
 // NewLockedSource takes a seed and returns a new
 // lockedSource for use with rand.New
 func NewLockedSource(seed int64) *lockedSource {
 	return &lockedSource{
-		src: rand.NewSource(seed).(rand.Source64),
+		src:  rand.NewSource(seed).(rand.Source64),
+		seed: seed,
 	}
 }
 
@@ -40,13 +42,23 @@ func (r *lockedSource) Uint64() (n uint64) {
 func (r *lockedSource) Seed(seed int64) {
 	r.lk.Lock()
 	r.src.Seed(seed)
+	r.seed = seed
 	r.lk.Unlock()
 }
 
+// lastSeed is the seed the source has last been seeded with
+func (r *lockedSource) lastSeed() (seed int64) {
+	r.lk.Lock()
+	seed = r.seed
+	r.lk.Unlock()
+	return
+}
+
 // seedPos implements Seed for a lockedSource without a race condition.
 func (r *lockedSource) seedPos(seed int64, readPos *int8) {
 	r.lk.Lock()
 	r.src.Seed(seed)
+	r.seed = seed
 	*readPos = 0
 	r.lk.Unlock()
 }
@@ -3,7 +3,9 @@ package gopter
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"runtime/debug"
+	"sync"
 )
 
 // Prop represent some kind of property that (drums please) can and should be checked
@@ -28,32 +30,204 @@ func SaveProp(prop Prop) Prop {
 
 // Check the property using specific parameters
 func (prop Prop) Check(parameters *TestParameters) *TestResult {
+	return prop.checkWith(parameters, nil)
+}
+
+// checkWith validates the parameters, checks the property (with early stop
+// and escalation) and records the seed of the result, onIteration (if not
+// nil) is notified about the result of every iteration (see check)
+func (prop Prop) checkWith(parameters *TestParameters, onIteration func(size int, propResult *PropResult)) *TestResult {
+	seed := parameters.currentSeed()
+	if parameters.seedErr != nil {
+		return &TestResult{Status: TestError, Error: parameters.seedErr, Seed: seed}
+	}
+	if err := parameters.checkShard(); err != nil {
+		return &TestResult{Status: TestError, Error: err, Seed: seed}
+	}
+	original := parameters
+	earlyStop := parameters.EarlyStopTests()
+	if earlyStop > 0 && earlyStop < parameters.MinSuccessfulTests {
+		stopped := *parameters
+		stopped.MinSuccessfulTests = earlyStop
+		parameters = &stopped
+	} else {
+		earlyStop = 0
+	}
+	result := prop.check(parameters, onIteration)
+	if result.options != nil {
+		return prop.checkWith(original.withOptions(result.options), onIteration)
+	}
+	if parameters.EscalationRounds > 0 && parameters.MaxSize > 0 && result.Status == TestPassed && !result.Exhaustive {
+		result = prop.escalate(parameters, result, onIteration)
+	}
+	result.EarlyStopped = earlyStop > 0 && result.Status == TestPassed && !result.Exhaustive
+	result.Seed = seed
+	return result
+}
+
+// escalate checks a passed property with doubled sizes in each round (see
+// TestParameters.EscalationRounds)
+func (prop Prop) escalate(parameters *TestParameters, result *TestResult, onIteration func(size int, propResult *PropResult)) *TestResult {
+	tests := parameters.EscalationTests
+	if tests <= 0 {
+		tests = (parameters.MinSuccessfulTests + 9) / 10
+	}
+	result.MaxSizeVerified = parameters.MaxSize
+	for round := 0; round < parameters.EscalationRounds; round++ {
+		escalated := *parameters
+		escalated.MinSize = result.MaxSizeVerified
+		escalated.MaxSize = 2 * result.MaxSizeVerified
+		escalated.MinSuccessfulTests = tests
+		escalated.Corpus = nil
+		if escalated.MaxSize <= escalated.MinSize {
+			// overflow
+			break
+		}
+		roundResult := prop.check(&escalated, onIteration)
+		roundResult.Succeeded += result.Succeeded
+		roundResult.Discarded += result.Discarded
+		roundResult.Duplicates += result.Duplicates
+		roundResult.CorpusExamples = result.CorpusExamples
+		roundResult.SieveStats = mergeSieveStats(result.SieveStats, roundResult.SieveStats)
+		roundResult.Time += result.Time
+		roundResult.Timing = roundResult.Timing.Add(result.Timing)
+		if roundResult.Status != TestPassed {
+			roundResult.MaxSizeVerified = result.MaxSizeVerified
+			return roundResult
+		}
+		roundResult.MaxSizeVerified = escalated.MaxSize
+		result = roundResult
+	}
+	return result
+}
+
+// check the property, onIteration (if not nil) is notified about the result
+// of every iteration (concurrently if there are multiple workers)
+func (prop Prop) check(parameters *TestParameters, onIteration func(size int, propResult *PropResult)) *TestResult {
+	sharded := parameters.sharded()
+	checkSeed := parameters.currentSeed()
+	// the sizes of the iterations of all shards grow evenly
+	allTests := parameters.MinSuccessfulTests
+	if sharded {
+		shard := *parameters
+		shard.MinSuccessfulTests = parameters.shardCount(parameters.MinSuccessfulTests)
+		parameters = &shard
+	}
 	iterations := math.Ceil(float64(parameters.MinSuccessfulTests) / float64(parameters.Workers))
 	sizeStep := float64(parameters.MaxSize-parameters.MinSize) / (iterations * float64(parameters.Workers))
+	if sharded {
+		sizeStep = float64(parameters.MaxSize-parameters.MinSize) / float64(allTests)
+	}
 
 	genParameters := GenParameters{
-		MinSize:        parameters.MinSize,
-		MaxSize:        parameters.MaxSize,
-		MaxShrinkCount: parameters.MaxShrinkCount,
-		Rng:            parameters.Rng,
+		MinSize:           parameters.MinSize,
+		MaxSize:           parameters.MaxSize,
+		MaxShrinkCount:    parameters.MaxShrinkCount,
+		ArgShrinkStrategy: parameters.ArgShrinkStrategy,
+		ShrinkStrategy:    parameters.ShrinkStrategy,
+		Rng:               parameters.Rng,
+		TraceGenerators:   parameters.TraceGenerators,
+		ExhaustiveLimit:   parameters.ExhaustiveLimit,
+		SieveStats:        NewSieveStats(),
+		RateLimiter:       NewRateLimiter(parameters.MaxRate),
+		UniquePools:       NewUniquePools(),
+		OptionsApplied:    parameters.optionsApplied,
 	}
+	if sharded {
+		genParameters.ShardIndex = parameters.ShardIndex
+		genParameters.ShardTotal = parameters.ShardTotal
+	}
+	if parameters.DedupInputs {
+		genParameters.InputDedup = NewInputDedup()
+	}
+	if parameters.Corpus != nil {
+		if result := prop.replayCorpus(parameters.Corpus, genParameters); result != nil {
+			result.SieveStats = genParameters.SieveStats.Stats()
+			return result
+		}
+	}
+	maxDuplicates := int(iterations * math.Max(parameters.MaxDiscardRatio, 1))
+	var checkedLock sync.Mutex
+	var checked *TestResult
+	var options []PropertyOption
 	runner := &runner{
 		parameters: parameters,
-		worker: func(workerIdx int, shouldStop shouldStop) *TestResult {
+		worker: func(workerIdx int, shouldStop shouldStop) (result *TestResult) {
 			var n int
 			var d int
+			var dups int
+			// attempt counts the duplicates of the current iteration
+			var attempt int
+			var timing TimeBreakdown
+			defer func() {
+				result.Timing = timing
+				result.Duplicates = dups
+			}()
 
 			isExhaused := func() bool {
 				return n+d > parameters.MinSuccessfulTests &&
 					1.0+float64(parameters.Workers*n)*parameters.MaxDiscardRatio < float64(d)
 			}
 
-			for !shouldStop() && n < int(iterations) {
-				size := float64(parameters.MinSize) + (sizeStep * float64(workerIdx+(parameters.Workers*(n+d))))
-				propResult := prop(genParameters.WithSize(int(size)))
+			workerIterations := int(iterations)
+			if sharded {
+				// the workers split the iterations of the shard exactly
+				workerIterations = (parameters.MinSuccessfulTests - workerIdx + parameters.Workers - 1) / parameters.Workers
+			}
+			for !shouldStop() && n < workerIterations {
+				iteration := workerIdx + (parameters.Workers * (n + d))
+				iterationParameters := genParameters
+				if sharded {
+					// the number of the iteration among the iterations of all shards
+					iteration = parameters.ShardIndex + parameters.ShardTotal*iteration
+					seed := iterationSeed(checkSeed, iteration)
+					if attempt > 0 {
+						// a duplicate is retried with different values
+						seed = iterationSeed(seed, attempt)
+					}
+					iterationParameters.Rng = rand.New(NewLockedSource(seed))
+				}
+				size := float64(parameters.MinSize) + (sizeStep * float64(iteration))
+				genParameters.RateLimiter.Wait()
+				propResult := prop(iterationParameters.WithSize(int(size)))
+				if propResult.Options != nil && !parameters.optionsApplied {
+					// the check is restarted with the options
+					checkedLock.Lock()
+					options = propResult.Options
+					checkedLock.Unlock()
+					return &TestResult{Status: TestExhausted}
+				}
+				if onIteration != nil {
+					onIteration(int(size), propResult)
+				}
+				timing = timing.Add(propResult.Timing)
+				if propResult.Checked != nil {
+					timing = timing.Add(propResult.Checked.Timing)
+					checkedLock.Lock()
+					checked = propResult.Checked
+					checkedLock.Unlock()
+					return propResult.Checked
+				}
 
+				if propResult.Duplicate {
+					attempt++
+				} else {
+					attempt = 0
+				}
 				switch propResult.Status {
 				case PropUndecided:
+					if propResult.Duplicate {
+						dups++
+						if dups > maxDuplicates {
+							// the generators do not produce enough distinct inputs
+							return &TestResult{
+								Status:    TestExhausted,
+								Succeeded: n,
+								Discarded: d,
+							}
+						}
+						break
+					}
 					d++
 					if isExhaused() {
 						return &TestResult{
@@ -79,6 +253,7 @@ func (prop Prop) Check(parameters *TestParameters) *TestResult {
 						Succeeded: n,
 						Discarded: d,
 						Labels:    propResult.Labels,
+						Error:     propResult.Error,
 						Args:      propResult.Args,
 					}
 				case PropError:
@@ -109,5 +284,17 @@ func (prop Prop) Check(parameters *TestParameters) *TestResult {
 		},
 	}
 
-	return runner.runWorkers()
+	result := runner.runWorkers()
+	if options != nil {
+		return &TestResult{options: options}
+	}
+	if checked != nil {
+		checked.Time = result.Time
+		result = checked
+	}
+	result.SieveStats = genParameters.SieveStats.Stats()
+	if parameters.Corpus != nil {
+		result.CorpusExamples = parameters.shardCount(len(parameters.Corpus.Examples()))
+	}
+	return result
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"strings"
 
 	"github.com/leanovate/gopter"
 )
@@ -16,35 +17,72 @@ func checkConditionFunc(check interface{}, numArgs int) (func([]reflect.Value) *
 	if checkType.Kind() != reflect.Func {
 		return nil, fmt.Errorf("First param of ForrAll has to be a func: %v", checkVal.Kind())
 	}
-	if checkType.NumIn() != numArgs {
+	collects := collectsFailures(checkType)
+	if collects && checkType.NumIn()-1 != numArgs {
+		return nil, fmt.Errorf("Number of parameters does not match number of generators: %d != %d", checkType.NumIn()-1, numArgs)
+	} else if !collects && checkType.NumIn() != numArgs {
 		return nil, fmt.Errorf("Number of parameters does not match number of generators: %d != %d", checkType.NumIn(), numArgs)
 	}
-	if checkType.NumOut() == 0 {
+	var callCheck func([]reflect.Value) *gopter.PropResult
+	if checkType.NumOut() == 0 && !collects {
 		return nil, errors.New("At least one output parameters is required")
 	} else if checkType.NumOut() > 2 {
 		return nil, fmt.Errorf("No more than 2 output parameters are allowed: %d", checkType.NumOut())
 	} else if checkType.NumOut() == 2 && !checkType.Out(1).Implements(typeOfError) {
 		return nil, fmt.Errorf("No 2 output has to be error: %v", checkType.Out(1).Kind())
 	} else if checkType.NumOut() == 2 {
-		return func(values []reflect.Value) *gopter.PropResult {
+		callCheck = func(values []reflect.Value) *gopter.PropResult {
 			results := checkVal.Call(values)
 			if results[1].IsNil() {
 				return convertResult(results[0].Interface(), nil)
 			}
 			return convertResult(results[0].Interface(), results[1].Interface().(error))
-		}, nil
-	}
-	return func(values []reflect.Value) (result *gopter.PropResult) {
-		defer func() {
-			if r := recover(); r != nil {
-				result = &gopter.PropResult{
-					Status:     gopter.PropError,
-					Error:      fmt.Errorf("Check paniced: %v", r),
-					ErrorStack: debug.Stack(),
+		}
+	} else {
+		isErrorCondition := checkType.NumOut() == 1 && checkType.Out(0).Implements(typeOfError)
+		callCheck = func(values []reflect.Value) (result *gopter.PropResult) {
+			defer func() {
+				if r := recover(); r != nil {
+					result = &gopter.PropResult{
+						Status:     gopter.PropError,
+						Error:      fmt.Errorf("Check paniced: %v", r),
+						ErrorStack: debug.Stack(),
+					}
 				}
+			}()
+			results := checkVal.Call(values)
+			if len(results) == 0 {
+				// only the failures of the collector count
+				return &gopter.PropResult{Status: gopter.PropTrue}
+			}
+			if isErrorCondition {
+				return convertErrorResult(results[0])
 			}
-		}()
-		results := checkVal.Call(values)
-		return convertResult(results[0].Interface(), nil)
-	}, nil
+			return convertResult(results[0].Interface(), nil)
+		}
+	}
+	if collects {
+		return withCollector(callCheck), nil
+	}
+	return callCheck, nil
+}
+
+// checkArgType verifies that the value type generated by genResult can be
+// used as the idx-th parameter of the check condition.
+func checkArgType(checkType reflect.Type, idx int, genResult *gopter.GenResult, valueType reflect.Type) error {
+	if valueType == nil || valueType.AssignableTo(checkType.In(idx)) {
+		return nil
+	}
+	generator := "generator"
+	if len(genResult.Labels) > 0 {
+		generator = fmt.Sprintf("generator %q", strings.Join(genResult.Labels, ", "))
+	}
+	return fmt.Errorf("Argument %d (ARG_%d) of condition %v expects %v, but %s produces %v", idx, idx, checkType, checkType.In(idx), generator, valueType)
+}
+
+func checkArgValue(checkType reflect.Type, idx int, genResult *gopter.GenResult, value reflect.Value) error {
+	if !value.IsValid() {
+		return nil
+	}
+	return checkArgType(checkType, idx, genResult, value.Type())
 }
@@ -0,0 +1,120 @@
+package prop
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+var typeOfCollector = reflect.TypeOf((*Collector)(nil))
+
+// Collector records the failed (named) assertions of a condition, so that all
+// expectations violated by the same arguments are reported instead of only
+// the first one.
+// A condition opts in by taking a *Collector as first parameter (followed by
+// the generated arguments), e.g.
+//
+//	prop.ForAll(func(c *prop.Collector, a, b int) {
+//		c.Check("commutative", a+b == b+a)
+//		c.Check("monotone", a+b >= a)
+//	}, gen.Int(), gen.Int())
+//
+// Every check of the condition gets a new Collector, the condition may return
+// a result as usual (which is combined with the recorded failures) or nothing
+// at all.
+type Collector struct {
+	failures []string
+}
+
+// Check records a failure of the assertion "name" unless ok is true, the result
+// is ok
+func (c *Collector) Check(name string, ok bool) bool {
+	if !ok {
+		c.failures = append(c.failures, name)
+	}
+	return ok
+}
+
+// Equal records a failure of the assertion "name" labeled with the differences
+// unless expected and actual are equal (see EqualValues), the result is true if
+// they are
+func (c *Collector) Equal(name string, expected, actual interface{}) bool {
+	return c.Result(name, EqualValues(expected, actual))
+}
+
+// Result records a failure of the assertion "name" unless result is a success
+// (e.g. the result of a comparison like SlicesEquivalent), the labels and error
+// of the result are part of the failure
+func (c *Collector) Result(name string, result *gopter.PropResult) bool {
+	if result.Success() {
+		return true
+	}
+	details := append([]string{}, result.Labels...)
+	if result.Error != nil {
+		details = append(details, result.Error.Error())
+	}
+	if len(details) == 0 {
+		c.failures = append(c.failures, name)
+	}
+	for _, detail := range details {
+		c.failures = append(c.failures, fmt.Sprintf("%s: %s", name, detail))
+	}
+	return false
+}
+
+// Errorf records a failure of the assertion "name" with a formatted message
+func (c *Collector) Errorf(name string, format string, args ...interface{}) {
+	c.failures = append(c.failures, fmt.Sprintf("%s: %s", name, fmt.Sprintf(format, args...)))
+}
+
+// Failed checks if any failure has been recorded
+func (c *Collector) Failed() bool {
+	return len(c.failures) > 0
+}
+
+// Failures are the recorded failures in the order of their assertions
+func (c *Collector) Failures() []string {
+	return c.failures
+}
+
+// collectsFailures checks if a condition takes a Collector as first parameter
+func collectsFailures(checkType reflect.Type) bool {
+	return checkType.Kind() == reflect.Func && checkType.NumIn() > 0 && checkType.In(0) == typeOfCollector
+}
+
+// conditionArgsType is the type of a condition without the Collector
+// parameter, i.e. its parameters correspond to the generators
+func conditionArgsType(condition interface{}) reflect.Type {
+	conditionType := reflect.TypeOf(condition)
+	if !collectsFailures(conditionType) {
+		return conditionType
+	}
+	in := make([]reflect.Type, conditionType.NumIn()-1)
+	for i := range in {
+		in[i] = conditionType.In(i + 1)
+	}
+	out := make([]reflect.Type, conditionType.NumOut())
+	for i := range out {
+		out[i] = conditionType.Out(i)
+	}
+	return reflect.FuncOf(in, out, conditionType.IsVariadic())
+}
+
+// withCollector passes a new Collector to each check of a condition and adds
+// the recorded failures to its result
+func withCollector(callCheck func([]reflect.Value) *gopter.PropResult) func([]reflect.Value) *gopter.PropResult {
+	return func(values []reflect.Value) *gopter.PropResult {
+		collector := &Collector{}
+		result := callCheck(append([]reflect.Value{reflect.ValueOf(collector)}, values...))
+		if !collector.Failed() {
+			return result
+		}
+		collected := *result
+		if collected.Status != gopter.PropError {
+			collected.Status = gopter.PropFalse
+		}
+		collected.Labels = append(append([]string{}, result.Labels...), collector.failures...)
+		return &collected
+	}
+}
@@ -0,0 +1,61 @@
+package prop_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestCollector(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+
+	result := prop.ForAll(func(c *prop.Collector, a, b int) {
+		c.Check("commutative", a+b == b+a)
+		c.Check("small sum", a+b < 100)
+		c.Equal("sum", a, a+b)
+		c.Errorf("always", "a=%d", a)
+	}, gen.IntRange(0, 100), gen.IntRange(1, 100)).Check(parameters)
+	if result.Status != gopter.TestFailed || len(result.Args) != 2 {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	// all violated assertions of the same (shrunk) arguments are reported
+	a, b := result.Args[0].Arg.(int), result.Args[1].Arg.(int)
+	if a != 0 || b != 1 || !reflect.DeepEqual(result.Labels, []string{"sum: 0 != 1", "always: a=0"}) {
+		t.Errorf("Invalid result: %d %d %#v", a, b, result.Labels)
+	}
+
+	result = prop.ForAll(func(c *prop.Collector, a int) bool {
+		c.Check("positive", a > 0)
+		return a < 50
+	}, gen.IntRange(-100, 100)).Check(parameters)
+	if result.Status != gopter.TestFailed || !reflect.DeepEqual(result.Labels, []string{"positive"}) {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	result = prop.ForAll(func(c *prop.Collector, a []int) {
+		c.Result("equivalent", prop.SlicesEquivalent([]int{1, 2}, a))
+	}, gen.Const([]int{2, 1})).Check(parameters)
+	if !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	result = prop.ForAll(func(c *prop.Collector, a int) {
+		panic("boom")
+	}, gen.Int()).Check(parameters)
+	if result.Status != gopter.TestError {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	result = prop.ForAll(func(c *prop.Collector, a string) {}, gen.Int()).Check(parameters)
+	if result.Status != gopter.TestError {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	result = prop.ForAll(func(a int) {}, gen.Int()).Check(parameters)
+	if result.Status != gopter.TestError {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
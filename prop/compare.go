@@ -0,0 +1,195 @@
+package prop
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+// maxReportedDiffs limits the number of differences added as labels
+const maxReportedDiffs = 10
+
+// EqualValues compares expected and actual (like reflect.DeepEqual) as result
+// of a condition. If they differ the result is false and labeled with the
+// differences, i.e. the paths of the differing elements, fields or map
+// entries (e.g. "[2].Name: \"a\" != \"b\"").
+func EqualValues(expected, actual interface{}) *gopter.PropResult {
+	if reflect.DeepEqual(expected, actual) {
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	}
+	var diffs []string
+	diffValues("", reflect.ValueOf(expected), reflect.ValueOf(actual), &diffs)
+	if len(diffs) == 0 {
+		// e.g. NaNs or funcs are never deeply equal
+		diffs = append(diffs, fmt.Sprintf("%#v != %#v", expected, actual))
+	}
+	return &gopter.PropResult{
+		Status: gopter.PropFalse,
+		Labels: limitDiffs(diffs),
+	}
+}
+
+// SlicesEquivalent compares two slices (or arrays) ignoring the order of
+// their elements as result of a condition, i.e. each element has to occur
+// equally often in both. If they differ the result is false and labeled with
+// the missing and unexpected elements of actual.
+func SlicesEquivalent(expected, actual interface{}) *gopter.PropResult {
+	expectedVal, actualVal := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if !isSliceOrArray(expectedVal) || !isSliceOrArray(actualVal) {
+		return &gopter.PropResult{
+			Status: gopter.PropError,
+			Error:  fmt.Errorf("SlicesEquivalent requires slices or arrays: %T, %T", expected, actual),
+		}
+	}
+	unmatched := make([]bool, actualVal.Len())
+	for i := range unmatched {
+		unmatched[i] = true
+	}
+	var diffs []string
+	for i := 0; i < expectedVal.Len(); i++ {
+		element := expectedVal.Index(i).Interface()
+		found := false
+		for j := range unmatched {
+			if unmatched[j] && reflect.DeepEqual(element, actualVal.Index(j).Interface()) {
+				unmatched[j], found = false, true
+				break
+			}
+		}
+		if !found {
+			diffs = append(diffs, fmt.Sprintf("missing: %#v", element))
+		}
+	}
+	for j, isUnmatched := range unmatched {
+		if isUnmatched {
+			diffs = append(diffs, fmt.Sprintf("unexpected: %#v", actualVal.Index(j).Interface()))
+		}
+	}
+	if len(diffs) == 0 {
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	}
+	return &gopter.PropResult{
+		Status: gopter.PropFalse,
+		Labels: limitDiffs(diffs),
+	}
+}
+
+// ApproxEqual creates a comparison of floats as result of a condition that
+// succeeds if expected and actual differ by at most epsilon (or are both NaN).
+// If they differ the result is false and labeled with the difference.
+func ApproxEqual(epsilon float64) func(expected, actual float64) *gopter.PropResult {
+	return func(expected, actual float64) *gopter.PropResult {
+		if math.IsNaN(expected) && math.IsNaN(actual) || expected == actual ||
+			math.Abs(expected-actual) <= epsilon {
+			return &gopter.PropResult{Status: gopter.PropTrue}
+		}
+		return &gopter.PropResult{
+			Status: gopter.PropFalse,
+			Labels: []string{
+				fmt.Sprintf("%v != %v (difference %v exceeds %v)", expected, actual, math.Abs(expected-actual), epsilon),
+			},
+		}
+	}
+}
+
+func isSliceOrArray(value reflect.Value) bool {
+	return value.Kind() == reflect.Slice || value.Kind() == reflect.Array
+}
+
+func limitDiffs(diffs []string) []string {
+	if len(diffs) > maxReportedDiffs {
+		return append(diffs[:maxReportedDiffs], fmt.Sprintf("... %d more differences", len(diffs)-maxReportedDiffs))
+	}
+	return diffs
+}
+
+// diffValues collects the differences of two values by their path
+func diffValues(path string, expected, actual reflect.Value, diffs *[]string) {
+	if !expected.IsValid() || !actual.IsValid() || expected.Type() != actual.Type() {
+		*diffs = append(*diffs, fmt.Sprintf("%s%s != %s", pathPrefix(path), formatValue(expected), formatValue(actual)))
+		return
+	}
+	if expected.CanInterface() && actual.CanInterface() &&
+		reflect.DeepEqual(expected.Interface(), actual.Interface()) {
+		return
+	}
+	switch expected.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !expected.IsNil() && !actual.IsNil() {
+			diffValues(path, expected.Elem(), actual.Elem(), diffs)
+			return
+		}
+	case reflect.Slice, reflect.Array:
+		if expected.Kind() == reflect.Array || !expected.IsNil() && !actual.IsNil() {
+			if expected.Len() != actual.Len() {
+				*diffs = append(*diffs, fmt.Sprintf("%slength %d != %d", pathPrefix(path), expected.Len(), actual.Len()))
+			}
+			for i := 0; i < expected.Len() && i < actual.Len(); i++ {
+				diffValues(fmt.Sprintf("%s[%d]", path, i), expected.Index(i), actual.Index(i), diffs)
+			}
+			return
+		}
+	case reflect.Map:
+		if !expected.IsNil() && !actual.IsNil() {
+			diffMaps(path, expected, actual, diffs)
+			return
+		}
+	case reflect.Struct:
+		if expected.CanInterface() {
+			for i := 0; i < expected.NumField(); i++ {
+				diffValues(path+"."+expected.Type().Field(i).Name, expected.Field(i), actual.Field(i), diffs)
+			}
+			return
+		}
+	}
+	if !expected.CanInterface() {
+		// unexported fields can only be compared by their parent
+		*diffs = append(*diffs, fmt.Sprintf("%sdiffers", pathPrefix(path)))
+		return
+	}
+	*diffs = append(*diffs, fmt.Sprintf("%s%s != %s", pathPrefix(path), formatValue(expected), formatValue(actual)))
+}
+
+func diffMaps(path string, expected, actual reflect.Value, diffs *[]string) {
+	keys := expected.MapKeys()
+	for _, key := range actual.MapKeys() {
+		if !expected.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+	// sort for a deterministic report
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i].Interface()) < fmt.Sprintf("%#v", keys[j].Interface())
+	})
+	for _, key := range keys {
+		keyPath := fmt.Sprintf("%s[%#v]", path, key.Interface())
+		expectedValue, actualValue := expected.MapIndex(key), actual.MapIndex(key)
+		switch {
+		case !actualValue.IsValid():
+			*diffs = append(*diffs, fmt.Sprintf("%smissing", pathPrefix(keyPath)))
+		case !expectedValue.IsValid():
+			*diffs = append(*diffs, fmt.Sprintf("%sunexpected %s", pathPrefix(keyPath), formatValue(actualValue)))
+		default:
+			diffValues(keyPath, expectedValue, actualValue, diffs)
+		}
+	}
+}
+
+func pathPrefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + ": "
+}
+
+func formatValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "nil"
+	}
+	if !value.CanInterface() {
+		return value.String()
+	}
+	return fmt.Sprintf("%#v", value.Interface())
+}
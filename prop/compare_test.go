@@ -0,0 +1,112 @@
+package prop_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+type compareItem struct {
+	Name  string
+	Count int
+	Tags  map[string]int
+}
+
+func TestEqualValues(t *testing.T) {
+	if result := prop.EqualValues([]int{1, 2}, []int{1, 2}); result.Status != gopter.PropTrue {
+		t.Errorf("Invalid result for equal values: %#v", result)
+	}
+
+	expected := []compareItem{{Name: "a", Count: 1}, {Name: "b", Tags: map[string]int{"x": 1, "y": 2}}}
+	actual := []compareItem{{Name: "a", Count: 2}, {Name: "b", Tags: map[string]int{"x": 3, "z": 2}}, {}}
+	result := prop.EqualValues(expected, actual)
+	if result.Status != gopter.PropFalse || !reflect.DeepEqual(result.Labels, []string{
+		"length 2 != 3",
+		"[0].Count: 1 != 2",
+		`[1].Tags["x"]: 1 != 3`,
+		`[1].Tags["y"]: missing`,
+		`[1].Tags["z"]: unexpected 2`,
+	}) {
+		t.Errorf("Invalid result for different values: %#v", result.Labels)
+	}
+
+	result = prop.EqualValues(1, "1")
+	if result.Status != gopter.PropFalse || !reflect.DeepEqual(result.Labels, []string{`1 != "1"`}) {
+		t.Errorf("Invalid result for different types: %#v", result.Labels)
+	}
+
+	result = prop.EqualValues(math.NaN(), math.NaN())
+	if result.Status != gopter.PropFalse || len(result.Labels) != 1 {
+		t.Errorf("Invalid result for NaN: %#v", result.Labels)
+	}
+
+	result = prop.EqualValues(make([]int, 20), []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+	if result.Status != gopter.PropFalse || len(result.Labels) != 11 || result.Labels[10] != "... 10 more differences" {
+		t.Errorf("Invalid result for many differences: %#v", result.Labels)
+	}
+}
+
+func TestSlicesEquivalent(t *testing.T) {
+	if result := prop.SlicesEquivalent([]string{"a", "b", "a"}, []string{"a", "a", "b"}); result.Status != gopter.PropTrue {
+		t.Errorf("Invalid result for equivalent slices: %#v", result)
+	}
+
+	result := prop.SlicesEquivalent([]string{"a", "b", "a"}, [3]string{"b", "c", "a"})
+	if result.Status != gopter.PropFalse || !reflect.DeepEqual(result.Labels, []string{`missing: "a"`, `unexpected: "c"`}) {
+		t.Errorf("Invalid result for different slices: %#v", result.Labels)
+	}
+
+	if result := prop.SlicesEquivalent("a", []string{"a"}); result.Status != gopter.PropError || result.Error == nil {
+		t.Errorf("Invalid result for non-slice: %#v", result)
+	}
+}
+
+func TestApproxEqual(t *testing.T) {
+	approx := prop.ApproxEqual(0.01)
+	if result := approx(1.0, 1.005); result.Status != gopter.PropTrue {
+		t.Errorf("Invalid result for approx equal: %#v", result)
+	}
+	if result := approx(math.NaN(), math.NaN()); result.Status != gopter.PropTrue {
+		t.Errorf("Invalid result for NaN: %#v", result)
+	}
+	if result := approx(math.Inf(1), math.Inf(1)); result.Status != gopter.PropTrue {
+		t.Errorf("Invalid result for infinity: %#v", result)
+	}
+	if result := approx(1.0, 1.1); result.Status != gopter.PropFalse || len(result.Labels) != 1 {
+		t.Errorf("Invalid result for different floats: %#v", result)
+	}
+}
+
+func TestCompareInCondition(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.Rng.Seed(1234)
+
+	reversed := prop.ForAll(
+		func(values []int) *gopter.PropResult {
+			reversed := make([]int, len(values))
+			for i, value := range values {
+				reversed[len(values)-1-i] = value
+			}
+			return prop.SlicesEquivalent(values, reversed)
+		},
+		gen.SliceOf(gen.Int()),
+	)
+	if result := reversed.Check(parameters); !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	truncated := prop.ForAll(
+		func(values []int) *gopter.PropResult {
+			return prop.EqualValues(values, values[:len(values)/2])
+		},
+		gen.SliceOfN(4, gen.IntRange(0, 10)),
+	)
+	result := truncated.Check(parameters)
+	if result.Passed() || len(result.Labels) == 0 || result.Labels[0] != "length 4 != 2" {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
@@ -2,6 +2,7 @@ package prop
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/leanovate/gopter"
 )
@@ -35,3 +36,19 @@ func convertResult(result interface{}, err error) *gopter.PropResult {
 		Error:  fmt.Errorf("Invalid check result: %#v", result),
 	}
 }
+
+// convertErrorResult converts the result of a condition returning an error:
+// nil means that the condition has passed, otherwise the condition is
+// falsified by the error
+func convertErrorResult(result reflect.Value) *gopter.PropResult {
+	switch result.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if result.IsNil() {
+			return &gopter.PropResult{Status: gopter.PropTrue}
+		}
+	}
+	return &gopter.PropResult{
+		Status: gopter.PropFalse,
+		Error:  result.Interface().(error),
+	}
+}
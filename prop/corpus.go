@@ -0,0 +1,43 @@
+package prop
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/leanovate/gopter"
+)
+
+// checkCorpusExample checks the condition with the arguments of a corpus
+// example (see gopter.Corpus), which are not shrunk
+func checkCorpusExample(example string, conditionType reflect.Type, callCheck func([]reflect.Value) *gopter.PropResult) *gopter.PropResult {
+	var args []json.RawMessage
+	if err := json.Unmarshal([]byte(example), &args); err != nil {
+		return &gopter.PropResult{Status: gopter.PropError, Error: fmt.Errorf("Invalid corpus example %s: %v", example, err)}
+	}
+	if len(args) != conditionType.NumIn() {
+		return &gopter.PropResult{
+			Status: gopter.PropError,
+			Error:  fmt.Errorf("Corpus example %s has %d arguments, expected %d", example, len(args), conditionType.NumIn()),
+		}
+	}
+	values := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		value := reflect.New(conditionType.In(i))
+		if err := json.Unmarshal(arg, value.Interface()); err != nil {
+			return &gopter.PropResult{
+				Status: gopter.PropError,
+				Error:  fmt.Errorf("Invalid argument %d of corpus example %s: %v", i, example, err),
+			}
+		}
+		values[i] = value.Elem()
+	}
+	start := time.Now()
+	result := callCheck(values)
+	for _, value := range values {
+		result = result.AddArgs(&gopter.PropArg{Arg: value.Interface(), OrigArg: value.Interface()})
+	}
+	result.Timing.Evaluation = time.Since(start)
+	return result
+}
@@ -0,0 +1,57 @@
+package prop_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestDedupInputs(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		var lock sync.Mutex
+		checked := map[int]int{}
+		parameters := gopter.DefaultTestParametersWithSeed(1234)
+		parameters.DedupInputs = true
+		parameters.Workers = workers
+		result := prop.ForAll(
+			func(a int, b bool) bool {
+				if b {
+					lock.Lock()
+					checked[a]++
+					lock.Unlock()
+				}
+				return true
+			},
+			gen.IntRange(0, 9), gen.Bool(),
+		).Check(parameters)
+
+		// there are only 20 distinct inputs
+		if result.Status != gopter.TestExhausted || result.Succeeded != 20 || result.Duplicates == 0 {
+			t.Errorf("Invalid result with %d workers: %#v", workers, result)
+		}
+		if len(checked) != 10 {
+			t.Errorf("Invalid checked inputs: %v", checked)
+		}
+		for a, count := range checked {
+			if count != 1 {
+				t.Errorf("Input %d checked %d times", a, count)
+			}
+		}
+	}
+
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	parameters.DedupInputs = true
+	result := prop.ForAllNoShrink(func(v int64) bool { return true }, gen.Int64()).Check(parameters)
+	if result.Status != gopter.TestPassed || result.Succeeded != 100 || result.Duplicates != 0 {
+		t.Errorf("Invalid result without duplicates: %#v", result)
+	}
+
+	parameters.DedupInputs = false
+	result = prop.ForAll(func(b bool) bool { return true }, gen.Bool()).Check(parameters)
+	if result.Status != gopter.TestPassed || result.Succeeded != 100 || result.Duplicates != 0 {
+		t.Errorf("Invalid result without deduplication: %#v", result)
+	}
+}
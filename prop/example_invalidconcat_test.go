@@ -35,6 +35,7 @@ func Example_invalidconcat() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! length is sum of lengths: Falsified after 17 passed tests.
+	// > Seed: 1234
 	// ARG_0: bahbxh6
 	// ARG_0_ORIGINAL (2 shrinks): pkpbahbxh6
 	// ARG_1: l
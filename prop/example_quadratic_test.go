@@ -56,6 +56,7 @@ func Example_quadratic() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! solve quadratic: Falsified after 0 passed tests.
+	// > Seed: 1234
 	// ARG_0: -1.4667384313385178e-05
 	// ARG_0_ORIGINAL (187 shrinks): -1.0960555181801604e+51
 	// ARG_1: 0
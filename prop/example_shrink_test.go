@@ -29,8 +29,10 @@ func Example_shrink() {
 	properties.Run(gopter.ConsoleReporter(false))
 	// Output:
 	// ! fail above 100: Falsified after 0 passed tests.
+	// > Seed: 1234
 	// ARG_0: 101
 	// ARG_0_ORIGINAL (56 shrinks): 2041104533947223744
 	// ! fail above 100 no shrink: Falsified after 0 passed tests.
+	// > Seed: 1234
 	// ARG_0: 6006156956070140861
 }
@@ -49,6 +49,7 @@ func Example_timeGen() {
 	//    tests: parsing time "10000-01-01T00:00:00Z" as
 	//    "2006-01-02T15:04:05.999999999Z07:00": cannot parse "0-01-01T00:00:00Z"
 	//    as "-"
+	// > Seed: 1234
 	// ARG_0: 10000-01-01 00:00:00 +0000 UTC
 	// ARG_0_ORIGINAL (45 shrinks): 237903042092-02-10 19:15:18.148265469 +0000
 	//    UTC
@@ -0,0 +1,86 @@
+package prop
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/leanovate/gopter"
+)
+
+// checkExhaustive checks a condition for all combinations of the domains of
+// the generator results. Returns nil if the domains are unknown or the number
+// of combinations exceeds the ExhaustiveLimit.
+func checkExhaustive(genParams *gopter.GenParameters, genResults []*gopter.GenResult,
+	callCheck func([]reflect.Value) *gopter.PropResult) *gopter.PropResult {
+	domains := make([][]interface{}, len(genResults))
+	size := 1
+	for i, genResult := range genResults {
+		domains[i] = genResult.DomainValues()
+		size *= len(domains[i])
+		if domains[i] == nil || size > genParams.ExhaustiveLimit {
+			return nil
+		}
+	}
+
+	checked := &gopter.TestResult{Status: gopter.TestPassed, Exhaustive: true}
+	var failed *gopter.PropResult
+	// index of the combination, skipped counts the combinations of other shards
+	index, skipped := -1, 0
+	gopter.EnumerateProduct(domains, func(combination []interface{}) bool {
+		index++
+		if !genParams.InShard(index) {
+			skipped++
+			return true
+		}
+		values := make([]reflect.Value, len(combination))
+		for i, value := range combination {
+			if value == nil {
+				values[i] = reflect.Zero(genResults[i].ResultType)
+			} else {
+				values[i] = reflect.ValueOf(value)
+			}
+		}
+		start := time.Now()
+		result := callCheck(values)
+		checked.Timing.Evaluation += time.Since(start)
+		switch result.Status {
+		case gopter.PropTrue, gopter.PropProof:
+			checked.Succeeded++
+		case gopter.PropUndecided:
+			checked.Discarded++
+		default:
+			if result.Status == gopter.PropFalse {
+				start = time.Now()
+				result = shrinkArgs(genParams, genResults, values, result, callCheck)
+				checked.Timing.Shrinking = time.Since(start)
+			} else {
+				for i, genResult := range genResults {
+					result = result.AddArgs(gopter.NewPropArg(genResult, 0, combination[i], combination[i]))
+				}
+			}
+			failed = result
+			return false
+		}
+		return true
+	})
+
+	switch {
+	case failed != nil && failed.Status == gopter.PropFalse:
+		checked.Status = gopter.TestFailed
+		checked.Error = failed.Error
+	case failed != nil:
+		checked.Status = gopter.TestError
+		checked.Error = failed.Error
+		checked.ErrorStack = failed.ErrorStack
+	case checked.Succeeded == 0 && (checked.Discarded > 0 || skipped == 0):
+		// a shard might not have any combination at all
+		checked.Status = gopter.TestExhausted
+		return &gopter.PropResult{Status: gopter.PropUndecided, Checked: checked}
+	default:
+		return &gopter.PropResult{Status: gopter.PropTrue, Checked: checked}
+	}
+	checked.Labels = failed.Labels
+	checked.Args = failed.Args
+	failed.Checked = checked
+	return failed
+}
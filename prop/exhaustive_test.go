@@ -0,0 +1,88 @@
+package prop_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestExhaustive(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.ExhaustiveLimit = 100
+	cases := map[string]bool{}
+	result := prop.ForAll(
+		func(b bool, v int, s string) bool {
+			cases[strings.Join([]string{map[bool]string{true: "t", false: "f"}[b], string(rune('0' + v)), s}, "")] = true
+			return true
+		},
+		gen.Bool(),
+		gen.IntRange(0, 4),
+		gen.OneConstOf("a", "b", "c"),
+	).Check(parameters)
+
+	if result.Status != gopter.TestPassed || !result.Exhaustive || result.Succeeded != 30 || len(cases) != 30 {
+		t.Errorf("Invalid result: %#v (cases: %d)", result, len(cases))
+	}
+
+	result = prop.ForAll(
+		func(a, b int8) bool {
+			return int(a)+int(b) < 10
+		},
+		gen.Int8Range(0, 9),
+		gen.Int8Range(0, 9),
+	).Check(parameters)
+	if result.Status != gopter.TestFailed || !result.Exhaustive || result.Succeeded != 19 ||
+		result.Args[0].Arg != int8(1) || result.Args[1].Arg != int8(9) {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	result = prop.ForAll(
+		func(a, b int) bool {
+			return true
+		},
+		gen.IntRange(0, 10),
+		gen.IntRange(0, 10),
+	).Check(parameters)
+	if result.Status != gopter.TestPassed || result.Exhaustive || result.Succeeded != 100 {
+		t.Errorf("Domain exceeding limit should be sampled: %#v", result)
+	}
+
+	var buffer bytes.Buffer
+	properties := gopter.NewProperties(parameters)
+	properties.Property("bools", prop.ForAll(func(a, b bool) bool { return true }, gen.Bool(), gen.Bool()))
+	properties.Run(gopter.NewFormatedReporter(false, 75, &buffer))
+	if buffer.String() != "+ bools: OK, exhaustively verified 4 cases.\n" {
+		t.Errorf("Invalid output: %#v", buffer.String())
+	}
+
+	result = prop.ForAll(
+		func(v int) *gopter.PropResult {
+			return &gopter.PropResult{Status: gopter.PropUndecided}
+		},
+		gen.IntRange(0, 3).WithDomain(0, 1, 2, 3),
+	).Check(parameters)
+	if result.Status != gopter.TestExhausted || !result.Exhaustive || result.Discarded != 4 {
+		t.Errorf("All discarded combinations should be exhausted: %#v", result)
+	}
+
+	// generators choosing between generators only know the domain of the
+	// chosen one
+	chosen := map[string]gopter.Gen{
+		"OneGenOf":  gen.OneGenOf(gen.IntRange(0, 1), gen.IntRange(5, 6)),
+		"Frequency": gen.Frequency(map[int]gopter.Gen{1: gen.IntRange(0, 1), 2: gen.IntRange(5, 6)}),
+		"FlatMap": gen.IntRange(0, 1).FlatMap(func(v interface{}) gopter.Gen {
+			return gen.IntRange(v.(int)*5, v.(int)*5+1)
+		}, reflect.TypeOf(0)),
+	}
+	for name, g := range chosen {
+		result = prop.ForAll(func(v int) bool { return true }, g).Check(parameters)
+		if result.Exhaustive {
+			t.Errorf("%s should not be checked exhaustively: %#v", name, result)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package prop
 
 import (
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/leanovate/gopter"
 )
@@ -15,58 +17,154 @@ condition falsiies the generated values will be shrunk.
 "condition" has to be a function with the same number of parameters as the provided
 generators "gens". The function may return a simple bool (true means that the
 condition has passed), a string (empty string means that condition has passed),
-a *PropResult, or one of former combined with an error.
+an error (nil means that the condition has passed, otherwise the error is reported
+as cause of the failure), a *PropResult, or one of former combined with an error.
+
+If the first parameter of the condition is a *Collector, it collects the failures
+of named assertions (the condition may return nothing in this case), so that all
+assertions violated by the same arguments are reported.
+
+Single arguments can be excluded from shrinking by wrapping their generator with
+NoShrinkArg.
+
+If TestParameters.ExhaustiveLimit is set and all generators have a small finite
+domain the condition is checked for all possible combinations of values instead.
+
+If TestParameters.DedupInputs is set, arguments that have already been checked
+are skipped.
+
+The examples of TestParameters.Corpus are checked before any generated
+arguments.
 */
 func ForAll(condition interface{}, gens ...gopter.Gen) gopter.Prop {
+	return forAll(condition, nil, gens)
+}
+
+// forAll implements ForAll, "wrapCheck" may be used to decorate the check of
+// the condition
+func forAll(condition interface{}, wrapCheck func(func([]reflect.Value) *gopter.PropResult) func([]reflect.Value) *gopter.PropResult,
+	gens []gopter.Gen) gopter.Prop {
 	callCheck, err := checkConditionFunc(condition, len(gens))
 	if err != nil {
 		return ErrorProp(err)
 	}
+	if wrapCheck != nil {
+		callCheck = wrapCheck(callCheck)
+	}
+	conditionType := conditionArgsType(condition)
+	tracedGens := traceArgs(gens)
 
 	return gopter.SaveProp(func(genParams *gopter.GenParameters) *gopter.PropResult {
-		genResults := make([]*gopter.GenResult, len(gens))
-		values := make([]reflect.Value, len(gens))
-		var ok bool
-		for i, gen := range gens {
-			result := gen(genParams)
-			genResults[i] = result
-			values[i], ok = result.RetrieveAsValue()
-			if !ok {
-				return &gopter.PropResult{
-					Status: gopter.PropUndecided,
-				}
+		if genParams.CorpusExample != "" {
+			return checkCorpusExample(genParams.CorpusExample, conditionType, callCheck)
+		}
+		start := time.Now()
+		genParams = genParams.WithTrace()
+		genResults, values, failed := generateArgs(genParams, conditionType, tracedGens)
+		if failed != nil {
+			failed.Timing.Generation = time.Since(start)
+			return failed
+		}
+		if genParams.DryRun {
+			return dryRun(genResults, values)
+		}
+		// further evaluations (beyond the first one of the runner) are limited
+		// as well
+		limitedCheck := rateLimited(genParams.RateLimiter, callCheck)
+		if genParams.ExhaustiveLimit > 0 {
+			if result := checkExhaustive(genParams, genResults, limitedCheck); result != nil {
+				return result
 			}
 		}
+		if isDuplicate(genParams, values) {
+			return &gopter.PropResult{Status: gopter.PropUndecided, Duplicate: true}
+		}
+		timing := gopter.TimeBreakdown{Generation: time.Since(start)}
+		start = time.Now()
 		result := callCheck(values)
+		timing.Evaluation = time.Since(start)
 		if result.Success() {
 			for i, genResult := range genResults {
 				result = result.AddArgs(gopter.NewPropArg(genResult, 0, values[i].Interface(), values[i].Interface()))
 			}
 		} else {
-			for i, genResult := range genResults {
-				nextResult, nextValue := shrinkValue(genParams.MaxShrinkCount, genResult, values[i].Interface(), result,
-					func(v interface{}) *gopter.PropResult {
-						shrunkOne := make([]reflect.Value, len(values))
-						copy(shrunkOne, values)
-						if v == nil {
-							shrunkOne[i] = reflect.Zero(values[i].Type())
-						} else {
-							shrunkOne[i] = reflect.ValueOf(v)
-						}
-						return callCheck(shrunkOne)
-					})
-				result = nextResult
-				if nextValue == nil {
-					values[i] = reflect.Zero(values[i].Type())
-				} else {
-					values[i] = reflect.ValueOf(nextValue)
-				}
-			}
+			start = time.Now()
+			result = shrinkArgs(genParams, genResults, values, result, limitedCheck)
+			timing.Shrinking = time.Since(start)
 		}
+		result.Timing = timing
 		return result
 	})
 }
 
+// generateArgs generates the arguments of a condition, returns an undecided or
+// error result if this fails
+func generateArgs(genParams *gopter.GenParameters, conditionType reflect.Type,
+	gens []gopter.Gen) ([]*gopter.GenResult, []reflect.Value, *gopter.PropResult) {
+	genResults := make([]*gopter.GenResult, len(gens))
+	values := make([]reflect.Value, len(gens))
+	var ok bool
+	for i, gen := range gens {
+		result := gen(genParams)
+		genResults[i] = result
+		// the result type is checked before the value, which might be missing
+		if err := checkArgType(conditionType, i, result, result.ResultType); err != nil {
+			return nil, nil, &gopter.PropResult{
+				Status: gopter.PropError,
+				Error:  err,
+			}
+		}
+		values[i], ok = result.RetrieveAsValue()
+		if !ok {
+			return nil, nil, &gopter.PropResult{
+				Status: gopter.PropUndecided,
+			}
+		}
+		if err := checkArgValue(conditionType, i, result, values[i]); err != nil {
+			return nil, nil, &gopter.PropResult{
+				Status: gopter.PropError,
+				Error:  err,
+			}
+		}
+	}
+	return genResults, values, nil
+}
+
+// rateLimited waits for the rate limiter (see TestParameters.MaxRate) before
+// every evaluation of a condition
+func rateLimited(rateLimiter *gopter.RateLimiter, callCheck func([]reflect.Value) *gopter.PropResult) func([]reflect.Value) *gopter.PropResult {
+	if rateLimiter == nil {
+		return callCheck
+	}
+	return func(values []reflect.Value) *gopter.PropResult {
+		rateLimiter.Wait()
+		return callCheck(values)
+	}
+}
+
+// dryRun creates the undecided result of a dry run (see
+// GenParameters.DryRun) with the generated arguments
+func dryRun(genResults []*gopter.GenResult, values []reflect.Value) *gopter.PropResult {
+	result := &gopter.PropResult{Status: gopter.PropUndecided}
+	for i, genResult := range genResults {
+		result = result.AddArgs(gopter.NewPropArg(genResult, 0, values[i].Interface(), values[i].Interface()))
+	}
+	return result
+}
+
+// isDuplicate checks if the arguments have already been checked (if enabled
+// by TestParameters.DedupInputs)
+func isDuplicate(genParams *gopter.GenParameters, values []reflect.Value) bool {
+	if genParams.InputDedup == nil {
+		return false
+	}
+	inputs := make([]interface{}, len(values))
+	for i, value := range values {
+		inputs[i] = value.Interface()
+	}
+	return genParams.InputDedup.Seen(inputs...)
+}
+
 // ForAll1 legacy interface to be removed in the future
 func ForAll1(gen gopter.Gen, check func(v interface{}) (interface{}, error)) gopter.Prop {
 	checkFunc := func(v interface{}) *gopter.PropResult {
@@ -80,6 +178,9 @@ func ForAll1(gen gopter.Gen, check func(v interface{}) (interface{}, error)) gop
 				Status: gopter.PropUndecided,
 			}
 		}
+		if genParams.DryRun {
+			return dryRun([]*gopter.GenResult{genResult}, []reflect.Value{reflect.ValueOf(value)})
+		}
 		result := checkFunc(value)
 		if result.Success() {
 			return result.AddArgs(gopter.NewPropArg(genResult, 0, value, value))
@@ -96,28 +197,40 @@ func shrinkValue(maxShrinkCount int, genResult *gopter.GenResult, origValue inte
 	lastValue := origValue
 
 	shrinks := 0
-	shrink := genResult.Shrinker(lastValue).Filter(genResult.Sieve)
-	nextResult, nextValue := firstFailure(shrink, check)
+	tree, ok := genResult.ShrinkTree()
+	if !ok {
+		return lastFail.WithArgs(firstFail.Args).AddArgs(gopter.NewPropArg(genResult, shrinks, lastValue, origValue)), lastValue
+	}
+	nextResult, nextTree := firstFailure(tree, check)
 	for nextResult != nil && shrinks < maxShrinkCount {
 		shrinks++
-		lastValue = nextValue
+		tree = nextTree
+		lastValue = nextTree.Value
 		lastFail = nextResult
 
-		shrink = genResult.Shrinker(lastValue).Filter(genResult.Sieve)
-		nextResult, nextValue = firstFailure(shrink, check)
+		nextResult, nextTree = firstFailure(tree, check)
 	}
 
 	return lastFail.WithArgs(firstFail.Args).AddArgs(gopter.NewPropArg(genResult, shrinks, lastValue, origValue)), lastValue
 }
 
-func firstFailure(shrink gopter.Shrink, check func(interface{}) *gopter.PropResult) (*gopter.PropResult, interface{}) {
-	value, ok := shrink()
-	for ok {
-		result := check(value)
+func firstFailure(tree *gopter.ShrinkTree, check func(interface{}) *gopter.PropResult) (*gopter.PropResult, *gopter.ShrinkTree) {
+	children := tree.Children()
+	for child, ok := children(); ok; child, ok = children() {
+		result := check(child.Value)
 		if !result.Success() {
-			return result, value
+			return result, child
 		}
-		value, ok = shrink()
 	}
 	return nil, nil
 }
+
+// traceArgs wraps the generators of the arguments to record their generation
+// (if enabled)
+func traceArgs(gens []gopter.Gen) []gopter.Gen {
+	traced := make([]gopter.Gen, len(gens))
+	for i, gen := range gens {
+		traced[i] = gen.Traced(fmt.Sprintf("ARG_%d", i))
+	}
+	return traced
+}
@@ -0,0 +1,201 @@
+package prop
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"runtime"
+	"sort"
+
+	"github.com/leanovate/gopter"
+)
+
+/*
+ForAllCached creates a property like ForAll that skips the evaluation of the
+condition for inputs it has already passed for in an earlier run.
+The results are stored in "cache" under "propKey" for a version of the
+property, which is derived from "version", the type of the condition and a
+hash of the source file of the condition. Changing any of them drops the
+entries of the other versions.
+
+IMPORTANT: Only changes of the file declaring the condition are detected.
+Changes of the generators, of functions called by the condition that are
+declared in other files or of the environment (e.g. test data) are not, so
+"version" has to be changed explicitly for them (e.g. a counter). The same
+applies if the source files are not available when the tests run (e.g. for
+binaries built with -trimpath). Only use this for pure conditions, i.e.
+conditions that always produce the same outcome for the same input.
+Inputs are identified by a hash of their values (following pointers, with
+sorted map keys and including unexported fields), inputs containing funcs,
+channels or cyclic references are always evaluated.
+As the cache only pays off if the same inputs are generated again, the
+properties have to be checked with a fixed seed (e.g. via
+gopter.DefaultTestParametersWithSeed or GOPTER_SEED).
+The cache has to be saved explicitly (ResultCache.Save), e.g. at the end of a
+test.
+*/
+func ForAllCached(cache *gopter.ResultCache, propKey, version string, condition interface{}, gens ...gopter.Gen) gopter.Prop {
+	cache.UseVersion(propKey, fmt.Sprintf("%s:%v:%s", version, reflect.TypeOf(condition), sourceHash(condition)))
+	return forAll(condition, func(callCheck func([]reflect.Value) *gopter.PropResult) func([]reflect.Value) *gopter.PropResult {
+		return func(values []reflect.Value) *gopter.PropResult {
+			inputKey, ok := hashInput(values)
+			if !ok {
+				return callCheck(values)
+			}
+			if cache.Passed(propKey, inputKey) {
+				return &gopter.PropResult{Status: gopter.PropTrue}
+			}
+			result := callCheck(values)
+			if result.Status == gopter.PropTrue {
+				cache.MarkPassed(propKey, inputKey)
+			}
+			return result
+		}
+	}, gens)
+}
+
+// sourceHash hashes the source file declaring a func, empty if the file can
+// not be read
+func sourceHash(f interface{}) string {
+	if reflect.ValueOf(f).Kind() != reflect.Func {
+		return ""
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return ""
+	}
+	file, _ := fn.FileLine(fn.Entry())
+	source, err := ioutil.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(source)
+	return hex.EncodeToString(hash[:])
+}
+
+// hashInput hashes the values of an input, false if the values can not be
+// hashed
+func hashInput(values []reflect.Value) (string, bool) {
+	var buf bytes.Buffer
+	for _, value := range values {
+		if !writeInput(&buf, value, map[uintptr]bool{}) {
+			return "", false
+		}
+		buf.WriteByte(0)
+	}
+	hash := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(hash[:]), true
+}
+
+// writeInput writes a stable representation of a value, pointers are
+// followed (visiting contains the pointers on the current path to detect
+// cycles)
+func writeInput(buf *bytes.Buffer, value reflect.Value, visiting map[uintptr]bool) bool {
+	if !value.IsValid() {
+		buf.WriteString("nil")
+		return true
+	}
+	fmt.Fprintf(buf, "%v(", value.Type())
+	defer buf.WriteByte(')')
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		// the values below a pointer are left with it
+		defer delete(visiting, value.Pointer())
+	}
+	switch value.Kind() {
+	case reflect.Bool:
+		fmt.Fprint(buf, value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprint(buf, value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprint(buf, value.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(buf, "%b", value.Float())
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprintf(buf, "%b", value.Complex())
+	case reflect.String:
+		fmt.Fprintf(buf, "%q", value.String())
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			buf.WriteString("nil")
+			return true
+		}
+		if value.Kind() == reflect.Ptr && !visit(value, visiting) {
+			return false
+		}
+		return writeInput(buf, value.Elem(), visiting)
+	case reflect.Slice:
+		if value.IsNil() {
+			buf.WriteString("nil")
+			return true
+		}
+		if !visit(value, visiting) {
+			return false
+		}
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !writeInput(buf, value.Index(i), visiting) {
+				return false
+			}
+			buf.WriteByte(',')
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			buf.WriteString("nil")
+			return true
+		}
+		if !visit(value, visiting) {
+			return false
+		}
+		entries := make([]string, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			var entry bytes.Buffer
+			if !writeInput(&entry, iter.Key(), visiting) {
+				return false
+			}
+			entry.WriteByte(':')
+			if !writeInput(&entry, iter.Value(), visiting) {
+				return false
+			}
+			entries = append(entries, entry.String())
+		}
+		sort.Strings(entries)
+		for _, entry := range entries {
+			buf.WriteString(entry)
+			buf.WriteByte(',')
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			buf.WriteString(value.Type().Field(i).Name)
+			buf.WriteByte(':')
+			if !writeInput(buf, value.Field(i), visiting) {
+				return false
+			}
+			buf.WriteByte(',')
+		}
+	default:
+		// funcs, channels and unsafe pointers have no stable representation
+		// (unless they are nil)
+		if value.IsNil() {
+			buf.WriteString("nil")
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// visit records a pointer on the current path, false if it is on the path
+// already (i.e. the value is cyclic)
+func visit(value reflect.Value, visiting map[uintptr]bool) bool {
+	if visiting[value.Pointer()] {
+		return false
+	}
+	visiting[value.Pointer()] = true
+	return true
+}
@@ -0,0 +1,119 @@
+package prop_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestForAllCached(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache, err := gopter.NewResultCache(filepath.Join(dir, "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	property := prop.ForAllCached(cache, "small ints", "1", func(v int) bool {
+		calls++
+		return v < 50
+	}, gen.IntRange(0, 9))
+
+	result := property.Check(gopter.DefaultTestParametersWithSeed(1234))
+	if result.Status != gopter.TestPassed || calls > 10 {
+		t.Fatalf("Invalid result: %#v (calls: %d)", result, calls)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the version is bound to the source of the condition
+	saved, err := ioutil.ReadFile(filepath.Join(dir, "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`"version": "1:func\(int\) bool:[0-9a-f]{64}"`).Match(saved) {
+		t.Errorf("Invalid version: %s", saved)
+	}
+
+	reloaded, err := gopter.NewResultCache(filepath.Join(dir, "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls = 0
+	result = prop.ForAllCached(reloaded, "small ints", "1", func(v int) bool {
+		calls++
+		return v < 50
+	}, gen.IntRange(0, 9)).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestPassed || result.Succeeded != 100 || calls != 0 {
+		t.Errorf("Cached inputs should be skipped: %#v (calls: %d)", result, calls)
+	}
+
+	result = prop.ForAllCached(reloaded, "tiny ints", "1", func(v int) bool {
+		return v < 5
+	}, gen.IntRange(0, 20)).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != 5 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	// a new version drops the entries of a changed property
+	result = prop.ForAllCached(reloaded, "small ints", "2", func(v int) bool {
+		return v < 5
+	}, gen.IntRange(0, 9)).Check(gopter.DefaultTestParameters())
+	if result.Status != gopter.TestFailed {
+		t.Errorf("Stale entries should be dropped: %#v", result)
+	}
+}
+
+func TestForAllCachedInputs(t *testing.T) {
+	cache, err := gopter.NewResultCache(filepath.Join(os.TempDir(), "unused.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type input struct {
+		values map[string]*int
+		f      func()
+	}
+	calls := 0
+	check := func(in input) bool {
+		calls++
+		return true
+	}
+	one, two := 1, 2
+	// equal values behind different pointers are the same input
+	inputs := gen.OneConstOf(
+		input{values: map[string]*int{"a": &one, "b": &two}},
+		input{values: map[string]*int{"a": new(int), "b": new(int)}},
+	)
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	parameters.MinSuccessfulTests = 20
+	if result := prop.ForAllCached(cache, "pointers", "1", check, inputs).Check(parameters); !result.Passed() || calls != 2 {
+		t.Errorf("Invalid result: %#v (calls: %d)", result, calls)
+	}
+
+	// funcs and cyclic values are not hashed
+	calls = 0
+	funcs := gen.Const(input{f: func() {}})
+	if result := prop.ForAllCached(cache, "funcs", "1", check, funcs).Check(parameters); !result.Passed() || calls != 20 {
+		t.Errorf("Invalid result: %#v (calls: %d)", result, calls)
+	}
+	cyclic := map[string]interface{}{}
+	cyclic["self"] = cyclic
+	calls = 0
+	if result := prop.ForAllCached(cache, "cyclic", "1", func(v map[string]interface{}) bool {
+		calls++
+		return true
+	}, gen.Const(cyclic)).Check(parameters); !result.Passed() || calls != 20 {
+		t.Errorf("Invalid result: %#v (calls: %d)", result, calls)
+	}
+}
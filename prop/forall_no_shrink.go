@@ -2,6 +2,7 @@ package prop
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/leanovate/gopter"
 )
@@ -13,32 +14,42 @@ As the name suggests the generated values will not be shrunk if the condition fa
 "condition" has to be a function with the same number of parameters as the provided
 generators "gens". The function may return a simple bool (true means that the
 condition has passed), a string (empty string means that condition has passed),
-a *PropResult, or one of former combined with an error.
+an error (nil means that the condition has passed), a *PropResult, or one of
+former combined with an error.
 */
 func ForAllNoShrink(condition interface{}, gens ...gopter.Gen) gopter.Prop {
 	callCheck, err := checkConditionFunc(condition, len(gens))
 	if err != nil {
 		return ErrorProp(err)
 	}
+	conditionType := conditionArgsType(condition)
+	tracedGens := traceArgs(gens)
 
 	return gopter.SaveProp(func(genParams *gopter.GenParameters) *gopter.PropResult {
-		genResults := make([]*gopter.GenResult, len(gens))
-		values := make([]reflect.Value, len(gens))
-		var ok bool
-		for i, gen := range gens {
-			result := gen(genParams)
-			genResults[i] = result
-			values[i], ok = result.RetrieveAsValue()
-			if !ok {
-				return &gopter.PropResult{
-					Status: gopter.PropUndecided,
-				}
-			}
+		if genParams.CorpusExample != "" {
+			return checkCorpusExample(genParams.CorpusExample, conditionType, callCheck)
+		}
+		start := time.Now()
+		genParams = genParams.WithTrace()
+		genResults, values, failed := generateArgs(genParams, conditionType, tracedGens)
+		if failed != nil {
+			failed.Timing.Generation = time.Since(start)
+			return failed
+		}
+		if genParams.DryRun {
+			return dryRun(genResults, values)
 		}
+		if isDuplicate(genParams, values) {
+			return &gopter.PropResult{Status: gopter.PropUndecided, Duplicate: true}
+		}
+		timing := gopter.TimeBreakdown{Generation: time.Since(start)}
+		start = time.Now()
 		result := callCheck(values)
+		timing.Evaluation = time.Since(start)
 		for i, genResult := range genResults {
 			result = result.AddArgs(gopter.NewPropArg(genResult, 0, values[i].Interface(), values[i].Interface()))
 		}
+		result.Timing = timing
 		return result
 	})
 }
@@ -54,6 +65,9 @@ func ForAllNoShrink1(gen gopter.Gen, check func(interface{}) (interface{}, error
 				Status: gopter.PropUndecided,
 			}
 		}
+		if genParams.DryRun {
+			return dryRun([]*gopter.GenResult{genResult}, []reflect.Value{reflect.ValueOf(value)})
+		}
 		return convertResult(check(value)).AddArgs(gopter.NewPropArg(genResult, 0, value, value))
 	})
 }
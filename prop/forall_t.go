@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package prop
+
+import "github.com/leanovate/gopter"
+
+// ForAllT creates a property like ForAll for a condition with one argument
+// whose type is checked against the generator at compile time
+func ForAllT[A any](condition func(A) bool, genA gopter.GenT[A]) gopter.Prop {
+	return ForAll(condition, genA.Untyped())
+}
+
+// ForAllT2 creates a property like ForAll for a condition with two arguments
+// whose types are checked against the generators at compile time
+func ForAllT2[A, B any](condition func(A, B) bool, genA gopter.GenT[A], genB gopter.GenT[B]) gopter.Prop {
+	return ForAll(condition, genA.Untyped(), genB.Untyped())
+}
+
+// ForAllT3 creates a property like ForAll for a condition with three arguments
+// whose types are checked against the generators at compile time
+func ForAllT3[A, B, C any](condition func(A, B, C) bool, genA gopter.GenT[A], genB gopter.GenT[B], genC gopter.GenT[C]) gopter.Prop {
+	return ForAll(condition, genA.Untyped(), genB.Untyped(), genC.Untyped())
+}
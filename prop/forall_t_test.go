@@ -0,0 +1,29 @@
+//go:build go1.18
+// +build go1.18
+
+package prop_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestForAllT(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	ints := gopter.Typed[int](gen.IntRange(-100, 100))
+	strs := gopter.Typed[string](gen.AlphaString())
+
+	if result := prop.ForAllT(func(v int) bool { return v >= -100 && v <= 100 }, ints).Check(parameters); !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	if result := prop.ForAllT2(func(a, b int) bool { return a+b == b+a }, ints, ints).Check(parameters); !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	result := prop.ForAllT3(func(a int, s string, b bool) bool { return a < 10 || len(s) >= 0 && b }, ints, strs, gopter.Typed[bool](gen.Bool())).Check(parameters)
+	if result.Status != gopter.TestFailed || len(result.Args) != 3 || result.Args[0].Arg != 10 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
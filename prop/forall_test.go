@@ -1,8 +1,13 @@
 package prop_test
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -61,3 +66,201 @@ func TestSqrt(t *testing.T) {
 		t.Errorf("Invalid result: %#v", result)
 	}
 }
+
+func TestForAllTypeMismatch(t *testing.T) {
+	mismatch := prop.ForAll(
+		func(a int, b string) bool {
+			return true
+		},
+		gen.Int(),
+		gen.Int64().WithLabel("the int64"),
+	)
+	result := mismatch(gopter.DefaultGenParameters())
+	if result.Status != gopter.PropError {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	for _, expected := range []string{"ARG_1", "string", "int64", "\"the int64\""} {
+		if !strings.Contains(result.Error.Error(), expected) {
+			t.Errorf("Error %q does not mention %s", result.Error.Error(), expected)
+		}
+	}
+
+	mixed := prop.ForAllNoShrink(
+		func(a int) bool {
+			return true
+		},
+		gen.OneConstOf(1, "one"),
+	)
+	parameters := gopter.DefaultTestParameters()
+	checkResult := mixed.Check(parameters)
+	if checkResult.Status != gopter.TestError || !strings.Contains(checkResult.Error.Error(), "ARG_0") {
+		t.Errorf("Invalid result: %#v", checkResult)
+	}
+
+	// the generators are not run before the property is checked
+	generated := 0
+	counting := gopter.Gen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+		generated++
+		return gopter.NewEmptyResult(reflect.TypeOf(int64(0)))
+	})
+	mismatch = prop.ForAll(func(a string) bool { return true }, counting)
+	if generated != 0 {
+		t.Errorf("Generator run %d times on definition", generated)
+	}
+	// the type of an empty result is checked as well
+	if result := mismatch(gopter.DefaultGenParameters()); result.Status != gopter.PropError || generated != 1 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
+
+func TestForAllShrinkArgPairs(t *testing.T) {
+	condition := func(a, b int) bool {
+		return a != b || a < 10
+	}
+	thousand := gen.Int().Map(func(int) int {
+		return 1000
+	})
+
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(condition, thousand, thousand).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg.(int) != 1000 || result.Args[1].Arg.(int) != 1000 {
+		t.Errorf("Individual shrinking should not be able to shrink: %#v", result.Args)
+	}
+
+	parameters.ArgShrinkStrategy = gopter.ShrinkArgPairs
+	result = prop.ForAll(condition, thousand, thousand).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	a, b := result.Args[0].Arg.(int), result.Args[1].Arg.(int)
+	if a != b || a >= 20 || a < 10 || result.Args[0].Shrinks == 0 || result.Args[0].OrigArg.(int) != 1000 {
+		t.Errorf("Pairs have not been shrunk: %#v %#v", result.Args[0], result.Args[1])
+	}
+}
+
+func TestForAllShrinkStrategy(t *testing.T) {
+	evaluations := 0
+	condition := func(v int) bool {
+		evaluations++
+		return v < 100
+	}
+	thousand := gen.Const(1000).WithShrinker(gen.IntShrinker)
+
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(condition, thousand).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg.(int) != 100 {
+		t.Fatalf("Invalid result: %#v", result.Args[0])
+	}
+	firstShrinks := result.Args[0].Shrinks
+
+	parameters.ShrinkStrategy = gopter.BestImprovement{Size: func(v interface{}) int {
+		return v.(int)
+	}}
+	result = prop.ForAll(condition, thousand).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg.(int) != 100 || result.Args[0].Shrinks > firstShrinks {
+		t.Errorf("Invalid best improvement: %#v (first improvement: %d shrinks)", result.Args[0], firstShrinks)
+	}
+
+	evaluations = 0
+	parameters.ShrinkStrategy = gopter.FirstImprovement{Evaluations: 3}
+	result = prop.ForAll(condition, thousand).Check(parameters)
+	if result.Status != gopter.TestFailed || evaluations != 4 || result.Args[0].Arg.(int) == 100 {
+		t.Errorf("Evaluation budget not respected: %d %#v", evaluations, result.Args[0])
+	}
+}
+
+func TestForAllTiming(t *testing.T) {
+	slowGen := gen.IntRange(0, 100).Map(func(v int) int {
+		time.Sleep(time.Millisecond)
+		return v
+	})
+	slowCondition := func(v int) bool {
+		time.Sleep(2 * time.Millisecond)
+		return v < 50
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+	result := prop.ForAll(slowCondition, slowGen.WithShrinker(gen.IntShrinker)).Check(parameters)
+	if result.Status != gopter.TestFailed {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if result.Timing.Generation < time.Millisecond || result.Timing.Evaluation < 2*time.Millisecond || result.Timing.Shrinking < 2*time.Millisecond {
+		t.Errorf("Invalid timing: %v", result.Timing)
+	}
+	if total := result.Timing.Generation + result.Timing.Evaluation + result.Timing.Shrinking; total > result.Time {
+		t.Errorf("Timing %v exceeds total time %s", result.Timing, result.Time)
+	}
+
+	result = prop.ForAllNoShrink(func(v int) bool {
+		return true
+	}, slowGen).Check(parameters)
+	if !result.Passed() || result.Timing.Generation < 10*time.Millisecond || result.Timing.Shrinking != 0 {
+		t.Errorf("Invalid timing: %v", result.Timing)
+	}
+}
+
+func TestForAllErrorCondition(t *testing.T) {
+	errTooLarge := errors.New("too large")
+	condition := func(v int) error {
+		if v > 10 {
+			return fmt.Errorf("check of %d failed: %w", v, errTooLarge)
+		}
+		return nil
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(condition, gen.IntRange(0, 100)).Check(parameters)
+	if result.Status != gopter.TestFailed || !errors.Is(result.Error, errTooLarge) {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if result.Args[0].Arg.(int) != 11 || result.Error.Error() != "check of 11 failed: too large" {
+		t.Errorf("Invalid shrunk result: %v %#v", result.Error, result.Args[0])
+	}
+
+	result = prop.ForAll(func(v int) error {
+		return nil
+	}, gen.IntRange(0, 100)).Check(parameters)
+	if !result.Passed() {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
+
+func TestForAllSieveStats(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.Workers = 2
+	result := prop.ForAll(
+		func(v int) bool {
+			return true
+		},
+		gen.IntRange(0, 100).SuchThatNamed("small", func(v int) bool {
+			return v < 5
+		}),
+	).Check(parameters)
+
+	if result.Status != gopter.TestExhausted {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	if len(result.SieveStats) != 1 || result.SieveStats[0].Name != "small" ||
+		result.SieveStats[0].Evaluated != result.Succeeded+result.Discarded ||
+		result.SieveStats[0].Rejected != result.Discarded {
+		t.Errorf("Invalid sieve stats: %#v", result)
+	}
+}
+
+func BenchmarkForAllPrimitives(b *testing.B) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 1000
+	property := prop.ForAll(
+		func(i int, u uint8, b bool, f float64) bool {
+			return true
+		},
+		gen.Int(), gen.UInt8(), gen.Bool(), gen.Float64(),
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if result := property.Check(parameters); !result.Passed() {
+			b.Fatalf("Invalid result: %#v", result)
+		}
+	}
+}
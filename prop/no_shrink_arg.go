@@ -0,0 +1,11 @@
+package prop
+
+import "github.com/leanovate/gopter"
+
+// NoShrinkArg marks an argument of ForAll as non-shrinkable, i.e. the value
+// of the generator will be kept as is while all other arguments are shrunk
+// normally. This is useful for arguments like fixture handles, that cannot
+// (or should not) be shrunk.
+func NoShrinkArg(gen gopter.Gen) gopter.Gen {
+	return gen.WithShrinker(gopter.NoShrinker)
+}
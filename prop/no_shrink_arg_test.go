@@ -0,0 +1,30 @@
+package prop_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestNoShrinkArg(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	result := prop.ForAll(
+		func(fixture int, v int) bool {
+			return v < 100
+		},
+		prop.NoShrinkArg(gen.IntRange(1000, 2000)),
+		gen.IntRange(0, 1000),
+	).Check(parameters)
+
+	if result.Status != gopter.TestFailed || len(result.Args) != 2 {
+		t.Fatalf("Invalid result: %#v", result)
+	}
+	if result.Args[0].Shrinks != 0 || result.Args[0].Arg != result.Args[0].OrigArg {
+		t.Errorf("Fixture argument should not be shrunk: %#v", result.Args[0])
+	}
+	if result.Args[1].Arg != 100 {
+		t.Errorf("Second argument should be shrunk: %#v", result.Args[1])
+	}
+}
@@ -0,0 +1,91 @@
+package prop
+
+import (
+	"math/rand"
+
+	"github.com/leanovate/gopter"
+)
+
+// Option adjusts the test parameters of a single property (see
+// ForAllWithOpts), e.g.
+//
+//	properties.Property("name", prop.ForAll(...), prop.WithMinSuccess(1000), prop.WithSeed(1234))
+type Option = gopter.PropertyOption
+
+/*
+ForAllWithOpts creates a property like ForAll that is checked with the test
+parameters adjusted by "opts", e.g.
+
+	prop.ForAllWithOpts(
+		func(v int) bool { ... },
+		[]prop.Option{prop.WithMinSuccess(1000), prop.WithSeed(1234)},
+		gen.Int(),
+	).Check(parameters)
+
+The options are applied by the check (see gopter.PropResult.Options) on top of
+the test parameters it has been started with (including the options of
+Properties.Property). If the property is combined with other properties, only
+the options of the first of them are applied.
+*/
+func ForAllWithOpts(condition interface{}, opts []Option, gens ...gopter.Gen) gopter.Prop {
+	prop := ForAll(condition, gens...)
+	if len(opts) == 0 {
+		return prop
+	}
+	return func(genParams *gopter.GenParameters) *gopter.PropResult {
+		switch {
+		case genParams.OptionsApplied:
+			return prop(genParams)
+		case genParams.DryRun:
+			result := prop(genParams)
+			result.Options = opts
+			return result
+		}
+		return &gopter.PropResult{Status: gopter.PropUndecided, Options: opts}
+	}
+}
+
+// WithMinSuccess overrides the number of successful tests required
+func WithMinSuccess(minSuccessfulTests int) Option {
+	return func(parameters *gopter.TestParameters) {
+		parameters.MinSuccessfulTests = minSuccessfulTests
+	}
+}
+
+// WithSeed overrides the seed, i.e. the property will always be checked with
+// the same values
+func WithSeed(seed int64) Option {
+	return func(parameters *gopter.TestParameters) {
+		parameters.Seed = seed
+		parameters.Rng = rand.New(gopter.NewLockedSource(seed))
+	}
+}
+
+// WithMaxShrinkCount overrides the maximum number of shrink steps
+func WithMaxShrinkCount(maxShrinkCount int) Option {
+	return func(parameters *gopter.TestParameters) {
+		parameters.MaxShrinkCount = maxShrinkCount
+	}
+}
+
+// WithWorkers overrides the number of workers checking the property
+func WithWorkers(workers int) Option {
+	return func(parameters *gopter.TestParameters) {
+		parameters.Workers = workers
+	}
+}
+
+// WithMaxSize overrides the (exclusive) upper limit on the size of the
+// generated values
+func WithMaxSize(maxSize int) Option {
+	return func(parameters *gopter.TestParameters) {
+		parameters.MaxSize = maxSize
+	}
+}
+
+// WithShrinkStrategy overrides the strategy of the shrink steps
+func WithShrinkStrategy(strategy gopter.ShrinkStrategy) Option {
+	return func(parameters *gopter.TestParameters) {
+		parameters.ShrinkStrategy = strategy
+	}
+}
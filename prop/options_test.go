@@ -0,0 +1,128 @@
+package prop_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestPropertyOptions(t *testing.T) {
+	var calls int64
+	var values [2]int
+	parameters := gopter.DefaultTestParameters()
+	parameters.MaxSize = 50
+	properties := gopter.NewProperties(parameters)
+	properties.Property("min success", prop.ForAll(
+		func(v int) bool {
+			atomic.AddInt64(&calls, 1)
+			return true
+		},
+		gen.Int(),
+	), prop.WithMinSuccess(500), prop.WithWorkers(2))
+	for i := range values {
+		i := i
+		properties.Property(string(rune('a'+i)), prop.ForAll(
+			func(v int) bool {
+				values[i] += v
+				return true
+			},
+			gen.IntRange(0, 1000),
+		), prop.WithSeed(1234), prop.WithMinSuccess(10))
+	}
+	properties.Property("max shrink count", prop.ForAll(
+		func(v int) bool {
+			return v < 10
+		},
+		gen.IntRange(100, 1000),
+	), prop.WithMaxShrinkCount(0))
+	properties.Property("defaults", prop.ForAll(
+		func(v []int) bool {
+			return len(v) <= 50
+		},
+		gen.SliceOf(gen.Int()),
+	))
+
+	results := properties.RunResults(nil)
+	if result := results[0]; result.Status != gopter.TestPassed || result.Succeeded != 500 || calls != 500 {
+		t.Errorf("Invalid result: %#v (calls: %d)", result.TestResult, calls)
+	}
+	for _, result := range results[1:3] {
+		if result.Status != gopter.TestPassed || result.Succeeded != 10 {
+			t.Errorf("Invalid result: %#v", result.TestResult)
+		}
+	}
+	if values[0] != values[1] {
+		t.Errorf("Fixed seed should generate same values: %#v", values)
+	}
+	if result := results[3]; result.Status != gopter.TestFailed || result.Args[0].Shrinks != 0 {
+		t.Errorf("Invalid result: %#v", result.TestResult)
+	}
+	// the parameters without an option are kept
+	if result := results[4]; result.Status != gopter.TestPassed || result.Succeeded != parameters.MinSuccessfulTests {
+		t.Errorf("Invalid result: %#v", result.TestResult)
+	}
+}
+
+func TestForAllWithOpts(t *testing.T) {
+	var calls int64
+	minSuccess := prop.ForAllWithOpts(
+		func(v int) bool {
+			atomic.AddInt64(&calls, 1)
+			return true
+		},
+		[]prop.Option{prop.WithMinSuccess(500), prop.WithWorkers(2)},
+		gen.Int(),
+	)
+	if result := minSuccess.Check(gopter.DefaultTestParameters()); result.Status != gopter.TestPassed || result.Succeeded != 500 || calls != 500 {
+		t.Errorf("Invalid result: %#v (calls: %d)", result, calls)
+	}
+	iterations := 0
+	for result := range minSuccess.CheckStream(gopter.DefaultTestParameters()) {
+		if result.TestResult == nil {
+			iterations++
+		}
+	}
+	if iterations != 500 {
+		t.Errorf("Invalid number of streamed iterations: %d", iterations)
+	}
+
+	var values [2]int
+	for i := range values {
+		i := i
+		result := prop.ForAllWithOpts(
+			func(v int) bool {
+				values[i] += v
+				return true
+			},
+			[]prop.Option{prop.WithSeed(1234)},
+			gen.IntRange(0, 1000),
+		).Check(gopter.DefaultTestParameters())
+		if result.Status != gopter.TestPassed || result.Seed != 1234 {
+			t.Errorf("Invalid result: %#v", result)
+		}
+	}
+	if values[0] != values[1] {
+		t.Errorf("Fixed seed should generate same values: %#v", values)
+	}
+
+	// the options are applied on top of the options of the properties
+	parameters := gopter.DefaultTestParameters()
+	properties := gopter.NewProperties(parameters)
+	properties.Property("max shrink count", prop.ForAllWithOpts(
+		func(v int) bool {
+			return v < 10
+		},
+		[]prop.Option{prop.WithMaxShrinkCount(0)},
+		gen.IntRange(100, 1000),
+	), prop.WithMinSuccess(10), prop.WithMaxShrinkCount(1000))
+	result := properties.RunResults(nil)[0]
+	if result.Status != gopter.TestFailed || result.Args[0].Shrinks != 0 {
+		t.Errorf("Invalid result: %#v", result.TestResult)
+	}
+	if budget := properties.Manifest().Properties[0].Budget; budget.MinSuccessfulTests != 10 || budget.MaxShrinkCount != 0 {
+		t.Errorf("Invalid budget: %#v", budget)
+	}
+}
@@ -0,0 +1,193 @@
+package prop
+
+import (
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// argsShrinker shrinks the arguments of a falsified multi argument property
+type argsShrinker struct {
+	maxShrinkCount int
+	genResults     []*gopter.GenResult
+	trees          []*gopter.ShrinkTree
+	values         []reflect.Value
+	origValues     []reflect.Value
+	shrinks        []int
+	lastFail       *gopter.PropResult
+	callCheck      func([]reflect.Value) *gopter.PropResult
+	strategy       gopter.ShrinkStrategy
+	evaluations    int
+}
+
+func shrinkArgs(genParams *gopter.GenParameters, genResults []*gopter.GenResult, values []reflect.Value,
+	firstFail *gopter.PropResult, callCheck func([]reflect.Value) *gopter.PropResult) *gopter.PropResult {
+	s := &argsShrinker{
+		maxShrinkCount: genParams.MaxShrinkCount,
+		genResults:     genResults,
+		trees:          make([]*gopter.ShrinkTree, len(values)),
+		values:         values,
+		origValues:     make([]reflect.Value, len(values)),
+		shrinks:        make([]int, len(values)),
+		lastFail:       firstFail,
+		callCheck:      callCheck,
+		strategy:       genParams.ShrinkStrategy,
+	}
+	if s.strategy == nil {
+		s.strategy = gopter.FirstImprovement{}
+	}
+	copy(s.origValues, values)
+	for i, genResult := range genResults {
+		s.trees[i] = treeOf(genResult, values[i].Interface())
+	}
+	firstFailArgs := append([]*gopter.PropArg{}, firstFail.Args...)
+
+	for i := range values {
+		s.shrinkOne(i)
+	}
+	if genParams.ArgShrinkStrategy == gopter.ShrinkArgPairs {
+		for improved := true; improved; {
+			improved = false
+			for i := 0; i < len(values); i++ {
+				for j := i + 1; j < len(values); j++ {
+					if s.shrinkPair(i, j) {
+						improved = true
+						for k := range values {
+							s.shrinkOne(k)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	result := s.lastFail.WithArgs(firstFailArgs)
+	for i, genResult := range genResults {
+		result = result.AddArgs(gopter.NewPropArg(genResult, s.shrinks[i], s.values[i].Interface(), s.origValues[i].Interface()))
+	}
+	return result
+}
+
+// treeOf creates the shrink tree of an argument: the generated value is shrunk
+// by the tree of its result, other values (e.g. of an exhaustive check) by the
+// shrinker
+func treeOf(genResult *gopter.GenResult, value interface{}) *gopter.ShrinkTree {
+	if tree, ok := genResult.ShrinkTree(); ok && reflect.DeepEqual(tree.Value, value) {
+		return tree
+	}
+	return gopter.NewShrinkTree(value, genResult.Shrinker, genResult.Sieve)
+}
+
+// shrink creates the shrinks of the i-th argument, their trees are collected
+// in trees
+func (s *argsShrinker) shrink(i int, trees *[]*gopter.ShrinkTree) gopter.Shrink {
+	children := s.trees[i].Children()
+	return func() (interface{}, bool) {
+		child, ok := children()
+		if !ok {
+			return nil, false
+		}
+		*trees = append(*trees, child)
+		return child.Value, true
+	}
+}
+
+// shrunkTree finds the tree of a shrink among the trees of a step (the
+// strategy might reorder the shrinks), the shrinker is used if it is not
+// found (e.g. because it contains funcs, which are never deep equal)
+func (s *argsShrinker) shrunkTree(i int, trees []*gopter.ShrinkTree, value interface{}) *gopter.ShrinkTree {
+	for j := len(trees) - 1; j >= 0; j-- {
+		if reflect.DeepEqual(trees[j].Value, value) {
+			return trees[j]
+		}
+	}
+	return gopter.NewShrinkTree(value, s.genResults[i].Shrinker, s.genResults[i].Sieve)
+}
+
+func (s *argsShrinker) valueOf(i int, v interface{}) reflect.Value {
+	if v == nil {
+		return reflect.Zero(s.values[i].Type())
+	}
+	return reflect.ValueOf(v)
+}
+
+// check evaluates the condition for the candidate arguments, ok is false if
+// the evaluation budget of the strategy is exhausted
+func (s *argsShrinker) check(candidate []reflect.Value) (result *gopter.PropResult, ok bool) {
+	if maxEvaluations := s.strategy.MaxEvaluations(); maxEvaluations > 0 && s.evaluations >= maxEvaluations {
+		return nil, false
+	}
+	s.evaluations++
+	return s.callCheck(candidate), true
+}
+
+// shrinkOne shrinks the i-th argument to a fixpoint
+func (s *argsShrinker) shrinkOne(i int) {
+	for s.shrinks[i] < s.maxShrinkCount {
+		var trees []*gopter.ShrinkTree
+		shrink := s.strategy.Candidates(s.shrink(i, &trees))
+		var best interface{}
+		var hasBest bool
+		var bestValues []reflect.Value
+		var bestResult *gopter.PropResult
+		for value, ok := shrink(); ok; value, ok = shrink() {
+			candidate := make([]reflect.Value, len(s.values))
+			copy(candidate, s.values)
+			candidate[i] = s.valueOf(i, value)
+			result, ok := s.check(candidate)
+			if !ok {
+				break
+			}
+			if result.Success() {
+				continue
+			}
+			accept, stop := s.strategy.Accept(best, hasBest, value)
+			if accept {
+				best, hasBest, bestValues, bestResult = value, true, candidate, result
+			}
+			if stop {
+				break
+			}
+		}
+		if bestValues == nil {
+			return
+		}
+		s.values = bestValues
+		s.lastFail = bestResult
+		s.trees[i] = s.shrunkTree(i, trees, best)
+		s.shrinks[i]++
+	}
+}
+
+// shrinkPair tries to shrink the i-th and j-th argument simultaneously by
+// combining the shrinks of both arguments in lockstep
+func (s *argsShrinker) shrinkPair(i, j int) bool {
+	if s.shrinks[i] >= s.maxShrinkCount || s.shrinks[j] >= s.maxShrinkCount {
+		return false
+	}
+	shrinkI := s.trees[i].Children()
+	shrinkJ := s.trees[j].Children()
+	treeI, okI := shrinkI()
+	treeJ, okJ := shrinkJ()
+	for okI && okJ {
+		candidate := make([]reflect.Value, len(s.values))
+		copy(candidate, s.values)
+		candidate[i] = s.valueOf(i, treeI.Value)
+		candidate[j] = s.valueOf(j, treeJ.Value)
+		result, ok := s.check(candidate)
+		if !ok {
+			return false
+		}
+		if !result.Success() {
+			s.values = candidate
+			s.lastFail = result
+			s.trees[i], s.trees[j] = treeI, treeJ
+			s.shrinks[i]++
+			s.shrinks[j]++
+			return true
+		}
+		treeI, okI = shrinkI()
+		treeJ, okJ = shrinkJ()
+	}
+	return false
+}
@@ -12,6 +12,9 @@ type PropArg struct {
 	OrigArg interface{}
 	Label   string
 	Shrinks int
+	// Trace of the generation of the original argument (if tracing is
+	// enabled)
+	Trace []TraceEntry
 }
 
 func (p *PropArg) String() string {
@@ -28,5 +31,6 @@ func NewPropArg(genResult *GenResult, shrinks int, value, origValue interface{})
 		Arg:     value,
 		OrigArg: origValue,
 		Shrinks: shrinks,
+		Trace:   genResult.Trace,
 	}
 }
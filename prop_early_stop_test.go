@@ -0,0 +1,56 @@
+package gopter_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestEarlyStopTests(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	if tests := parameters.EarlyStopTests(); tests != 0 {
+		t.Errorf("Early stopping should be disabled: %d", tests)
+	}
+	parameters.EarlyStopFailureRate = 0.05
+	if tests := parameters.EarlyStopTests(); tests != 59 {
+		t.Errorf("Invalid tests: %d", tests)
+	}
+	parameters.EarlyStopConfidence = 0.99
+	if tests := parameters.EarlyStopTests(); tests != 90 {
+		t.Errorf("Invalid tests: %d", tests)
+	}
+}
+
+func TestCheckEarlyStop(t *testing.T) {
+	prop := gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	})
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.EarlyStopFailureRate = 0.05
+	result := prop.Check(parameters)
+	if result.Status != gopter.TestPassed || !result.EarlyStopped || result.Succeeded != 59 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	parameters.Workers = 4
+	result = prop.Check(parameters)
+	if result.Status != gopter.TestPassed || !result.EarlyStopped || result.Succeeded < 59 || result.Succeeded >= 100 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	parameters.Workers = 1
+	parameters.EarlyStopFailureRate = 0.01
+	result = prop.Check(parameters)
+	if result.Status != gopter.TestPassed || result.EarlyStopped || result.Succeeded != 100 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	failing := gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		return &gopter.PropResult{Status: gopter.PropFalse}
+	})
+	parameters.EarlyStopFailureRate = 0.05
+	if result := failing.Check(parameters); result.Status != gopter.TestFailed || result.EarlyStopped {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
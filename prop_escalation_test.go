@@ -0,0 +1,42 @@
+package gopter_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestCheckEscalation(t *testing.T) {
+	var maxSize int
+	prop := gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		if genParams.MaxSize > maxSize {
+			maxSize = genParams.MaxSize
+		}
+		if genParams.MaxSize >= 500 {
+			return &gopter.PropResult{Status: gopter.PropFalse}
+		}
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	})
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.EscalationRounds = 2
+	result := prop.Check(parameters)
+	if result.Status != gopter.TestPassed || result.MaxSizeVerified != 400 || result.Succeeded != 120 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+	if maxSize >= 400 {
+		t.Errorf("Invalid max size: %d", maxSize)
+	}
+
+	parameters.EscalationRounds = 4
+	parameters.EscalationTests = 50
+	result = prop.Check(parameters)
+	if result.Status != gopter.TestFailed || result.MaxSizeVerified != 400 || result.Succeeded < 200 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+
+	parameters.EscalationRounds = 0
+	if result := prop.Check(parameters); result.MaxSizeVerified != 0 {
+		t.Errorf("Invalid result: %#v", result)
+	}
+}
@@ -38,6 +38,18 @@ type PropResult struct {
 	ErrorStack []byte
 	Args       []*PropArg
 	Labels     []string
+	// Checked contains the complete result of a property that has checked
+	// all its cases at once (see TestParameters.ExhaustiveLimit)
+	Checked *TestResult
+	// Timing is the time spent in the different phases of the property
+	Timing TimeBreakdown
+	// Duplicate marks an undecided result of inputs that have already been
+	// checked (see TestParameters.DedupInputs)
+	Duplicate bool
+	// Options are the options of a property that adjust its test parameters
+	// (see prop.ForAllWithOpts). The check is restarted with the adjusted
+	// parameters unless GenParameters.OptionsApplied is set.
+	Options []PropertyOption
 }
 
 // NewPropResult create a PropResult with label
@@ -105,5 +117,6 @@ func (r *PropResult) mergeWith(other *PropResult, status propStatus) *PropResult
 		Status: status,
 		Args:   append(append(make([]*PropArg, 0, len(r.Args)+len(other.Args)), r.Args...), other.Args...),
 		Labels: append(append(make([]string, 0, len(r.Labels)+len(other.Labels)), r.Labels...), other.Labels...),
+		Timing: r.Timing.Add(other.Timing),
 	}
 }
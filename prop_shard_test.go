@@ -0,0 +1,148 @@
+package gopter_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// shardValues checks the shards of a property and collects the generated
+// values of each shard
+func shardValues(t *testing.T, shardTotal int, configure func(*gopter.TestParameters), g gopter.Gen) [][]int64 {
+	var shards [][]int64
+	for shardIndex := 0; shardIndex < shardTotal; shardIndex++ {
+		var lock sync.Mutex
+		var values []int64
+		parameters := gopter.DefaultTestParametersWithSeed(1234)
+		parameters.ShardIndex = shardIndex
+		parameters.ShardTotal = shardTotal
+		configure(parameters)
+		result := prop.ForAll(func(v int64) bool {
+			lock.Lock()
+			defer lock.Unlock()
+			values = append(values, v)
+			return true
+		}, g).Check(parameters)
+		if !result.Passed() {
+			t.Fatalf("Invalid result of shard %d: %#v", shardIndex, result)
+		}
+		shards = append(shards, values)
+	}
+	return shards
+}
+
+func allShardValues(shards [][]int64) []int64 {
+	var all []int64
+	for _, values := range shards {
+		all = append(all, values...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all
+}
+
+func TestCheckSharded(t *testing.T) {
+	sequential := func(parameters *gopter.TestParameters) {}
+	threeShards := shardValues(t, 3, sequential, gen.Int64())
+	if len(threeShards[0]) != 34 || len(threeShards[1]) != 33 || len(threeShards[2]) != 33 {
+		t.Errorf("Invalid number of tests per shard: %d %d %d", len(threeShards[0]), len(threeShards[1]), len(threeShards[2]))
+	}
+	all := allShardValues(threeShards)
+	for i := 1; i < len(all); i++ {
+		if all[i] == all[i-1] {
+			t.Errorf("Shards overlap: %d", all[i])
+		}
+	}
+
+	// the shards check the same iterations regardless of the number of shards
+	// and workers
+	parallel := func(parameters *gopter.TestParameters) {
+		parameters.Workers = 4
+	}
+	if other := allShardValues(shardValues(t, 2, parallel, gen.Int64())); len(other) != len(all) {
+		t.Errorf("Invalid number of tests: %d != %d", len(other), len(all))
+	} else {
+		for i := range all {
+			if all[i] != other[i] {
+				t.Fatalf("Shards check different iterations: %d != %d", all[i], other[i])
+			}
+		}
+	}
+
+	exhaustive := func(parameters *gopter.TestParameters) {
+		parameters.ExhaustiveLimit = 100
+	}
+	threeShards = shardValues(t, 3, exhaustive, gen.Int64Range(0, 9))
+	if all := allShardValues(threeShards); len(threeShards[0]) != 4 || len(all) != 10 || all[0] != 0 || all[9] != 9 {
+		t.Errorf("Invalid exhaustive shards: %v", threeShards)
+	}
+	// more shards than cases
+	if shards := shardValues(t, 20, exhaustive, gen.Int64Range(0, 9)); len(allShardValues(shards)) != 10 {
+		t.Errorf("Invalid exhaustive shards: %v", shards)
+	}
+}
+
+func TestCheckShardedDedup(t *testing.T) {
+	for shardIndex := 0; shardIndex < 3; shardIndex++ {
+		var lock sync.Mutex
+		checked := map[int64]bool{}
+		parameters := gopter.DefaultTestParametersWithSeed(1234)
+		parameters.ShardIndex = shardIndex
+		parameters.ShardTotal = 3
+		parameters.DedupInputs = true
+		// duplicates are frequent, but the shard has enough distinct inputs
+		result := prop.ForAll(func(v int64) bool {
+			lock.Lock()
+			defer lock.Unlock()
+			checked[v] = true
+			return true
+		}, gen.Int64Range(0, 199)).Check(parameters)
+		if result.Status != gopter.TestPassed || result.Succeeded != parameters.MinSuccessfulTests/3+boolToInt(shardIndex == 0) {
+			t.Errorf("Invalid result of shard %d: %#v", shardIndex, result)
+		}
+		if len(checked) != result.Succeeded {
+			t.Errorf("Shard %d checked %d distinct inputs in %d tests", shardIndex, len(checked), result.Succeeded)
+		}
+
+		// a duplicate cutoff does not pass
+		parameters.MinSuccessfulTests = 300
+		result = prop.ForAll(func(v int64) bool { return true }, gen.Int64Range(0, 9)).Check(parameters)
+		if result.Status != gopter.TestExhausted || result.Succeeded > 10 {
+			t.Errorf("Invalid result of shard %d with few distinct inputs: %#v", shardIndex, result)
+		}
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestCheckInvalidShard(t *testing.T) {
+	prop := gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	})
+	for _, shard := range [][2]int{{3, 3}, {-1, 2}, {0, -1}, {1, 0}} {
+		parameters := gopter.DefaultTestParameters()
+		parameters.ShardIndex = shard[0]
+		parameters.ShardTotal = shard[1]
+		if result := prop.Check(parameters); result.Status != gopter.TestError {
+			t.Errorf("Invalid result of shard %v: %#v", shard, result)
+		}
+		for result := range prop.CheckStream(parameters) {
+			if result.TestResult == nil || result.TestResult.Status != gopter.TestError {
+				t.Errorf("Invalid streamed result of shard %v: %#v", shard, result)
+			}
+		}
+	}
+	parameters := gopter.DefaultTestParameters()
+	parameters.ShardTotal = 1
+	if result := prop.Check(parameters); !result.Passed() || result.Succeeded != parameters.MinSuccessfulTests {
+		t.Errorf("Invalid result of a single shard: %#v", result)
+	}
+}
@@ -0,0 +1,51 @@
+package gopter
+
+import "sync"
+
+// IterationResult is the outcome of a single iteration of a property check
+// streamed by CheckStream
+type IterationResult struct {
+	// Iteration is the number of the iteration (starting with 0)
+	Iteration int
+	// Size is the size the arguments have been generated with
+	Size int
+	// PropResult is the result of the property in this iteration (with the
+	// shrunk arguments if it has been falsified)
+	PropResult *PropResult
+	// TestResult is only set for the final value of a stream (which has no
+	// PropResult) and contains the overall result of the check
+	TestResult *TestResult
+}
+
+// CheckStream checks the property like Check (with the same validation of
+// the parameters, early stop and escalation), but yields the outcome of each
+// iteration as soon as it is available. This allows custom harnesses (e.g.
+// UIs or controllers adapting the number of tests) to be built on top of the
+// regular runner.
+// The final value of the stream contains the TestResult, afterwards the
+// channel is closed. The channel has to be drained, otherwise the check does
+// not finish.
+func (prop Prop) CheckStream(parameters *TestParameters) <-chan IterationResult {
+	results := make(chan IterationResult)
+	go func() {
+		defer close(results)
+
+		var lock sync.Mutex
+		iteration := 0
+		result := prop.checkWith(parameters, func(size int, propResult *PropResult) {
+			lock.Lock()
+			defer lock.Unlock()
+			results <- IterationResult{
+				Iteration:  iteration,
+				Size:       size,
+				PropResult: propResult,
+			}
+			iteration++
+		})
+		results <- IterationResult{
+			Iteration:  iteration,
+			TestResult: result,
+		}
+	}()
+	return results
+}
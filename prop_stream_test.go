@@ -0,0 +1,70 @@
+package gopter_test
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestCheckStream(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	prop := gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		if genParams.MaxSize > 50 {
+			return &gopter.PropResult{Status: gopter.PropFalse}
+		}
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	})
+
+	var iterations []gopter.IterationResult
+	for result := range prop.CheckStream(parameters) {
+		iterations = append(iterations, result)
+	}
+	last := iterations[len(iterations)-1]
+	if last.TestResult == nil || last.TestResult.Status != gopter.TestFailed || last.PropResult != nil {
+		t.Fatalf("Invalid final result: %#v", last)
+	}
+	if len(iterations) != last.TestResult.Succeeded+2 {
+		t.Errorf("Invalid number of iterations: %d", len(iterations))
+	}
+	for i, result := range iterations[:len(iterations)-1] {
+		if result.Iteration != i || result.PropResult == nil || result.TestResult != nil {
+			t.Errorf("Invalid iteration result: %#v", result)
+		}
+		if failed := result.PropResult.Status == gopter.PropFalse; failed != (result.Size > 50) {
+			t.Errorf("Invalid iteration result: %#v", result)
+		}
+	}
+
+	parameters.Workers = 4
+	count := 0
+	for result := range gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	}).CheckStream(parameters) {
+		if result.TestResult != nil && (result.TestResult.Status != gopter.TestPassed || result.Iteration != count) {
+			t.Errorf("Invalid final result: %#v", result)
+		}
+		count++
+	}
+	if count != parameters.MinSuccessfulTests+1 {
+		t.Errorf("Invalid number of iterations: %d", count)
+	}
+}
+
+func TestCheckStreamMatchesCheck(t *testing.T) {
+	prop := gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		if genParams.Rng.Intn(100) == 0 {
+			return &gopter.PropResult{Status: gopter.PropFalse}
+		}
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	})
+
+	checked := prop.Check(gopter.DefaultTestParametersWithSeed(4242))
+	var streamed *gopter.TestResult
+	for result := range prop.CheckStream(gopter.DefaultTestParametersWithSeed(4242)) {
+		streamed = result.TestResult
+	}
+	if streamed == nil || streamed.Seed != 4242 || streamed.Status != checked.Status ||
+		streamed.Succeeded != checked.Succeeded || streamed.Seed != checked.Seed {
+		t.Errorf("Streamed result %#v does not match checked result %#v", streamed, checked)
+	}
+}
@@ -6,9 +6,14 @@ import "testing"
 type Properties struct {
 	parameters *TestParameters
 	props      map[string]Prop
+	propOpts   map[string][]PropertyOption
 	propNames  []string
 }
 
+// PropertyOption adjusts the test parameters of a single property (see
+// Properties.Property and e.g. prop.WithMinSuccess)
+type PropertyOption func(*TestParameters)
+
 // NewProperties create new Properties with given test parameters.
 // If parameters is nil default test parameters will be used
 func NewProperties(parameters *TestParameters) *Properties {
@@ -18,30 +23,116 @@ func NewProperties(parameters *TestParameters) *Properties {
 	return &Properties{
 		parameters: parameters,
 		props:      make(map[string]Prop, 0),
+		propOpts:   make(map[string][]PropertyOption, 0),
 		propNames:  make([]string, 0),
 	}
 }
 
+// Seed sets the seed the properties are checked with (overriding the seed of
+// the test parameters, e.g. to reproduce a reported failure) and returns the
+// properties for chaining:
+//
+//	properties := gopter.NewProperties(nil).Seed(1234)
+func (p *Properties) Seed(seed int64) *Properties {
+	p.parameters = p.parameters.withSeed(seed)
+	return p
+}
+
 // Property add/defines a property in a test.
-func (p *Properties) Property(name string, prop Prop) {
+// The property is checked with the test parameters of the properties adjusted
+// by opts, e.g.
+//
+//	properties.Property("slow", prop.ForAll(...), prop.WithMinSuccess(10))
+func (p *Properties) Property(name string, prop Prop, opts ...PropertyOption) {
 	p.propNames = append(p.propNames, name)
 	p.props[name] = prop
+	p.propOpts[name] = opts
 }
 
 // Run checks all definied propertiesand reports the result
 func (p *Properties) Run(reporter Reporter) bool {
 	success := true
+	for _, result := range p.RunResults(reporter) {
+		if !result.Passed() {
+			success = false
+		}
+	}
+	return success
+}
+
+// RunResults checks all defined properties, reports each result (unless
+// reporter is nil) and returns the results in the order the properties were
+// defined.
+// This is useful to consume the results programmatically outside of go test.
+func (p *Properties) RunResults(reporter Reporter) []*PropertyResult {
+	results := make([]*PropertyResult, 0, len(p.propNames))
 	for _, propName := range p.propNames {
 		prop := p.props[propName]
+		parameters, seed := p.propertyParameters(propName)
+
+		var result *TestResult
+		if parameters.CorpusDir != "" {
+			corpus, err := LoadCorpus(CorpusPath(parameters.CorpusDir, propName))
+			if err != nil {
+				result = &TestResult{Status: TestError, Error: err}
+			} else {
+				withCorpus := *parameters
+				withCorpus.Corpus = corpus
+				parameters = &withCorpus
+			}
+		}
+		var failures *Corpus
+		if result == nil && parameters.FailureDir != "" {
+			var err error
+			failures, err = LoadCorpus(CorpusPath(parameters.FailureDir, propName))
+			if err != nil {
+				result = &TestResult{Status: TestError, Error: err}
+			} else {
+				withFailures := *parameters
+				if withFailures.Corpus != nil {
+					withFailures.Corpus = withFailures.Corpus.combine(failures)
+				} else {
+					withFailures.Corpus = failures
+				}
+				parameters = &withFailures
+			}
+		}
+		if result == nil {
+			result = prop.Check(parameters)
+		}
+		result.Seed = seed
 
-		result := prop.Check(p.parameters)
+		propertyResult := &PropertyResult{
+			Name:       propName,
+			TestResult: result,
+		}
+		propertyResult.recordFailure(failures)
+		propertyResult.writeArtifact(parameters)
+		if reporter != nil {
+			reporter.ReportTestResult(propName, result)
+		}
+		results = append(results, propertyResult)
+	}
+	return results
+}
 
-		reporter.ReportTestResult(propName, result)
-		if !result.Passed() {
-			success = false
+// propertyParameters are the test parameters of a property adjusted by its
+// options and the seed the check is reproduced with, i.e. with SeedPerProperty
+// the seed of the properties
+func (p *Properties) propertyParameters(propName string) (*TestParameters, int64) {
+	parameters := p.parameters
+	if opts := p.propOpts[propName]; len(opts) > 0 {
+		withOpts := *parameters
+		for _, opt := range opts {
+			opt(&withOpts)
 		}
+		parameters = &withOpts
 	}
-	return success
+	seed := parameters.currentSeed()
+	if parameters.SeedPerProperty {
+		parameters = parameters.withSeed(PropertySeed(seed, propName))
+	}
+	return parameters, seed
 }
 
 // TestingRun checks all definied properties with a testing.T context.
@@ -54,6 +145,7 @@ func (p *Properties) TestingRun(t *testing.T, opts ...interface{}) {
 		}
 	}
 	if !p.Run(reporter) {
-		t.Errorf("failed with initial seed: %d", p.parameters.Seed)
+		seed := p.parameters.currentSeed()
+		t.Errorf("failed with initial seed: %d (reproduce with %s=%d)", seed, SeedEnv, seed)
 	}
 }
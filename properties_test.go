@@ -1,6 +1,8 @@
 package gopter_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"testing"
 
@@ -45,3 +47,152 @@ func TestPropertiesCustomReporter(t *testing.T) {
 		t.Errorf("fakeT has not failed")
 	}
 }
+
+func TestPropertiesRunResults(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("always pass", prop.ForAll(
+		func(v int32) bool {
+			return true
+		},
+		gen.Int32(),
+	))
+	properties.Property("always fail", prop.ForAll(
+		func(v int32) bool {
+			return false
+		},
+		gen.Int32().WithLabel("value"),
+	))
+
+	results := properties.RunResults(nil)
+	if len(results) != 2 {
+		t.Fatalf("Invalid results: %#v", results)
+	}
+	if results[0].Name != "always pass" || !results[0].Passed() || results[0].Seed != 1234 {
+		t.Errorf("Invalid first result: %#v", results[0])
+	}
+	if results[1].Name != "always fail" || results[1].Status != gopter.TestFailed ||
+		len(results[1].Args) != 1 || results[1].Args[0].Label != "value" {
+		t.Errorf("Invalid second result: %#v", results[1])
+	}
+}
+
+func TestPropertiesSeedPerProperty(t *testing.T) {
+	firstValues := func(names ...string) map[string]int64 {
+		parameters := gopter.DefaultTestParametersWithSeed(1234)
+		parameters.SeedPerProperty = true
+		properties := gopter.NewProperties(parameters)
+		values := map[string]int64{}
+		for _, name := range names {
+			name := name
+			properties.Property(name, prop.ForAll(
+				func(v int64) bool {
+					if _, ok := values[name]; !ok {
+						values[name] = v
+					}
+					return true
+				},
+				gen.Int64(),
+			))
+		}
+		for _, result := range properties.RunResults(nil) {
+			// the seed of the properties reproduces the property seed
+			if result.Seed != 1234 {
+				t.Errorf("Invalid reported seed of %s: %d", result.Name, result.Seed)
+			}
+		}
+		return values
+	}
+
+	values := firstValues("a", "b")
+	reordered := firstValues("c", "b", "a")
+	if values["a"] != reordered["a"] || values["b"] != reordered["b"] {
+		t.Errorf("Values depend on the order of the properties: %v != %v", values, reordered)
+	}
+	if values["a"] == values["b"] {
+		t.Errorf("Properties use the same values: %v", values)
+	}
+	if gopter.PropertySeed(1234, "a") == gopter.PropertySeed(1235, "a") {
+		t.Errorf("Property seed does not depend on the seed")
+	}
+}
+
+func TestPropertiesSeed(t *testing.T) {
+	firstValue := func(properties *gopter.Properties) (value int64) {
+		properties.Property("first value", prop.ForAll(
+			func(v int64) bool {
+				if value == 0 {
+					value = v
+				}
+				return true
+			},
+			gen.Int64(),
+		))
+		results := properties.RunResults(nil)
+		if results[0].Seed != 1234 {
+			t.Errorf("Invalid seed: %d", results[0].Seed)
+		}
+		return value
+	}
+
+	parameters := gopter.DefaultTestParameters()
+	if seeded := firstValue(gopter.NewProperties(parameters).Seed(1234)); seeded != firstValue(gopter.NewProperties(gopter.DefaultTestParametersWithSeed(1234))) {
+		t.Errorf("Seed does not define the values: %d", seeded)
+	}
+	if parameters.Seed == 1234 {
+		t.Error("Seed changed the test parameters")
+	}
+
+	// the seed of a reseeded Rng is reported
+	parameters = gopter.DefaultTestParameters()
+	parameters.Rng.Seed(1234)
+	if seeded := firstValue(gopter.NewProperties(parameters)); seeded != firstValue(gopter.NewProperties(gopter.DefaultTestParametersWithSeed(1234))) {
+		t.Errorf("Rng.Seed does not define the values: %d", seeded)
+	}
+}
+
+func TestPropertiesManifest(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.SeedPerProperty = true
+	properties := gopter.NewProperties(parameters)
+	evaluated := 0
+	properties.Property("labeled", prop.ForAll(
+		func(a int, b string) bool {
+			evaluated++
+			return true
+		},
+		gen.Int().WithLabel("count"), gen.AlphaString(),
+	))
+	properties.Property("no shrink", prop.ForAllNoShrink(
+		func(a bool) bool {
+			evaluated++
+			return true
+		},
+		gen.Bool().WithLabel("flag"),
+	), prop.WithMinSuccess(10))
+
+	var buffer bytes.Buffer
+	if err := properties.WriteManifest(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	if evaluated != 0 {
+		t.Errorf("Conditions have been evaluated %d times", evaluated)
+	}
+	var manifest gopter.SuiteManifest
+	if err := json.Unmarshal(buffer.Bytes(), &manifest); err != nil {
+		t.Fatalf("Invalid manifest: %s: %v", buffer.String(), err)
+	}
+	if manifest.Seed != parameters.Seed || len(manifest.Properties) != 2 {
+		t.Fatalf("Invalid manifest: %#v", manifest)
+	}
+	labeled := manifest.Properties[0]
+	if labeled.Name != "labeled" || labeled.Seed != gopter.PropertySeed(parameters.Seed, "labeled") ||
+		len(labeled.Labels) != 2 || labeled.Labels[0] != "count" || labeled.Labels[1] != "" ||
+		labeled.Budget.MinSuccessfulTests != parameters.MinSuccessfulTests || labeled.Budget.MaxSize != parameters.MaxSize {
+		t.Errorf("Invalid property manifest: %#v", labeled)
+	}
+	if noShrink := manifest.Properties[1]; noShrink.Name != "no shrink" || len(noShrink.Labels) != 1 || noShrink.Labels[0] != "flag" || noShrink.Budget.MinSuccessfulTests != 10 {
+		t.Errorf("Invalid property manifest: %#v", noShrink)
+	}
+}
@@ -0,0 +1,10 @@
+package gopter
+
+// PropertyResult contains the result of a named property checked as part of
+// Properties.
+type PropertyResult struct {
+	// Name of the property
+	Name string
+	// Result of the property check (status, args, labels, seed, elapsed time ...)
+	*TestResult
+}
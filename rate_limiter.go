@@ -0,0 +1,39 @@
+package gopter
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces events (e.g. the evaluations of a property) evenly to
+// at most a rate per second, it is safe for concurrent use
+type RateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter creates a rate limiter for a rate (events per second), nil
+// if the rate is not positive (a nil RateLimiter does not limit anything)
+func NewRateLimiter(rate float64) *RateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &RateLimiter{interval: time.Duration(float64(time.Second) / rate)}
+}
+
+// Wait blocks until the next event is allowed
+func (r *RateLimiter) Wait() {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.lock.Unlock()
+	time.Sleep(wait)
+}
@@ -0,0 +1,66 @@
+package gopter_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var nilLimiter *gopter.RateLimiter
+	nilLimiter.Wait()
+	if gopter.NewRateLimiter(0) != nil {
+		t.Error("Rate limiter for rate 0")
+	}
+
+	limiter := gopter.NewRateLimiter(100)
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		limiter.Wait()
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Rate not limited: %v", elapsed)
+	}
+}
+
+func TestMaxRate(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		var evaluations int32
+		parameters := gopter.DefaultTestParameters()
+		parameters.MinSuccessfulTests = 20
+		parameters.Workers = workers
+		parameters.MaxRate = 200
+		start := time.Now()
+		result := prop.ForAll(func(v int) bool {
+			atomic.AddInt32(&evaluations, 1)
+			return true
+		}, gen.Int()).Check(parameters)
+		elapsed := time.Since(start)
+		if !result.Passed() {
+			t.Errorf("Invalid result: %#v", result)
+		}
+		if minimum := time.Duration(evaluations-1) * 5 * time.Millisecond; elapsed < minimum {
+			t.Errorf("Rate not limited with %d workers: %d evaluations in %v", workers, evaluations, elapsed)
+		}
+	}
+
+	var evaluations int32
+	parameters := gopter.DefaultTestParameters()
+	parameters.MaxRate = 1000
+	start := time.Now()
+	result := prop.ForAll(func(v int) bool {
+		evaluations++
+		return v < 1000
+	}, gen.Int()).Check(parameters)
+	elapsed := time.Since(start)
+	if result.Status != gopter.TestFailed || evaluations < 10 {
+		t.Fatalf("Invalid result: %#v (%d evaluations)", result, evaluations)
+	}
+	if minimum := time.Duration(evaluations-1) * time.Millisecond; elapsed < minimum {
+		t.Errorf("Shrinking not limited: %d evaluations in %v", evaluations, elapsed)
+	}
+}
@@ -0,0 +1,149 @@
+package gopter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultResultCachePath is the default location of a ResultCache
+const DefaultResultCachePath = "testdata/gopter_result_cache.json"
+
+// DefaultResultCacheMaxInputs is the default number of inputs a ResultCache
+// keeps per property
+const DefaultResultCacheMaxInputs = 10000
+
+// ResultCache remembers the inputs a property has already passed for.
+// The cache is persisted as JSON file (usually under testdata) so that
+// subsequent runs can skip the evaluation of (pure) properties for known
+// inputs (see prop.ForAllCached).
+// The entries of a property are only valid for a version of the property: The
+// version has to be changed explicitly whenever the property changes, which
+// drops all entries of the other versions. Per property only the most recently
+// passed MaxInputs are kept.
+type ResultCache struct {
+	// MaxInputs is the maximum number of inputs per property, the inputs
+	// passed first are evicted once the cache is full
+	MaxInputs int
+
+	lk      sync.Mutex
+	path    string
+	entries map[string]*resultCacheEntry
+	dirty   bool
+}
+
+// resultCacheEntry contains the inputs of a property (in the order they
+// passed)
+type resultCacheEntry struct {
+	Version string   `json:"version"`
+	Inputs  []string `json:"inputs"`
+	passed  map[string]bool
+}
+
+// NewResultCache creates a ResultCache persisted at path.
+// Existing entries are loaded, a missing file is not an error.
+func NewResultCache(path string) (*ResultCache, error) {
+	cache := &ResultCache{
+		MaxInputs: DefaultResultCacheMaxInputs,
+		path:      path,
+		entries:   map[string]*resultCacheEntry{},
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cache.entries); err != nil {
+		return nil, err
+	}
+	for propKey, entry := range cache.entries {
+		if entry == nil {
+			delete(cache.entries, propKey)
+			continue
+		}
+		entry.passed = make(map[string]bool, len(entry.Inputs))
+		for _, inputKey := range entry.Inputs {
+			entry.passed[inputKey] = true
+		}
+	}
+	return cache, nil
+}
+
+// UseVersion sets the version of a property, the entries of all other versions
+// of the property are removed
+func (c *ResultCache) UseVersion(propKey, version string) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if entry, ok := c.entries[propKey]; ok && entry.Version == version {
+		return
+	}
+	c.entries[propKey] = &resultCacheEntry{Version: version, passed: map[string]bool{}}
+	c.dirty = true
+}
+
+// Passed checks if a property has already passed for an input
+func (c *ResultCache) Passed(propKey, inputKey string) bool {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	entry, ok := c.entries[propKey]
+	return ok && entry.passed[inputKey]
+}
+
+// MarkPassed records that a property has passed for an input
+func (c *ResultCache) MarkPassed(propKey, inputKey string) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	entry, ok := c.entries[propKey]
+	if !ok {
+		entry = &resultCacheEntry{passed: map[string]bool{}}
+		c.entries[propKey] = entry
+	}
+	if entry.passed[inputKey] {
+		return
+	}
+	entry.passed[inputKey] = true
+	entry.Inputs = append(entry.Inputs, inputKey)
+	if c.MaxInputs > 0 && len(entry.Inputs) > c.MaxInputs {
+		evicted := len(entry.Inputs) - c.MaxInputs
+		for _, inputKey := range entry.Inputs[:evicted] {
+			delete(entry.passed, inputKey)
+		}
+		entry.Inputs = append([]string{}, entry.Inputs[evicted:]...)
+	}
+	c.dirty = true
+}
+
+// Invalidate removes all entries of a property (e.g. if the property has
+// changed)
+func (c *ResultCache) Invalidate(propKey string) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if _, ok := c.entries[propKey]; ok {
+		delete(c.entries, propKey)
+		c.dirty = true
+	}
+}
+
+// Save persists the cache if it has been modified
+func (c *ResultCache) Save() error {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(c.path, data, 0644); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
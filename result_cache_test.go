@@ -0,0 +1,71 @@
+package gopter_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestResultCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "cache.json")
+
+	cache, err := gopter.NewResultCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.Passed("prop", "input") {
+		t.Error("Empty cache should not contain entries")
+	}
+	cache.MarkPassed("prop", "input")
+	cache.MarkPassed("other", "input")
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := gopter.NewResultCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Passed("prop", "input") || loaded.Passed("prop", "other") {
+		t.Error("Loaded cache should contain saved entries")
+	}
+	loaded.Invalidate("prop")
+	if loaded.Passed("prop", "input") || !loaded.Passed("other", "input") {
+		t.Error("Invalidate should only remove entries of property")
+	}
+
+	loaded.UseVersion("other", "2")
+	if loaded.Passed("other", "input") {
+		t.Error("UseVersion should remove entries of other versions")
+	}
+
+	loaded.MaxInputs = 2
+	for _, input := range []string{"a", "b", "c"} {
+		loaded.MarkPassed("prop", input)
+	}
+	if loaded.Passed("prop", "a") || !loaded.Passed("prop", "b") || !loaded.Passed("prop", "c") {
+		t.Error("The inputs passed first should be evicted")
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"prop": null, "other": {"version": "1", "inputs": ["input"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if withNull, err := gopter.NewResultCache(path); err != nil || withNull.Passed("prop", "input") || !withNull.Passed("other", "input") {
+		t.Errorf("Null entries should be skipped: %v", err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("invalid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gopter.NewResultCache(path); err == nil {
+		t.Error("Invalid cache file should fail")
+	}
+}
@@ -36,6 +36,8 @@ func (r *runner) mergeCheckResults(r1, r2 *TestResult) *TestResult {
 
 	result.Succeeded = r1.Succeeded + r2.Succeeded
 	result.Discarded = r1.Discarded + r2.Discarded
+	result.Duplicates = r1.Duplicates + r2.Duplicates
+	result.Timing = r1.Timing.Add(r2.Timing)
 
 	return &result
 }
@@ -0,0 +1,45 @@
+package gopter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+)
+
+// sharded checks if the iterations are partitioned into shards (see
+// TestParameters.ShardTotal)
+func (p *TestParameters) sharded() bool {
+	return p.ShardTotal > 1
+}
+
+// checkShard checks if the shard is valid
+func (p *TestParameters) checkShard() error {
+	if p.ShardTotal < 0 || p.ShardIndex < 0 || p.ShardIndex >= p.ShardTotal && (p.ShardTotal > 0 || p.ShardIndex > 0) {
+		return fmt.Errorf("Invalid shard %d of %d", p.ShardIndex, p.ShardTotal)
+	}
+	return nil
+}
+
+// shardCount is the number of the first n iterations (or cases) that belong
+// to the shard
+func (p *TestParameters) shardCount(n int) int {
+	if !p.sharded() {
+		return n
+	}
+	if n <= p.ShardIndex {
+		return 0
+	}
+	return (n - p.ShardIndex + p.ShardTotal - 1) / p.ShardTotal
+}
+
+// iterationSeed derives the seed of an iteration of a sharded check from the
+// seed of the test parameters, so that it does not depend on the shard it is
+// checked by
+func iterationSeed(seed int64, iteration int) int64 {
+	hash := fnv.New64a()
+	var bytes [16]byte
+	binary.LittleEndian.PutUint64(bytes[:8], uint64(seed))
+	binary.LittleEndian.PutUint64(bytes[8:], uint64(iteration))
+	hash.Write(bytes[:])
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,103 @@
+package gopter
+
+import "fmt"
+
+// ShrinkStrategy controls the shrink loop of a falsified property: In each
+// step the candidates of the current (failing) value are checked in order
+// and the step continues with the accepted failing candidate, until there is
+// no failing candidate or the budget is exhausted.
+// Strategies can be selected per property (see TestParameters.ShrinkStrategy).
+type ShrinkStrategy interface {
+	// Candidates returns the candidates of a step in the order they are
+	// checked, it may reorder or limit the shrinks of the current value
+	Candidates(shrink Shrink) Shrink
+	// Accept is called with every failing candidate of a step and the best
+	// failing candidate accepted so far (hasBest is false for the first one,
+	// as nil is a valid candidate). It decides if the candidate becomes the
+	// best one and if the step stops (i.e. the remaining candidates are not
+	// checked).
+	Accept(best interface{}, hasBest bool, candidate interface{}) (accept, stop bool)
+	// MaxEvaluations is the maximum number of condition evaluations while
+	// shrinking a falsified property (0 for no limit)
+	MaxEvaluations() int
+}
+
+// FirstImprovement is the default ShrinkStrategy: Each step continues with
+// the first failing candidate.
+type FirstImprovement struct {
+	// MaxCandidates limits the number of candidates per step (0 for no limit)
+	MaxCandidates int
+	// Evaluations is the maximum number of condition evaluations (0 for no
+	// limit)
+	Evaluations int
+}
+
+// Candidates limits the shrinks to MaxCandidates
+func (s FirstImprovement) Candidates(shrink Shrink) Shrink {
+	return limitShrink(shrink, s.MaxCandidates)
+}
+
+// Accept accepts the first failing candidate and stops the step
+func (s FirstImprovement) Accept(best interface{}, hasBest bool, candidate interface{}) (bool, bool) {
+	return true, true
+}
+
+// MaxEvaluations is the configured Evaluations
+func (s FirstImprovement) MaxEvaluations() int {
+	return s.Evaluations
+}
+
+// BestImprovement is a ShrinkStrategy that checks all candidates of a step
+// and continues with the smallest failing one.
+// This requires more evaluations than FirstImprovement, but might find
+// smaller counter examples in fewer steps.
+type BestImprovement struct {
+	// Size measures a value, if nil the length of its "%v" representation is
+	// used
+	Size func(interface{}) int
+	// MaxCandidates limits the number of candidates per step (0 for no limit)
+	MaxCandidates int
+	// Evaluations is the maximum number of condition evaluations (0 for no
+	// limit)
+	Evaluations int
+}
+
+// Candidates limits the shrinks to MaxCandidates
+func (s BestImprovement) Candidates(shrink Shrink) Shrink {
+	return limitShrink(shrink, s.MaxCandidates)
+}
+
+// Accept accepts a candidate that is smaller than the best one, the step is
+// never stopped early
+func (s BestImprovement) Accept(best interface{}, hasBest bool, candidate interface{}) (bool, bool) {
+	if !hasBest {
+		return true, false
+	}
+	return s.size(candidate) < s.size(best), false
+}
+
+// MaxEvaluations is the configured Evaluations
+func (s BestImprovement) MaxEvaluations() int {
+	return s.Evaluations
+}
+
+func (s BestImprovement) size(value interface{}) int {
+	if s.Size != nil {
+		return s.Size(value)
+	}
+	return len(fmt.Sprintf("%v", value))
+}
+
+func limitShrink(shrink Shrink, limit int) Shrink {
+	if limit <= 0 {
+		return shrink
+	}
+	count := 0
+	return func() (interface{}, bool) {
+		if count >= limit {
+			return nil, false
+		}
+		count++
+		return shrink()
+	}
+}
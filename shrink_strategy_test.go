@@ -0,0 +1,48 @@
+package gopter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+func TestFirstImprovement(t *testing.T) {
+	strategy := gopter.FirstImprovement{MaxCandidates: 2}
+	if shrinks := strategy.Candidates(gen.IntShrinker(100)).All(); !reflect.DeepEqual(shrinks, []interface{}{0, 50}) {
+		t.Errorf("Invalid candidates: %#v", shrinks)
+	}
+	if accept, stop := strategy.Accept(nil, false, 50); !accept || !stop {
+		t.Errorf("Invalid acceptance: %v %v", accept, stop)
+	}
+	if shrinks := (gopter.FirstImprovement{}).Candidates(gen.IntShrinker(100)).All(); len(shrinks) <= 2 {
+		t.Errorf("Invalid candidates: %#v", shrinks)
+	}
+}
+
+func TestBestImprovement(t *testing.T) {
+	strategy := gopter.BestImprovement{}
+	if accept, stop := strategy.Accept(nil, false, "abc"); !accept || stop {
+		t.Errorf("Invalid acceptance of first candidate: %v %v", accept, stop)
+	}
+	if accept, _ := strategy.Accept("abc", true, "ab"); !accept {
+		t.Error("Smaller candidate not accepted")
+	}
+	if accept, _ := strategy.Accept("ab", true, "abc"); accept {
+		t.Error("Larger candidate accepted")
+	}
+	// nil is a valid best candidate
+	sized := gopter.BestImprovement{Size: func(v interface{}) int {
+		if v == nil {
+			return 0
+		}
+		return len(v.([]int))
+	}}
+	if accept, _ := sized.Accept(nil, true, []int{1}); accept {
+		t.Error("Larger candidate accepted over nil")
+	}
+	if strategy.MaxEvaluations() != 0 || (gopter.BestImprovement{Evaluations: 10}).MaxEvaluations() != 10 {
+		t.Error("Invalid evaluation budget")
+	}
+}
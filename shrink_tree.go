@@ -0,0 +1,180 @@
+package gopter
+
+import "reflect"
+
+// ShrinkTree is a lazy tree of a value and its shrunk down values, i.e. the
+// children of a tree are the trees of the shrinks of its value.
+// Contrary to a Shrinker, which only gets the value to shrink, a tree keeps
+// track of how its values have been created. Therefore it can be mapped to
+// values of another type without losing the ability to shrink (see Map), which
+// is used by the combinators of generators (see GenResult.Tree).
+// The children are created on demand and not cached, i.e. a tree is a pure
+// function of the value it has been created for.
+type ShrinkTree struct {
+	// Value is the value at the root of the tree
+	Value    interface{}
+	children func() TreeShrink
+}
+
+// TreeShrink is a stream of the (child) trees of a ShrinkTree.
+// Once the result is false, it is considered to be exhausted.
+type TreeShrink func() (*ShrinkTree, bool)
+
+// NewShrinkTree creates the tree of a value shrunk by a shrinker, the shrinks
+// are filtered by the sieve (if not nil)
+func NewShrinkTree(value interface{}, shrinker Shrinker, sieve func(interface{}) bool) *ShrinkTree {
+	if shrinker == nil {
+		shrinker = NoShrinker
+	}
+	return &ShrinkTree{
+		Value: value,
+		children: func() TreeShrink {
+			shrink := shrinker(value).Filter(sieve)
+			return func() (*ShrinkTree, bool) {
+				shrunk, ok := shrink()
+				if !ok {
+					return nil, false
+				}
+				return NewShrinkTree(shrunk, shrinker, sieve), true
+			}
+		},
+	}
+}
+
+// ShrinkTree creates the tree of the generated value (false if there is no
+// valid value).
+// This is the Tree of the result if set, otherwise the value is shrunk by the
+// Shrinker. In both cases the shrinks are filtered by the Sieve.
+func (r *GenResult) ShrinkTree() (*ShrinkTree, bool) {
+	value, ok := r.Retrieve()
+	if !ok {
+		return nil, false
+	}
+	if r.Tree == nil {
+		return NewShrinkTree(value, r.Shrinker, r.Sieve), true
+	}
+	return r.Tree().Filter(r.Sieve), true
+}
+
+// Children creates the stream of the child trees
+func (t *ShrinkTree) Children() TreeShrink {
+	return t.children()
+}
+
+// Map creates a tree with all values (lazily) mapped by f
+func (t *ShrinkTree) Map(f func(interface{}) interface{}) *ShrinkTree {
+	return &ShrinkTree{
+		Value: f(t.Value),
+		children: func() TreeShrink {
+			children := t.children()
+			return func() (*ShrinkTree, bool) {
+				child, ok := children()
+				if !ok {
+					return nil, false
+				}
+				return child.Map(f), true
+			}
+		},
+	}
+}
+
+// FilterMap creates a tree with all values (lazily) mapped by f, the children
+// that f rejects (i.e. returns false) are left out
+func (t *ShrinkTree) FilterMap(f func(interface{}) (interface{}, bool)) *ShrinkTree {
+	value, _ := f(t.Value)
+	return t.filterMapped(value, f)
+}
+
+func (t *ShrinkTree) filterMapped(value interface{}, f func(interface{}) (interface{}, bool)) *ShrinkTree {
+	return &ShrinkTree{
+		Value: value,
+		children: func() TreeShrink {
+			children := t.children()
+			return func() (*ShrinkTree, bool) {
+				for {
+					child, ok := children()
+					if !ok {
+						return nil, false
+					}
+					if mapped, ok := f(child.Value); ok {
+						return child.filterMapped(mapped, f), true
+					}
+				}
+			}
+		},
+	}
+}
+
+// Filter creates a tree without the children (and their subtrees) that do not
+// pass the sieve (the tree itself if the sieve is nil)
+func (t *ShrinkTree) Filter(sieve func(interface{}) bool) *ShrinkTree {
+	if sieve == nil {
+		return t
+	}
+	return t.filterMapped(t.Value, func(v interface{}) (interface{}, bool) {
+		return v, sieve(v)
+	})
+}
+
+// Shrinker creates a shrinker that shrinks the value of the tree (compared by
+// reflect.DeepEqual) to the values of its children, all other values are not
+// shrunk.
+// Shrinking further requires the child trees (like prop.ForAll does), so this
+// only gives a single step to code that shrinks by Shrinkers (e.g. the
+// elements of gen.SliceOf).
+func (t *ShrinkTree) Shrinker() Shrinker {
+	return treeShrinker(func() *ShrinkTree {
+		return t
+	})
+}
+
+// treeShrinker is the Shrinker of a tree that is created on demand (see
+// ShrinkTree.Shrinker)
+func treeShrinker(tree func() *ShrinkTree) Shrinker {
+	return func(v interface{}) Shrink {
+		root := tree()
+		if !reflect.DeepEqual(root.Value, v) {
+			return NoShrink
+		}
+		children := root.Children()
+		return func() (interface{}, bool) {
+			child, ok := children()
+			if !ok {
+				return nil, false
+			}
+			return child.Value, true
+		}
+	}
+}
+
+// combineTrees creates the tree of the []interface{} of the values of trees,
+// the children shrink one element after another like CombineShrinker
+func combineTrees(trees []*ShrinkTree) *ShrinkTree {
+	values := make([]interface{}, len(trees))
+	for i, tree := range trees {
+		values[i] = tree.Value
+	}
+	return &ShrinkTree{
+		Value: values,
+		children: func() TreeShrink {
+			index := 0
+			var children TreeShrink
+			return func() (*ShrinkTree, bool) {
+				for index < len(trees) {
+					if children == nil {
+						children = trees[index].Children()
+					}
+					if child, ok := children(); ok {
+						shrunk := make([]*ShrinkTree, len(trees))
+						copy(shrunk, trees)
+						shrunk[index] = child
+						return combineTrees(shrunk), true
+					}
+					index++
+					children = nil
+				}
+				return nil, false
+			}
+		},
+	}
+}
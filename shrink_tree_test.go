@@ -0,0 +1,132 @@
+package gopter_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestShrinkTree(t *testing.T) {
+	tree := gopter.NewShrinkTree(8, gen.IntShrinker, func(v interface{}) bool { return v.(int) >= 0 })
+	var children []interface{}
+	shrink := tree.Children()
+	for child, ok := shrink(); ok; child, ok = shrink() {
+		children = append(children, child.Value)
+	}
+	if !reflect.DeepEqual(children, []interface{}{0, 4, 6, 7}) {
+		t.Errorf("Invalid children: %#v", children)
+	}
+
+	mapped := tree.Map(func(v interface{}) interface{} { return strconv.Itoa(v.(int)) })
+	if mapped.Value != "8" {
+		t.Errorf("Invalid mapped value: %#v", mapped.Value)
+	}
+	children = nil
+	shrink = mapped.Children()
+	for child, ok := shrink(); ok; child, ok = shrink() {
+		children = append(children, child.Value)
+		if child.Value == "4" {
+			if shrinks := child.Shrinker()("4").All(); !reflect.DeepEqual(shrinks, []interface{}{"0", "2", "3"}) {
+				t.Errorf("Invalid mapped shrinks of a shrink: %#v", shrinks)
+			}
+		}
+	}
+	if !reflect.DeepEqual(children, []interface{}{"0", "4", "6", "7"}) {
+		t.Errorf("Invalid mapped children: %#v", children)
+	}
+
+	// the shrinker only shrinks the value of the tree, but does not depend on
+	// the values shrunk before
+	shrinker := mapped.Shrinker()
+	for i := 0; i < 2; i++ {
+		if shrinks := shrinker("8").All(); !reflect.DeepEqual(shrinks, []interface{}{"0", "4", "6", "7"}) {
+			t.Errorf("Invalid mapped shrinks: %#v", shrinks)
+		}
+		if shrinks := shrinker("4").All(); len(shrinks) != 0 {
+			t.Errorf("Invalid shrinks of another value: %#v", shrinks)
+		}
+	}
+
+	if _, ok := gen.Int().SuchThat(func(int) bool { return false })(gopter.DefaultGenParameters()).ShrinkTree(); ok {
+		t.Error("Tree of an invalid value")
+	}
+}
+
+func TestMapShrinksAcrossTypes(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	type wrapper struct {
+		Text string
+	}
+	strs := gen.IntRange(0, 10000).Map(strconv.Itoa).Map(func(s string) wrapper { return wrapper{Text: s} })
+	result := prop.ForAll(func(w wrapper) bool { return len(w.Text) < 3 }, strs).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != (wrapper{Text: "100"}) {
+		t.Errorf("Invalid result: %#v", result.Args[0])
+	}
+}
+
+func TestFilterMapShrinks(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	evens := gen.IntRange(0, 10000).FilterMap(func(v int) (string, bool) {
+		return strconv.Itoa(v), v%2 == 0
+	})
+	result := prop.ForAll(func(s string) bool { return len(s) < 3 }, evens).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != "102" {
+		t.Errorf("Invalid result: %#v", result.Args[0])
+	}
+}
+
+func TestMapCreatesTreeOnDemand(t *testing.T) {
+	calls := 0
+	strs := gen.IntRange(0, 10000).Map(func(v int) string {
+		calls++
+		return strconv.Itoa(v)
+	})
+	result := strs(gopter.DefaultGenParameters())
+	if calls != 1 {
+		t.Errorf("Invalid calls of the mapping function: %d", calls)
+	}
+	if result.Tree == nil {
+		t.Fatal("Mapped result without tree")
+	}
+	tree, _ := result.ShrinkTree()
+	if tree.Value != result.Result {
+		t.Errorf("Invalid tree value: %#v", tree.Value)
+	}
+}
+
+func TestCombinatorsShrinkByTrees(t *testing.T) {
+	parameters := gopter.DefaultTestParametersWithSeed(1234)
+	strs := gen.IntRange(0, 10000).Map(strconv.Itoa)
+
+	combined := gopter.CombineGens(strs, gen.IntRange(0, 10))
+	result := prop.ForAll(func(v []interface{}) bool { return len(v[0].(string)) < 3 }, combined).Check(parameters)
+	if result.Status != gopter.TestFailed || !reflect.DeepEqual(result.Args[0].Arg, []interface{}{"100", 0}) {
+		t.Errorf("Invalid combined result: %#v", result.Args[0])
+	}
+
+	quoted := strs.MapResult(func(r *gopter.GenResult) *gopter.GenResult {
+		return &gopter.GenResult{
+			ResultType: reflect.TypeOf(""),
+			Result:     "'" + r.Result.(string) + "'",
+		}
+	})
+	result = prop.ForAll(func(s string) bool { return len(s) < 5 }, quoted).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != "'100'" {
+		t.Errorf("Invalid mapped result: %#v", result.Args[0])
+	}
+
+	flatMapped := gen.Const(1).FlatMap(func(interface{}) gopter.Gen { return strs }, reflect.TypeOf(""))
+	result = prop.ForAll(func(s string) bool { return len(s) < 3 }, flatMapped).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != "100" {
+		t.Errorf("Invalid flat mapped result: %#v", result.Args[0])
+	}
+
+	result = prop.ForAll(func(a string, b int) bool { return len(a) < 3 || b < 5 }, strs, gen.IntRange(0, 10)).Check(parameters)
+	if result.Status != gopter.TestFailed || result.Args[0].Arg != "100" || result.Args[1].Arg != 5 {
+		t.Errorf("Invalid arguments: %#v, %#v", result.Args[0], result.Args[1])
+	}
+}
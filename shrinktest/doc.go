@@ -0,0 +1,15 @@
+/*
+Package shrinktest contains helpers to test custom shrinkers.
+
+A gopter.Shrinker creates a gopter.Shrink for a value, which is a stream of
+candidates that are "smaller" than the original value. When a property is
+falsified the first failing candidate is shrunk again until no candidate fails
+anymore. The assertions of this package check the candidates of a shrinker
+without having to iterate the stream manually, e.g.
+
+	func TestMyShrinker(t *testing.T) {
+		shrinktest.AssertShrinksTo(t, MyShrinker, myValue, smallerValue)
+		shrinktest.AssertTerminates(t, MyShrinker, myValue, 1000)
+	}
+*/
+package shrinktest
@@ -0,0 +1,115 @@
+package shrinktest
+
+import (
+	"reflect"
+
+	"github.com/leanovate/gopter"
+)
+
+// DefaultMaxCandidates is the maximum number of candidates taken from a
+// single shrink (shrinks may be infinite)
+const DefaultMaxCandidates = 10000
+
+// TestingT is the part of testing.TB used by the assertions
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+type helper interface {
+	Helper()
+}
+
+func markHelper(t TestingT) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+}
+
+// Candidates collects up to max candidates of the shrink of a value
+func Candidates(shrinker gopter.Shrinker, value interface{}, max int) []interface{} {
+	shrink := shrinker(value)
+	candidates := []interface{}{}
+	for len(candidates) < max {
+		candidate, ok := shrink()
+		if !ok {
+			break
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// AssertShrinksTo asserts that the candidates of the shrink of "from" contain
+// all expected candidates (compared via reflect.DeepEqual)
+func AssertShrinksTo(t TestingT, shrinker gopter.Shrinker, from interface{}, expectedCandidates ...interface{}) bool {
+	markHelper(t)
+	candidates := Candidates(shrinker, from, DefaultMaxCandidates)
+	success := true
+	for _, expected := range expectedCandidates {
+		if !containsCandidate(candidates, expected) {
+			t.Errorf("Shrink of %#v does not contain %#v: %#v", from, expected, candidates)
+			success = false
+		}
+	}
+	return success
+}
+
+// AssertNoShrink asserts that a value is not shrunk at all
+func AssertNoShrink(t TestingT, shrinker gopter.Shrinker, value interface{}) bool {
+	markHelper(t)
+	if candidates := Candidates(shrinker, value, 10); len(candidates) > 0 {
+		t.Errorf("Expected no shrink of %#v: %#v", value, candidates)
+		return false
+	}
+	return true
+}
+
+// AssertCandidates asserts that all candidates of the shrink of "from"
+// satisfy a condition (e.g. that they are valid values or smaller than
+// "from")
+func AssertCandidates(t TestingT, shrinker gopter.Shrinker, from interface{}, condition func(candidate interface{}) bool) bool {
+	markHelper(t)
+	for _, candidate := range Candidates(shrinker, from, DefaultMaxCandidates) {
+		if !condition(candidate) {
+			t.Errorf("Invalid candidate in shrink of %#v: %#v", from, candidate)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertTerminates asserts that repeatedly shrinking a value ends after at
+// most maxSteps steps. Like the shrinking of a falsified property every step
+// continues with the first candidate of the shrink, i.e. the property is
+// assumed to fail for all values.
+// Shrinks containing the value itself or more than DefaultMaxCandidates
+// candidates are reported as well.
+func AssertTerminates(t TestingT, shrinker gopter.Shrinker, value interface{}, maxSteps int) bool {
+	markHelper(t)
+	for step := 0; step < maxSteps; step++ {
+		candidates := Candidates(shrinker, value, DefaultMaxCandidates+1)
+		if len(candidates) == 0 {
+			return true
+		}
+		if len(candidates) > DefaultMaxCandidates {
+			t.Errorf("Shrink of %#v (step %d) has more than %d candidates", value, step, DefaultMaxCandidates)
+			return false
+		}
+		if containsCandidate(candidates, value) {
+			t.Errorf("Shrink of %#v (step %d) contains the value itself", value, step)
+			return false
+		}
+		value = candidates[0]
+	}
+	t.Errorf("Shrinking did not terminate after %d steps, last value: %#v", maxSteps, value)
+	return false
+}
+
+func containsCandidate(candidates []interface{}, expected interface{}) bool {
+	for _, candidate := range candidates {
+		if reflect.DeepEqual(candidate, expected) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package shrinktest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/shrinktest"
+)
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// loopShrinker never terminates: 1 -> 2 -> 1 -> ...
+func loopShrinker(v interface{}) gopter.Shrink {
+	next := 3 - v.(int)
+	return gopter.Shrink(func() (interface{}, bool) {
+		return next, true
+	})
+}
+
+// selfShrinker shrinks a value to itself
+func selfShrinker(v interface{}) gopter.Shrink {
+	return gopter.Shrink(func() (interface{}, bool) {
+		return v, true
+	})
+}
+
+func TestAssertShrinksTo(t *testing.T) {
+	shrinktest.AssertShrinksTo(t, gen.IntShrinker, 100, 0, 50, 75)
+
+	recorder := &recordingT{}
+	if shrinktest.AssertShrinksTo(recorder, gen.IntShrinker, 100, 101) || len(recorder.errors) != 1 {
+		t.Errorf("Invalid candidate not reported: %v", recorder.errors)
+	}
+}
+
+func TestAssertNoShrink(t *testing.T) {
+	shrinktest.AssertNoShrink(t, gen.IntShrinker, 0)
+
+	recorder := &recordingT{}
+	if shrinktest.AssertNoShrink(recorder, gen.IntShrinker, 10) || len(recorder.errors) != 1 {
+		t.Errorf("Shrink not reported: %v", recorder.errors)
+	}
+}
+
+func TestAssertCandidates(t *testing.T) {
+	shrinktest.AssertCandidates(t, gen.IntShrinker, 100, func(candidate interface{}) bool {
+		return candidate.(int) < 100 && candidate.(int) > -100
+	})
+
+	recorder := &recordingT{}
+	if shrinktest.AssertCandidates(recorder, gen.IntShrinker, 100, func(candidate interface{}) bool {
+		return candidate.(int) > 0
+	}) || len(recorder.errors) != 1 {
+		t.Errorf("Invalid candidate not reported: %v", recorder.errors)
+	}
+}
+
+func TestAssertTerminates(t *testing.T) {
+	shrinktest.AssertTerminates(t, gen.IntShrinker, 1000000, 100)
+	shrinktest.AssertTerminates(t, gen.SliceShrinker(gen.IntShrinker), []int{1, 2, 3, 4, 5}, 100)
+
+	recorder := &recordingT{}
+	if shrinktest.AssertTerminates(recorder, selfShrinker, 1, 100) || len(recorder.errors) != 1 {
+		t.Errorf("Shrink to itself not reported: %v", recorder.errors)
+	}
+
+	recorder = &recordingT{}
+	if shrinktest.AssertTerminates(recorder, gen.SliceShrinker(gen.IntShrinker), []int{1, 2, 3, 4, 5}, 1) || len(recorder.errors) != 1 {
+		t.Errorf("Too many steps not reported: %v", recorder.errors)
+	}
+
+	recorder = &recordingT{}
+	if shrinktest.AssertTerminates(recorder, loopShrinker, 1, 100) || len(recorder.errors) != 1 {
+		t.Errorf("Endless shrink not reported: %v", recorder.errors)
+	}
+}
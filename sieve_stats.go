@@ -0,0 +1,89 @@
+package gopter
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SieveStat is the number of values evaluated and rejected by a sieve (see
+// Gen.SuchThatNamed)
+type SieveStat struct {
+	Name      string
+	Evaluated int
+	Rejected  int
+}
+
+// RejectionRate is the ratio of evaluated values rejected by the sieve
+func (s SieveStat) RejectionRate() float64 {
+	if s.Evaluated == 0 {
+		return 0
+	}
+	return float64(s.Rejected) / float64(s.Evaluated)
+}
+
+func (s SieveStat) String() string {
+	return fmt.Sprintf("%.0f%% of generated values rejected by sieve '%s'", 100*s.RejectionRate(), s.Name)
+}
+
+// SieveStats collects the statistics of the sieves of a property check.
+// It is safe for concurrent use.
+type SieveStats struct {
+	lock  sync.Mutex
+	stats []SieveStat
+	index map[string]int
+}
+
+// NewSieveStats creates an empty statistic
+func NewSieveStats() *SieveStats {
+	return &SieveStats{
+		index: map[string]int{},
+	}
+}
+
+// Record records the evaluation of a generated value by a sieve
+func (s *SieveStats) Record(name string, accepted bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	idx, ok := s.index[name]
+	if !ok {
+		idx = len(s.stats)
+		s.index[name] = idx
+		s.stats = append(s.stats, SieveStat{Name: name})
+	}
+	s.stats[idx].Evaluated++
+	if !accepted {
+		s.stats[idx].Rejected++
+	}
+}
+
+// Stats gets the statistics of all sieves in the order of their first
+// evaluation
+func (s *SieveStats) Stats() []SieveStat {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.stats) == 0 {
+		return nil
+	}
+	return append([]SieveStat{}, s.stats...)
+}
+
+// mergeSieveStats sums up the statistics of sieves with the same name
+func mergeSieveStats(s1, s2 []SieveStat) []SieveStat {
+	var merged []SieveStat
+	merged = append(merged, s1...)
+	for _, stat := range s2 {
+		found := false
+		for i := range merged {
+			if merged[i].Name == stat.Name {
+				merged[i].Evaluated += stat.Evaluated
+				merged[i].Rejected += stat.Rejected
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, stat)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,108 @@
+package gopter
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+)
+
+// PropertyBudget is the configured budget of a property check
+type PropertyBudget struct {
+	MinSuccessfulTests int     `json:"minSuccessfulTests"`
+	MinSize            int     `json:"minSize"`
+	MaxSize            int     `json:"maxSize"`
+	MaxShrinkCount     int     `json:"maxShrinkCount"`
+	Workers            int     `json:"workers"`
+	MaxDiscardRatio    float64 `json:"maxDiscardRatio"`
+	ExhaustiveLimit    int     `json:"exhaustiveLimit,omitempty"`
+	EscalationRounds   int     `json:"escalationRounds,omitempty"`
+	ShardIndex         int     `json:"shardIndex,omitempty"`
+	ShardTotal         int     `json:"shardTotal,omitempty"`
+}
+
+// PropertyManifest describes a registered property
+type PropertyManifest struct {
+	Name string `json:"name"`
+	// Seed the property is checked with
+	Seed int64 `json:"seed"`
+	// Labels of the generators of the arguments (empty for unlabeled
+	// generators)
+	Labels []string       `json:"labels"`
+	Budget PropertyBudget `json:"budget"`
+}
+
+// SuiteManifest describes all properties of a test suite in the order they
+// have been registered
+type SuiteManifest struct {
+	Seed       int64              `json:"seed"`
+	Properties []PropertyManifest `json:"properties"`
+}
+
+// Manifest describes all registered properties, e.g. to audit or shard a
+// property suite or to detect deleted properties.
+// The generator labels are determined by generating a set of arguments
+// without evaluating the condition (see GenParameters.DryRun). This is
+// supported by the properties of the prop package, other properties are
+// evaluated once.
+func (p *Properties) Manifest() *SuiteManifest {
+	manifest := &SuiteManifest{
+		Seed:       p.parameters.currentSeed(),
+		Properties: make([]PropertyManifest, 0, len(p.propNames)),
+	}
+	for _, propName := range p.propNames {
+		parameters, _ := p.propertyParameters(propName)
+		labels, opts := p.props[propName].argLabels(parameters)
+		if opts != nil {
+			parameters = parameters.withOptions(opts)
+		}
+		manifest.Properties = append(manifest.Properties, PropertyManifest{
+			Name:   propName,
+			Seed:   parameters.currentSeed(),
+			Labels: labels,
+			Budget: PropertyBudget{
+				MinSuccessfulTests: parameters.MinSuccessfulTests,
+				MinSize:            parameters.MinSize,
+				MaxSize:            parameters.MaxSize,
+				MaxShrinkCount:     parameters.MaxShrinkCount,
+				Workers:            parameters.Workers,
+				MaxDiscardRatio:    parameters.MaxDiscardRatio,
+				ExhaustiveLimit:    parameters.ExhaustiveLimit,
+				EscalationRounds:   parameters.EscalationRounds,
+				ShardIndex:         parameters.ShardIndex,
+				ShardTotal:         parameters.ShardTotal,
+			},
+		})
+	}
+	return manifest
+}
+
+// WriteManifest writes the Manifest of all registered properties as JSON
+func (p *Properties) WriteManifest(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(p.Manifest())
+}
+
+// argLabels gets the labels of the arguments of a dry run of the property and
+// the options of the property (see PropResult.Options).
+// Undecided runs (i.e. rejected arguments) are retried a few times.
+func (prop Prop) argLabels(parameters *TestParameters) ([]string, []PropertyOption) {
+	genParams := &GenParameters{
+		MinSize:        parameters.MinSize,
+		MaxSize:        parameters.MaxSize,
+		MaxShrinkCount: parameters.MaxShrinkCount,
+		Rng:            rand.New(NewLockedSource(parameters.currentSeed())),
+		DryRun:         true,
+	}
+	for i := 0; i < 10; i++ {
+		result := prop(genParams)
+		if len(result.Args) > 0 || result.Status != PropUndecided {
+			labels := make([]string, len(result.Args))
+			for j, arg := range result.Args {
+				labels[j] = arg.Label
+			}
+			return labels, result.Options
+		}
+	}
+	return []string{}, nil
+}
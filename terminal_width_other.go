@@ -0,0 +1,12 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package gopter
+
+import "os"
+
+// terminalWidth queries the number of columns of the terminal of a file, the
+// size of terminals is not supported on this platform
+func terminalWidth(file *os.File) (int, bool) {
+	return 0, false
+}
@@ -1,10 +1,29 @@
 package gopter
 
 import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
 	"math/rand"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 )
 
+// SeedEnv is the environment variable that overrides the random seed of
+// DefaultTestParameters, e.g. to reproduce a failed CI run locally with the
+// reported seed:
+//
+//	GOPTER_SEED=1234 go test ./...
+const SeedEnv = "GOPTER_SEED"
+
+var (
+	defaultParametersLock sync.Mutex
+	defaultParametersFunc func(*TestParameters)
+)
+
 // TestParameters to run property tests
 type TestParameters struct {
 	MinSuccessfulTests int
@@ -17,23 +36,231 @@ type TestParameters struct {
 	Rng             *rand.Rand
 	Workers         int
 	MaxDiscardRatio float64
+	// ArgShrinkStrategy defines how the arguments of a falsified property are
+	// shrunk (see ShrinkArgsIndividually and ShrinkArgPairs)
+	ArgShrinkStrategy ArgShrinkStrategy
+	// ShrinkStrategy controls the candidate order, budget and acceptance of
+	// the shrink steps (nil for FirstImprovement)
+	ShrinkStrategy ShrinkStrategy
+	// TraceGenerators enables the generation trace, i.e. the invocations of
+	// traced (or labeled) generators are recorded and reported for the
+	// arguments of a falsified property
+	TraceGenerators bool
+	// ExhaustiveLimit enables exhaustive checks: If the domains of all
+	// generators of a property are finite (e.g. bools, small ranges or enums)
+	// and their product does not exceed the limit, the property is checked for
+	// all cases instead of random samples.
+	ExhaustiveLimit int
+	// EscalationRounds enables the escalation mode: If a property passes, it
+	// is checked again in rounds at 2x, 4x, 8x ... the MaxSize to probe for
+	// failures that only occur with large inputs (e.g. overflows or quadratic
+	// blowups).
+	EscalationRounds int
+	// EscalationTests is the number of tests per escalation round (if 0 a
+	// tenth of MinSuccessfulTests)
+	EscalationTests int
+	// SeedPerProperty derives the seed of each property of Properties from
+	// the Seed and the property name (see PropertySeed) instead of using the
+	// shared Rng, i.e. adding, removing or reordering properties does not
+	// change the values checked by the others.
+	SeedPerProperty bool
+	// DedupInputs enables the deduplication of generated inputs: Arguments
+	// that have already been checked (i.e. have the same %#v representation)
+	// are skipped and counted as TestResult.Duplicates. If a property keeps
+	// generating duplicates (e.g. due to a small domain), the check gives up
+	// with TestExhausted (use ExhaustiveLimit to verify small domains).
+	DedupInputs bool
+	// Corpus contains examples that are checked before the generated values
+	// (see Corpus)
+	Corpus *Corpus
+	// CorpusDir enables the corpus files of Properties: The examples of
+	// each property are loaded from the file CorpusPath(CorpusDir, name)
+	// (e.g. under DefaultCorpusDir)
+	CorpusDir string
+	// FailureDir enables the failure corpus files of Properties: The (shrunk)
+	// arguments of a failed property are recorded in the file
+	// CorpusPath(FailureDir, name) (e.g. under DefaultFailureDir) and
+	// replayed before the generated values (and after the examples of
+	// CorpusDir) in all subsequent checks, i.e. a fixed failure is checked
+	// as regression. Arguments that can not be restored from JSON are not
+	// recorded (see Corpus).
+	FailureDir string
+	// EarlyStopFailureRate enables early stopping: A property passes as soon
+	// as its successful tests reject the hypothesis that it fails with a
+	// probability of at least EarlyStopFailureRate (e.g. 0.05) at the
+	// EarlyStopConfidence, even if less than MinSuccessfulTests have been
+	// checked (see EarlyStopTests).
+	EarlyStopFailureRate float64
+	// EarlyStopConfidence is the confidence of early stopping (if 0 0.95)
+	EarlyStopConfidence float64
+	// MaxRate limits the rate of evaluations (test cases and shrink steps
+	// per second) of each check of a property across all workers, e.g. for
+	// properties using rate-limited external systems (0 is unlimited)
+	MaxRate float64
+	// ArtifactDir enables the artifact bundles of Properties: If a property
+	// fails, the details of the failure are written to the directory
+	// ArtifactPath(ArtifactDir, name) (e.g. under DefaultArtifactDir, see
+	// WriteArtifact)
+	ArtifactDir string
+	// ArtifactLogs is called for a failed property to get the logs of the
+	// system under test for its artifact bundle (e.g. captured by a logger
+	// of the test), nil logs are omitted
+	ArtifactLogs func(propName string) []byte
+	// ShardTotal enables sharding (if greater than 1), i.e. the checks are
+	// split into ShardTotal shards (e.g. parallel CI jobs) that are checked
+	// separately with the same Seed: The iterations are numbered and every
+	// iteration is checked with its own seed derived from the Seed, a shard
+	// only checks the iterations whose number modulo ShardTotal is the
+	// ShardIndex. Thereby the shards check the MinSuccessfulTests together
+	// without overlapping or missing iterations, regardless of the number of
+	// shards. Corpus examples and the cases of exhaustive checks are split
+	// the same way. The properties of Properties derive the same iteration
+	// seeds unless SeedPerProperty is enabled.
+	ShardTotal int
+	// ShardIndex is the shard (from 0 to ShardTotal-1) that is checked
+	ShardIndex int
+
+	// source of the Rng created for the Seed (rng), to track the seed of
+	// reseeding via Rng.Seed
+	source *lockedSource
+	rng    *rand.Rand
+	// seedErr is the error of an invalid seed of SeedEnv, the checks fail
+	// with it
+	seedErr error
+	// optionsApplied is set if the options of the property have been applied
+	// (see PropResult.Options)
+	optionsApplied bool
 }
 
 // DefaultTestParameterWithSeeds creates reasonable default Parameters for most cases based on a fixed RNG-seed
 func DefaultTestParametersWithSeed(seed int64) *TestParameters {
-	return &TestParameters{
+	parameters := &TestParameters{
 		MinSuccessfulTests: 100,
 		MinSize:            0,
 		MaxSize:            100,
 		MaxShrinkCount:     1000,
-		Seed:               seed,
-		Rng:                rand.New(NewLockedSource(seed)),
 		Workers:            1,
 		MaxDiscardRatio:    5,
 	}
+	parameters.setSeed(seed)
+
+	defaultParametersLock.Lock()
+	configure := defaultParametersFunc
+	defaultParametersLock.Unlock()
+	if configure != nil {
+		configure(parameters)
+		if parameters.Seed != seed && parameters.Rng != nil {
+			// the seed has been overwritten
+			parameters.setSeed(parameters.Seed)
+		}
+	}
+	return parameters
 }
 
 // DefaultTestParameterWithSeeds creates reasonable default Parameters for most cases with an undefined RNG-seed
+// (unless the seed is defined by the environment variable GOPTER_SEED, see SeedEnv)
+// An invalid seed of the environment variable fails the checks with an error,
+// since checks with an unintended random seed would not reproduce anything.
 func DefaultTestParameters() *TestParameters {
-	return DefaultTestParametersWithSeed(time.Now().UnixNano())
+	seed, ok, err := envSeed()
+	if ok {
+		return DefaultTestParametersWithSeed(seed)
+	}
+	parameters := DefaultTestParametersWithSeed(time.Now().UnixNano())
+	parameters.seedErr = err
+	return parameters
+}
+
+// envSeed is the seed of the environment variable SeedEnv (if set)
+func envSeed() (int64, bool, error) {
+	value, ok := os.LookupEnv(SeedEnv)
+	if !ok || value == "" {
+		return 0, false, nil
+	}
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("Invalid seed in %s: %q", SeedEnv, value)
+	}
+	return seed, true, nil
+}
+
+// SetDefaultTestParameters sets a function that is applied to all parameters
+// created by DefaultTestParameters and DefaultTestParametersWithSeed, e.g. to
+// configure project-wide policies in TestMain:
+//
+//	gopter.SetDefaultTestParameters(func(parameters *gopter.TestParameters) {
+//		parameters.MinSuccessfulTests = 1000
+//	})
+//
+// If the function changes the Seed, the Rng is re-created accordingly.
+// Use nil to remove the function.
+func SetDefaultTestParameters(configure func(*TestParameters)) {
+	defaultParametersLock.Lock()
+	defer defaultParametersLock.Unlock()
+	defaultParametersFunc = configure
+}
+
+// PropertySeed derives the seed of a property from the seed of the test
+// parameters and the name of the property (see SeedPerProperty)
+func PropertySeed(seed int64, name string) int64 {
+	hash := fnv.New64a()
+	var seedBytes [8]byte
+	binary.LittleEndian.PutUint64(seedBytes[:], uint64(seed))
+	hash.Write(seedBytes[:])
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
+
+// EarlyStopTests is the number of successful tests after which a property
+// stops early (0 if early stopping is disabled).
+// As the first failure ends a check, the sequential probability ratio test of
+// the hypothesis "the failure rate is at least EarlyStopFailureRate" stops
+// once the probability of n consecutive successes (1-rate)^n drops below
+// 1-EarlyStopConfidence, e.g. after 59 tests for a rate of 0.05 with 95%
+// confidence.
+func (p *TestParameters) EarlyStopTests() int {
+	if p.EarlyStopFailureRate <= 0 || p.EarlyStopFailureRate >= 1 {
+		return 0
+	}
+	confidence := p.EarlyStopConfidence
+	if confidence <= 0 || confidence >= 1 {
+		confidence = 0.95
+	}
+	return int(math.Ceil(math.Log(1-confidence) / math.Log(1-p.EarlyStopFailureRate)))
+}
+
+// withSeed creates a copy of the parameters with a fresh Rng for the seed
+func (p *TestParameters) withSeed(seed int64) *TestParameters {
+	parameters := *p
+	parameters.setSeed(seed)
+	return &parameters
+}
+
+// withOptions creates a copy of the parameters adjusted by the options of a
+// property (see PropResult.Options)
+func (p *TestParameters) withOptions(opts []PropertyOption) *TestParameters {
+	parameters := *p
+	for _, opt := range opts {
+		opt(&parameters)
+	}
+	parameters.optionsApplied = true
+	return &parameters
+}
+
+// setSeed sets the seed and creates a fresh Rng for it
+func (p *TestParameters) setSeed(seed int64) {
+	p.Seed = seed
+	p.source = NewLockedSource(seed)
+	p.rng = rand.New(p.source)
+	p.Rng = p.rng
+}
+
+// currentSeed is the seed the checks are reproduced with, i.e. the seed the
+// Rng has last been seeded with if it has been reseeded (e.g. with
+// parameters.Rng.Seed(1234)), the Seed otherwise
+func (p *TestParameters) currentSeed() int64 {
+	if p.source != nil && p.Rng == p.rng {
+		return p.source.lastSeed()
+	}
+	return p.Seed
 }
@@ -0,0 +1,62 @@
+package gopter_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/leanovate/gopter"
+)
+
+func TestSetDefaultTestParameters(t *testing.T) {
+	gopter.SetDefaultTestParameters(func(parameters *gopter.TestParameters) {
+		parameters.MinSuccessfulTests = 1000
+		parameters.Seed = 1234
+	})
+	defer gopter.SetDefaultTestParameters(nil)
+
+	parameters := gopter.DefaultTestParameters()
+	expected := gopter.DefaultTestParametersWithSeed(1234)
+	if parameters.MinSuccessfulTests != 1000 || parameters.Seed != 1234 {
+		t.Errorf("Parameters not configured: %#v", parameters)
+	}
+	if parameters.Rng.Int63() != expected.Rng.Int63() {
+		t.Error("Rng does not match the configured seed")
+	}
+
+	gopter.SetDefaultTestParameters(nil)
+	if parameters := gopter.DefaultTestParameters(); parameters.MinSuccessfulTests != 100 {
+		t.Errorf("Parameters still configured: %#v", parameters)
+	}
+}
+
+func TestSeedEnv(t *testing.T) {
+	defer os.Unsetenv(gopter.SeedEnv)
+
+	os.Setenv(gopter.SeedEnv, "1234")
+	parameters := gopter.DefaultTestParameters()
+	expected := gopter.DefaultTestParametersWithSeed(1234)
+	if parameters.Seed != 1234 || parameters.Rng.Int63() != expected.Rng.Int63() {
+		t.Errorf("Seed not set by %s: %d", gopter.SeedEnv, parameters.Seed)
+	}
+	if parameters := gopter.DefaultTestParametersWithSeed(42); parameters.Seed != 42 {
+		t.Errorf("Explicit seed overridden: %d", parameters.Seed)
+	}
+
+	os.Setenv(gopter.SeedEnv, "")
+	if parameters := gopter.DefaultTestParameters(); parameters.Seed == 1234 {
+		t.Errorf("Seed of empty %s: %d", gopter.SeedEnv, parameters.Seed)
+	}
+
+	os.Setenv(gopter.SeedEnv, "seed")
+	prop := gopter.Prop(func(genParams *gopter.GenParameters) *gopter.PropResult {
+		return &gopter.PropResult{Status: gopter.PropTrue}
+	})
+	if result := prop.Check(gopter.DefaultTestParameters()); result.Status != gopter.TestError || result.Error == nil {
+		t.Errorf("Invalid %s does not fail: %#v", gopter.SeedEnv, result)
+	}
+	for result := range prop.CheckStream(gopter.DefaultTestParameters()) {
+		if result.TestResult == nil || result.TestResult.Status != gopter.TestError {
+			t.Errorf("Invalid %s does not fail the stream: %#v", gopter.SeedEnv, result)
+		}
+	}
+}
@@ -12,7 +12,7 @@ const (
 	// TestFailed indicates that the property check has failed.
 	TestFailed
 	// TestExhausted indicates that the property check has exhausted, i.e. the generators have
-	// generated too many empty results.
+	// generated too many empty results (or duplicates, see TestParameters.DedupInputs).
 	TestExhausted
 	// TestError indicates that the property check has finished with an error.
 	TestError
@@ -44,6 +44,37 @@ type TestResult struct {
 	ErrorStack []byte
 	Args       PropArgs
 	Time       time.Duration
+	// Exhaustive is true if the property has been checked for all possible
+	// cases
+	Exhaustive bool
+	// Timing is the breakdown of the time spent generating arguments,
+	// evaluating the condition and shrinking (summed up over all workers)
+	Timing TimeBreakdown
+	// MaxSizeVerified is the largest size the property has been verified with
+	// in escalation mode (0 if escalation is disabled or has not been started)
+	MaxSizeVerified int
+	// Duplicates is the number of skipped duplicate inputs (see
+	// TestParameters.DedupInputs)
+	Duplicates int
+	// SieveStats are the statistics of the named sieves of the generators
+	// (see Gen.SuchThatNamed)
+	SieveStats []SieveStat
+	// CorpusExamples is the number of examples of TestParameters.Corpus that
+	// have been checked before the generated values
+	CorpusExamples int
+	// EarlyStopped is true if the property has passed with less than
+	// MinSuccessfulTests due to TestParameters.EarlyStopFailureRate
+	EarlyStopped bool
+	// Seed the check can be reproduced with (e.g. via the environment variable
+	// GOPTER_SEED, see SeedEnv), i.e. the seed the Rng of the test parameters
+	// has last been seeded with (also via Rng.Seed). The properties of
+	// Properties report the seed of the properties (see SeedPerProperty).
+	// 0 if the seed is unknown (e.g. for a TestResult not created by a check).
+	Seed int64
+
+	// options requested by the property, the check has to be restarted with
+	// them (see PropResult.Options)
+	options []PropertyOption
 }
 
 // Passed checks if the check has passed
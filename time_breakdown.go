@@ -0,0 +1,37 @@
+package gopter
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeBreakdown contains the wall time spent in the different phases of a
+// property check. Properties that do not measure their phases (i.e. anything
+// besides prop.ForAll and prop.ForAllNoShrink) leave it empty.
+type TimeBreakdown struct {
+	// Generation is the time spent generating the arguments
+	Generation time.Duration
+	// Evaluation is the time spent evaluating the condition
+	Evaluation time.Duration
+	// Shrinking is the time spent shrinking falsified arguments (including the
+	// evaluations of the condition for the shrunk values)
+	Shrinking time.Duration
+}
+
+// Add sums up two time breakdowns
+func (t TimeBreakdown) Add(other TimeBreakdown) TimeBreakdown {
+	return TimeBreakdown{
+		Generation: t.Generation + other.Generation,
+		Evaluation: t.Evaluation + other.Evaluation,
+		Shrinking:  t.Shrinking + other.Shrinking,
+	}
+}
+
+// IsEmpty checks if no time has been recorded at all
+func (t TimeBreakdown) IsEmpty() bool {
+	return t.Generation == 0 && t.Evaluation == 0 && t.Shrinking == 0
+}
+
+func (t TimeBreakdown) String() string {
+	return fmt.Sprintf("generation: %s, evaluation: %s, shrinking: %s", t.Generation, t.Evaluation, t.Shrinking)
+}
@@ -0,0 +1,53 @@
+package gopter
+
+import (
+	"fmt"
+	"sync"
+)
+
+// UniquePools records the values taken from named pools of unique values
+// during a property check (see gen.Unique), it is safe for concurrent use
+type UniquePools struct {
+	lock  sync.Mutex
+	pools map[string]map[string]bool
+}
+
+// NewUniquePools creates empty UniquePools
+func NewUniquePools() *UniquePools {
+	return &UniquePools{pools: map[string]map[string]bool{}}
+}
+
+// Take records a value (by its %#v representation) in a pool and reports if it
+// has not been taken before
+func (p *UniquePools) Take(pool string, value interface{}) bool {
+	key := fmt.Sprintf("%#v", value)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	taken := p.pools[pool]
+	if taken == nil {
+		taken = map[string]bool{}
+		p.pools[pool] = taken
+	}
+	if taken[key] {
+		return false
+	}
+	taken[key] = true
+	return true
+}
+
+// Taken checks if a value has been taken from a pool
+func (p *UniquePools) Taken(pool string, value interface{}) bool {
+	key := fmt.Sprintf("%#v", value)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.pools[pool][key]
+}
+
+// Reset starts a pool over, i.e. all its values may be taken again
+func (p *UniquePools) Reset(pool string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.pools, pool)
+}